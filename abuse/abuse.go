@@ -0,0 +1,237 @@
+/*
+Package abuse scores clients that behave like scrapers or attackers and keeps
+a temporary deny list of the worst offenders. Clients earn points by hitting
+decoy (honeypot) endpoints that no real producer calls, by sending a user
+agent matching a suspicious pattern, or by connecting with a suspicious TLS
+client fingerprint. Once a client's score within the scoring
+window reaches the threshold it is denied for DenyDuration.
+*/
+package abuse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/glob"
+)
+
+const (
+	defaultHoneypotScore  = 10
+	defaultUserAgentScore = 1
+	defaultTLSScore       = 1
+	defaultScoreThreshold = 10
+	defaultScoreWindow    = "10m"
+	defaultDenyDuration   = "1h"
+	defaultMaxTrackedIPs  = 100000
+)
+
+// Config configures abuse scoring.
+type Config struct {
+	// HoneypotPaths are decoy request paths. Requests to them are answered
+	// with a 404 and never logged as events.
+	HoneypotPaths []string
+
+	// SuspiciousUserAgents are glob patterns of user agents that add to a
+	// client's score, e.g. "*sqlmap*"
+	SuspiciousUserAgents []string
+
+	// SuspiciousTLSFingerprints are the JA3 or JA4 fingerprints of TLS
+	// clients that add to a client's score, e.g. those of scripting
+	// libraries. They only match when TLS is terminated by the edge.
+	SuspiciousTLSFingerprints []string
+
+	// HoneypotScore, UserAgentScore and TLSScore are the points added per hit
+	HoneypotScore  int
+	UserAgentScore int
+	TLSScore       int
+
+	// ScoreThreshold is the score at which a client is denied
+	ScoreThreshold int
+
+	// ScoreWindow is how long points count towards a client's score, e.g. "10m"
+	ScoreWindow string
+
+	// DenyDuration is how long offenders are denied, e.g. "1h"
+	DenyDuration string
+
+	// MaxTrackedIPs bounds the number of clients scored at once
+	MaxTrackedIPs int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.HoneypotScore == 0 {
+		c.HoneypotScore = defaultHoneypotScore
+	}
+	if c.UserAgentScore == 0 {
+		c.UserAgentScore = defaultUserAgentScore
+	}
+	if c.TLSScore == 0 {
+		c.TLSScore = defaultTLSScore
+	}
+	if c.ScoreThreshold == 0 {
+		c.ScoreThreshold = defaultScoreThreshold
+	}
+	if c.ScoreWindow == "" {
+		c.ScoreWindow = defaultScoreWindow
+	}
+	if c.DenyDuration == "" {
+		c.DenyDuration = defaultDenyDuration
+	}
+	if c.MaxTrackedIPs == 0 {
+		c.MaxTrackedIPs = defaultMaxTrackedIPs
+	}
+
+	for _, p := range c.HoneypotPaths {
+		if !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("honeypot path %q must start with /", p)
+		}
+	}
+	for _, ua := range c.SuspiciousUserAgents {
+		if _, err := glob.Compile(ua); err != nil {
+			return fmt.Errorf("invalid user agent pattern %s: %v", ua, err)
+		}
+	}
+	if c.HoneypotScore < 0 || c.UserAgentScore < 0 || c.TLSScore < 0 || c.ScoreThreshold < 0 || c.MaxTrackedIPs < 0 {
+		return errors.New("scores, ScoreThreshold and MaxTrackedIPs must be greater than 0")
+	}
+	for _, d := range []string{c.ScoreWindow, c.DenyDuration} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+type client struct {
+	score       int
+	windowStart time.Time
+	deniedUntil time.Time
+}
+
+// Tracker scores clients and keeps the deny list.
+type Tracker struct {
+	honeypots       map[string]bool
+	userAgents      []glob.Glob
+	tlsFingerprints map[string]bool
+	honeypotScore   int
+	userAgentScore  int
+	tlsScore        int
+	threshold       int
+	window          time.Duration
+	denyDuration    time.Duration
+	maxTracked      int
+	now             func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*client
+}
+
+// NewTracker returns a Tracker for config.
+func NewTracker(config Config) (*Tracker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	t := &Tracker{
+		honeypots:      make(map[string]bool, len(config.HoneypotPaths)),
+		honeypotScore:  config.HoneypotScore,
+		userAgentScore: config.UserAgentScore,
+		tlsScore:       config.TLSScore,
+		threshold:      config.ScoreThreshold,
+		maxTracked:     config.MaxTrackedIPs,
+		now:            time.Now,
+		clients:        make(map[string]*client),
+	}
+	t.window, _ = time.ParseDuration(config.ScoreWindow)
+	t.denyDuration, _ = time.ParseDuration(config.DenyDuration)
+	for _, p := range config.HoneypotPaths {
+		t.honeypots[p] = true
+	}
+	t.tlsFingerprints = make(map[string]bool, len(config.SuspiciousTLSFingerprints))
+	for _, fp := range config.SuspiciousTLSFingerprints {
+		t.tlsFingerprints[fp] = true
+	}
+	for _, ua := range config.SuspiciousUserAgents {
+		t.userAgents = append(t.userAgents, glob.MustCompile(ua))
+	}
+	return t, nil
+}
+
+// IsHoneypot reports whether path is a decoy endpoint.
+func (t *Tracker) IsHoneypot(path string) bool {
+	return t.honeypots[path]
+}
+
+// Observe scores a request from ip and reports whether the client was denied
+// as a result of it. tlsFingerprints are the fingerprints of the client's TLS
+// connection, if the edge terminated it.
+func (t *Tracker) Observe(ip, path, userAgent string, tlsFingerprints ...string) bool {
+	points := 0
+	if t.honeypots[path] {
+		points += t.honeypotScore
+	}
+	for _, ua := range t.userAgents {
+		if ua.Match(userAgent) {
+			points += t.userAgentScore
+			break
+		}
+	}
+	for _, fp := range tlsFingerprints {
+		if t.tlsFingerprints[fp] {
+			points += t.tlsScore
+			break
+		}
+	}
+	if points == 0 || ip == "" {
+		return false
+	}
+
+	now := t.now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.clients[ip]
+	if !ok {
+		if len(t.clients) >= t.maxTracked {
+			t.expire(now)
+		}
+		if len(t.clients) >= t.maxTracked {
+			return false
+		}
+		c = &client{windowStart: now}
+		t.clients[ip] = c
+	}
+	if now.Sub(c.windowStart) > t.window {
+		c.score = 0
+		c.windowStart = now
+	}
+	c.score += points
+	if c.score >= t.threshold && !now.Before(c.deniedUntil) {
+		c.deniedUntil = now.Add(t.denyDuration)
+		return true
+	}
+	return false
+}
+
+// Denied reports whether ip is on the deny list.
+func (t *Tracker) Denied(ip string) bool {
+	t.mu.Lock()
+	c, ok := t.clients[ip]
+	denied := ok && t.now().Before(c.deniedUntil)
+	t.mu.Unlock()
+	return denied
+}
+
+// expire forgets clients that are neither denied nor scored within the
+// window. It must be called with mu held.
+func (t *Tracker) expire(now time.Time) {
+	for ip, c := range t.clients {
+		if now.After(c.deniedUntil) && now.Sub(c.windowStart) > t.window {
+			delete(t.clients, ip)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package abuse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	tracker, err := NewTracker(Config{
+		HoneypotPaths:        []string{"/wp-login.php"},
+		SuspiciousUserAgents: []string{"*sqlmap*"},
+		HoneypotScore:        5,
+		ScoreThreshold:       10,
+		ScoreWindow:          "1m",
+		DenyDuration:         "1h",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %s", err)
+	}
+	now := time.Unix(1500000000, 0)
+	tracker.now = func() time.Time { return now }
+
+	if !tracker.IsHoneypot("/wp-login.php") || tracker.IsHoneypot("/track") {
+		t.Error("Expected only /wp-login.php to be a honeypot")
+	}
+	if tracker.Observe("1.1.1.1", "/track", "Mozilla/5.0") || tracker.Denied("1.1.1.1") {
+		t.Error("Expected a normal request not to be scored")
+	}
+	if tracker.Observe("1.1.1.1", "/wp-login.php", "sqlmap/1.0") {
+		t.Error("Expected client to stay below the threshold")
+	}
+	now = now.Add(2 * time.Minute)
+	if tracker.Observe("1.1.1.1", "/wp-login.php", "") {
+		t.Error("Expected score to reset after the window")
+	}
+	if !tracker.Observe("1.1.1.1", "/wp-login.php", "") || !tracker.Denied("1.1.1.1") {
+		t.Error("Expected client to be denied at the threshold")
+	}
+	if tracker.Denied("2.2.2.2") {
+		t.Error("Expected other clients not to be denied")
+	}
+	now = now.Add(2 * time.Hour)
+	if tracker.Denied("1.1.1.1") {
+		t.Error("Expected deny to expire")
+	}
+}
+
+func TestTLSFingerprints(t *testing.T) {
+	tracker, err := NewTracker(Config{
+		SuspiciousTLSFingerprints: []string{"e7d705a3286e19ea42f587b344ee6865"},
+		TLSScore:                  5,
+		ScoreThreshold:            10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %s", err)
+	}
+	if tracker.Observe("1.1.1.1", "/track", "", "other") {
+		t.Error("Expected other fingerprints not to be scored")
+	}
+	if tracker.Observe("1.1.1.1", "/track", "", "e7d705a3286e19ea42f587b344ee6865") {
+		t.Error("Expected client to stay below the threshold")
+	}
+	if !tracker.Observe("1.1.1.1", "/track", "", "e7d705a3286e19ea42f587b344ee6865") {
+		t.Error("Expected client to be denied at the threshold")
+	}
+}
@@ -0,0 +1,399 @@
+/*
+Package accounting counts the events and payload bytes each tenant sends of
+each event name, for chargeback. Counts are kept in memory and every
+FlushInterval written as accounting records, one JSON object per line, to S3
+or a Kinesis stream:
+
+	{"start":"2017-06-01T13:00:00Z","end":"2017-06-01T13:01:00Z","instance":"i-1234",
+	 "tenant":"video-team","event":"minute-watched","count":5120,"bytes":3407872}
+
+The tenant is the authenticated producer, or the TenantHeader of requests
+without a token. Tenants and event names past their caps are counted under
+OtherKey, so a misbehaving client can't exhaust memory or flood the records.
+*/
+package accounting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// OtherKey is the tenant or event name counts past the caps are kept under.
+const OtherKey = "__other__"
+
+// NoTenant is the tenant of requests with neither a token nor a TenantHeader.
+const NoTenant = "none"
+
+const (
+	defaultFlushInterval = "1m"
+	defaultMaxTenants    = 100
+	defaultMaxEventNames = 5000
+
+	// maxPending is the most flushes kept to retry when writing them fails.
+	maxPending = 10
+
+	// maxRecordsPerPut is the most records Kinesis accepts per PutRecords.
+	maxRecordsPerPut = 500
+)
+
+// Config configures chargeback accounting.
+type Config struct {
+	// FlushInterval is how often accounting records are written, e.g. "1m"
+	FlushInterval string
+
+	// TenantHeader names the request header holding the tenant of requests
+	// without a token.
+	TenantHeader string
+
+	// MaxTenants and MaxEventNames cap the distinct tenants, and event names
+	// per tenant, counted between flushes. They default to 100 and 5000.
+	MaxTenants    int
+	MaxEventNames int
+
+	// Bucket is the S3 bucket records are uploaded to, gzipped, under Prefix.
+	Bucket string
+	Prefix string
+
+	// Stream is the Kinesis stream records are put to instead.
+	Stream string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if (c.Bucket == "") == (c.Stream == "") {
+		return errors.New("one of Bucket or Stream is required")
+	}
+	if c.FlushInterval == "" {
+		c.FlushInterval = defaultFlushInterval
+	}
+	d, err := time.ParseDuration(c.FlushInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FlushInterval, err)
+	}
+	if d <= 0 {
+		return errors.New("FlushInterval must be greater than 0")
+	}
+	if c.MaxTenants == 0 {
+		c.MaxTenants = defaultMaxTenants
+	}
+	if c.MaxEventNames == 0 {
+		c.MaxEventNames = defaultMaxEventNames
+	}
+	if c.MaxTenants < 0 || c.MaxEventNames < 0 {
+		return errors.New("MaxTenants and MaxEventNames must be positive values")
+	}
+	return nil
+}
+
+// Record is an accounting record: the events of a name a tenant sent in a
+// window.
+type Record struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Instance string    `json:"instance"`
+	Tenant   string    `json:"tenant"`
+	Event    string    `json:"event"`
+	Count    int64     `json:"count"`
+	Bytes    int64     `json:"bytes"`
+}
+
+type usage struct {
+	count int64
+	bytes int64
+}
+
+// RecordPutter is the part of the Kinesis API records are put with.
+type RecordPutter interface {
+	PutRecords(*kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error)
+}
+
+// Accountant counts usage and flushes it as accounting records.
+type Accountant struct {
+	config        Config
+	flushInterval time.Duration
+	instance      string
+	uploader      s3manageriface.UploaderAPI
+	putter        RecordPutter
+	stats         statsd.StatSender
+	now           func() time.Time
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]map[string]*usage
+
+	// pending are flushes that failed to be written, to retry.
+	pending [][]Record
+
+	runMu   sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns an Accountant for config, writing records from instance with
+// uploader if config has a Bucket, or putter if it has a Stream.
+func New(config Config, instance string, uploader s3manageriface.UploaderAPI, putter RecordPutter,
+	stats statsd.StatSender) (*Accountant, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	a := &Accountant{
+		config:   config,
+		instance: instance,
+		uploader: uploader,
+		putter:   putter,
+		stats:    stats,
+		now:      time.Now,
+		counts:   make(map[string]map[string]*usage),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	a.flushInterval, _ = time.ParseDuration(config.FlushInterval)
+	a.start = a.now()
+	return a, nil
+}
+
+// TenantHeader returns the header holding the tenant of requests without a
+// token, if one is configured.
+func (a *Accountant) TenantHeader() string {
+	return a.config.TenantHeader
+}
+
+// Observe counts the events in data, base64 encoded as logged, against
+// tenant. Each event's bytes are the size of its decoded JSON.
+func (a *Accountant) Observe(tenant, data string) {
+	events := decodeEvents(data)
+	if len(events) == 0 {
+		return
+	}
+	if tenant == "" {
+		tenant = NoTenant
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	names, ok := a.counts[tenant]
+	if !ok {
+		if len(a.counts) >= a.config.MaxTenants {
+			_ = a.stats.Inc("accounting.tenant_overflow", 1, 0.1)
+			tenant = OtherKey
+			names = a.counts[tenant]
+		}
+		if names == nil {
+			names = make(map[string]*usage)
+			a.counts[tenant] = names
+		}
+	}
+	for _, e := range events {
+		u, ok := names[e.name]
+		if !ok {
+			if len(names) >= a.config.MaxEventNames {
+				_ = a.stats.Inc("accounting.event_overflow", 1, 0.1)
+				e.name = OtherKey
+				u = names[e.name]
+			}
+			if u == nil {
+				u = &usage{}
+				names[e.name] = u
+			}
+		}
+		u.count++
+		u.bytes += int64(e.size)
+	}
+}
+
+type sizedEvent struct {
+	name string
+	size int
+}
+
+// decodeEvents returns the name and size of each event in data.
+func decodeEvents(data string) []sizedEvent {
+	decoded, err := spade.DetermineBase64Encoding([]byte(data)).DecodeString(data)
+	if err != nil {
+		return nil
+	}
+	decoded = bytes.TrimSpace(decoded)
+	var raw []json.RawMessage
+	if len(decoded) > 0 && decoded[0] == '[' {
+		if json.Unmarshal(decoded, &raw) != nil {
+			return nil
+		}
+	} else {
+		raw = []json.RawMessage{decoded}
+	}
+	events := make([]sizedEvent, 0, len(raw))
+	for _, r := range raw {
+		var named struct {
+			Event string `json:"event"`
+		}
+		if json.Unmarshal(r, &named) != nil {
+			continue
+		}
+		events = append(events, sizedEvent{name: named.Event, size: len(r)})
+	}
+	return events
+}
+
+// Flush writes the usage counted since the last flush as accounting records,
+// along with earlier flushes that failed to be written.
+func (a *Accountant) Flush() error {
+	now := a.now()
+	a.mu.Lock()
+	counts, start := a.counts, a.start
+	a.counts, a.start = make(map[string]map[string]*usage), now
+	a.mu.Unlock()
+
+	var records []Record
+	for tenant, names := range counts {
+		for name, u := range names {
+			records = append(records, Record{
+				Start:    start.UTC(),
+				End:      now.UTC(),
+				Instance: a.instance,
+				Tenant:   tenant,
+				Event:    name,
+				Count:    u.count,
+				Bytes:    u.bytes,
+			})
+		}
+	}
+	if len(records) > 0 {
+		a.pending = append(a.pending, records)
+	}
+	if len(a.pending) > maxPending {
+		_ = a.stats.Inc("accounting.dropped", int64(len(a.pending)-maxPending), 1)
+		a.pending = a.pending[len(a.pending)-maxPending:]
+	}
+	for len(a.pending) > 0 {
+		if err := a.write(a.pending[0]); err != nil {
+			_ = a.stats.Inc("accounting.flush_failed", 1, 1)
+			return err
+		}
+		_ = a.stats.Inc("accounting.records", int64(len(a.pending[0])), 1)
+		a.pending = a.pending[1:]
+	}
+	return nil
+}
+
+func (a *Accountant) write(records []Record) error {
+	lines := make([][]byte, len(records))
+	for i, r := range records {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		lines[i] = b
+	}
+	if a.config.Stream != "" {
+		return a.put(lines)
+	}
+	return a.upload(records[0].End, lines)
+}
+
+// upload uploads lines as a gzipped object keyed by the hour and the end of
+// the window.
+func (a *Accountant) upload(end time.Time, lines [][]byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	for _, line := range lines {
+		_, _ = gz.Write(line)
+		_, _ = gz.Write([]byte{'\n'})
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	key := path.Join(a.config.Prefix, end.Format("2006/01/02/15"),
+		a.instance+"-"+strconv.FormatInt(end.UnixNano(), 10)+".json.gz")
+	_, err := a.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(a.config.Bucket),
+		Key:    aws.String(key),
+		Body:   &buf,
+	})
+	return err
+}
+
+// put puts each line in a record of its own, retrying the ones Kinesis fails
+// once.
+func (a *Accountant) put(lines [][]byte) error {
+	for len(lines) > 0 {
+		n := len(lines)
+		if n > maxRecordsPerPut {
+			n = maxRecordsPerPut
+		}
+		batch := lines[:n]
+		lines = lines[n:]
+		for attempt := 0; len(batch) > 0; attempt++ {
+			if attempt > 1 {
+				return fmt.Errorf("%d accounting records failed to be put", len(batch))
+			}
+			entries := make([]*kinesis.PutRecordsRequestEntry, len(batch))
+			for i, line := range batch {
+				entries[i] = &kinesis.PutRecordsRequestEntry{Data: line, PartitionKey: aws.String(a.instance)}
+			}
+			out, err := a.putter.PutRecords(&kinesis.PutRecordsInput{
+				StreamName: aws.String(a.config.Stream),
+				Records:    entries,
+			})
+			if err != nil {
+				return err
+			}
+			var failed [][]byte
+			for i, r := range out.Records {
+				if r.ErrorCode != nil {
+					failed = append(failed, batch[i])
+				}
+			}
+			batch = failed
+		}
+	}
+	return nil
+}
+
+// Run flushes every FlushInterval until Close is called.
+func (a *Accountant) Run() {
+	a.runMu.Lock()
+	a.running = true
+	a.runMu.Unlock()
+	defer close(a.done)
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.quit:
+			return
+		case <-ticker.C:
+			if err := a.Flush(); err != nil {
+				logger.WithError(err).Error("Failed to write accounting records")
+			}
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return, and flushes the last window.
+func (a *Accountant) Close() {
+	close(a.quit)
+	a.runMu.Lock()
+	running := a.running
+	a.runMu.Unlock()
+	if running {
+		<-a.done
+	}
+	if err := a.Flush(); err != nil {
+		logger.WithError(err).Error("Failed to write accounting records")
+	}
+}
@@ -0,0 +1,152 @@
+package accounting
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func encode(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+type fakeUploader struct {
+	s3manageriface.UploaderAPI
+	keys    []string
+	records []Record
+	err     error
+}
+
+func (u *fakeUploader) Upload(input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	u.keys = append(u.keys, *input.Key)
+	gz, err := gzip.NewReader(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var r Record
+		if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		u.records = append(u.records, r)
+	}
+	return &s3manager.UploadOutput{}, nil
+}
+
+type fakePutter struct {
+	calls  int
+	failed int
+}
+
+func (p *fakePutter) PutRecords(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+	p.calls++
+	out := &kinesis.PutRecordsOutput{}
+	for range input.Records {
+		entry := &kinesis.PutRecordsResultEntry{}
+		if p.failed > 0 {
+			p.failed--
+			entry.ErrorCode = aws.String("ProvisionedThroughputExceededException")
+		}
+		out.Records = append(out.Records, entry)
+	}
+	return out, nil
+}
+
+func usageOf(records []Record) map[string]Record {
+	byKey := make(map[string]Record)
+	for _, r := range records {
+		byKey[r.Tenant+"/"+r.Event] = r
+	}
+	return byKey
+}
+
+func TestFlushToS3(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	uploader := &fakeUploader{}
+	a, err := New(Config{Bucket: "accounting", Prefix: "edge", MaxTenants: 2, MaxEventNames: 2},
+		"i-1234", uploader, nil, stats)
+	if err != nil {
+		t.Fatalf("Failed to create accountant: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+	a.start = now
+
+	a.Observe("video", encode(`[{"event":"play"},{"event":"play","properties":{"a":1}}]`))
+	a.Observe("video", encode(`{"event":"pause"}`))
+	a.Observe("video", encode(`{"event":"seek"}`))
+	a.Observe("", encode(`{"event":"play"}`))
+	a.Observe("chat", encode(`{"event":"message"}`))
+	a.Observe("video", "not base64")
+
+	uploader.err = errors.New("throttled")
+	now = now.Add(time.Minute)
+	if err = a.Flush(); err == nil {
+		t.Fatal("Expected the failed upload to be returned")
+	}
+	uploader.err = nil
+	now = now.Add(time.Minute)
+	if err = a.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %s", err)
+	}
+	if len(uploader.keys) != 1 || uploader.keys[0] != "edge/2017/06/01/13/i-1234-1496322060000000000.json.gz" {
+		t.Errorf("Expected the failed flush retried, got %v", uploader.keys)
+	}
+	usage := usageOf(uploader.records)
+	if r := usage["video/play"]; r.Count != 2 || r.Bytes != 53 || r.Instance != "i-1234" ||
+		!r.End.Equal(time.Date(2017, 6, 1, 13, 1, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected video play usage %+v", r)
+	}
+	if r := usage["video/"+OtherKey]; r.Count != 1 {
+		t.Errorf("Expected seek counted under %s, got %+v", OtherKey, usage)
+	}
+	if r := usage[NoTenant+"/play"]; r.Count != 1 {
+		t.Errorf("Expected untenanted play, got %+v", usage)
+	}
+	if r := usage[OtherKey+"/message"]; r.Count != 1 || len(usage) != 5 {
+		t.Errorf("Expected chat counted under %s, got %+v", OtherKey, usage)
+	}
+}
+
+func TestFlushToKinesis(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	putter := &fakePutter{failed: 1}
+	a, err := New(Config{Stream: "accounting"}, "i-1234", nil, putter, stats)
+	if err != nil {
+		t.Fatalf("Failed to create accountant: %s", err)
+	}
+	a.Observe("video", encode(`[{"event":"play"},{"event":"pause"}]`))
+	if err = a.Flush(); err != nil || putter.calls != 2 {
+		t.Errorf("Expected the failed record put again, got %d calls, %v", putter.calls, err)
+	}
+	if err = a.Flush(); err != nil || putter.calls != 2 {
+		t.Errorf("Expected nothing to put, got %d calls, %v", putter.calls, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{Bucket: "b", Stream: "s"},
+		{Bucket: "b", FlushInterval: "often"},
+		{Bucket: "b", MaxTenants: -1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
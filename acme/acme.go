@@ -0,0 +1,514 @@
+/*
+Package acme obtains and renews TLS certificates from an ACME CA like Let's
+Encrypt, so the edge can terminate TLS for its tracking domains without a
+certificate pipeline.
+
+A Manager serves the certificates of its hosts from its GetCertificate, which
+is installed in the edge's tls.Config, obtaining a host's certificate the first
+time it's asked for and renewing it before it expires. The CA is shown control
+of a host with a tls-alpn-01 challenge, answered in the TLS handshake of the
+listen addresses themselves, or an http-01 challenge, answered by the handler
+of HTTPHandler on port 80. Certificates and the account key are kept in a
+Cache, a local directory or an S3 bucket shared by a fleet.
+
+Certificates are counted under acme.certificate.<obtained|loaded|failed>, and
+those renewed under acme.certificate.renewed.
+*/
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+// The challenges a host's control can be shown with.
+const (
+	ChallengeTLSALPN = "tls-alpn-01"
+	ChallengeHTTP    = "http-01"
+)
+
+// ALPNProto is the protocol the CA negotiates to validate a tls-alpn-01
+// challenge. It must be in the tls.Config's NextProtos.
+const ALPNProto = "acme-tls/1"
+
+// LetsEncryptURL is the directory of Let's Encrypt's production CA.
+const LetsEncryptURL = "https://acme-v02.api.letsencrypt.org/directory"
+
+const (
+	defaultRenewBefore   = "720h"
+	defaultCheckInterval = "12h"
+
+	// obtainTimeout bounds ordering a certificate, challenges and all.
+	obtainTimeout = 5 * time.Minute
+
+	accountKey        = "acme_account+key"
+	httpChallengePath = "/.well-known/acme-challenge/"
+)
+
+// idPeACMEIdentifier is the extension of a tls-alpn-01 challenge certificate
+// holding the key authorization's digest.
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// Config configures the certificates obtained from an ACME CA.
+type Config struct {
+	// Hosts are the server names certificates are obtained for. Others are
+	// refused, so clients can't make the edge order certificates for any name
+	// pointed at it.
+	Hosts []string
+
+	// AcceptTOS agrees to the CA's terms of service, which it requires
+	AcceptTOS bool
+
+	// Email, if set, is the contact address of the account, which the CA
+	// warns of problems with its certificates
+	Email string
+
+	// DirectoryURL is the CA's ACME directory. It defaults to Let's Encrypt's.
+	DirectoryURL string
+
+	// Challenge is tls-alpn-01, answered on the listen addresses, or http-01,
+	// answered on HTTPAddress. It defaults to tls-alpn-01.
+	Challenge string
+
+	// HTTPAddress is the address http-01 challenges are answered on, e.g. ":80"
+	HTTPAddress string
+
+	// CacheDir, or CacheBucket under CachePrefix, is where certificates and
+	// the account key are kept
+	CacheDir    string
+	CacheBucket string
+	CachePrefix string
+
+	// RenewBefore is how long before they expire certificates are renewed,
+	// e.g. "720h"
+	RenewBefore string
+
+	// CheckInterval is how often certificates are checked for renewal, e.g.
+	// "12h"
+	CheckInterval string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if !c.AcceptTOS {
+		return errors.New("AcceptTOS must be set to agree to the CA's terms of service")
+	}
+	if c.DirectoryURL == "" {
+		c.DirectoryURL = LetsEncryptURL
+	}
+	switch c.Challenge {
+	case "":
+		c.Challenge = ChallengeTLSALPN
+	case ChallengeTLSALPN:
+	case ChallengeHTTP:
+		if c.HTTPAddress == "" {
+			return errors.New("HTTPAddress is required for http-01 challenges")
+		}
+	default:
+		return fmt.Errorf("Challenge must be %s or %s, got %q", ChallengeTLSALPN, ChallengeHTTP, c.Challenge)
+	}
+	if (c.CacheDir == "") == (c.CacheBucket == "") {
+		return errors.New("exactly one of CacheDir and CacheBucket is required")
+	}
+	for _, d := range []struct {
+		value *string
+		def   string
+	}{
+		{&c.RenewBefore, defaultRenewBefore},
+		{&c.CheckInterval, defaultCheckInterval},
+	} {
+		if *d.value == "" {
+			*d.value = d.def
+		}
+		parsed, err := time.ParseDuration(*d.value)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", *d.value, err)
+		}
+		if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", *d.value)
+		}
+	}
+	return nil
+}
+
+// call is an in-flight load or renewal of a host's certificate, which others
+// asking for it wait on.
+type call struct {
+	done chan struct{}
+	cert *tls.Certificate
+	err  error
+}
+
+// Manager obtains, renews and serves the certificates of its hosts.
+type Manager struct {
+	config      Config
+	cache       Cache
+	stats       statsd.StatSender
+	hosts       map[string]bool
+	renewBefore time.Duration
+	interval    time.Duration
+	now         func() time.Time
+	// ctx is cancelled by Close, abandoning orders in flight.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	clientMu sync.Mutex
+	client   *client
+
+	mu         sync.Mutex
+	certs      map[string]*tls.Certificate
+	calls      map[string]*call
+	tokens     map[string]string
+	challenges map[string]*tls.Certificate
+	running    bool
+	quit       chan struct{}
+	done       chan struct{}
+}
+
+// New returns a Manager for config that keeps what it obtains in cache.
+func New(config Config, cache Cache, stats statsd.StatSender) (*Manager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		config:     config,
+		cache:      cache,
+		stats:      stats,
+		hosts:      make(map[string]bool, len(config.Hosts)),
+		now:        time.Now,
+		certs:      make(map[string]*tls.Certificate),
+		calls:      make(map[string]*call),
+		tokens:     make(map[string]string),
+		challenges: make(map[string]*tls.Certificate),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	for _, host := range config.Hosts {
+		m.hosts[strings.ToLower(host)] = true
+	}
+	m.renewBefore, _ = time.ParseDuration(config.RenewBefore)
+	m.interval, _ = time.ParseDuration(config.CheckInterval)
+	return m, nil
+}
+
+// GetCertificate returns the certificate of the host the client asks for,
+// obtaining it if there's none yet, or the challenge certificate if it's the
+// CA validating a tls-alpn-01 challenge. It returns nil for hosts that aren't
+// the Manager's, to fall back to the tls.Config's Certificates.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	host := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	if !m.hosts[host] {
+		return nil, nil
+	}
+	for _, proto := range hello.SupportedProtos {
+		if proto == ALPNProto {
+			m.mu.Lock()
+			cert := m.challenges[host]
+			m.mu.Unlock()
+			if cert == nil {
+				return nil, fmt.Errorf("no %s challenge for %s", ChallengeTLSALPN, host)
+			}
+			return cert, nil
+		}
+	}
+	return m.certificate(host)
+}
+
+// HTTPHandler answers http-01 challenges, and nothing else.
+func (m *Manager) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, httpChallengePath) {
+			http.NotFound(w, r)
+			return
+		}
+		m.mu.Lock()
+		keyAuth, ok := m.tokens[strings.TrimPrefix(r.URL.Path, httpChallengePath)]
+		m.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(keyAuth))
+	})
+}
+
+// certificate returns host's certificate, loading it from the cache or
+// obtaining it if it isn't in memory or has expired.
+func (m *Manager) certificate(host string) (*tls.Certificate, error) {
+	m.mu.Lock()
+	cert := m.certs[host]
+	m.mu.Unlock()
+	if cert != nil && m.now().Before(cert.Leaf.NotAfter) {
+		return cert, nil
+	}
+	return m.once(host, func() (*tls.Certificate, error) {
+		if cert, err := m.load(host); err == nil {
+			_ = m.stats.Inc("acme.certificate.loaded", 1, 1)
+			return cert, nil
+		} else if err != ErrCacheMiss {
+			logger.WithError(err).WithField("host", host).Warn("Failed to load cached certificate")
+		}
+		return m.obtain(host)
+	})
+}
+
+// once runs fn for host unless it's already running, and keeps the
+// certificate it returns.
+func (m *Manager) once(host string, fn func() (*tls.Certificate, error)) (*tls.Certificate, error) {
+	m.mu.Lock()
+	if c, ok := m.calls[host]; ok {
+		m.mu.Unlock()
+		<-c.done
+		return c.cert, c.err
+	}
+	c := &call{done: make(chan struct{})}
+	m.calls[host] = c
+	m.mu.Unlock()
+
+	c.cert, c.err = fn()
+	m.mu.Lock()
+	delete(m.calls, host)
+	if c.err == nil {
+		m.certs[host] = c.cert
+	}
+	m.mu.Unlock()
+	close(c.done)
+	return c.cert, c.err
+}
+
+// load returns host's certificate from the cache, unless it has expired.
+func (m *Manager) load(host string) (*tls.Certificate, error) {
+	data, err := m.cache.Get(host)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+	if !m.now().Before(cert.Leaf.NotAfter) {
+		return nil, ErrCacheMiss
+	}
+	return cert, nil
+}
+
+// obtain orders a certificate for host from the CA and caches it.
+func (m *Manager) obtain(host string) (*tls.Certificate, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, obtainTimeout)
+	defer cancel()
+	cert, err := m.order(ctx, host)
+	if err != nil {
+		_ = m.stats.Inc("acme.certificate.failed", 1, 1)
+		return nil, fmt.Errorf("error obtaining certificate for %s: %v", host, err)
+	}
+	_ = m.stats.Inc("acme.certificate.obtained", 1, 1)
+	logger.WithField("host", host).WithField("expires", cert.Leaf.NotAfter).Info("Obtained certificate")
+	return cert, nil
+}
+
+func (m *Manager) order(ctx context.Context, host string) (*tls.Certificate, error) {
+	c, err := m.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chain, key, err := c.obtain(ctx, host, m.config.Challenge, m.accept)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// The key and chain are cached as one PEM file.
+	data := append(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), chain...)
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.cache.Put(host, data); err != nil {
+		logger.WithError(err).WithField("host", host).Warn("Failed to cache certificate")
+	}
+	return cert, nil
+}
+
+// account returns the client of the Manager's account, registering it the
+// first time. The account key is kept in the cache.
+func (m *Manager) account(ctx context.Context) (*client, error) {
+	m.clientMu.Lock()
+	defer m.clientMu.Unlock()
+	if m.client != nil {
+		return m.client, nil
+	}
+	var key *ecdsa.PrivateKey
+	data, err := m.cache.Get(accountKey)
+	switch err {
+	case nil:
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("cached account key isn't PEM encoded")
+		}
+		if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("error parsing cached account key: %v", err)
+		}
+	case ErrCacheMiss:
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+		der, _ := x509.MarshalECPrivateKey(key)
+		if err = m.cache.Put(accountKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})); err != nil {
+			return nil, fmt.Errorf("error caching account key: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("error loading account key: %v", err)
+	}
+	c := newClient(m.config.DirectoryURL, key)
+	if err = c.register(ctx, m.config.Email); err != nil {
+		return nil, err
+	}
+	m.client = c
+	return c, nil
+}
+
+// accept answers a challenge for host until the function it returns is
+// called.
+func (m *Manager) accept(host, token, keyAuth string) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.config.Challenge == ChallengeHTTP {
+		m.tokens[token] = keyAuth
+		return func() {
+			m.mu.Lock()
+			delete(m.tokens, token)
+			m.mu.Unlock()
+		}
+	}
+	cert, err := challengeCertificate(host, keyAuth)
+	if err != nil {
+		// The CA won't validate the challenge, which fails the order.
+		logger.WithError(err).WithField("host", host).Error("Failed to create challenge certificate")
+		return func() {}
+	}
+	m.challenges[host] = cert
+	return func() {
+		m.mu.Lock()
+		delete(m.challenges, host)
+		m.mu.Unlock()
+	}
+}
+
+// challengeCertificate returns the self-signed certificate answering a
+// tls-alpn-01 challenge for host with keyAuth.
+func challengeCertificate(host, keyAuth string) (*tls.Certificate, error) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	value, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(now.UnixNano()),
+		Subject:         pkix.Name{CommonName: host},
+		DNSNames:        []string{host},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(24 * time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: idPeACMEIdentifier, Critical: true, Value: value}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// parseCertificate parses a PEM encoded key and certificate chain.
+func parseCertificate(data []byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+// Renew obtains the certificates of the hosts that have none, and renews
+// those expiring within RenewBefore.
+func (m *Manager) Renew() {
+	hosts := make([]string, 0, len(m.hosts))
+	for host := range m.hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		cert, err := m.certificate(host)
+		if err == nil && m.now().Add(m.renewBefore).Before(cert.Leaf.NotAfter) {
+			continue
+		}
+		if err == nil {
+			if _, err = m.once(host, func() (*tls.Certificate, error) { return m.obtain(host) }); err == nil {
+				_ = m.stats.Inc("acme.certificate.renewed", 1, 1)
+			}
+		}
+		if err != nil {
+			logger.WithError(err).WithField("host", host).Error("Failed to renew certificate")
+		}
+	}
+}
+
+// Run renews certificates every CheckInterval, starting right away, until
+// Close is called.
+func (m *Manager) Run() {
+	m.mu.Lock()
+	m.running = true
+	m.mu.Unlock()
+	defer close(m.done)
+	m.Renew()
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.quit:
+			return
+		case <-ticker.C:
+			m.Renew()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (m *Manager) Close() {
+	m.cancel()
+	close(m.quit)
+	m.mu.Lock()
+	running := m.running
+	m.mu.Unlock()
+	if running {
+		<-m.done
+	}
+}
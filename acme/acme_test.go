@@ -0,0 +1,376 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// fakeCA is an ACME CA that verifies the requests signed by its accounts and
+// validates challenges by asking the Manager under test directly.
+type fakeCA struct {
+	t        *testing.T
+	server   *httptest.Server
+	key      *ecdsa.PrivateKey
+	cert     *x509.Certificate
+	validity time.Duration
+	manager  *Manager
+
+	mu       sync.Mutex
+	nonce    int
+	nonces   map[string]bool
+	accounts map[string]*ecdsa.PublicKey
+	orders   map[string]*fakeOrder
+	badNonce bool
+}
+
+type fakeOrder struct {
+	host    string
+	token   string
+	status  string
+	authz   string
+	certPEM []byte
+}
+
+func newFakeCA(t *testing.T) *fakeCA {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fake CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	cert, _ := x509.ParseCertificate(der)
+	ca := &fakeCA{
+		t:        t,
+		key:      key,
+		cert:     cert,
+		validity: 90 * 24 * time.Hour,
+		nonces:   make(map[string]bool),
+		accounts: make(map[string]*ecdsa.PublicKey),
+		orders:   make(map[string]*fakeOrder),
+	}
+	ca.server = httptest.NewServer(http.HandlerFunc(ca.serve))
+	return ca
+}
+
+func (ca *fakeCA) url(path string) string {
+	return ca.server.URL + path
+}
+
+func (ca *fakeCA) orderCount() int {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return len(ca.orders)
+}
+
+func (ca *fakeCA) serve(w http.ResponseWriter, r *http.Request) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.nonce++
+	nonce := fmt.Sprint(ca.nonce)
+	ca.nonces[nonce] = true
+	w.Header().Set("Replay-Nonce", nonce)
+
+	switch {
+	case r.URL.Path == "/directory":
+		_ = json.NewEncoder(w).Encode(directory{
+			NewNonce:   ca.url("/nonce"),
+			NewAccount: ca.url("/account"),
+			NewOrder:   ca.url("/order"),
+		})
+		return
+	case r.URL.Path == "/nonce":
+		return
+	}
+
+	payload, kid, err := ca.verify(r)
+	if err != nil {
+		ca.t.Errorf("Bad request to %s: %s", r.URL.Path, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if ca.badNonce {
+		ca.badNonce = false
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(Problem{Type: problemBadNonce})
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var o *fakeOrder
+	if len(parts) > 1 {
+		if o = ca.orders[parts[1]]; o == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
+	switch {
+	case parts[0] == "account":
+		w.Header().Set("Location", kid)
+		w.WriteHeader(http.StatusCreated)
+	case parts[0] == "order" && o == nil:
+		var req struct {
+			Identifiers []struct{ Value string } `json:"identifiers"`
+		}
+		_ = json.Unmarshal(payload, &req)
+		id := fmt.Sprint(len(ca.orders) + 1)
+		o = &fakeOrder{host: req.Identifiers[0].Value, token: "token" + id, status: "pending", authz: "pending"}
+		ca.orders[id] = o
+		w.Header().Set("Location", ca.url("/order/"+id))
+		w.WriteHeader(http.StatusCreated)
+		ca.writeOrder(w, id, o)
+	case parts[0] == "order":
+		ca.writeOrder(w, parts[1], o)
+	case parts[0] == "authz":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":     o.authz,
+			"identifier": map[string]string{"type": "dns", "value": o.host},
+			"challenges": []challenge{
+				{Type: ChallengeHTTP, URL: ca.url("/challenge/" + parts[1]), Token: o.token},
+				{Type: ChallengeTLSALPN, URL: ca.url("/challenge/" + parts[1]), Token: o.token},
+			},
+		})
+	case parts[0] == "challenge":
+		keyAuth := o.token + "." + ca.thumbprint(kid)
+		if ca.validate(o.host, o.token, keyAuth) {
+			o.authz = "valid"
+		} else {
+			o.authz = "invalid"
+		}
+		_, _ = w.Write([]byte("{}"))
+	case parts[0] == "finalize":
+		var req struct{ CSR string }
+		_ = json.Unmarshal(payload, &req)
+		csrDER, _ := b64.DecodeString(req.CSR)
+		csr, err := x509.ParseCertificateRequest(csrDER)
+		if o.authz != "valid" || err != nil || len(csr.DNSNames) != 1 || csr.DNSNames[0] != o.host {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(time.Now().UnixNano()),
+			Subject:      pkix.Name{CommonName: o.host},
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(ca.validity),
+		}
+		der, _ := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+		o.certPEM = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})...)
+		o.status = "valid"
+		ca.writeOrder(w, parts[1], o)
+	case parts[0] == "cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(o.certPEM)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (ca *fakeCA) writeOrder(w http.ResponseWriter, id string, o *fakeOrder) {
+	resp := map[string]interface{}{
+		"status":         o.status,
+		"authorizations": []string{ca.url("/authz/" + id)},
+		"finalize":       ca.url("/finalize/" + id),
+	}
+	if o.status == "valid" {
+		resp["certificate"] = ca.url("/cert/" + id)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// verify checks the JWS of a request, returning its payload and the URL of
+// the account that signed it.
+func (ca *fakeCA) verify(r *http.Request) ([]byte, string, error) {
+	var jws struct{ Protected, Payload, Signature string }
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, "", err
+	}
+	protectedJSON, _ := b64.DecodeString(jws.Protected)
+	var protected struct {
+		Alg, Nonce, URL, Kid string
+		JWK                  map[string]string
+	}
+	if err := json.Unmarshal(protectedJSON, &protected); err != nil {
+		return nil, "", err
+	}
+	if !ca.nonces[protected.Nonce] {
+		return nil, "", fmt.Errorf("nonce %q wasn't issued or was used", protected.Nonce)
+	}
+	delete(ca.nonces, protected.Nonce)
+	if protected.URL != ca.url(r.URL.Path) {
+		return nil, "", fmt.Errorf("signed for %s", protected.URL)
+	}
+	kid := protected.Kid
+	if protected.JWK != nil {
+		x, _ := b64.DecodeString(protected.JWK["x"])
+		y, _ := b64.DecodeString(protected.JWK["y"])
+		kid = ca.url("/account/" + protected.JWK["x"])
+		ca.accounts[kid] = &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+	}
+	key := ca.accounts[kid]
+	if key == nil {
+		return nil, "", fmt.Errorf("unknown account %q", kid)
+	}
+	signature, _ := b64.DecodeString(jws.Signature)
+	hash := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	if len(signature) != 64 || !ecdsa.Verify(key, hash[:],
+		new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+		return nil, "", fmt.Errorf("bad signature")
+	}
+	payload, _ := b64.DecodeString(jws.Payload)
+	return payload, kid, nil
+}
+
+func (ca *fakeCA) thumbprint(kid string) string {
+	key := ca.accounts[kid]
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+	encoded := fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, b64.EncodeToString(x), b64.EncodeToString(y))
+	sum := sha256.Sum256([]byte(encoded))
+	return b64.EncodeToString(sum[:])
+}
+
+// validate asks the Manager for the answer to a challenge, as the CA would
+// over the network.
+func (ca *fakeCA) validate(host, token, keyAuth string) bool {
+	if ca.manager.config.Challenge == ChallengeHTTP {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "http://"+host+httpChallengePath+token, nil)
+		ca.manager.HTTPHandler().ServeHTTP(recorder, req)
+		return recorder.Code == http.StatusOK && recorder.Body.String() == keyAuth
+	}
+	cert, err := ca.manager.GetCertificate(&tls.ClientHelloInfo{ServerName: host, SupportedProtos: []string{ALPNProto}})
+	if err != nil {
+		return false
+	}
+	leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+	digest := sha256.Sum256([]byte(keyAuth))
+	for _, ext := range leaf.Extensions {
+		var value []byte
+		if ext.Id.Equal(idPeACMEIdentifier) && ext.Critical {
+			_, _ = asn1.Unmarshal(ext.Value, &value)
+			return string(value) == string(digest[:])
+		}
+	}
+	return false
+}
+
+func newTestManager(t *testing.T, ca *fakeCA, challengeType, cacheDir string) *Manager {
+	stats, _ := statsd.NewNoop()
+	m, err := New(Config{
+		Hosts:        []string{"edge.example.com"},
+		AcceptTOS:    true,
+		DirectoryURL: ca.url("/directory"),
+		Challenge:    challengeType,
+		HTTPAddress:  ":80",
+		CacheDir:     cacheDir,
+	}, DirCache(cacheDir), stats)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %s", err)
+	}
+	ca.manager = m
+	return m
+}
+
+func TestGetCertificate(t *testing.T) {
+	for _, challengeType := range []string{ChallengeHTTP, ChallengeTLSALPN} {
+		ca := newFakeCA(t)
+		cacheDir, _ := ioutil.TempDir("", "acme")
+		m := newTestManager(t, ca, challengeType, cacheDir)
+		ca.badNonce = true
+
+		cert, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "Edge.example.com."})
+		if err != nil {
+			t.Fatalf("Failed to get a certificate with %s: %s", challengeType, err)
+		}
+		if err = cert.Leaf.VerifyHostname("edge.example.com"); err != nil {
+			t.Errorf("Expected a certificate for the host, got %s", err)
+		}
+		if again, _ := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "edge.example.com"}); again != cert {
+			t.Error("Expected the certificate to be kept")
+		}
+		if other, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "other.example.com"}); other != nil || err != nil {
+			t.Errorf("Expected no certificate for another host, got %v, %v", other, err)
+		}
+
+		// Another edge sharing the cache loads the certificate instead of
+		// ordering one.
+		restarted := newTestManager(t, ca, challengeType, cacheDir)
+		if cert, err = restarted.GetCertificate(&tls.ClientHelloInfo{ServerName: "edge.example.com"}); err != nil || cert == nil {
+			t.Errorf("Expected the cached certificate, got %v", err)
+		}
+		if ca.orderCount() != 1 {
+			t.Errorf("Expected a single order with %s, got %d", challengeType, ca.orderCount())
+		}
+		ca.server.Close()
+		_ = os.RemoveAll(cacheDir)
+	}
+}
+
+func TestRenew(t *testing.T) {
+	ca := newFakeCA(t)
+	defer ca.server.Close()
+	cacheDir, _ := ioutil.TempDir("", "acme")
+	defer func() { _ = os.RemoveAll(cacheDir) }()
+	m := newTestManager(t, ca, ChallengeTLSALPN, cacheDir)
+
+	m.Renew()
+	m.Renew()
+	if ca.orderCount() != 1 {
+		t.Fatalf("Expected a certificate to be obtained and kept, got %d orders", ca.orderCount())
+	}
+	// The certificate now expires within RenewBefore.
+	m.now = func() time.Time { return time.Now().Add(80 * 24 * time.Hour) }
+	m.Renew()
+	if ca.orderCount() != 2 {
+		t.Errorf("Expected the certificate to be renewed, got %d orders", ca.orderCount())
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, tt := range []struct {
+		config Config
+		valid  bool
+	}{
+		{Config{AcceptTOS: true, CacheDir: "/tmp/acme"}, true},
+		{Config{CacheDir: "/tmp/acme"}, false},
+		{Config{AcceptTOS: true}, false},
+		{Config{AcceptTOS: true, CacheDir: "/tmp/acme", CacheBucket: "certs"}, false},
+		{Config{AcceptTOS: true, CacheDir: "/tmp/acme", Challenge: ChallengeHTTP}, false},
+		{Config{AcceptTOS: true, CacheDir: "/tmp/acme", Challenge: "dns-01"}, false},
+		{Config{AcceptTOS: true, CacheDir: "/tmp/acme", RenewBefore: "soon"}, false},
+	} {
+		if err := tt.config.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Expected %+v to be valid: %v, got %v", tt.config, tt.valid, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package acme
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// ErrCacheMiss is returned by a Cache that doesn't have a key.
+var ErrCacheMiss = errors.New("acme: cache miss")
+
+// Cache keeps the account key and the certificates obtained, so restarts
+// don't order them again and run into the CA's rate limits.
+type Cache interface {
+	// Get returns the data kept under key, or ErrCacheMiss.
+	Get(key string) ([]byte, error)
+	Put(key string, data []byte) error
+}
+
+// DirCache is a Cache keeping each key in a file of the directory.
+type DirCache string
+
+// Get returns the contents of key's file.
+func (d DirCache) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(string(d), key))
+	if os.IsNotExist(err) {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+// Put replaces key's file with one holding data, readable only by the edge.
+func (d DirCache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(string(d), key+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(string(d), key))
+}
+
+// S3Cache is a Cache keeping each key in an object of Bucket, under Prefix,
+// so the edges of a fleet share their certificates.
+type S3Cache struct {
+	Client s3iface.S3API
+	Bucket string
+	Prefix string
+}
+
+// Get returns the contents of key's object.
+func (c *S3Cache) Get(key string) ([]byte, error) {
+	out, err := c.Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(c.Prefix + key),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchKey" {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = out.Body.Close() }()
+	return ioutil.ReadAll(out.Body)
+}
+
+// Put replaces key's object with one holding data, encrypted at rest.
+func (c *S3Cache) Put(key string, data []byte) error {
+	_, err := c.Client.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(c.Bucket),
+		Key:                  aws.String(c.Prefix + key),
+		Body:                 bytes.NewReader(data),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+	return err
+}
@@ -0,0 +1,382 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	problemBadNonce = "urn:ietf:params:acme:error:badNonce"
+
+	// maxBadNonceRetries is how many times a request is resent with a fresh
+	// nonce when the CA rejects the one it was sent with.
+	maxBadNonceRetries = 3
+
+	defaultPollInterval = time.Second
+)
+
+var b64 = base64.RawURLEncoding
+
+// Problem is an error response of the CA.
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Type, p.Detail)
+}
+
+// directory holds the URLs of the CA's resources.
+type directory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type order struct {
+	Status         string   `json:"status"`
+	Authorizations []string `json:"authorizations"`
+	Finalize       string   `json:"finalize"`
+	Certificate    string   `json:"certificate"`
+	Error          *Problem `json:"error"`
+}
+
+type authorization struct {
+	Status     string `json:"status"`
+	Identifier struct {
+		Value string `json:"value"`
+	} `json:"identifier"`
+	Challenges []challenge `json:"challenges"`
+}
+
+type challenge struct {
+	Type   string   `json:"type"`
+	URL    string   `json:"url"`
+	Token  string   `json:"token"`
+	Status string   `json:"status"`
+	Error  *Problem `json:"error"`
+}
+
+// client speaks ACME (RFC 8555) to a CA on behalf of an account.
+type client struct {
+	directoryURL string
+	key          *ecdsa.PrivateKey
+	http         *http.Client
+	pollInterval time.Duration
+
+	dir directory
+	// kid is the account's URL, set once it's registered.
+	kid string
+
+	mu     sync.Mutex
+	nonces []string
+}
+
+func newClient(directoryURL string, key *ecdsa.PrivateKey) *client {
+	return &client{
+		directoryURL: directoryURL,
+		key:          key,
+		http:         &http.Client{Timeout: 30 * time.Second},
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// register fetches the CA's directory and registers the account, or looks it
+// up if it's already registered, agreeing to the CA's terms of service.
+func (c *client) register(ctx context.Context, email string) error {
+	req, err := http.NewRequest("GET", c.directoryURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d fetching the directory", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&c.dir); err != nil {
+		return fmt.Errorf("error decoding the directory: %v", err)
+	}
+
+	account := map[string]interface{}{"termsOfServiceAgreed": true}
+	if email != "" {
+		account["contact"] = []string{"mailto:" + email}
+	}
+	resp, _, err = c.post(ctx, c.dir.NewAccount, account, nil)
+	if err != nil {
+		return fmt.Errorf("error registering account: %v", err)
+	}
+	if c.kid = resp.Header.Get("Location"); c.kid == "" {
+		return errors.New("the CA didn't return an account URL")
+	}
+	return nil
+}
+
+// obtain orders a certificate for host, proving control of it with the
+// challenge of type challengeType. accept is called to start answering a
+// challenge with its token and key authorization, and returns a function that
+// stops answering it. It returns the PEM encoded certificate chain and its key.
+func (c *client) obtain(ctx context.Context, host, challengeType string,
+	accept func(host, token, keyAuth string) func()) ([]byte, *ecdsa.PrivateKey, error) {
+	var o order
+	identifiers := []map[string]string{{"type": "dns", "value": host}}
+	resp, _, err := c.post(ctx, c.dir.NewOrder, map[string]interface{}{"identifiers": identifiers}, &o)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating order: %v", err)
+	}
+	orderURL := resp.Header.Get("Location")
+	for _, authzURL := range o.Authorizations {
+		if err = c.authorize(ctx, authzURL, challengeType, accept); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: host},
+		DNSNames: []string{host},
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp, _, err = c.post(ctx, o.Finalize, map[string]string{"csr": b64.EncodeToString(csr)}, &o); err != nil {
+		return nil, nil, fmt.Errorf("error finalizing order: %v", err)
+	}
+	for o.Status != "valid" {
+		if o.Status == "invalid" {
+			return nil, nil, fmt.Errorf("order for %s is invalid: %v", host, o.Error)
+		}
+		if err = c.wait(ctx, resp); err != nil {
+			return nil, nil, err
+		}
+		if resp, _, err = c.post(ctx, orderURL, nil, &o); err != nil {
+			return nil, nil, fmt.Errorf("error polling order: %v", err)
+		}
+	}
+	_, chain, err := c.post(ctx, o.Certificate, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error downloading certificate: %v", err)
+	}
+	return chain, key, nil
+}
+
+// authorize completes the authorization at authzURL, if it isn't already.
+func (c *client) authorize(ctx context.Context, authzURL, challengeType string,
+	accept func(host, token, keyAuth string) func()) error {
+	var a authorization
+	resp, _, err := c.post(ctx, authzURL, nil, &a)
+	if err != nil {
+		return fmt.Errorf("error fetching authorization: %v", err)
+	}
+	if a.Status == "valid" {
+		return nil
+	}
+	var ch *challenge
+	for i := range a.Challenges {
+		if a.Challenges[i].Type == challengeType {
+			ch = &a.Challenges[i]
+			break
+		}
+	}
+	if ch == nil {
+		return fmt.Errorf("the CA offers no %s challenge for %s", challengeType, a.Identifier.Value)
+	}
+	stop := accept(a.Identifier.Value, ch.Token, ch.Token+"."+c.thumbprint())
+	defer stop()
+	if _, _, err = c.post(ctx, ch.URL, struct{}{}, nil); err != nil {
+		return fmt.Errorf("error accepting challenge: %v", err)
+	}
+	for {
+		switch a.Status {
+		case "valid":
+			return nil
+		case "invalid":
+			for _, failed := range a.Challenges {
+				if failed.Error != nil {
+					return fmt.Errorf("%s failed for %s: %v", failed.Type, a.Identifier.Value, failed.Error)
+				}
+			}
+			return fmt.Errorf("authorization of %s is invalid", a.Identifier.Value)
+		}
+		if err = c.wait(ctx, resp); err != nil {
+			return err
+		}
+		if resp, _, err = c.post(ctx, authzURL, nil, &a); err != nil {
+			return fmt.Errorf("error polling authorization: %v", err)
+		}
+	}
+}
+
+// wait waits as long as resp's Retry-After asks, or the poll interval.
+func (c *client) wait(ctx context.Context, resp *http.Response) error {
+	d := c.pollInterval
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		d = time.Duration(seconds) * time.Second
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// post sends payload to url signed with the account key, or a POST-as-GET
+// if it's nil, decoding the JSON response into v if it's set. It returns the
+// response, whose body is read and closed, and the body.
+func (c *client) post(ctx context.Context, url string, payload interface{}, v interface{}) (*http.Response, []byte, error) {
+	var encoded []byte
+	if payload != nil {
+		var err error
+		if encoded, err = json.Marshal(payload); err != nil {
+			return nil, nil, err
+		}
+	}
+	for retries := 0; ; retries++ {
+		nonce, err := c.nonce(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting nonce: %v", err)
+		}
+		body, err := c.sign(url, nonce, encoded)
+		if err != nil {
+			return nil, nil, err
+		}
+		req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Content-Type", "application/jose+json")
+		resp, err := c.http.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, nil, err
+		}
+		c.saveNonce(resp)
+		respBody, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode >= 400 {
+			p := &Problem{Status: resp.StatusCode}
+			_ = json.Unmarshal(respBody, p)
+			if p.Type == problemBadNonce && retries < maxBadNonceRetries {
+				continue
+			}
+			return nil, nil, p
+		}
+		if v != nil {
+			if err = json.Unmarshal(respBody, v); err != nil {
+				return nil, nil, fmt.Errorf("error decoding response of %s: %v", url, err)
+			}
+		}
+		return resp, respBody, nil
+	}
+}
+
+// nonce returns a nonce saved from a response, or a new one.
+func (c *client) nonce(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if n := len(c.nonces); n > 0 {
+		nonce := c.nonces[n-1]
+		c.nonces = c.nonces[:n-1]
+		c.mu.Unlock()
+		return nonce, nil
+	}
+	c.mu.Unlock()
+	req, err := http.NewRequest("HEAD", c.dir.NewNonce, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.http.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("no nonce in response")
+	}
+	return nonce, nil
+}
+
+func (c *client) saveNonce(resp *http.Response) {
+	if nonce := resp.Header.Get("Replay-Nonce"); nonce != "" {
+		c.mu.Lock()
+		c.nonces = append(c.nonces, nonce)
+		c.mu.Unlock()
+	}
+}
+
+// sign returns the flattened JWS of payload for url, identifying the account
+// by its URL once it's registered and by its key until then.
+func (c *client) sign(url, nonce string, payload []byte) ([]byte, error) {
+	protected := map[string]interface{}{"alg": "ES256", "nonce": nonce, "url": url}
+	if c.kid == "" {
+		protected["jwk"] = c.jwk()
+	} else {
+		protected["kid"] = c.kid
+	}
+	encodedProtected, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	p, pl := b64.EncodeToString(encodedProtected), b64.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(p + "." + pl))
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return json.Marshal(map[string]string{
+		"protected": p,
+		"payload":   pl,
+		"signature": b64.EncodeToString(signature),
+	})
+}
+
+// jwk returns the account's public key as a JWK. Its members marshal in the
+// lexicographic order its thumbprint is computed with.
+func (c *client) jwk() map[string]string {
+	x, y := make([]byte, 32), make([]byte, 32)
+	c.key.X.FillBytes(x)
+	c.key.Y.FillBytes(y)
+	return map[string]string{
+		"crv": "P-256",
+		"kty": "EC",
+		"x":   b64.EncodeToString(x),
+		"y":   b64.EncodeToString(y),
+	}
+}
+
+// thumbprint returns the RFC 7638 thumbprint of the account's key.
+func (c *client) thumbprint() string {
+	encoded, _ := json.Marshal(c.jwk())
+	sum := sha256.Sum256(encoded)
+	return b64.EncodeToString(sum[:])
+}
@@ -0,0 +1,221 @@
+/*
+Package admission sheds load before it can overwhelm the edge. A Controller
+admits a request only if the number of requests in flight is below an
+adaptive limit and the request rate is below a fixed ceiling; everything else
+is turned away early with a 503 and a Retry-After header so clients back off.
+
+The in-flight limit follows a gradient algorithm: while request latency stays
+close to its long-term baseline the limit grows towards MaxInFlight, and once
+latency rises (requests are queueing on the sinks) the limit shrinks in
+proportion, shedding load before the edge collapses.
+*/
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+const (
+	defaultMinInFlight    = 64
+	defaultRetryAfter     = "1s"
+	defaultUpdateInterval = "1s"
+
+	// baselineSmoothing is how quickly the latency baseline follows recent
+	// latency; it is slow so sustained overload still lowers the gradient.
+	baselineSmoothing = 0.05
+	// limitSmoothing damps changes to the limit between updates.
+	limitSmoothing = 0.2
+	// minGradient bounds how much the limit can drop in a single update.
+	minGradient = 0.5
+)
+
+// Config configures admission control.
+type Config struct {
+	// MaxInFlight is the most requests handled at once. The adaptive limit
+	// never exceeds it.
+	MaxInFlight int
+
+	// MinInFlight is the least the adaptive limit can drop to
+	MinInFlight int
+
+	// MaxEventsPerSecond caps the rate of admitted requests, each of which
+	// counts as one event since the events aren't decoded yet. 0 disables it.
+	MaxEventsPerSecond float64
+
+	// RetryAfter is sent to shed clients, e.g. "1s"
+	RetryAfter string
+
+	// UpdateInterval is how often the adaptive limit is recomputed, e.g. "1s"
+	UpdateInterval string
+
+	// ExemptPaths are never shed, e.g. the load balancer health check
+	ExemptPaths []string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.MaxInFlight <= 0 {
+		return errors.New("MaxInFlight must be greater than 0")
+	}
+	if c.MinInFlight == 0 {
+		c.MinInFlight = defaultMinInFlight
+		if c.MinInFlight > c.MaxInFlight {
+			c.MinInFlight = c.MaxInFlight
+		}
+	}
+	if c.MinInFlight < 0 || c.MinInFlight > c.MaxInFlight {
+		return errors.New("MinInFlight must be between 1 and MaxInFlight")
+	}
+	if c.MaxEventsPerSecond < 0 {
+		return errors.New("MaxEventsPerSecond must not be negative")
+	}
+	if c.RetryAfter == "" {
+		c.RetryAfter = defaultRetryAfter
+	}
+	if c.UpdateInterval == "" {
+		c.UpdateInterval = defaultUpdateInterval
+	}
+	for _, d := range []string{c.RetryAfter, c.UpdateInterval} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// Controller decides whether to admit requests.
+type Controller struct {
+	maxInFlight    float64
+	minInFlight    float64
+	rate           float64
+	retryAfter     string
+	updateInterval time.Duration
+	exempt         map[string]bool
+	stats          statsd.StatSender
+	now            func() time.Time
+
+	mu       sync.Mutex
+	inFlight int
+	limit    float64
+
+	// latency tracking for the gradient
+	baseline    float64
+	sampleSum   float64
+	sampleCount int
+	lastUpdate  time.Time
+
+	// token bucket for the rate ceiling
+	tokens     float64
+	lastRefill time.Time
+}
+
+// New returns a Controller for config.
+func New(config Config, stats statsd.StatSender) (*Controller, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	retryAfter, _ := time.ParseDuration(config.RetryAfter)
+	updateInterval, _ := time.ParseDuration(config.UpdateInterval)
+	c := &Controller{
+		maxInFlight:    float64(config.MaxInFlight),
+		minInFlight:    float64(config.MinInFlight),
+		rate:           config.MaxEventsPerSecond,
+		retryAfter:     strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))),
+		updateInterval: updateInterval,
+		exempt:         make(map[string]bool, len(config.ExemptPaths)),
+		stats:          stats,
+		now:            time.Now,
+		limit:          float64(config.MaxInFlight),
+		tokens:         config.MaxEventsPerSecond,
+	}
+	for _, p := range config.ExemptPaths {
+		c.exempt[p] = true
+	}
+	c.lastUpdate = c.now()
+	c.lastRefill = c.lastUpdate
+	return c, nil
+}
+
+// acquire admits a request if possible, returning the reason it was shed
+// otherwise.
+func (c *Controller) acquire() (time.Time, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+
+	if float64(c.inFlight) >= c.limit {
+		return now, "in_flight"
+	}
+	if c.rate > 0 {
+		c.tokens = math.Min(c.rate, c.tokens+now.Sub(c.lastRefill).Seconds()*c.rate)
+		c.lastRefill = now
+		if c.tokens < 1 {
+			return now, "rate"
+		}
+		c.tokens--
+	}
+	c.inFlight++
+	return now, ""
+}
+
+// release records the latency of an admitted request and updates the limit
+// once per UpdateInterval.
+func (c *Controller) release(start time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.inFlight--
+	c.sampleSum += float64(now.Sub(start))
+	c.sampleCount++
+
+	if now.Sub(c.lastUpdate) < c.updateInterval {
+		return
+	}
+	c.lastUpdate = now
+	latency := c.sampleSum / float64(c.sampleCount)
+	c.sampleSum, c.sampleCount = 0, 0
+	if latency <= 0 {
+		return
+	}
+	if c.baseline == 0 {
+		c.baseline = latency
+	}
+
+	gradient := math.Max(minGradient, math.Min(1, c.baseline/latency))
+	// Allow a queue of sqrt(limit) so the limit can grow while latency holds.
+	next := c.limit*gradient + math.Sqrt(c.limit)
+	c.limit = c.limit*(1-limitSmoothing) + next*limitSmoothing
+	c.limit = math.Max(c.minInFlight, math.Min(c.maxInFlight, c.limit))
+	c.baseline = c.baseline*(1-baselineSmoothing) + latency*baselineSmoothing
+
+	_ = c.stats.Gauge("admission.limit", int64(c.limit), 1)
+}
+
+// Handler returns a handler that sheds requests to next that aren't admitted.
+func (c *Controller) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.exempt[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start, reason := c.acquire()
+		if reason != "" {
+			_ = c.stats.Inc("admission.shed."+reason, 1, 0.1)
+			w.Header().Set("Retry-After", c.retryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		defer c.release(start)
+		next.ServeHTTP(w, r)
+	})
+}
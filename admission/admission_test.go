@@ -0,0 +1,116 @@
+package admission
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (f *fakeClock) now() time.Time { return f.t }
+
+func newTestController(t *testing.T, config Config) (*Controller, *fakeClock) {
+	stats, _ := statsd.NewNoop()
+	c, err := New(config, stats)
+	if err != nil {
+		t.Fatalf("Failed to create controller: %s", err)
+	}
+	clock := &fakeClock{t: time.Unix(1500000000, 0)}
+	c.now = clock.now
+	c.lastUpdate, c.lastRefill = clock.t, clock.t
+	return c, clock
+}
+
+func TestInFlightLimit(t *testing.T) {
+	c, _ := newTestController(t, Config{MaxInFlight: 2, ExemptPaths: []string{"/healthcheck"}})
+
+	block := make(chan struct{})
+	handler := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.example.com"+path, nil)
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			serve("/")
+			done <- struct{}{}
+		}()
+	}
+	for {
+		c.mu.Lock()
+		inFlight := c.inFlight
+		c.mu.Unlock()
+		if inFlight == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := serve("/")
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected request over the limit to be shed, got %d", rec.Code)
+	}
+	go func() {
+		serve("/healthcheck")
+		done <- struct{}{}
+	}()
+	close(block)
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c, clock := newTestController(t, Config{MaxInFlight: 100, MaxEventsPerSecond: 2})
+	for i := 0; i < 2; i++ {
+		start, reason := c.acquire()
+		if reason != "" {
+			t.Fatalf("Expected request %d to be admitted, got %s", i, reason)
+		}
+		c.release(start)
+	}
+	if _, reason := c.acquire(); reason != "rate" {
+		t.Errorf("Expected request over the rate to be shed, got %q", reason)
+	}
+	clock.t = clock.t.Add(time.Second)
+	if _, reason := c.acquire(); reason != "" {
+		t.Errorf("Expected tokens to refill, got %q", reason)
+	}
+}
+
+func TestAdaptiveLimit(t *testing.T) {
+	c, clock := newTestController(t, Config{MaxInFlight: 1000, MinInFlight: 10})
+	request := func(latency time.Duration) {
+		clock.t = clock.t.Add(time.Second)
+		start, reason := c.acquire()
+		if reason != "" {
+			t.Fatalf("Expected request to be admitted, got %s", reason)
+		}
+		clock.t = clock.t.Add(latency)
+		c.release(start)
+	}
+
+	// Establish a 10ms baseline, then let latency climb tenfold.
+	for i := 0; i < 10; i++ {
+		request(10 * time.Millisecond)
+	}
+	healthy := c.limit
+	for i := 0; i < 20; i++ {
+		request(100 * time.Millisecond)
+	}
+	if c.limit >= healthy || c.limit < 10 {
+		t.Errorf("Expected limit to drop from %v when latency rose, got %v", healthy, c.limit)
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Package advisor recommends how many shards the edge's Kinesis streams need for
+the traffic they sustain, and can reshard them to match.
+
+Every Interval, the per-minute IncomingRecords and IncomingBytes of each stream
+over the last Window are read from CloudWatch, so the rates are those of every
+edge writing to the stream rather than this one's. The sustained rate is the
+Percentile of the per-minute rates, which ignores short bursts the stream's
+retries absorb. A shard takes 1000 records and 1 MiB per second, and the
+recommendation is the shards needed to carry the sustained rate at
+TargetUtilization of their capacity, between MinShards and MaxShards.
+
+The rates, the stream's open shards and the recommendation are sent as gauges
+
+	advisor.<stream>.records_per_second, advisor.<stream>.bytes_per_second
+	advisor.<stream>.shards, advisor.<stream>.recommended_shards
+
+and a recommendation differing from the open shards is logged. With AutoScale
+set, an active stream is then resharded with UpdateShardCount, at most
+doubling or halving it at once as Kinesis requires.
+*/
+package advisor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultInterval          = "5m"
+	defaultWindow            = "1h"
+	defaultPercentile        = 0.9
+	defaultTargetUtilization = 0.7
+	defaultMinShards         = 1
+
+	// The write capacity of a shard.
+	shardRecordsPerSecond = 1000
+	shardBytesPerSecond   = 1 << 20
+)
+
+// Config configures the throughput advisor.
+type Config struct {
+	// Streams are the streams advised on. They default to the EventStream.
+	Streams []string
+
+	// Interval is how often the streams are checked, e.g. "5m"
+	Interval string
+
+	// Window is how far back rates are read, e.g. "1h"
+	Window string
+
+	// Percentile of the per-minute rates in Window is the sustained rate,
+	// between 0 and 1. It defaults to 0.9.
+	Percentile float64
+
+	// TargetUtilization is the fraction of their capacity shards should be
+	// sized to use at the sustained rate. It defaults to 0.7.
+	TargetUtilization float64
+
+	// MinShards and MaxShards bound the recommendation. MinShards defaults
+	// to 1, and MaxShards is required with AutoScale.
+	MinShards int
+	MaxShards int
+
+	// AutoScale calls UpdateShardCount to reshard streams to the
+	// recommendation.
+	AutoScale bool
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Interval == "" {
+		c.Interval = defaultInterval
+	}
+	if c.Window == "" {
+		c.Window = defaultWindow
+	}
+	for _, d := range []string{c.Interval, c.Window} {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed < time.Minute {
+			return errors.New("Interval and Window must be at least 1m")
+		}
+	}
+	if c.Percentile == 0 {
+		c.Percentile = defaultPercentile
+	}
+	if c.Percentile < 0 || c.Percentile > 1 {
+		return errors.New("Percentile must be between 0 and 1")
+	}
+	if c.TargetUtilization == 0 {
+		c.TargetUtilization = defaultTargetUtilization
+	}
+	if c.TargetUtilization < 0 || c.TargetUtilization > 1 {
+		return errors.New("TargetUtilization must be between 0 and 1")
+	}
+	if c.MinShards == 0 {
+		c.MinShards = defaultMinShards
+	}
+	if c.MinShards < 0 || c.MaxShards < 0 {
+		return errors.New("MinShards and MaxShards must be positive values")
+	}
+	if c.MaxShards != 0 && c.MaxShards < c.MinShards {
+		return errors.New("MaxShards must be at least MinShards")
+	}
+	if c.AutoScale && c.MaxShards == 0 {
+		return errors.New("MaxShards is required with AutoScale")
+	}
+	return nil
+}
+
+// KinesisAPI is the part of the Kinesis API the advisor uses.
+type KinesisAPI interface {
+	DescribeStream(*kinesis.DescribeStreamInput) (*kinesis.DescribeStreamOutput, error)
+	UpdateShardCount(*kinesis.UpdateShardCountInput) (*kinesis.UpdateShardCountOutput, error)
+}
+
+// CloudWatchAPI is the part of the CloudWatch API the advisor uses.
+type CloudWatchAPI interface {
+	GetMetricStatistics(*cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error)
+}
+
+// Advice is the advisor's recommendation for a stream.
+type Advice struct {
+	Stream            string
+	Status            string
+	RecordsPerSecond  float64
+	BytesPerSecond    float64
+	Shards            int
+	RecommendedShards int
+}
+
+// Advisor checks the streams' throughput against their shards.
+type Advisor struct {
+	config     Config
+	interval   time.Duration
+	window     time.Duration
+	kinesis    KinesisAPI
+	cloudwatch CloudWatchAPI
+	stats      statsd.StatSender
+	now        func() time.Time
+
+	mu      sync.Mutex
+	quit    chan struct{}
+	done    chan struct{}
+	running bool
+}
+
+// New returns an Advisor for config.
+func New(config Config, kinesis KinesisAPI, cloudwatch CloudWatchAPI, stats statsd.StatSender) (*Advisor, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if len(config.Streams) == 0 {
+		return nil, errors.New("no Streams to advise on")
+	}
+	a := &Advisor{
+		config:     config,
+		kinesis:    kinesis,
+		cloudwatch: cloudwatch,
+		stats:      stats,
+		now:        time.Now,
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	a.interval, _ = time.ParseDuration(config.Interval)
+	a.window, _ = time.ParseDuration(config.Window)
+	return a, nil
+}
+
+// Run checks the streams every Interval until Close is called.
+func (a *Advisor) Run() {
+	a.mu.Lock()
+	a.running = true
+	a.mu.Unlock()
+	defer close(a.done)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		a.Check()
+		select {
+		case <-a.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (a *Advisor) Close() {
+	close(a.quit)
+	a.mu.Lock()
+	running := a.running
+	a.mu.Unlock()
+	if running {
+		<-a.done
+	}
+}
+
+// Check advises on each stream, and reshards it if AutoScale is set.
+func (a *Advisor) Check() {
+	for _, stream := range a.config.Streams {
+		advice, err := a.Advise(stream)
+		if err != nil {
+			logger.WithError(err).WithField("stream", stream).Warn("Failed to advise on stream throughput")
+			_ = a.stats.Inc("advisor.failed", 1, 1)
+			continue
+		}
+		prefix := "advisor." + strings.Replace(stream, ".", "_", -1) + "."
+		_ = a.stats.Gauge(prefix+"records_per_second", int64(advice.RecordsPerSecond), 1)
+		_ = a.stats.Gauge(prefix+"bytes_per_second", int64(advice.BytesPerSecond), 1)
+		_ = a.stats.Gauge(prefix+"shards", int64(advice.Shards), 1)
+		_ = a.stats.Gauge(prefix+"recommended_shards", int64(advice.RecommendedShards), 1)
+		if advice.RecommendedShards == advice.Shards {
+			continue
+		}
+		logger.WithFields(map[string]interface{}{
+			"stream":             stream,
+			"records_per_second": advice.RecordsPerSecond,
+			"bytes_per_second":   advice.BytesPerSecond,
+			"shards":             advice.Shards,
+			"recommended_shards": advice.RecommendedShards,
+		}).Infof("Stream %s needs ~%d shards", stream, advice.RecommendedShards)
+		// Another edge may already be resharding it.
+		if a.config.AutoScale && advice.Status == kinesis.StreamStatusActive {
+			a.reshard(advice)
+		}
+	}
+}
+
+// Advise returns the advice for stream.
+func (a *Advisor) Advise(stream string) (*Advice, error) {
+	shards, status, err := a.openShards(stream)
+	if err != nil {
+		return nil, fmt.Errorf("error describing stream: %v", err)
+	}
+	records, err := a.sustainedRate(stream, "IncomingRecords")
+	if err != nil {
+		return nil, fmt.Errorf("error reading IncomingRecords: %v", err)
+	}
+	bytes, err := a.sustainedRate(stream, "IncomingBytes")
+	if err != nil {
+		return nil, fmt.Errorf("error reading IncomingBytes: %v", err)
+	}
+	return &Advice{
+		Stream:            stream,
+		Status:            status,
+		RecordsPerSecond:  records,
+		BytesPerSecond:    bytes,
+		Shards:            shards,
+		RecommendedShards: a.recommend(records, bytes),
+	}, nil
+}
+
+// recommend returns the shards needed for the rates.
+func (a *Advisor) recommend(recordsPerSecond, bytesPerSecond float64) int {
+	load := math.Max(recordsPerSecond/shardRecordsPerSecond, bytesPerSecond/shardBytesPerSecond)
+	shards := int(math.Ceil(load / a.config.TargetUtilization))
+	if shards < a.config.MinShards {
+		shards = a.config.MinShards
+	}
+	if a.config.MaxShards > 0 && shards > a.config.MaxShards {
+		shards = a.config.MaxShards
+	}
+	return shards
+}
+
+// openShards returns the number of open shards of stream, and its status.
+func (a *Advisor) openShards(stream string) (int, string, error) {
+	open := 0
+	input := &kinesis.DescribeStreamInput{StreamName: aws.String(stream)}
+	for {
+		out, err := a.kinesis.DescribeStream(input)
+		if err != nil {
+			return 0, "", err
+		}
+		description := out.StreamDescription
+		for _, shard := range description.Shards {
+			if shard.SequenceNumberRange == nil || shard.SequenceNumberRange.EndingSequenceNumber == nil {
+				open++
+			}
+		}
+		if !aws.BoolValue(description.HasMoreShards) || len(description.Shards) == 0 {
+			return open, aws.StringValue(description.StreamStatus), nil
+		}
+		input.ExclusiveStartShardId = description.Shards[len(description.Shards)-1].ShardId
+	}
+}
+
+// sustainedRate returns the Percentile of the per-second rates of metric, per
+// minute, over the Window.
+func (a *Advisor) sustainedRate(stream, metric string) (float64, error) {
+	end := a.now()
+	out, err := a.cloudwatch.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/Kinesis"),
+		MetricName: aws.String(metric),
+		Dimensions: []*cloudwatch.Dimension{{Name: aws.String("StreamName"), Value: aws.String(stream)}},
+		StartTime:  aws.Time(end.Add(-a.window)),
+		EndTime:    aws.Time(end),
+		Period:     aws.Int64(60),
+		Statistics: []*string{aws.String("Sum")},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(out.Datapoints) == 0 {
+		return 0, nil
+	}
+	rates := make([]float64, len(out.Datapoints))
+	for i, d := range out.Datapoints {
+		rates[i] = aws.Float64Value(d.Sum) / 60
+	}
+	sort.Float64s(rates)
+	return rates[int(math.Ceil(a.config.Percentile*float64(len(rates))))-1], nil
+}
+
+// reshard updates the stream's shard count towards the recommendation.
+// UpdateShardCount can at most double or halve the open shards.
+func (a *Advisor) reshard(advice *Advice) {
+	target := advice.RecommendedShards
+	if advice.Shards > 0 {
+		if target > 2*advice.Shards {
+			target = 2 * advice.Shards
+		}
+		if half := (advice.Shards + 1) / 2; target < half {
+			target = half
+		}
+	}
+	_, err := a.kinesis.UpdateShardCount(&kinesis.UpdateShardCountInput{
+		StreamName:       aws.String(advice.Stream),
+		TargetShardCount: aws.Int64(int64(target)),
+		ScalingType:      aws.String(kinesis.ScalingTypeUniformScaling),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("stream", advice.Stream).Warn("Failed to update shard count")
+		_ = a.stats.Inc("advisor.reshard_failed", 1, 1)
+		return
+	}
+	logger.WithField("stream", advice.Stream).WithField("shards", target).Info("Resharding stream")
+	_ = a.stats.Inc("advisor.resharded", 1, 1)
+}
@@ -0,0 +1,131 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+)
+
+type fakeKinesis struct {
+	shards   []*kinesis.Shard
+	pageSize int
+	updates  []*kinesis.UpdateShardCountInput
+}
+
+func (f *fakeKinesis) DescribeStream(in *kinesis.DescribeStreamInput) (*kinesis.DescribeStreamOutput, error) {
+	start := 0
+	if in.ExclusiveStartShardId != nil {
+		for i, s := range f.shards {
+			if *s.ShardId == *in.ExclusiveStartShardId {
+				start = i + 1
+			}
+		}
+	}
+	end := start + f.pageSize
+	if end > len(f.shards) {
+		end = len(f.shards)
+	}
+	return &kinesis.DescribeStreamOutput{StreamDescription: &kinesis.StreamDescription{
+		StreamStatus:  aws.String(kinesis.StreamStatusActive),
+		Shards:        f.shards[start:end],
+		HasMoreShards: aws.Bool(end < len(f.shards)),
+	}}, nil
+}
+
+func (f *fakeKinesis) UpdateShardCount(in *kinesis.UpdateShardCountInput) (*kinesis.UpdateShardCountOutput, error) {
+	f.updates = append(f.updates, in)
+	return &kinesis.UpdateShardCountOutput{}, nil
+}
+
+// fakeCloudWatch returns the per-minute sums of each metric.
+type fakeCloudWatch map[string][]float64
+
+func (f fakeCloudWatch) GetMetricStatistics(in *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	out := &cloudwatch.GetMetricStatisticsOutput{}
+	for _, sum := range f[*in.MetricName] {
+		out.Datapoints = append(out.Datapoints, &cloudwatch.Datapoint{Sum: aws.Float64(sum)})
+	}
+	return out, nil
+}
+
+func shards(open, closed int) []*kinesis.Shard {
+	var s []*kinesis.Shard
+	for i := 0; i < open+closed; i++ {
+		r := &kinesis.SequenceNumberRange{StartingSequenceNumber: aws.String("0")}
+		if i < closed {
+			r.EndingSequenceNumber = aws.String("1")
+		}
+		s = append(s, &kinesis.Shard{ShardId: aws.String(string(rune('a' + i))), SequenceNumberRange: r})
+	}
+	return s
+}
+
+func TestAdvise(t *testing.T) {
+	k := &fakeKinesis{shards: shards(2, 3), pageSize: 2}
+	// Ten minutes at 3000 records/s, one burst at 30000/s; the bytes need
+	// fewer shards.
+	records := []float64{300000 * 6, 180000, 180000, 180000, 180000, 180000, 180000, 180000, 180000, 180000}
+	cw := fakeCloudWatch{"IncomingRecords": records, "IncomingBytes": {60 << 20}}
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	a, err := New(Config{Streams: []string{"spade.events"}, MaxShards: 10, AutoScale: true}, k, cw, stats)
+	if err != nil {
+		t.Fatalf("Failed to create advisor: %s", err)
+	}
+
+	advice, err := a.Advise("spade.events")
+	if err != nil {
+		t.Fatalf("Failed to advise: %s", err)
+	}
+	// 3000 records/s at 70% of 1000 per shard.
+	if advice.Shards != 2 || advice.RecordsPerSecond != 3000 || advice.RecommendedShards != 5 {
+		t.Errorf("Expected 2 shards, 3000 records/s and 5 recommended shards, got %+v", advice)
+	}
+
+	a.Check()
+	if len(k.updates) != 1 || *k.updates[0].TargetShardCount != 4 {
+		t.Fatalf("Expected the stream to be doubled to 4 shards, got %v", k.updates)
+	}
+	if got := sender.GetSent().CollectNamed("advisor.spade_events.recommended_shards"); len(got) != 1 || got[0].Value != "5" {
+		t.Errorf("Expected the recommendation to be sent, got %v", got)
+	}
+}
+
+func TestRecommendBounds(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	a, err := New(Config{Streams: []string{"s"}, MinShards: 2, MaxShards: 8}, &fakeKinesis{}, fakeCloudWatch{}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create advisor: %s", err)
+	}
+	for _, tc := range []struct {
+		records, bytes float64
+		expected       int
+	}{
+		{0, 0, 2},
+		{100000, 0, 8},
+		{0, 3 << 20, 5},
+	} {
+		if got := a.recommend(tc.records, tc.bytes); got != tc.expected {
+			t.Errorf("Expected %d shards for %v records/s and %v bytes/s, got %d",
+				tc.expected, tc.records, tc.bytes, got)
+		}
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, c := range []Config{
+		{Interval: "1s"},
+		{Percentile: 2},
+		{TargetUtilization: -1},
+		{MinShards: 4, MaxShards: 2},
+		{AutoScale: true},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
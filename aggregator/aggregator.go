@@ -0,0 +1,206 @@
+/*
+Package aggregator batches statsd counters and gauges in memory and flushes
+them on an interval, so a busy edge sends one packet per stat per interval
+rather than one per request.
+
+Counters and gauges are kept in shards, each with its own lock, so requests
+updating different stats rarely contend. Counts are exact: since every
+increment is added up before it is sent, aggregated counters don't need to be
+sampled and are flushed with a rate of 1. Timings and sets can't be combined
+without losing information and are passed straight through.
+*/
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultFlushInterval = "1s"
+	defaultShards        = 32
+)
+
+// Config configures stats aggregation.
+type Config struct {
+	// FlushInterval is how often aggregated stats are sent, e.g. "1s"
+	FlushInterval string
+
+	// Shards is the number of independently locked shards stats are spread
+	// over. It must be a power of two.
+	Shards int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.FlushInterval == "" {
+		c.FlushInterval = defaultFlushInterval
+	}
+	interval, err := time.ParseDuration(c.FlushInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FlushInterval, err)
+	}
+	if interval <= 0 {
+		return errors.New("FlushInterval must be greater than 0")
+	}
+	if c.Shards == 0 {
+		c.Shards = defaultShards
+	}
+	if c.Shards < 0 || c.Shards&(c.Shards-1) != 0 {
+		return errors.New("Shards must be a power of two")
+	}
+	return nil
+}
+
+type shard struct {
+	mu          sync.Mutex
+	counters    map[string]int64
+	gauges      map[string]int64
+	gaugeDeltas map[string]int64
+}
+
+// swap returns the shard's stats and starts it over with empty ones.
+func (s *shard) swap() (counters, gauges, gaugeDeltas map[string]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counters, gauges, gaugeDeltas = s.counters, s.gauges, s.gaugeDeltas
+	s.counters = make(map[string]int64, len(counters))
+	s.gauges = make(map[string]int64, len(gauges))
+	s.gaugeDeltas = make(map[string]int64, len(gaugeDeltas))
+	return
+}
+
+// Statter is a statsd.Statter that aggregates counters and gauges before
+// sending them to the wrapped Statter.
+type Statter struct {
+	statsd.Statter
+	shards []*shard
+	mask   uint32
+	closed chan struct{}
+	done   sync.WaitGroup
+}
+
+// New returns a Statter aggregating stats sent to statter.
+func New(config Config, statter statsd.Statter) (*Statter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	interval, _ := time.ParseDuration(config.FlushInterval)
+	s := &Statter{
+		Statter: statter,
+		shards:  make([]*shard, config.Shards),
+		mask:    uint32(config.Shards - 1),
+		closed:  make(chan struct{}),
+	}
+	for i := range s.shards {
+		s.shards[i] = &shard{
+			counters:    make(map[string]int64),
+			gauges:      make(map[string]int64),
+			gaugeDeltas: make(map[string]int64),
+		}
+	}
+	s.done.Add(1)
+	logger.Go(func() {
+		defer s.done.Done()
+		s.flushLoop(interval)
+	})
+	return s, nil
+}
+
+// shard picks the shard of stat by its FNV-1a hash.
+func (s *Statter) shard(stat string) *shard {
+	h := uint32(2166136261)
+	for i := 0; i < len(stat); i++ {
+		h ^= uint32(stat[i])
+		h *= 16777619
+	}
+	return s.shards[h&s.mask]
+}
+
+// add adds value to stat in the map picked by which.
+func (s *Statter) add(stat string, value int64, rate float32, which func(*shard) map[string]int64) {
+	// The wrapped Statter would never send stats with a rate of 0, so
+	// neither do we.
+	if rate <= 0 {
+		return
+	}
+	sh := s.shard(stat)
+	sh.mu.Lock()
+	which(sh)[stat] += value
+	sh.mu.Unlock()
+}
+
+func counters(sh *shard) map[string]int64    { return sh.counters }
+func gaugeDeltas(sh *shard) map[string]int64 { return sh.gaugeDeltas }
+
+// Inc adds value to a counter. Every call is counted, whatever the rate.
+func (s *Statter) Inc(stat string, value int64, rate float32) error {
+	s.add(stat, value, rate, counters)
+	return nil
+}
+
+// Dec subtracts value from a counter. Every call is counted, whatever the rate.
+func (s *Statter) Dec(stat string, value int64, rate float32) error {
+	s.add(stat, -value, rate, counters)
+	return nil
+}
+
+// Gauge sets a gauge, which is sent with the last value set each interval.
+func (s *Statter) Gauge(stat string, value int64, rate float32) error {
+	if rate <= 0 {
+		return nil
+	}
+	sh := s.shard(stat)
+	sh.mu.Lock()
+	sh.gauges[stat] = value
+	sh.mu.Unlock()
+	return nil
+}
+
+// GaugeDelta changes a gauge by value, summing the changes each interval.
+func (s *Statter) GaugeDelta(stat string, value int64, rate float32) error {
+	s.add(stat, value, rate, gaugeDeltas)
+	return nil
+}
+
+// Flush sends the stats aggregated so far.
+func (s *Statter) Flush() {
+	for _, sh := range s.shards {
+		counters, gauges, gaugeDeltas := sh.swap()
+		for stat, value := range counters {
+			_ = s.Statter.Inc(stat, value, 1)
+		}
+		for stat, value := range gauges {
+			_ = s.Statter.Gauge(stat, value, 1)
+		}
+		for stat, value := range gaugeDeltas {
+			_ = s.Statter.GaugeDelta(stat, value, 1)
+		}
+	}
+}
+
+func (s *Statter) flushLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+			s.Flush()
+		}
+	}
+}
+
+// Close flushes the remaining stats and closes the wrapped Statter.
+func (s *Statter) Close() error {
+	close(s.closed)
+	s.done.Wait()
+	s.Flush()
+	return s.Statter.Close()
+}
@@ -0,0 +1,69 @@
+package aggregator
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+)
+
+func TestAggregation(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	// Flush by hand rather than on the interval.
+	s, err := New(Config{FlushInterval: "1h", Shards: 4}, statter)
+	if err != nil {
+		t.Fatalf("Failed to create aggregator: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = s.Inc("requests", 1, 0.1)
+			}
+		}()
+	}
+	wg.Wait()
+	_ = s.Dec("requests", 10, 1)
+	_ = s.Inc("disabled", 1, 0)
+	_ = s.Gauge("queue_depth", 5, 1)
+	_ = s.Gauge("queue_depth", 3, 1)
+	_ = s.GaugeDelta("limit", 2, 1)
+	_ = s.GaugeDelta("limit", -5, 1)
+	_ = s.Timing("latency", 7, 1)
+	if err = s.Close(); err != nil {
+		t.Fatalf("Failed to close aggregator: %s", err)
+	}
+
+	var sent []string
+	for _, stat := range rs.GetSent() {
+		sent = append(sent, stat.Stat+" "+stat.Value+" "+stat.Tag+" "+stat.Rate)
+	}
+	sort.Strings(sent)
+	expected := []string{
+		"latency 7 ms ",
+		"limit -3 g ",
+		"queue_depth 3 g ",
+		"requests 990 c ",
+	}
+	if len(sent) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, sent)
+	}
+	for i := range expected {
+		if sent[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], sent[i])
+		}
+	}
+}
+
+func TestShardsMustBePowerOfTwo(t *testing.T) {
+	c := Config{Shards: 3}
+	if err := c.Validate(); err == nil {
+		t.Error("Expected 3 shards to be rejected")
+	}
+}
@@ -0,0 +1,369 @@
+/*
+Package alerts pages operators through webhooks, such as Slack's or
+PagerDuty's, when the edge gets into trouble, for deployments without
+alerting on their metrics.
+
+Conditions are either states, checked every Interval, which alert when they
+start and again when they resolve:
+
+	sink_unhealthy         a sink's writes have been failing for SinkUnhealthyFor
+	spool_above_threshold  the fallback spool holds more than SpoolThreshold bytes
+
+or events, which alert when they happen:
+
+	panic_recovered        a request handler panicked
+	config_reload_failed   a new config couldn't be fetched or was invalid
+
+Each condition alerts about each subject (a sink, the spool, a config
+location) at most once per MinInterval. A state change in between is sent once
+it passes, unless it has been undone by then, and events in between are sent
+together once it passes, counted under "events".
+*/
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/notify"
+	"github.com/twitchscience/spade_edge/status"
+)
+
+// The conditions alerted on.
+const (
+	SinkUnhealthy       = "sink_unhealthy"
+	SpoolAboveThreshold = "spool_above_threshold"
+	PanicRecovered      = "panic_recovered"
+	ConfigReloadFailed  = "config_reload_failed"
+)
+
+var conditions = []string{SinkUnhealthy, SpoolAboveThreshold, PanicRecovered, ConfigReloadFailed}
+
+const (
+	defaultInterval         = "10s"
+	defaultMinInterval      = "15m"
+	defaultSinkUnhealthyFor = "1m"
+	defaultSpoolThreshold   = 1 << 30
+)
+
+// Config configures the alerts.
+type Config struct {
+	// Webhooks are where alerts are POSTed
+	Webhooks []*notify.WebhookConfig
+
+	// Conditions are the conditions alerted on. They default to all of them.
+	Conditions []string
+
+	// Interval is how often states are checked, e.g. "10s"
+	Interval string
+
+	// MinInterval is the least time between alerts about a subject, e.g. "15m"
+	MinInterval string
+
+	// SinkUnhealthyFor is how long a sink's writes must fail before it's
+	// alerted on, e.g. "1m"
+	SinkUnhealthyFor string
+
+	// SpoolThreshold is the size of the spool, in bytes, above which it's
+	// alerted on. It defaults to 1GB.
+	SpoolThreshold int64
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if len(c.Webhooks) == 0 {
+		return errors.New("Webhooks are required")
+	}
+	for i, w := range c.Webhooks {
+		if w == nil {
+			return fmt.Errorf("Webhooks[%d] is empty", i)
+		}
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("Webhooks[%d]: %v", i, err)
+		}
+	}
+	if len(c.Conditions) == 0 {
+		c.Conditions = conditions
+	}
+	for _, condition := range c.Conditions {
+		if !isCondition(condition) {
+			return fmt.Errorf("unknown condition %s", condition)
+		}
+	}
+	if c.Interval == "" {
+		c.Interval = defaultInterval
+	}
+	if c.MinInterval == "" {
+		c.MinInterval = defaultMinInterval
+	}
+	if c.SinkUnhealthyFor == "" {
+		c.SinkUnhealthyFor = defaultSinkUnhealthyFor
+	}
+	for _, d := range []string{c.Interval, c.MinInterval, c.SinkUnhealthyFor} {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed <= 0 {
+			return errors.New("Interval, MinInterval and SinkUnhealthyFor must be greater than 0")
+		}
+	}
+	if c.SpoolThreshold == 0 {
+		c.SpoolThreshold = defaultSpoolThreshold
+	}
+	if c.SpoolThreshold < 0 {
+		return errors.New("SpoolThreshold must be a positive value")
+	}
+	return nil
+}
+
+func isCondition(s string) bool {
+	for _, condition := range conditions {
+		if s == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// subject is the state of the alerts about a condition's subject.
+type subject struct {
+	condition string
+	name      string
+
+	// firing is whether a state condition holds, and since when; for an
+	// event, it's unset.
+	firing bool
+	since  time.Time
+	// message describes the last change or event.
+	message string
+	// events counts the events since the last alert.
+	events int64
+
+	sent struct {
+		at     time.Time
+		firing bool
+		events int64
+	}
+}
+
+// Alerter sends alerts about the conditions of an edge.
+type Alerter struct {
+	notifiers        []notify.Notifier
+	enabled          map[string]bool
+	interval         time.Duration
+	minInterval      time.Duration
+	sinkUnhealthyFor time.Duration
+	spoolThreshold   int64
+	stats            statsd.StatSender
+	now              func() time.Time
+
+	mu        sync.Mutex
+	subjects  map[string]*subject
+	sinks     func() []status.SinkStatus
+	spoolSize func() (int64, error)
+	unhealthy map[string]time.Time
+	quit      chan struct{}
+	done      chan struct{}
+	running   bool
+}
+
+// New returns an Alerter posting to the webhooks of config, stamping each
+// alert with host.
+func New(config Config, host string, stats statsd.StatSender) (*Alerter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	a := &Alerter{
+		enabled:        make(map[string]bool, len(config.Conditions)),
+		spoolThreshold: config.SpoolThreshold,
+		stats:          stats,
+		now:            time.Now,
+		subjects:       make(map[string]*subject),
+		unhealthy:      make(map[string]time.Time),
+		quit:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	for _, w := range config.Webhooks {
+		n, err := notify.NewWebhook(*w, host, stats)
+		if err != nil {
+			return nil, err
+		}
+		a.notifiers = append(a.notifiers, n)
+	}
+	for _, condition := range config.Conditions {
+		a.enabled[condition] = true
+	}
+	a.interval, _ = time.ParseDuration(config.Interval)
+	a.minInterval, _ = time.ParseDuration(config.MinInterval)
+	a.sinkUnhealthyFor, _ = time.ParseDuration(config.SinkUnhealthyFor)
+	return a, nil
+}
+
+// WatchSinks alerts on the sinks sinks reports as unhealthy.
+func (a *Alerter) WatchSinks(sinks func() []status.SinkStatus) {
+	a.mu.Lock()
+	a.sinks = sinks
+	a.mu.Unlock()
+}
+
+// WatchSpool alerts on the spool when size reports it's above the
+// SpoolThreshold.
+func (a *Alerter) WatchSpool(size func() (int64, error)) {
+	a.mu.Lock()
+	a.spoolSize = size
+	a.mu.Unlock()
+}
+
+// Fire records that the event condition happened to name. It's alerted on
+// by the next check.
+func (a *Alerter) Fire(condition, name, message string) {
+	if !a.enabled[condition] {
+		return
+	}
+	a.mu.Lock()
+	s := a.subject(condition, name)
+	s.events++
+	s.message = message
+	a.mu.Unlock()
+}
+
+// Run checks the conditions every Interval until Close is called.
+func (a *Alerter) Run() {
+	a.mu.Lock()
+	a.running = true
+	a.mu.Unlock()
+	defer close(a.done)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.quit:
+			return
+		case <-ticker.C:
+			a.Check()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (a *Alerter) Close() {
+	close(a.quit)
+	a.mu.Lock()
+	running := a.running
+	a.mu.Unlock()
+	if running {
+		<-a.done
+	}
+}
+
+// Check updates the states and sends the alerts that are due.
+func (a *Alerter) Check() {
+	now := a.now()
+	a.mu.Lock()
+	sinks, spoolSize := a.sinks, a.spoolSize
+	a.mu.Unlock()
+
+	// The sources are read without the lock, as they may be slow.
+	var sinkStatuses []status.SinkStatus
+	if sinks != nil && a.enabled[SinkUnhealthy] {
+		sinkStatuses = sinks()
+	}
+	var size int64
+	var sizeErr error
+	if spoolSize != nil && a.enabled[SpoolAboveThreshold] {
+		size, sizeErr = spoolSize()
+		if sizeErr != nil {
+			logger.WithError(sizeErr).Warn("Failed to read the size of the spool")
+		}
+	}
+
+	a.mu.Lock()
+	for _, sink := range sinkStatuses {
+		if sink.Healthy {
+			delete(a.unhealthy, sink.Name)
+			a.setState(SinkUnhealthy, sink.Name, false, now, fmt.Sprintf("Sink %s recovered", sink.Name))
+			continue
+		}
+		since, ok := a.unhealthy[sink.Name]
+		if !ok {
+			since = now
+			a.unhealthy[sink.Name] = now
+		}
+		if now.Sub(since) >= a.sinkUnhealthyFor {
+			a.setState(SinkUnhealthy, sink.Name, true, since,
+				fmt.Sprintf("Sink %s is unhealthy: %s", sink.Name, sink.LastError))
+		}
+	}
+	if spoolSize != nil && sizeErr == nil && a.enabled[SpoolAboveThreshold] {
+		above := size > a.spoolThreshold
+		a.setState(SpoolAboveThreshold, "spool", above, now,
+			fmt.Sprintf("The spool holds %d bytes, the threshold is %d", size, a.spoolThreshold))
+	}
+	due := a.due(now)
+	a.mu.Unlock()
+
+	for _, n := range due {
+		for _, notifier := range a.notifiers {
+			if err := notifier.Notify(n); err != nil {
+				logger.WithError(err).WithField("condition", n.Event).Error("Failed to send alert")
+			}
+		}
+		_ = a.stats.Inc("alerts."+n.Event, 1, 1)
+	}
+}
+
+func (a *Alerter) subject(condition, name string) *subject {
+	key := condition + "/" + name
+	s, ok := a.subjects[key]
+	if !ok {
+		s = &subject{condition: condition, name: name}
+		a.subjects[key] = s
+	}
+	return s
+}
+
+// setState records whether the state condition holds for name, since when.
+func (a *Alerter) setState(condition, name string, firing bool, since time.Time, message string) {
+	s := a.subject(condition, name)
+	if s.firing == firing {
+		return
+	}
+	s.firing = firing
+	s.since = since
+	s.message = message
+}
+
+// due returns the alerts that are due, and marks them sent.
+func (a *Alerter) due(now time.Time) []*notify.Notification {
+	var due []*notify.Notification
+	for _, s := range a.subjects {
+		if now.Sub(s.sent.at) < a.minInterval {
+			continue
+		}
+		n := &notify.Notification{
+			Event:   s.condition,
+			Subject: s.name,
+			Time:    now,
+			Message: s.message,
+		}
+		switch {
+		case s.events > s.sent.events:
+			n.Counts = map[string]int64{"events": s.events - s.sent.events}
+		case s.firing != s.sent.firing:
+			n.Time = s.since
+			n.Resolved = !s.firing
+		default:
+			continue
+		}
+		s.sent.at = now
+		s.sent.firing = s.firing
+		s.sent.events = s.events
+		due = append(due, n)
+	}
+	return due
+}
@@ -0,0 +1,136 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/spade_edge/notify"
+	"github.com/twitchscience/spade_edge/status"
+)
+
+// webhook records the bodies POSTed to it.
+type webhook struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newWebhook() *webhook {
+	wh := &webhook{}
+	wh.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		wh.mu.Lock()
+		wh.bodies = append(wh.bodies, body)
+		wh.mu.Unlock()
+	}))
+	return wh
+}
+
+func (wh *webhook) take() []map[string]interface{} {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	bodies := wh.bodies
+	wh.bodies = nil
+	return bodies
+}
+
+func TestAlerts(t *testing.T) {
+	wh := newWebhook()
+	defer wh.Close()
+	stats, _ := statsd.NewNoop()
+	a, err := New(Config{
+		Webhooks: []*notify.WebhookConfig{{
+			URL:      wh.URL,
+			Template: `{"text": {{json .Message}}, "event": {{json .Event}}, "resolved": {{.Resolved}}}`,
+		}},
+		MinInterval:      "10m",
+		SinkUnhealthyFor: "1m",
+		SpoolThreshold:   100,
+	}, "i-123", stats)
+	if err != nil {
+		t.Fatalf("Failed to create alerter: %s", err)
+	}
+	now := time.Unix(1500000000, 0)
+	a.now = func() time.Time { return now }
+	healthy := true
+	a.WatchSinks(func() []status.SinkStatus {
+		return []status.SinkStatus{{Name: "kinesis", Healthy: healthy, LastError: "throttled"}}
+	})
+	spool := int64(0)
+	a.WatchSpool(func() (int64, error) { return spool, nil })
+	check := func(d time.Duration) []map[string]interface{} {
+		now = now.Add(d)
+		a.Check()
+		return wh.take()
+	}
+
+	healthy = false
+	if got := check(time.Second); len(got) != 0 {
+		t.Errorf("Expected a sink to be unhealthy for a minute before alerting, got %v", got)
+	}
+	got := check(time.Minute)
+	if len(got) != 1 || got[0]["event"] != SinkUnhealthy || got[0]["text"] != "Sink kinesis is unhealthy: throttled" {
+		t.Fatalf("Expected the unhealthy sink to be alerted on, got %v", got)
+	}
+	healthy = true
+	if got = check(time.Minute); len(got) != 0 {
+		t.Errorf("Expected the recovery to wait for MinInterval, got %v", got)
+	}
+	if got = check(10 * time.Minute); len(got) != 1 || got[0]["resolved"] != true {
+		t.Errorf("Expected the recovery to be sent, got %v", got)
+	}
+
+	// Events are sent together, once per MinInterval.
+	spool = 1000
+	a.Fire(ConfigReloadFailed, "s3://config", "invalid")
+	a.Fire(ConfigReloadFailed, "s3://config", "invalid")
+	a.Fire(PanicRecovered, "requests", "boom")
+	if got = check(time.Second); len(got) != 3 {
+		t.Errorf("Expected the spool, config and panic alerts, got %v", got)
+	}
+	a.Fire(PanicRecovered, "requests", "boom")
+	if got = check(time.Second); len(got) != 0 {
+		t.Errorf("Expected the second panic to wait for MinInterval, got %v", got)
+	}
+	if got = check(10 * time.Minute); len(got) != 1 || got[0]["event"] != PanicRecovered {
+		t.Errorf("Expected the second panic to be sent, got %v", got)
+	}
+}
+
+func TestSpoolErrors(t *testing.T) {
+	wh := newWebhook()
+	defer wh.Close()
+	stats, _ := statsd.NewNoop()
+	a, err := New(Config{Webhooks: []*notify.WebhookConfig{{URL: wh.URL}}, Conditions: []string{SpoolAboveThreshold}},
+		"i-123", stats)
+	if err != nil {
+		t.Fatalf("Failed to create alerter: %s", err)
+	}
+	a.WatchSpool(func() (int64, error) { return 0, errors.New("no such directory") })
+	a.Fire(PanicRecovered, "requests", "boom")
+	a.Check()
+	if got := wh.take(); len(got) != 0 {
+		t.Errorf("Expected nothing to be sent, got %v", got)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{Webhooks: []*notify.WebhookConfig{{}}},
+		{Webhooks: []*notify.WebhookConfig{{URL: "http://x", Template: "{{"}}},
+		{Webhooks: []*notify.WebhookConfig{{URL: "http://x"}}, Conditions: []string{"disk_full"}},
+		{Webhooks: []*notify.WebhookConfig{{URL: "http://x"}}, MinInterval: "-1m"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
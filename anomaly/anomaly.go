@@ -0,0 +1,315 @@
+/*
+Package anomaly watches the volume of accepted events for sudden changes,
+which show up at the edge well before downstream lag does, e.g. when a client
+release breaks tracking.
+
+The events per second of every interval are compared to a baseline, an
+exponentially weighted moving average of the past rates, both in total and for
+each of the busiest event names. A rate more than Factor times its baseline is
+a spike, and one less than the baseline divided by Factor is a drop. Each
+interval a rate is anomalous, it's counted under
+
+	anomaly.total.spike, anomaly.total.drop
+	anomaly.event.spike, anomaly.event.drop
+
+and a warning naming the event is logged when it becomes anomalous and when
+it recovers. The total rate and baseline are also sent as the gauges
+anomaly.total.rate and anomaly.total.baseline.
+*/
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultInterval   = "10s"
+	defaultHalfLife   = "15m"
+	defaultWarmUp     = "30m"
+	defaultFactor     = 3
+	defaultMinRate    = 1
+	defaultTopEvents  = 20
+	defaultSampleRate = 1
+
+	// totalName is the name the total rate is tracked under. Events without
+	// a name aren't tracked by name, so it's free.
+	totalName = ""
+
+	// maxCandidatesFactor bounds the distinct event names counted per
+	// interval to this many times TopEvents.
+	maxCandidatesFactor = 10
+)
+
+// Config configures event volume anomaly detection.
+type Config struct {
+	// Interval is how often rates are computed and compared, e.g. "10s"
+	Interval string
+
+	// HalfLife is how long it takes a rate to make up half of the baseline,
+	// e.g. "15m"
+	HalfLife string
+
+	// WarmUp is how long baselines are learned before they're alarmed on,
+	// e.g. "30m"
+	WarmUp string
+
+	// Factor is how many times higher or lower than its baseline a rate must
+	// be to be anomalous. It defaults to 3.
+	Factor float64
+
+	// MinRate is the lowest baseline, in events per second, alarmed on. It
+	// defaults to 1.
+	MinRate float64
+
+	// TopEvents is how many of the busiest event names are tracked. It
+	// defaults to 20.
+	TopEvents int
+
+	// SampleRate is the fraction of requests whose events are counted, to
+	// bound the cost of decoding them. It defaults to 1.
+	SampleRate float64
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Interval == "" {
+		c.Interval = defaultInterval
+	}
+	if c.HalfLife == "" {
+		c.HalfLife = defaultHalfLife
+	}
+	if c.WarmUp == "" {
+		c.WarmUp = defaultWarmUp
+	}
+	for _, d := range []string{c.Interval, c.HalfLife} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	if warmUp, err := time.ParseDuration(c.WarmUp); err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.WarmUp, err)
+	} else if warmUp < 0 {
+		return errors.New("WarmUp must not be negative")
+	}
+
+	if c.Factor == 0 {
+		c.Factor = defaultFactor
+	}
+	if c.Factor <= 1 {
+		return errors.New("Factor must be greater than 1")
+	}
+	if c.MinRate == 0 {
+		c.MinRate = defaultMinRate
+	}
+	if c.MinRate < 0 {
+		return errors.New("MinRate must not be negative")
+	}
+	if c.TopEvents == 0 {
+		c.TopEvents = defaultTopEvents
+	}
+	if c.TopEvents < 0 {
+		return errors.New("TopEvents must be a positive value")
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = defaultSampleRate
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return errors.New("SampleRate must be between 0 and 1")
+	}
+	return nil
+}
+
+// baseline is the moving average rate of the total or an event name.
+type baseline struct {
+	rate      float64
+	since     time.Time
+	anomalous bool
+}
+
+// Detector counts accepted events and raises alarms on their volume.
+type Detector struct {
+	config   Config
+	interval time.Duration
+	warmUp   time.Duration
+	// alpha is the weight of each interval's rate in the baseline.
+	alpha float64
+	stats statsd.StatSender
+	now   func() time.Time
+
+	mu        sync.Mutex
+	counts    map[string]float64
+	baselines map[string]*baseline
+
+	quit    chan struct{}
+	done    chan struct{}
+	running bool
+}
+
+// New returns a Detector for config.
+func New(config Config, stats statsd.StatSender) (*Detector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	d := &Detector{
+		config:    config,
+		stats:     stats,
+		now:       time.Now,
+		counts:    make(map[string]float64),
+		baselines: make(map[string]*baseline),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	d.interval, _ = time.ParseDuration(config.Interval)
+	d.warmUp, _ = time.ParseDuration(config.WarmUp)
+	halfLife, _ := time.ParseDuration(config.HalfLife)
+	d.alpha = 1 - math.Pow(0.5, d.interval.Seconds()/halfLife.Seconds())
+	return d, nil
+}
+
+// Observe counts the events in data, the base64 encoded event (or array of
+// events) of an accepted request.
+func (d *Detector) Observe(data string) {
+	if d.config.SampleRate < 1 && rand.Float64() >= d.config.SampleRate {
+		return
+	}
+	names := transform.EventNames(data)
+	if len(names) == 0 {
+		return
+	}
+	weight := 1 / d.config.SampleRate
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.counts[totalName] += weight * float64(len(names))
+	for _, name := range names {
+		if name == totalName {
+			continue
+		}
+		if _, ok := d.counts[name]; ok || len(d.counts) <= maxCandidatesFactor*d.config.TopEvents {
+			d.counts[name] += weight
+		}
+	}
+}
+
+// Check compares the rates since the last Check to their baselines, raises
+// alarms and updates the baselines.
+func (d *Detector) Check() {
+	now := d.now()
+	d.mu.Lock()
+	counts := d.counts
+	d.counts = make(map[string]float64, len(counts))
+	seconds := d.interval.Seconds()
+	for name, count := range counts {
+		if _, ok := d.baselines[name]; !ok {
+			d.baselines[name] = &baseline{rate: count / seconds, since: now}
+		}
+	}
+
+	for name, b := range d.baselines {
+		rate := counts[name] / seconds
+		d.compare(name, b, rate, now)
+		b.rate += d.alpha * (rate - b.rate)
+		if name == totalName {
+			_ = d.stats.Gauge("anomaly.total.rate", int64(rate), 1)
+			_ = d.stats.Gauge("anomaly.total.baseline", int64(b.rate), 1)
+		}
+	}
+	d.prune()
+	d.mu.Unlock()
+}
+
+// compare raises an alarm if rate is anomalous for the baseline b of name.
+// d.mu must be held.
+func (d *Detector) compare(name string, b *baseline, rate float64, now time.Time) {
+	if now.Sub(b.since) < d.warmUp || b.rate < d.config.MinRate {
+		return
+	}
+	direction := ""
+	switch {
+	case rate > b.rate*d.config.Factor:
+		direction = "spike"
+	case rate < b.rate/d.config.Factor:
+		direction = "drop"
+	}
+	scope := "event"
+	if name == totalName {
+		scope = "total"
+	}
+	if direction != "" {
+		_ = d.stats.Inc("anomaly."+scope+"."+direction, 1, 1)
+	}
+	if anomalous := direction != ""; anomalous != b.anomalous {
+		b.anomalous = anomalous
+		entry := logger.WithField("scope", scope).
+			WithField("rate", rate).
+			WithField("baseline", b.rate)
+		if name != totalName {
+			entry = entry.WithField("event", name)
+		}
+		if anomalous {
+			entry.WithField("direction", direction).Warn("Event volume anomaly")
+		} else {
+			entry.Info("Event volume back to normal")
+		}
+	}
+}
+
+// prune keeps the baselines of the total and the TopEvents busiest names.
+// d.mu must be held.
+func (d *Detector) prune() {
+	if len(d.baselines) <= d.config.TopEvents+1 {
+		return
+	}
+	names := make([]string, 0, len(d.baselines))
+	for name := range d.baselines {
+		if name != totalName {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return d.baselines[names[i]].rate > d.baselines[names[j]].rate
+	})
+	for _, name := range names[d.config.TopEvents:] {
+		delete(d.baselines, name)
+	}
+}
+
+// Run checks the rates every interval until Close is called.
+func (d *Detector) Run() {
+	d.mu.Lock()
+	d.running = true
+	d.mu.Unlock()
+	defer close(d.done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.quit:
+			return
+		case <-ticker.C:
+			d.Check()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (d *Detector) Close() {
+	close(d.quit)
+	d.mu.Lock()
+	running := d.running
+	d.mu.Unlock()
+	if running {
+		<-d.done
+	}
+}
@@ -0,0 +1,85 @@
+package anomaly
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// countingStatter records the counters it's sent.
+type countingStatter struct {
+	statsd.Statter
+	counts map[string]int64
+}
+
+func (s *countingStatter) Inc(stat string, value int64, _ float32) error {
+	s.counts[stat] += value
+	return nil
+}
+
+func (s *countingStatter) Gauge(string, int64, float32) error {
+	return nil
+}
+
+func encode(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+func TestDetector(t *testing.T) {
+	stats := &countingStatter{counts: make(map[string]int64)}
+	d, err := New(Config{Interval: "1s", HalfLife: "10s", WarmUp: "5s"}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	interval := func(play, pause int) {
+		for i := 0; i < play; i++ {
+			d.Observe(encode(`{"event":"play"}`))
+		}
+		for i := 0; i < pause; i++ {
+			d.Observe(encode(`[{"event":"pause"},{"event":"pause"}]`))
+		}
+		d.Check()
+		now = now.Add(time.Second)
+	}
+
+	// Anomalies aren't raised while warming up.
+	interval(10, 10)
+	interval(100, 10)
+	if len(stats.counts) != 0 {
+		t.Fatalf("Expected no alarms during warm up, got %v", stats.counts)
+	}
+	for i := 0; i < 100; i++ {
+		interval(10, 10)
+	}
+	if len(stats.counts) != 0 {
+		t.Fatalf("Expected no alarms for steady volume, got %v", stats.counts)
+	}
+
+	// A release breaks play tracking, though not enough to move the total.
+	interval(0, 10)
+	if stats.counts["anomaly.event.drop"] != 1 || stats.counts["anomaly.total.drop"] != 0 {
+		t.Errorf("Expected play to drop without the total dropping, got %v", stats.counts)
+	}
+	interval(100, 100)
+	if stats.counts["anomaly.event.spike"] != 2 || stats.counts["anomaly.total.spike"] != 1 {
+		t.Errorf("Expected both events and the total to spike, got %v", stats.counts)
+	}
+}
+
+func TestTopEvents(t *testing.T) {
+	stats := &countingStatter{counts: make(map[string]int64)}
+	d, err := New(Config{TopEvents: 1}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create detector: %s", err)
+	}
+	d.Observe(encode(`[{"event":"busy"},{"event":"busy"},{"event":"quiet"}]`))
+	d.Observe(encode(`not json`))
+	d.Check()
+	if len(d.baselines) != 2 || d.baselines["busy"] == nil || d.baselines[totalName].rate != 0.3 {
+		t.Errorf("Expected the total and busiest event to be tracked, got %v", d.baselines)
+	}
+}
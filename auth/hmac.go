@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers carrying the signature of a signed request.
+const (
+	KeyIDHeader     = "X-Spade-Key-Id"
+	TimestampHeader = "X-Spade-Timestamp"
+	NonceHeader     = "X-Spade-Nonce"
+	SignatureHeader = "X-Spade-Signature"
+)
+
+const (
+	defaultClockTolerance = "5m"
+	defaultNonceCacheSize = 100000
+
+	// maxSignedBodyBytes matches the body limit of http.Request.ParseForm.
+	maxSignedBodyBytes = 10 << 20
+	maxNonceLength     = 128
+)
+
+var (
+	// ErrBadSignature is returned for requests without a valid signature.
+	ErrBadSignature = errors.New("bad signature")
+
+	// ErrStaleRequest is returned for signed requests whose timestamp is
+	// outside the clock tolerance.
+	ErrStaleRequest = errors.New("request timestamp outside clock tolerance")
+
+	// ErrReplayedRequest is returned for signed requests whose nonce has
+	// already been seen.
+	ErrReplayedRequest = errors.New("replayed request")
+
+	// ErrNonceCacheFull is returned for signed requests that can't be checked
+	// for replays because the nonce cache is full of unexpired nonces.
+	ErrNonceCacheFull = errors.New("nonce cache full")
+)
+
+// HMACConfig configures HMAC request signing with anti-replay protection.
+//
+// A signed request carries the headers KeyIDHeader, TimestampHeader (Unix
+// seconds), NonceHeader (a unique string of at most 128 bytes) and
+// SignatureHeader, the hex encoded HMAC-SHA256 using the key's secret of
+//
+//	timestamp + "\n" + nonce + "\n" + method + "\n" + request URI + "\n" + body
+type HMACConfig struct {
+	// Secrets are the signing secrets keyed by key id
+	Secrets map[string]string
+
+	// ClockTolerance is how far a request timestamp may be from the edge's
+	// clock, e.g. "5m". Nonces are remembered for twice this long.
+	ClockTolerance string
+
+	// NonceCacheSize bounds the number of nonces remembered. It must cover
+	// the signed request rate over twice ClockTolerance: the default 100000
+	// allows about 167 requests per second at a 5m tolerance. Once full of
+	// unexpired nonces, further signed requests are rejected rather than
+	// risk accepting a replay
+	NonceCacheSize int
+
+	// Endpoints are the request paths that require a signature
+	Endpoints []string
+}
+
+// Validate verifies that an HMACConfig is valid and fills in defaults
+func (c *HMACConfig) Validate() error {
+	if len(c.Secrets) == 0 {
+		return errors.New("Secrets must not be empty")
+	}
+	for id, secret := range c.Secrets {
+		if len(secret) < 16 {
+			return fmt.Errorf("secret for key %s must be at least 16 bytes", id)
+		}
+	}
+	if len(c.Endpoints) == 0 {
+		return errors.New("Endpoints must not be empty")
+	}
+	if c.ClockTolerance == "" {
+		c.ClockTolerance = defaultClockTolerance
+	}
+	if c.NonceCacheSize == 0 {
+		c.NonceCacheSize = defaultNonceCacheSize
+	}
+	d, err := time.ParseDuration(c.ClockTolerance)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.ClockTolerance, err)
+	} else if d <= 0 {
+		return errors.New("ClockTolerance must be greater than 0")
+	}
+	// A cache smaller than this can't even hold one signed request per second
+	// for as long as nonces are remembered.
+	if window := int((2 * d).Seconds()); c.NonceCacheSize < window {
+		return fmt.Errorf("NonceCacheSize must be at least %d, one nonce per second of twice ClockTolerance", window)
+	}
+	return nil
+}
+
+// HMACVerifier verifies signed requests and rejects replays.
+type HMACVerifier struct {
+	secrets   map[string][]byte
+	endpoints map[string]bool
+	tolerance time.Duration
+	nonces    *nonceCache
+	now       func() time.Time
+}
+
+// NewHMACVerifier returns a verifier for config.
+func NewHMACVerifier(config HMACConfig) (*HMACVerifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	tolerance, _ := time.ParseDuration(config.ClockTolerance)
+	secrets := make(map[string][]byte, len(config.Secrets))
+	for id, secret := range config.Secrets {
+		secrets[id] = []byte(secret)
+	}
+	endpoints := make(map[string]bool, len(config.Endpoints))
+	for _, e := range config.Endpoints {
+		endpoints[e] = true
+	}
+	return &HMACVerifier{
+		secrets:   secrets,
+		endpoints: endpoints,
+		tolerance: tolerance,
+		nonces:    newNonceCache(config.NonceCacheSize),
+		now:       time.Now,
+	}, nil
+}
+
+// Protects reports whether requests to path must be signed.
+func (v *HMACVerifier) Protects(path string) bool {
+	return v.endpoints[path]
+}
+
+// VerifyRequest checks the signature, timestamp and nonce of r and returns
+// the key id it was signed with. The body of r is read and replaced so it can
+// still be parsed afterwards.
+func (v *HMACVerifier) VerifyRequest(r *http.Request) (string, error) {
+	keyID := r.Header.Get(KeyIDHeader)
+	nonce := r.Header.Get(NonceHeader)
+	secret, ok := v.secrets[keyID]
+	if !ok || nonce == "" || len(nonce) > maxNonceLength {
+		return keyID, ErrBadSignature
+	}
+	signature, err := hex.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil {
+		return keyID, ErrBadSignature
+	}
+	timestamp := r.Header.Get(TimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return keyID, ErrBadSignature
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSignedBodyBytes+1))
+	if err != nil {
+		return keyID, err
+	}
+	if len(body) > maxSignedBodyBytes {
+		return keyID, errors.New("http: request body too large")
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = io.WriteString(mac, timestamp+"\n"+nonce+"\n"+r.Method+"\n"+r.URL.RequestURI()+"\n")
+	_, _ = mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return keyID, ErrBadSignature
+	}
+
+	// Only well-signed requests reach the nonce cache, so it can't be
+	// flooded by unauthenticated clients.
+	now := v.now()
+	sent := time.Unix(seconds, 0)
+	if sent.Before(now.Add(-v.tolerance)) || sent.After(now.Add(v.tolerance)) {
+		return keyID, ErrStaleRequest
+	}
+	return keyID, v.nonces.add(keyID+":"+nonce, now.Add(2*v.tolerance), now)
+}
+
+// SignRequest signs r, whose body is body, with the secret of keyID as of now,
+// so an HMACVerifier with that secret accepts it. Each call signs with a new
+// random nonce.
+func SignRequest(r *http.Request, keyID, secret string, body []byte, now time.Time) error {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return err
+	}
+	nonce := hex.EncodeToString(b[:])
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = io.WriteString(mac, timestamp+"\n"+nonce+"\n"+r.Method+"\n"+r.URL.RequestURI()+"\n")
+	_, _ = mac.Write(body)
+	r.Header.Set(KeyIDHeader, keyID)
+	r.Header.Set(TimestampHeader, timestamp)
+	r.Header.Set(NonceHeader, nonce)
+	r.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
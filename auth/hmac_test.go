@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef"
+
+func signedRequest(t *testing.T, timestamp time.Time, nonce, body string) *http.Request {
+	r, err := http.NewRequest("POST", "http://spade.example.com/track?img=1", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build request: %s", err)
+	}
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	_, _ = mac.Write([]byte(ts + "\n" + nonce + "\nPOST\n/track?img=1\n" + body))
+	r.Header.Set(KeyIDHeader, "producer")
+	r.Header.Set(TimestampHeader, ts)
+	r.Header.Set(NonceHeader, nonce)
+	r.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func TestHMACVerifyRequest(t *testing.T) {
+	v, err := NewHMACVerifier(HMACConfig{
+		Secrets:        map[string]string{"producer": testSecret},
+		ClockTolerance: "1s",
+		NonceCacheSize: 2,
+		Endpoints:      []string{"/track"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %s", err)
+	}
+	v.now = func() time.Time { return now }
+
+	r := signedRequest(t, now, "n1", "data=blah")
+	if keyID, err := v.VerifyRequest(r); err != nil || keyID != "producer" {
+		t.Fatalf("Expected request to verify, got %q, %v", keyID, err)
+	}
+	if b, _ := ioutil.ReadAll(r.Body); string(b) != "data=blah" {
+		t.Errorf("Expected body to be readable after verifying, got %q", b)
+	}
+
+	if _, err = v.VerifyRequest(signedRequest(t, now, "n1", "data=blah")); err != ErrReplayedRequest {
+		t.Errorf("Expected replay to be detected, got %v", err)
+	}
+	if _, err = v.VerifyRequest(signedRequest(t, now.Add(-time.Hour), "n2", "data=blah")); err != ErrStaleRequest {
+		t.Errorf("Expected stale request to be rejected, got %v", err)
+	}
+
+	tampered := signedRequest(t, now, "n3", "data=blah")
+	tampered.Body = ioutil.NopCloser(strings.NewReader("data=other"))
+	if _, err = v.VerifyRequest(tampered); err != ErrBadSignature {
+		t.Errorf("Expected tampered request to be rejected, got %v", err)
+	}
+
+	if _, err = v.VerifyRequest(signedRequest(t, now, "n4", "data=blah")); err != nil {
+		t.Fatalf("Expected request to verify, got %v", err)
+	}
+	if _, err = v.VerifyRequest(signedRequest(t, now, "n5", "data=blah")); err != ErrNonceCacheFull {
+		t.Errorf("Expected a full nonce cache to reject the request, got %v", err)
+	}
+	if _, err = v.VerifyRequest(signedRequest(t, now, "n1", "data=blah")); err != ErrReplayedRequest {
+		t.Errorf("Expected a full nonce cache to still detect replays, got %v", err)
+	}
+}
+
+func TestHMACConfigNonceCacheSize(t *testing.T) {
+	c := HMACConfig{
+		Secrets:        map[string]string{"producer": testSecret},
+		NonceCacheSize: 599,
+		Endpoints:      []string{"/track"},
+	}
+	if err := c.Validate(); err == nil {
+		t.Error("Expected a cache smaller than twice ClockTolerance in seconds to be rejected")
+	}
+	c.NonceCacheSize = 600
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected config to validate, got %v", err)
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	v, err := NewHMACVerifier(HMACConfig{Secrets: map[string]string{"relay": testSecret}, Endpoints: []string{"/relay"}})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %s", err)
+	}
+	v.now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		r, _ := http.NewRequest("POST", "https://spade.example.com/relay", strings.NewReader("events"))
+		if err = SignRequest(r, "relay", testSecret, []byte("events"), now); err != nil {
+			t.Fatalf("Failed to sign: %s", err)
+		}
+		if _, err = v.VerifyRequest(r); err != nil {
+			t.Errorf("Expected the signed request to verify, got %v", err)
+		}
+	}
+}
+
+func TestNonceCache(t *testing.T) {
+	c := newNonceCache(2)
+	if c.add("a", now.Add(time.Minute), now) != nil || c.add("a", now.Add(time.Minute), now) != ErrReplayedRequest {
+		t.Error("Expected only the first add of a nonce to succeed")
+	}
+	if err := c.add("a", now.Add(2*time.Minute), now.Add(time.Minute)); err != nil {
+		t.Errorf("Expected expired nonce to be forgotten, got %v", err)
+	}
+	_ = c.add("b", now.Add(3*time.Minute), now.Add(time.Minute))
+	if err := c.add("c", now.Add(3*time.Minute), now.Add(time.Minute)); err != ErrNonceCacheFull {
+		t.Errorf("Expected a full cache to refuse new nonces, got %v", err)
+	}
+	if len(c.seen) != 2 || c.add("a", now.Add(3*time.Minute), now.Add(time.Minute)) != ErrReplayedRequest {
+		t.Error("Expected unexpired nonces to be kept while the cache is full")
+	}
+	if err := c.add("c", now.Add(4*time.Minute), now.Add(2*time.Minute)); err != nil {
+		t.Errorf("Expected room once a nonce expires, got %v", err)
+	}
+}
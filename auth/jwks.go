@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// key returns the public key with id kid, fetching the JWKS if the cached keys
+// are stale or, at most once a minute, if kid is unknown. Only one fetch runs
+// at a time, without holding v.mu: the cached keys are served meanwhile, and
+// only callers with no keys to serve yet wait for it.
+func (v *JWTVerifier) key(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	now := v.now()
+	age := now.Sub(v.fetchedAt)
+	key, ok := v.keys[kid]
+	if v.keys == nil || age >= v.refreshInterval || (!ok && age >= minJWKSFetchInterval) {
+		if v.fetching == nil {
+			v.fetching = make(chan struct{})
+			v.mu.Unlock()
+			v.refresh(now)
+			v.mu.Lock()
+		} else if v.keys == nil {
+			fetching := v.fetching
+			v.mu.Unlock()
+			<-fetching
+			v.mu.Lock()
+		}
+		key, ok = v.keys[kid]
+	}
+	keys, fetchErr := v.keys, v.fetchErr
+	v.mu.Unlock()
+
+	if keys == nil {
+		return nil, fmt.Errorf("error fetching JWKS: %v", fetchErr)
+	}
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return key, nil
+}
+
+// refresh fetches the JWKS as of now and wakes up the callers waiting for it.
+// The cached keys are kept if the fetch fails.
+func (v *JWTVerifier) refresh(now time.Time) {
+	keys, err := v.fetchKeys()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err == nil {
+		v.keys = keys
+	}
+	v.fetchErr = err
+	v.fetchedAt = now
+	close(v.fetching)
+	v.fetching = nil
+}
+
+func (v *JWTVerifier) fetchKeys() (map[string]crypto.PublicKey, error) {
+	resp, err := v.client.Get(v.config.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, v.config.JWKSURL)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", k.KeyID, err)
+		}
+		keys[k.KeyID] = pub
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
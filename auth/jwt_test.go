@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var now = time.Unix(1500000000, 0)
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Failed to marshal %v: %s", v, err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := enc(map[string]string{"alg": "RS256", "kid": kid}) + "." + enc(claims)
+	h := crypto.SHA256.New()
+	_, _ = h.Write([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %s", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func newTestVerifier(t *testing.T) (*JWTVerifier, *rsa.PrivateKey, *httptest.Server, *int) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_ = json.NewEncoder(w).Encode(map[string][]map[string]string{"keys": {{
+			"kty": "RSA",
+			"kid": "k1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+
+	v, err := NewJWTVerifier(JWTConfig{
+		Issuer:    "https://issuer.example.com",
+		Audience:  "spade",
+		JWKSURL:   server.URL,
+		Endpoints: []string{"/track"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %s", err)
+	}
+	v.now = func() time.Time { return now }
+	return v, key, server, &fetches
+}
+
+func TestVerify(t *testing.T) {
+	v, key, server, fetches := newTestVerifier(t)
+	defer server.Close()
+	valid := map[string]interface{}{
+		"iss": "https://issuer.example.com",
+		"sub": "service-a",
+		"aud": []string{"other", "spade"},
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	claims, err := v.Verify(signToken(t, key, "k1", valid))
+	if err != nil {
+		t.Fatalf("Expected token to be valid: %s", err)
+	}
+	if claims.Subject != "service-a" {
+		t.Errorf("Expected subject service-a, got %q", claims.Subject)
+	}
+
+	for name, change := range map[string]map[string]interface{}{
+		"wrong issuer":   {"iss": "https://evil.example.com"},
+		"wrong audience": {"aud": "other"},
+		"expired":        {"exp": now.Add(-time.Hour).Unix()},
+		"not yet valid":  {"nbf": now.Add(time.Hour).Unix()},
+	} {
+		claims := map[string]interface{}{}
+		for k, val := range valid {
+			claims[k] = val
+		}
+		for k, val := range change {
+			claims[k] = val
+		}
+		if _, err = v.Verify(signToken(t, key, "k1", claims)); err != ErrInvalidToken {
+			t.Errorf("Expected %s token to be invalid, got %v", name, err)
+		}
+	}
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err = v.Verify(signToken(t, other, "k1", valid)); err != ErrInvalidToken {
+		t.Errorf("Expected token signed by another key to be invalid, got %v", err)
+	}
+	if _, err = v.Verify(signToken(t, key, "k2", valid)); err != ErrInvalidToken {
+		t.Errorf("Expected token with unknown kid to be invalid, got %v", err)
+	}
+	if *fetches != 1 {
+		t.Errorf("Expected keys to be fetched once, got %d", *fetches)
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	v, _, server, _ := newTestVerifier(t)
+	defer server.Close()
+	r, _ := http.NewRequest("POST", "http://spade.example.com/track", nil)
+	if _, err := v.VerifyRequest(r); err != ErrMissingToken {
+		t.Errorf("Expected ErrMissingToken, got %v", err)
+	}
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", "not.a.token"))
+	if _, err := v.VerifyRequest(r); err != ErrInvalidToken {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+	if !v.Protects("/track") || v.Protects("/") {
+		t.Error("Expected only /track to be protected")
+	}
+}
+
+func TestKeyRefreshServesCachedKeys(t *testing.T) {
+	v, key, server, _ := newTestVerifier(t)
+	defer server.Close()
+	valid := map[string]interface{}{
+		"iss": "https://issuer.example.com",
+		"aud": "spade",
+		"exp": now.Add(2 * time.Hour).Unix(),
+	}
+	token := signToken(t, key, "k1", valid)
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("Expected token to be valid: %s", err)
+	}
+
+	// The refresh hangs until released, while requests keep being verified
+	// with the cached keys.
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer hung.Close()
+	v.mu.Lock()
+	v.config.JWKSURL = hung.URL
+	v.now = func() time.Time { return now.Add(time.Hour) }
+	v.mu.Unlock()
+
+	refreshed := make(chan error)
+	go func() {
+		_, err := v.Verify(token)
+		refreshed <- err
+	}()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		v.mu.Lock()
+		fetching := v.fetching != nil
+		v.mu.Unlock()
+		if fetching {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a refresh to start")
+		}
+	}
+	if _, err := v.Verify(token); err != nil {
+		t.Errorf("Expected the cached keys served during a refresh, got %v", err)
+	}
+	close(release)
+	if err := <-refreshed; err != nil {
+		t.Errorf("Expected the cached keys kept after a failed refresh, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+type nonceEntry struct {
+	nonce   string
+	expires time.Time
+}
+
+// nonceCache remembers nonces until they expire, holding at most size of
+// them. Nonces are added with increasing expiry times, so the oldest entry is
+// always at the head of the queue.
+type nonceCache struct {
+	mu    sync.Mutex
+	size  int
+	seen  map[string]time.Time
+	queue []nonceEntry
+}
+
+func newNonceCache(size int) *nonceCache {
+	return &nonceCache{
+		size: size,
+		seen: make(map[string]time.Time, size),
+	}
+}
+
+// add records nonce until expires. It returns ErrReplayedRequest if nonce was
+// already seen and ErrNonceCacheFull if every slot holds an unexpired nonce;
+// forgetting one of those would let its request be replayed.
+func (c *nonceCache) add(nonce string, expires, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.queue) > 0 && !c.queue[0].expires.After(now) {
+		c.evictOldest()
+	}
+	if _, ok := c.seen[nonce]; ok {
+		return ErrReplayedRequest
+	}
+	if len(c.queue) >= c.size {
+		return ErrNonceCacheFull
+	}
+	c.seen[nonce] = expires
+	c.queue = append(c.queue, nonceEntry{nonce: nonce, expires: expires})
+	return nil
+}
+
+func (c *nonceCache) evictOldest() {
+	delete(c.seen, c.queue[0].nonce)
+	c.queue = c.queue[1:]
+}
@@ -0,0 +1,141 @@
+/*
+Package avro encodes spade events as Avro and registers their schema with a
+Confluent-style schema registry.
+
+Records are framed in the Confluent wire format, a zero magic byte and the
+big-endian schema ID followed by the Avro binary datum, so consumers using a
+registry-aware deserializer can decode them without being told the schema.
+*/
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// EventSchema is the Avro schema of a spade event.
+const EventSchema = `{"type":"record","name":"Event","namespace":"tv.twitch.spade","fields":[` +
+	`{"name":"receivedAt","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+	`{"name":"clientIp","type":"string"},` +
+	`{"name":"xForwardedFor","type":"string"},` +
+	`{"name":"uuid","type":"string"},` +
+	`{"name":"data","type":"string"},` +
+	`{"name":"userAgent","type":"string"},` +
+	`{"name":"recordversion","type":"int"},` +
+	`{"name":"edgeType","type":"string"}]}`
+
+// GlobSchema is the Avro schema of a glob: an array of spade events.
+const GlobSchema = `{"type":"array","items":` + EventSchema + `}`
+
+// magicByte starts every record in the Confluent wire format.
+const magicByte = 0
+
+// AppendFrame appends the Confluent wire format header for schemaID to b.
+func AppendFrame(b []byte, schemaID int32) []byte {
+	var id [4]byte
+	binary.BigEndian.PutUint32(id[:], uint32(schemaID))
+	return append(append(b, magicByte), id[:]...)
+}
+
+// AppendEvent appends e encoded as an EventSchema datum to b.
+func AppendEvent(b []byte, e *spade.Event) []byte {
+	b = appendLong(b, e.ReceivedAt.UnixNano()/1e6)
+	clientIP := ""
+	if e.ClientIp != nil {
+		clientIP = e.ClientIp.String()
+	}
+	b = appendString(b, clientIP)
+	b = appendString(b, e.XForwardedFor)
+	b = appendString(b, e.Uuid)
+	b = appendString(b, e.Data)
+	b = appendString(b, e.UserAgent)
+	b = appendLong(b, int64(e.Version))
+	return appendString(b, e.EdgeType)
+}
+
+// AppendGlob appends events encoded as a GlobSchema datum to b.
+func AppendGlob(b []byte, events []*spade.Event) []byte {
+	// Arrays are written as a block of items followed by an empty block.
+	if len(events) > 0 {
+		b = appendLong(b, int64(len(events)))
+		for _, e := range events {
+			b = AppendEvent(b, e)
+		}
+	}
+	return appendLong(b, 0)
+}
+
+// errTruncated is returned for data ending in the middle of a datum.
+var errTruncated = errors.New("avro: truncated datum")
+
+// GlobLength returns the number of events in a record holding a framed
+// GlobSchema datum, checking that each of them decodes.
+func GlobLength(record []byte) (int, error) {
+	if len(record) < 5 || record[0] != magicByte {
+		return 0, errors.New("avro: missing wire format header")
+	}
+	b := record[5:]
+	length := 0
+	for {
+		count, n := binary.Varint(b)
+		if n <= 0 {
+			return 0, errTruncated
+		}
+		b = b[n:]
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// A negative count is followed by the block's size in bytes.
+			count = -count
+			if _, n = binary.Varint(b); n <= 0 {
+				return 0, errTruncated
+			}
+			b = b[n:]
+		}
+		for i := int64(0); i < count; i++ {
+			var err error
+			if b, err = skipEvent(b); err != nil {
+				return 0, err
+			}
+		}
+		length += int(count)
+	}
+	if len(b) > 0 {
+		return 0, errors.New("avro: trailing data after glob")
+	}
+	return length, nil
+}
+
+// skipEvent returns b after the EventSchema datum it starts with.
+func skipEvent(b []byte) ([]byte, error) {
+	// receivedAt, then clientIp, xForwardedFor, uuid, data and userAgent,
+	// then recordversion, then edgeType.
+	for _, isString := range []bool{false, true, true, true, true, true, false, true} {
+		n, size := binary.Varint(b)
+		if size <= 0 {
+			return nil, errTruncated
+		}
+		b = b[size:]
+		if isString {
+			if n < 0 || n > int64(len(b)) {
+				return nil, errTruncated
+			}
+			b = b[n:]
+		}
+	}
+	return b, nil
+}
+
+// appendLong appends n as a zig-zag encoded varint, which Avro uses for both
+// int and long.
+func appendLong(b []byte, n int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], n)]...)
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendLong(b, int64(len(s))), s...)
+}
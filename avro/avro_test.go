@@ -0,0 +1,75 @@
+package avro
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+func TestAppendEvent(t *testing.T) {
+	e := spade.NewEvent(time.Unix(1, 0), net.ParseIP("1.2.3.4"), "x", "u", "d", "", spade.INTERNAL_EDGE)
+	got := AppendFrame(nil, 7)
+	got = AppendGlob(got, []*spade.Event{e})
+
+	expected := []byte{0, 0, 0, 0, 7, // frame
+		2,        // one item
+		0xd0, 15, // 1000ms
+		14, '1', '.', '2', '.', '3', '.', '4',
+		2, 'x',
+		2, 'u',
+		2, 'd',
+		0,
+		byte(spade.PROTOCOL_VERSION * 2),
+		byte(len(spade.INTERNAL_EDGE) * 2)}
+	expected = append(expected, spade.INTERNAL_EDGE...)
+	expected = append(expected, 0) // end of array
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestGlobLength(t *testing.T) {
+	e := spade.NewEvent(time.Unix(1, 0), net.ParseIP("1.2.3.4"), "x", "u", "d", "", spade.INTERNAL_EDGE)
+	for _, events := range [][]*spade.Event{nil, {e}, {e, e, e}} {
+		glob := AppendGlob(AppendFrame(nil, 7), events)
+		if n, err := GlobLength(glob); n != len(events) || err != nil {
+			t.Errorf("Expected %d events, got %d, %v", len(events), n, err)
+		}
+		if _, err := GlobLength(glob[:len(glob)-2]); err == nil {
+			t.Errorf("Expected a truncated glob of %d events to be rejected", len(events))
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var request map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects/spade-events-value/versions" {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &request)
+		_, _ = w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	id, err := NewRegistry(server.URL+"/").Register("spade-events-value", GlobSchema)
+	if err != nil {
+		t.Fatalf("Failed to register: %s", err)
+	}
+	if id != 42 || request["schema"] != GlobSchema {
+		t.Errorf("Expected id 42 for the glob schema, got %d for %q", id, request["schema"])
+	}
+
+	if _, err = NewRegistry(server.URL).Register("unknown", GlobSchema); err == nil {
+		t.Error("Expected registry errors to be returned")
+	}
+}
@@ -0,0 +1,57 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const registryContentType = "application/vnd.schemaregistry.v1+json"
+
+// Registry is a client of a Confluent-style schema registry.
+type Registry struct {
+	url    string
+	client *http.Client
+}
+
+// NewRegistry returns a client of the registry at url.
+func NewRegistry(url string) *Registry {
+	return &Registry{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Register registers schema under subject, or looks it up if it already is,
+// and returns its ID.
+func (r *Registry) Register(subject, schema string) (int32, error) {
+	body, err := json.Marshal(map[string]string{"schema": schema})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := r.client.Post(r.url+"/subjects/"+subject+"/versions", registryContentType,
+		bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error registering schema for %s: %v", subject, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error reading schema registry response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schema registry returned %d registering %s: %s",
+			resp.StatusCode, subject, respBody)
+	}
+	var registered struct {
+		ID int32 `json:"id"`
+	}
+	if err = json.Unmarshal(respBody, &registered); err != nil {
+		return 0, fmt.Errorf("error parsing schema registry response %s: %v", respBody, err)
+	}
+	return registered.ID, nil
+}
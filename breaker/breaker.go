@@ -0,0 +1,179 @@
+/*
+Package breaker provides a small circuit breaker used to stop the edge from
+hammering a sink that is failing. A breaker counts successes and failures over
+a rolling window and opens once the error percentage crosses a threshold. After
+a sleep window it lets a limited number of probe calls through and closes again
+if they all succeed.
+
+Like a Hystrix command, a breaker can also time calls out and cap how many run
+at once. Each breaker has its own cap, so a slow sink only uses up its own
+concurrency and not that of the other sinks. Timeouts and calls rejected
+because the cap is reached count as failures.
+*/
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultWindow                 = "10s"
+	defaultBuckets                = 10
+	defaultRequestVolumeThreshold = 20
+	defaultErrorPercentThreshold  = 50
+	defaultSleepWindow            = "5s"
+	defaultHalfOpenProbes         = 1
+)
+
+var (
+	// ErrOpen is returned by Do when the breaker rejects a call without running it.
+	ErrOpen = errors.New("circuit breaker is open")
+
+	// ErrTimeout is returned by Do when a call takes longer than the timeout.
+	ErrTimeout = errors.New("circuit breaker call timed out")
+
+	// ErrMaxConcurrency is returned by Do when MaxConcurrentRequests calls are
+	// already running.
+	ErrMaxConcurrency = errors.New("circuit breaker max concurrency reached")
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// Closed breakers let every call through.
+	Closed State = iota
+	// Open breakers reject every call until the sleep window elapses.
+	Open
+	// HalfOpen breakers let a limited number of probe calls through.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// A Breaker guards calls to a dependency that may fail.
+type Breaker interface {
+	// Do runs fn if the breaker allows it and records the outcome. If the
+	// breaker rejects the call ErrOpen is returned and fn is not run.
+	Do(fn func() error) error
+
+	// State returns the current state of the breaker.
+	State() State
+}
+
+// Config configures a circuit breaker. Zero values are replaced with defaults.
+type Config struct {
+	// Window is the length of the rolling window that outcomes are counted over
+	Window string
+
+	// Buckets is the number of buckets the rolling window is divided into
+	Buckets int
+
+	// RequestVolumeThreshold is the minimum number of calls in the window before
+	// the breaker can open
+	RequestVolumeThreshold int
+
+	// ErrorPercentThreshold is the percentage of failed calls in the window at
+	// which the breaker opens
+	ErrorPercentThreshold int
+
+	// SleepWindow is how long an open breaker waits before probing again
+	SleepWindow string
+
+	// HalfOpenProbes is the number of successful probes needed to close the breaker
+	HalfOpenProbes int
+
+	// Timeout, if set, is how long a call may take before Do returns
+	// ErrTimeout. The call itself keeps running.
+	Timeout string
+
+	// MaxConcurrentRequests, if set, is the most calls that may run at once,
+	// including calls that have timed out but not yet returned
+	MaxConcurrentRequests int
+}
+
+func (c *Config) applyDefaults() {
+	if c.Window == "" {
+		c.Window = defaultWindow
+	}
+	if c.Buckets == 0 {
+		c.Buckets = defaultBuckets
+	}
+	if c.RequestVolumeThreshold == 0 {
+		c.RequestVolumeThreshold = defaultRequestVolumeThreshold
+	}
+	if c.ErrorPercentThreshold == 0 {
+		c.ErrorPercentThreshold = defaultErrorPercentThreshold
+	}
+	if c.SleepWindow == "" {
+		c.SleepWindow = defaultSleepWindow
+	}
+	if c.HalfOpenProbes == 0 {
+		c.HalfOpenProbes = defaultHalfOpenProbes
+	}
+}
+
+// Validate fills in defaults and verifies that a Config is valid.
+func (c *Config) Validate() error {
+	c.applyDefaults()
+
+	window, err := time.ParseDuration(c.Window)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Window, err)
+	}
+	if window <= 0 {
+		return errors.New("Window must be greater than 0")
+	}
+
+	sleep, err := time.ParseDuration(c.SleepWindow)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.SleepWindow, err)
+	}
+	if sleep <= 0 {
+		return errors.New("SleepWindow must be greater than 0")
+	}
+
+	if c.Buckets <= 0 || time.Duration(c.Buckets) > window {
+		return errors.New("Buckets must be positive and no larger than the window in nanoseconds")
+	}
+
+	if c.RequestVolumeThreshold < 0 {
+		return errors.New("RequestVolumeThreshold must not be negative")
+	}
+
+	if c.ErrorPercentThreshold < 0 || c.ErrorPercentThreshold > 100 {
+		return errors.New("ErrorPercentThreshold must be between 0 and 100")
+	}
+
+	if c.HalfOpenProbes < 0 {
+		return errors.New("HalfOpenProbes must not be negative")
+	}
+
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Timeout, err)
+		}
+		if timeout <= 0 {
+			return errors.New("Timeout must be greater than 0")
+		}
+	}
+
+	if c.MaxConcurrentRequests < 0 {
+		return errors.New("MaxConcurrentRequests must not be negative")
+	}
+	return nil
+}
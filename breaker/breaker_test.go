@@ -0,0 +1,189 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+var errSink = errors.New("sink failed")
+
+func newTestBreaker(t *testing.T, now *time.Time) *CircuitBreaker {
+	s, _ := statsd.NewNoop()
+	cb, err := New("test", Config{
+		RequestVolumeThreshold: 4,
+		ErrorPercentThreshold:  50,
+		SleepWindow:            "5s",
+		HalfOpenProbes:         2,
+	}, s)
+	if err != nil {
+		t.Fatalf("Failed to create breaker: %s", err)
+	}
+	cb.now = func() time.Time { return *now }
+	return cb
+}
+
+func succeed() error { return nil }
+func fail() error    { return errSink }
+
+func TestBreakerOpensOnErrorPercent(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := newTestBreaker(t, &now)
+
+	_ = cb.Do(succeed)
+	_ = cb.Do(fail)
+	_ = cb.Do(succeed)
+	if cb.State() != Closed {
+		t.Fatalf("Expected closed breaker below the volume threshold, got %s", cb.State())
+	}
+
+	_ = cb.Do(fail)
+	if cb.State() != Open {
+		t.Fatalf("Expected open breaker, got %s", cb.State())
+	}
+
+	called := false
+	err := cb.Do(func() error {
+		called = true
+		return nil
+	})
+	if err != ErrOpen || called {
+		t.Fatalf("Expected ErrOpen without running fn, got %v (called: %t)", err, called)
+	}
+}
+
+func TestBreakerHalfOpenProbes(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := newTestBreaker(t, &now)
+	for i := 0; i < 4; i++ {
+		_ = cb.Do(fail)
+	}
+	if cb.State() != Open {
+		t.Fatalf("Expected open breaker, got %s", cb.State())
+	}
+
+	now = now.Add(5 * time.Second)
+	if cb.State() != HalfOpen {
+		t.Fatalf("Expected half-open breaker after the sleep window, got %s", cb.State())
+	}
+	if err := cb.Do(fail); err != errSink {
+		t.Fatalf("Expected probe to run, got %v", err)
+	}
+	if cb.State() != Open {
+		t.Fatalf("Expected failed probe to reopen the breaker, got %s", cb.State())
+	}
+
+	now = now.Add(5 * time.Second)
+	_ = cb.Do(succeed)
+	if cb.State() != HalfOpen {
+		t.Fatalf("Expected breaker to stay half-open until all probes succeed, got %s", cb.State())
+	}
+	_ = cb.Do(succeed)
+	if cb.State() != Closed {
+		t.Fatalf("Expected breaker to close after successful probes, got %s", cb.State())
+	}
+}
+
+func TestRollingWindowExpiry(t *testing.T) {
+	w := newRollingWindow(10*time.Second, 10)
+	now := time.Unix(1000, 0)
+	w.recordFailure(now)
+	w.recordSuccess(now.Add(5 * time.Second))
+	if total := w.sum(now.Add(5 * time.Second)); total.failure != 1 || total.success != 1 {
+		t.Fatalf("Unexpected totals inside the window: %+v", total)
+	}
+	if total := w.sum(now.Add(12 * time.Second)); total.failure != 0 || total.success != 1 {
+		t.Fatalf("Expected old bucket to expire: %+v", total)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := Config{}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Expected defaults to be valid: %s", err)
+	}
+	if c.Window != defaultWindow || c.HalfOpenProbes != defaultHalfOpenProbes {
+		t.Fatalf("Expected defaults to be filled in: %+v", c)
+	}
+
+	c = Config{ErrorPercentThreshold: 101}
+	if err := c.Validate(); err == nil {
+		t.Fatal("Expected ErrorPercentThreshold above 100 to be rejected")
+	}
+	c = Config{Timeout: "-1s"}
+	if err := c.Validate(); err == nil {
+		t.Fatal("Expected a negative Timeout to be rejected")
+	}
+	c = Config{SleepWindow: "soon"}
+	if err := c.Validate(); err == nil {
+		t.Fatal("Expected unparsable SleepWindow to be rejected")
+	}
+}
+
+func TestBreakerTimeout(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	cb, err := New("timeout", Config{Timeout: "10ms", RequestVolumeThreshold: 1}, s)
+	if err != nil {
+		t.Fatalf("Failed to create breaker: %s", err)
+	}
+	release := make(chan struct{})
+	defer close(release)
+	err = cb.Do(func() error {
+		<-release
+		return nil
+	})
+	if err != ErrTimeout {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if counts, state := cb.snapshot(); counts.timeout != 1 || state != Open {
+		t.Errorf("Expected the timeout to count as a failure and open the breaker, got %+v %s", counts, state)
+	}
+}
+
+func TestBreakerMaxConcurrentRequests(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	cb, err := New("concurrency", Config{MaxConcurrentRequests: 1}, s)
+	if err != nil {
+		t.Fatalf("Failed to create breaker: %s", err)
+	}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- cb.Do(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	if err = cb.Do(succeed); err != ErrMaxConcurrency {
+		t.Errorf("Expected ErrMaxConcurrency while a call is running, got %v", err)
+	}
+	close(release)
+	if err = <-done; err != nil {
+		t.Errorf("Expected the running call to succeed, got %v", err)
+	}
+	if err = cb.Do(succeed); err != nil {
+		t.Errorf("Expected the slot to be released, got %v", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	now := time.Now()
+	registry, other := NewRegistry(), NewRegistry()
+	registry.Register(newTestBreaker(t, &now))
+	registry.Register(newTestBreaker(t, &now))
+
+	var names []string
+	registry.each(func(cb *CircuitBreaker) { names = append(names, cb.Name()) })
+	if len(names) != 1 || names[0] != "test" {
+		t.Errorf("Expected the breaker to be registered once, got %v", names)
+	}
+	other.each(func(cb *CircuitBreaker) {
+		t.Errorf("Expected another registry to be empty, got %s", cb.Name())
+	})
+}
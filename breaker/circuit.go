@@ -0,0 +1,257 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const statsPrefix = "breaker."
+
+// Registry holds the breakers a StreamHandler reports, by name.
+type Registry struct {
+	mu       sync.RWMutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*CircuitBreaker)}
+}
+
+// Register adds cb to the registry, replacing any breaker of the same name.
+func (r *Registry) Register(cb *CircuitBreaker) {
+	r.mu.Lock()
+	r.breakers[cb.name] = cb
+	r.mu.Unlock()
+}
+
+// each calls fn with every registered breaker.
+func (r *Registry) each(fn func(*CircuitBreaker)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, cb := range r.breakers {
+		fn(cb)
+	}
+}
+
+// CircuitBreaker is the default Breaker implementation.
+type CircuitBreaker struct {
+	name    string
+	config  Config
+	sleep   time.Duration
+	window  time.Duration
+	timeout time.Duration
+	// slots holds a token per running call if MaxConcurrentRequests is set.
+	slots   chan struct{}
+	statter statsd.StatSender
+	now     func() time.Time
+
+	mu             sync.Mutex
+	state          State
+	openedAt       time.Time
+	probesInFlight int
+	probeSuccesses int
+	counts         *rollingWindow
+}
+
+// New creates a CircuitBreaker for the named dependency. Register it with a
+// Registry for a StreamHandler to report it.
+func New(name string, config Config, statter statsd.StatSender) (*CircuitBreaker, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	window, _ := time.ParseDuration(config.Window)
+	sleep, _ := time.ParseDuration(config.SleepWindow)
+	var timeout time.Duration
+	if config.Timeout != "" {
+		timeout, _ = time.ParseDuration(config.Timeout)
+	}
+
+	cb := &CircuitBreaker{
+		name:    name,
+		config:  config,
+		sleep:   sleep,
+		window:  window,
+		timeout: timeout,
+		statter: statter,
+		now:     time.Now,
+		counts:  newRollingWindow(window, config.Buckets),
+	}
+	if config.MaxConcurrentRequests > 0 {
+		cb.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	return cb, nil
+}
+
+// Name returns the name the breaker was created with.
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
+}
+
+// Do runs fn if the breaker allows it and records the outcome.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if !cb.acquire() {
+		_ = cb.statter.Inc(statsPrefix+cb.name+".max_concurrency", 1, 0.1)
+		cb.record(ErrMaxConcurrency, false)
+		return ErrMaxConcurrency
+	}
+	allowed, probe := cb.allow()
+	if !allowed {
+		cb.release()
+		_ = cb.statter.Inc(statsPrefix+cb.name+".rejected", 1, 0.1)
+		return ErrOpen
+	}
+
+	err := cb.run(fn)
+	cb.record(err, probe)
+	switch err {
+	case nil:
+		_ = cb.statter.Inc(statsPrefix+cb.name+".success", 1, 0.1)
+	case ErrTimeout:
+		_ = cb.statter.Inc(statsPrefix+cb.name+".timeout", 1, 0.1)
+	default:
+		_ = cb.statter.Inc(statsPrefix+cb.name+".failure", 1, 0.1)
+	}
+	return err
+}
+
+// acquire takes a concurrency slot, if the breaker has a limit.
+func (cb *CircuitBreaker) acquire() bool {
+	if cb.slots == nil {
+		return true
+	}
+	select {
+	case cb.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (cb *CircuitBreaker) release() {
+	if cb.slots != nil {
+		<-cb.slots
+	}
+}
+
+// run runs fn, giving up waiting for it after the timeout. The concurrency
+// slot is released when fn returns.
+func (cb *CircuitBreaker) run(fn func() error) error {
+	if cb.timeout <= 0 {
+		defer cb.release()
+		return fn()
+	}
+	done := make(chan error, 1)
+	logger.Go(func() {
+		defer cb.release()
+		done <- fn()
+	})
+	timer := time.NewTimer(cb.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return ErrTimeout
+	}
+}
+
+// State returns the current state of the breaker.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == Open && cb.now().Sub(cb.openedAt) >= cb.sleep {
+		return HalfOpen
+	}
+	return cb.state
+}
+
+// allow reports whether a call may go ahead, and whether that call is a
+// half-open probe.
+func (cb *CircuitBreaker) allow() (allowed bool, probe bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	if cb.state == Open && now.Sub(cb.openedAt) >= cb.sleep {
+		cb.setState(HalfOpen)
+	}
+
+	switch cb.state {
+	case Closed:
+		return true, false
+	case HalfOpen:
+		if cb.probesInFlight < cb.config.HalfOpenProbes {
+			cb.probesInFlight++
+			return true, true
+		}
+	}
+	cb.counts.recordRejected(now)
+	return false, false
+}
+
+func (cb *CircuitBreaker) record(err error, probe bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	success := err == nil
+	switch err {
+	case nil:
+		cb.counts.recordSuccess(now)
+	case ErrTimeout:
+		cb.counts.recordTimeout(now)
+	case ErrMaxConcurrency:
+		cb.counts.recordMaxConcurrency(now)
+	default:
+		cb.counts.recordFailure(now)
+	}
+
+	switch {
+	case probe && cb.state == HalfOpen:
+		cb.probesInFlight--
+		if !success {
+			cb.trip(now)
+			return
+		}
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.config.HalfOpenProbes {
+			cb.counts.reset()
+			cb.setState(Closed)
+		}
+	case !probe && cb.state == Closed && !success:
+		total := cb.counts.sum(now)
+		requests := total.success + total.failure
+		if requests >= int64(cb.config.RequestVolumeThreshold) &&
+			total.failure*100 >= int64(cb.config.ErrorPercentThreshold)*requests {
+			cb.trip(now)
+		}
+	}
+}
+
+func (cb *CircuitBreaker) trip(now time.Time) {
+	cb.openedAt = now
+	cb.setState(Open)
+}
+
+func (cb *CircuitBreaker) setState(s State) {
+	if cb.state == s {
+		return
+	}
+	cb.state = s
+	cb.probesInFlight = 0
+	cb.probeSuccesses = 0
+	_ = cb.statter.Inc(statsPrefix+cb.name+".state."+s.String(), 1, 1)
+	logger.WithField("breaker", cb.name).WithField("state", s.String()).Warn("Circuit breaker changed state")
+}
+
+// snapshot returns the rolling counts along with the current state.
+func (cb *CircuitBreaker) snapshot() (bucket, State) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.counts.sum(cb.now()), cb.state
+}
@@ -0,0 +1,86 @@
+package breaker
+
+import (
+	"time"
+)
+
+type bucket struct {
+	start    int64
+	success  int64
+	failure  int64
+	rejected int64
+
+	// timeout and maxConcurrency are the failures that were timeouts and
+	// calls rejected by the concurrency limit.
+	timeout        int64
+	maxConcurrency int64
+}
+
+// rollingWindow counts outcomes over the most recent window, split into
+// fixed-width buckets that are recycled as time moves on. It is not safe for
+// concurrent use; callers hold the breaker's lock.
+type rollingWindow struct {
+	width   int64
+	buckets []bucket
+}
+
+func newRollingWindow(window time.Duration, n int) *rollingWindow {
+	return &rollingWindow{
+		width:   int64(window) / int64(n),
+		buckets: make([]bucket, n),
+	}
+}
+
+func (w *rollingWindow) current(now time.Time) *bucket {
+	start := now.UnixNano() / w.width
+	b := &w.buckets[start%int64(len(w.buckets))]
+	if b.start != start {
+		*b = bucket{start: start}
+	}
+	return b
+}
+
+func (w *rollingWindow) recordSuccess(now time.Time) {
+	w.current(now).success++
+}
+
+func (w *rollingWindow) recordFailure(now time.Time) {
+	w.current(now).failure++
+}
+
+func (w *rollingWindow) recordTimeout(now time.Time) {
+	b := w.current(now)
+	b.failure++
+	b.timeout++
+}
+
+func (w *rollingWindow) recordMaxConcurrency(now time.Time) {
+	b := w.current(now)
+	b.failure++
+	b.maxConcurrency++
+}
+
+func (w *rollingWindow) recordRejected(now time.Time) {
+	w.current(now).rejected++
+}
+
+// sum returns the totals of all buckets that are still inside the window.
+func (w *rollingWindow) sum(now time.Time) (total bucket) {
+	oldest := now.UnixNano()/w.width - int64(len(w.buckets)) + 1
+	for _, b := range w.buckets {
+		if b.start >= oldest {
+			total.success += b.success
+			total.failure += b.failure
+			total.rejected += b.rejected
+			total.timeout += b.timeout
+			total.maxConcurrency += b.maxConcurrency
+		}
+	}
+	return
+}
+
+func (w *rollingWindow) reset() {
+	for i := range w.buckets {
+		w.buckets[i] = bucket{}
+	}
+}
@@ -0,0 +1,168 @@
+package breaker
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const streamEventBufferSize = 10
+
+// StreamHandler publishes the state of every breaker of a Registry once a
+// second as a text/event-stream in the format understood by the Hystrix
+// dashboard.
+type StreamHandler struct {
+	registry *Registry
+	mu       sync.RWMutex
+	requests map[*http.Request]chan []byte
+	done     chan struct{}
+}
+
+// streamCmdEvent is the subset of the Hystrix command metrics the dashboard
+// needs to render a circuit.
+type streamCmdEvent struct {
+	Type               string `json:"type"`
+	Name               string `json:"name"`
+	Group              string `json:"group"`
+	Time               int64  `json:"currentTime"`
+	ReportingHosts     uint32 `json:"reportingHosts"`
+	RequestCount       uint32 `json:"requestCount"`
+	ErrorCount         uint32 `json:"errorCount"`
+	ErrorPct           uint32 `json:"errorPercentage"`
+	CircuitBreakerOpen bool   `json:"isCircuitBreakerOpen"`
+
+	RollingCountFailure        uint32 `json:"rollingCountFailure"`
+	RollingCountShortCircuited uint32 `json:"rollingCountShortCircuited"`
+	RollingCountSuccess        uint32 `json:"rollingCountSuccess"`
+	RollingCountTimeout        uint32 `json:"rollingCountTimeout"`
+	RollingCountRejected       uint32 `json:"rollingCountSemaphoreRejected"`
+
+	CircuitBreakerRequestVolumeThreshold uint32 `json:"propertyValue_circuitBreakerRequestVolumeThreshold"`
+	CircuitBreakerSleepWindow            uint32 `json:"propertyValue_circuitBreakerSleepWindowInMilliseconds"`
+	CircuitBreakerErrorThresholdPercent  uint32 `json:"propertyValue_circuitBreakerErrorThresholdPercentage"`
+	CircuitBreakerEnabled                bool   `json:"propertyValue_circuitBreakerEnabled"`
+	RollingStatsWindow                   uint32 `json:"propertyValue_metricsRollingStatisticalWindowInMilliseconds"`
+	ExecutionTimeout                     uint32 `json:"propertyValue_executionIsolationThreadTimeoutInMilliseconds"`
+	MaxConcurrentRequests                uint32 `json:"propertyValue_executionIsolationSemaphoreMaxConcurrentRequests"`
+}
+
+// NewStreamHandler returns a StreamHandler of the breakers of registry; call
+// Start before serving it.
+func NewStreamHandler(registry *Registry) *StreamHandler {
+	return &StreamHandler{
+		registry: registry,
+		requests: make(map[*http.Request]chan []byte),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins publishing breaker metrics to connected clients.
+func (sh *StreamHandler) Start() {
+	logger.Go(sh.loop)
+}
+
+// Stop stops publishing breaker metrics.
+func (sh *StreamHandler) Stop() {
+	close(sh.done)
+}
+
+// ServeHTTP streams breaker metrics to the client until it disconnects.
+func (sh *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	events := sh.register(r)
+	defer sh.unregister(r)
+
+	w.Header().Add("Content-Type", "text/event-stream")
+	for event := range events {
+		if _, err := w.Write(event); err != nil {
+			return
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+func (sh *StreamHandler) loop() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sh.registry.each(sh.publish)
+		case <-sh.done:
+			return
+		}
+	}
+}
+
+func (sh *StreamHandler) publish(cb *CircuitBreaker) {
+	counts, state := cb.snapshot()
+	requests := counts.success + counts.failure
+	var errPct int64
+	if requests > 0 {
+		errPct = counts.failure * 100 / requests
+	}
+
+	b, err := json.Marshal(&streamCmdEvent{
+		Type:               "HystrixCommand",
+		Name:               cb.name,
+		Group:              cb.name,
+		Time:               time.Now().UnixNano() / int64(time.Millisecond),
+		ReportingHosts:     1,
+		RequestCount:       uint32(requests),
+		ErrorCount:         uint32(counts.failure),
+		ErrorPct:           uint32(errPct),
+		CircuitBreakerOpen: state != Closed,
+
+		RollingCountFailure:        uint32(counts.failure),
+		RollingCountShortCircuited: uint32(counts.rejected),
+		RollingCountSuccess:        uint32(counts.success),
+		RollingCountTimeout:        uint32(counts.timeout),
+		RollingCountRejected:       uint32(counts.maxConcurrency),
+
+		CircuitBreakerRequestVolumeThreshold: uint32(cb.config.RequestVolumeThreshold),
+		CircuitBreakerSleepWindow:            uint32(cb.sleep / time.Millisecond),
+		CircuitBreakerErrorThresholdPercent:  uint32(cb.config.ErrorPercentThreshold),
+		CircuitBreakerEnabled:                true,
+		RollingStatsWindow:                   uint32(cb.window / time.Millisecond),
+		ExecutionTimeout:                     uint32(cb.timeout / time.Millisecond),
+		MaxConcurrentRequests:                uint32(cb.config.MaxConcurrentRequests),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal breaker metrics")
+		return
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("data:")
+	_, _ = buf.Write(b)
+	_, _ = buf.WriteString("\n\n")
+	data := buf.Bytes()
+
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	for _, events := range sh.requests {
+		select {
+		case events <- data:
+		default:
+		}
+	}
+}
+
+func (sh *StreamHandler) register(r *http.Request) <-chan []byte {
+	events := make(chan []byte, streamEventBufferSize)
+	sh.mu.Lock()
+	sh.requests[r] = events
+	sh.mu.Unlock()
+	return events
+}
+
+func (sh *StreamHandler) unregister(r *http.Request) {
+	sh.mu.Lock()
+	delete(sh.requests, r)
+	sh.mu.Unlock()
+}
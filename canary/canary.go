@@ -0,0 +1,255 @@
+/*
+Package canary detects silent write failures by regularly sending a synthetic
+event through the edge's own handler and checking that every sink accepts it.
+
+Canary events are named spade_edge_canary and carry a User-Agent starting with
+spade-edge-canary/, so downstream processing can drop them. Sinks are wrapped
+with Wrap to see canary events go by; a sink acknowledges a canary when its Log
+returns without error, which for Kinesis means the event was buffered for
+sending.
+*/
+package canary
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+const (
+	// EventName is the name of canary events.
+	EventName = "spade_edge_canary"
+
+	// UserAgentPrefix starts the User-Agent of canary events.
+	UserAgentPrefix = "spade-edge-canary/"
+
+	defaultInterval = "1m"
+	defaultTimeout  = "10s"
+	defaultPath     = "/track"
+)
+
+// Config configures the canary.
+type Config struct {
+	// Interval is how often a canary event is sent, e.g. "1m"
+	Interval string
+
+	// Timeout is how long the sinks have to acknowledge a canary, e.g. "10s"
+	Timeout string
+
+	// Path is the endpoint canary events are sent to
+	Path string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Interval == "" {
+		c.Interval = defaultInterval
+	}
+	if c.Timeout == "" {
+		c.Timeout = defaultTimeout
+	}
+	if c.Path == "" {
+		c.Path = defaultPath
+	}
+	for _, d := range []string{c.Interval, c.Timeout} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return errors.New("Path must start with /")
+	}
+	return nil
+}
+
+// Canary sends canary events and checks that the wrapped sinks acknowledge
+// them.
+type Canary struct {
+	handler  http.Handler
+	stats    statsd.StatSender
+	interval time.Duration
+	timeout  time.Duration
+	path     string
+	count    uint64
+	closed   chan struct{}
+
+	mu      sync.Mutex
+	sinks   []string
+	pending map[string]*probe
+}
+
+// probe is a canary event waiting for acknowledgements.
+type probe struct {
+	sent time.Time
+	acks map[string]error
+	// latency is the time each sink took to acknowledge the probe
+	latency map[string]time.Duration
+}
+
+// New returns a Canary sending events to handler.
+func New(config Config, handler http.Handler, stats statsd.StatSender) (*Canary, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	interval, _ := time.ParseDuration(config.Interval)
+	timeout, _ := time.ParseDuration(config.Timeout)
+	return &Canary{
+		handler:  handler,
+		stats:    stats,
+		interval: interval,
+		timeout:  timeout,
+		path:     config.Path,
+		closed:   make(chan struct{}),
+		pending:  make(map[string]*probe),
+	}, nil
+}
+
+// Wrap returns a logger that passes events on to l and reports canary events
+// it sees to the Canary under name. It must be called before Run.
+func (c *Canary) Wrap(name string, l loggers.SpadeEdgeLogger) loggers.SpadeEdgeLogger {
+	c.mu.Lock()
+	c.sinks = append(c.sinks, name)
+	c.mu.Unlock()
+	return &sink{name: name, logger: l, canary: c}
+}
+
+// Run sends a canary event every interval until Close is called.
+func (c *Canary) Run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+			c.send()
+		}
+	}
+}
+
+// Close stops sending canary events.
+func (c *Canary) Close() {
+	close(c.closed)
+}
+
+// send sends a canary event through the handler and checks on it once the
+// timeout has passed.
+func (c *Canary) send() {
+	id := strconv.FormatUint(atomic.AddUint64(&c.count, 1), 10)
+	c.mu.Lock()
+	c.pending[id] = &probe{
+		sent:    time.Now(),
+		acks:    make(map[string]error, len(c.sinks)),
+		latency: make(map[string]time.Duration, len(c.sinks)),
+	}
+	c.mu.Unlock()
+	_ = c.stats.Inc("canary.sent", 1, 1)
+
+	data := base64.StdEncoding.EncodeToString([]byte(
+		`{"event":"` + EventName + `","properties":{"canary_id":"` + id + `"}}`))
+	req, err := http.NewRequest("POST", c.path+"?ua=1", strings.NewReader(url.Values{"data": {data}}.Encode()))
+	if err != nil {
+		logger.WithError(err).Error("Error building canary request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", UserAgentPrefix+id)
+	req.Header.Set("X-Forwarded-For", "127.0.0.1")
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		logger.WithField("status", rec.Code).Warn("Canary request failed")
+		_ = c.stats.Inc("canary.request.failed", 1, 1)
+	}
+
+	time.AfterFunc(c.timeout, func() { c.check(id) })
+}
+
+// ack records the result of a sink logging a canary event.
+func (c *Canary) ack(sinkName, id string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[id]
+	if !ok {
+		return
+	}
+	p.acks[sinkName] = err
+	p.latency[sinkName] = time.Since(p.sent)
+}
+
+// check reports how each sink handled the canary event id.
+func (c *Canary) check(id string) {
+	c.mu.Lock()
+	p := c.pending[id]
+	delete(c.pending, id)
+	sinks := c.sinks
+	c.mu.Unlock()
+	if p == nil {
+		return
+	}
+
+	for _, name := range sinks {
+		err, acked := p.acks[name]
+		switch {
+		case !acked:
+			logger.WithField("sink", name).Warn("Canary event never reached sink")
+			_ = c.stats.Inc("canary."+name+".missing", 1, 1)
+		case err != nil:
+			logger.WithError(err).WithField("sink", name).Warn("Sink failed to log canary event")
+			_ = c.stats.Inc("canary."+name+".failed", 1, 1)
+		default:
+			_ = c.stats.Inc("canary."+name+".success", 1, 1)
+			_ = c.stats.TimingDuration("canary."+name+".latency", p.latency[name], 1)
+		}
+	}
+}
+
+// sink reports canary events logged to a sink.
+type sink struct {
+	name   string
+	logger loggers.SpadeEdgeLogger
+	canary *Canary
+}
+
+func (s *sink) Log(e *spade.Event) error {
+	err := s.logger.Log(e)
+	s.observe(e, err)
+	return err
+}
+
+func (s *sink) LogSerialized(e *spade.Event, serialized []byte) error {
+	err := loggers.LogSerialized(s.logger, e, serialized)
+	s.observe(e, err)
+	return err
+}
+
+func (s *sink) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	err := loggers.LogDurably(s.logger, e, serialized, done)
+	s.observe(e, err)
+	return err
+}
+
+func (s *sink) observe(e *spade.Event, err error) {
+	if strings.HasPrefix(e.UserAgent, UserAgentPrefix) {
+		s.canary.ack(s.name, e.UserAgent[len(UserAgentPrefix):], err)
+	}
+}
+
+func (s *sink) Close() {
+	s.logger.Close()
+}
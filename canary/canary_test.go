@@ -0,0 +1,74 @@
+package canary
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+type fakeLogger struct {
+	err error
+}
+
+func (f *fakeLogger) Log(e *spade.Event) error { return f.err }
+func (f *fakeLogger) Close()                   {}
+
+func TestCanary(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(rs, "")
+
+	var sinks []loggers.SpadeEdgeLogger
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("data") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		e := spade.NewEvent(time.Now(), net.ParseIP("127.0.0.1"), "", "uuid",
+			r.Form.Get("data"), r.Header.Get("User-Agent"), spade.INTERNAL_EDGE)
+		for _, s := range sinks {
+			_ = s.Log(e)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, err := New(Config{Timeout: "10ms"}, handler, stats)
+	if err != nil {
+		t.Fatalf("Failed to create canary: %s", err)
+	}
+	sinks = []loggers.SpadeEdgeLogger{
+		c.Wrap("event", &fakeLogger{}),
+		c.Wrap("kinesis", &fakeLogger{err: errors.New("channel full")}),
+	}
+	// A sink that drops events on the floor never logs the canary.
+	_ = c.Wrap("fallback", &fakeLogger{})
+
+	c.send()
+	time.Sleep(100 * time.Millisecond)
+
+	counts := map[string]int{}
+	for _, stat := range rs.GetSent() {
+		counts[stat.Stat]++
+	}
+	for _, stat := range []string{"canary.sent", "canary.event.success", "canary.event.latency",
+		"canary.kinesis.failed", "canary.fallback.missing"} {
+		if counts[stat] != 1 {
+			t.Errorf("Expected %s to be sent once, got %v", stat, counts)
+		}
+	}
+	if counts["canary.request.failed"] != 0 {
+		t.Error("Expected the canary request to succeed")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) != 0 {
+		t.Errorf("Expected checked canaries to be forgotten, got %d pending", len(c.pending))
+	}
+}
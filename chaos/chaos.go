@@ -0,0 +1,345 @@
+/*
+Package chaos injects sink failures and latency on demand, to rehearse how the
+edge and the people running it respond to a failing sink.
+
+Faults are set through an admin handler served on the internal debug port:
+
+	POST   /debug/chaos?sink=kinesis&failure_rate=0.5&latency=200ms&duration=5m
+	DELETE /debug/chaos?sink=kinesis
+	GET    /debug/chaos
+
+A fault expires after its duration, which is capped by MaxDuration, so a
+forgotten rehearsal ends on its own.
+
+For soak tests in staging, Soak adds latency to a sink's writes for as long
+as the edge runs, so timeouts and load shedding can be validated against a
+realistically slow sink before it's enabled in production.
+*/
+package chaos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+const defaultMaxDuration = "15m"
+
+// ErrInjected is returned by sinks failing because of an injected fault.
+var ErrInjected = errors.New("chaos: injected sink failure")
+
+// Config configures fault injection.
+type Config struct {
+	// Enabled must be set for faults to be injectable. It guards against a
+	// Chaos section being copied into a config by accident.
+	Enabled bool
+
+	// MaxDuration caps how long a fault lasts, e.g. "15m"
+	MaxDuration string
+
+	// Soak is the latency added to every write of a sink, by sink name. It
+	// doesn't expire.
+	Soak map[string]SoakConfig
+}
+
+// SoakConfig configures the latency soak tests add to a sink's writes.
+type SoakConfig struct {
+	// Latency is added to every write, e.g. "50ms"
+	Latency string
+
+	// Jitter is the most random latency added on top of Latency, e.g. "100ms"
+	Jitter string
+}
+
+// soak is a parsed SoakConfig.
+type soak struct {
+	latency time.Duration
+	jitter  time.Duration
+}
+
+func (c *SoakConfig) parse() (soak, error) {
+	var s soak
+	for _, d := range []struct {
+		value  string
+		parsed *time.Duration
+	}{{c.Latency, &s.latency}, {c.Jitter, &s.jitter}} {
+		if d.value == "" {
+			continue
+		}
+		var err error
+		if *d.parsed, err = time.ParseDuration(d.value); err != nil {
+			return s, fmt.Errorf("error parsing %s as a time.Duration: %v", d.value, err)
+		}
+		if *d.parsed < 0 {
+			return s, fmt.Errorf("duration %s must not be negative", d.value)
+		}
+	}
+	if s.latency == 0 && s.jitter == 0 {
+		return s, errors.New("Latency or Jitter is required")
+	}
+	return s, nil
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return errors.New("Enabled must be true to use fault injection")
+	}
+	if c.MaxDuration == "" {
+		c.MaxDuration = defaultMaxDuration
+	}
+	d, err := time.ParseDuration(c.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.MaxDuration, err)
+	}
+	if d <= 0 {
+		return errors.New("MaxDuration must be greater than 0")
+	}
+	for name, soak := range c.Soak {
+		if _, err := soak.parse(); err != nil {
+			return fmt.Errorf("Soak: %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Fault is a failure injected into a sink.
+type Fault struct {
+	// FailureRate is the fraction of events the sink fails
+	FailureRate float64 `json:"failure_rate"`
+
+	// Latency is added to every event the sink logs
+	Latency time.Duration `json:"latency"`
+
+	// Until is when the fault expires
+	Until time.Time `json:"until"`
+}
+
+// Injector wraps sinks and injects the faults set through its admin handler.
+type Injector struct {
+	stats       statsd.StatSender
+	maxDuration time.Duration
+	soaks       map[string]soak
+	now         func() time.Time
+	sleep       func(time.Duration)
+
+	mu     sync.Mutex
+	sinks  map[string]bool
+	faults map[string]Fault
+}
+
+// New returns an Injector with no faults set.
+func New(config Config, stats statsd.StatSender) (*Injector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	maxDuration, _ := time.ParseDuration(config.MaxDuration)
+	i := &Injector{
+		stats:       stats,
+		maxDuration: maxDuration,
+		soaks:       make(map[string]soak, len(config.Soak)),
+		now:         time.Now,
+		sleep:       time.Sleep,
+		sinks:       make(map[string]bool),
+		faults:      make(map[string]Fault),
+	}
+	for name, c := range config.Soak {
+		i.soaks[name], _ = c.parse()
+	}
+	return i, nil
+}
+
+// CheckSoak returns an error if a sink given Soak latency hasn't been
+// wrapped, since its latency would never be added.
+func (i *Injector) CheckSoak() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for name := range i.soaks {
+		if !i.sinks[name] {
+			return fmt.Errorf("Soak: unknown sink %q", name)
+		}
+	}
+	return nil
+}
+
+// Wrap returns a logger that passes events on to l unless a fault set for
+// name says otherwise.
+func (i *Injector) Wrap(name string, l loggers.SpadeEdgeLogger) loggers.SpadeEdgeLogger {
+	i.mu.Lock()
+	i.sinks[name] = true
+	i.mu.Unlock()
+	return &sink{name: name, logger: l, injector: i}
+}
+
+// Set injects f into the sink name.
+func (i *Injector) Set(name string, f Fault) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !i.sinks[name] {
+		return fmt.Errorf("unknown sink %q", name)
+	}
+	i.faults[name] = f
+	logger.WithField("sink", name).WithField("fault", f).Warn("Injecting sink fault")
+	return nil
+}
+
+// Clear removes the fault injected into the sink name.
+func (i *Injector) Clear(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.faults[name]; ok {
+		delete(i.faults, name)
+		logger.WithField("sink", name).Warn("Cleared sink fault")
+	}
+}
+
+// Faults returns the faults in effect by sink.
+func (i *Injector) Faults() map[string]Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	now := i.now()
+	faults := make(map[string]Fault, len(i.faults))
+	for name, f := range i.faults {
+		if now.Before(f.Until) {
+			faults[name] = f
+		}
+	}
+	return faults
+}
+
+// fault returns the fault in effect for the sink name, if any.
+func (i *Injector) fault(name string) (Fault, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	f, ok := i.faults[name]
+	if !ok {
+		return Fault{}, false
+	}
+	if !i.now().Before(f.Until) {
+		delete(i.faults, name)
+		logger.WithField("sink", name).Info("Sink fault expired")
+		return Fault{}, false
+	}
+	return f, true
+}
+
+// ServeHTTP sets, clears and lists faults.
+func (i *Injector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		f, err := i.parseFault(r)
+		if err == nil {
+			err = i.Set(r.FormValue("sink"), f)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "DELETE":
+		i.Clear(r.FormValue("sink"))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(i.Faults())
+}
+
+func (i *Injector) parseFault(r *http.Request) (Fault, error) {
+	var f Fault
+	if v := r.FormValue("failure_rate"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return f, fmt.Errorf("failure_rate must be between 0 and 1, got %q", v)
+		}
+		f.FailureRate = rate
+	}
+	if v := r.FormValue("latency"); v != "" {
+		latency, err := time.ParseDuration(v)
+		if err != nil || latency < 0 {
+			return f, fmt.Errorf("invalid latency %q", v)
+		}
+		f.Latency = latency
+	}
+	duration := i.maxDuration
+	if v := r.FormValue("duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return f, fmt.Errorf("invalid duration %q", v)
+		}
+		if d < duration {
+			duration = d
+		}
+	}
+	f.Until = i.now().Add(duration)
+	return f, nil
+}
+
+// sink injects faults into a logger.
+type sink struct {
+	name     string
+	logger   loggers.SpadeEdgeLogger
+	injector *Injector
+}
+
+func (s *sink) Log(e *spade.Event) error {
+	if err := s.inject(); err != nil {
+		return err
+	}
+	return s.logger.Log(e)
+}
+
+func (s *sink) LogSerialized(e *spade.Event, serialized []byte) error {
+	if err := s.inject(); err != nil {
+		return err
+	}
+	return loggers.LogSerialized(s.logger, e, serialized)
+}
+
+func (s *sink) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	if err := s.inject(); err != nil {
+		return err
+	}
+	return loggers.LogDurably(s.logger, e, serialized, done)
+}
+
+// inject applies the soak latency and the fault in effect, returning
+// ErrInjected if the event should fail.
+func (s *sink) inject() error {
+	if soak, ok := s.injector.soaks[s.name]; ok {
+		latency := soak.latency
+		if soak.jitter > 0 {
+			latency += time.Duration(rand.Int63n(int64(soak.jitter)))
+		}
+		_ = s.injector.stats.Inc("chaos."+s.name+".soak", 1, 0.1)
+		s.injector.sleep(latency)
+	}
+	f, ok := s.injector.fault(s.name)
+	if !ok {
+		return nil
+	}
+	if f.Latency > 0 {
+		_ = s.injector.stats.Inc("chaos."+s.name+".latency", 1, 0.1)
+		s.injector.sleep(f.Latency)
+	}
+	if f.FailureRate > 0 && rand.Float64() < f.FailureRate {
+		_ = s.injector.stats.Inc("chaos."+s.name+".failure", 1, 0.1)
+		return ErrInjected
+	}
+	return nil
+}
+
+func (s *sink) Close() {
+	s.logger.Close()
+}
@@ -0,0 +1,111 @@
+package chaos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/testkit"
+)
+
+func TestValidateRequiresEnabled(t *testing.T) {
+	if err := (&Config{}).Validate(); err == nil {
+		t.Fatal("expected a config without Enabled to be invalid")
+	}
+}
+
+func TestInjector(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	i, err := New(Config{Enabled: true, MaxDuration: "1m"}, stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	i.now = func() time.Time { return now }
+
+	mem := &testkit.MemoryLogger{}
+	l := i.Wrap("kinesis", mem)
+
+	serve := func(method, query string) int {
+		rec := httptest.NewRecorder()
+		i.ServeHTTP(rec, httptest.NewRequest(method, "/debug/chaos?"+query, nil))
+		return rec.Code
+	}
+
+	if code := serve("POST", "sink=s3&failure_rate=1"); code != http.StatusBadRequest {
+		t.Errorf("expected unknown sink to be rejected, got %d", code)
+	}
+	if code := serve("POST", "sink=kinesis&failure_rate=2"); code != http.StatusBadRequest {
+		t.Errorf("expected invalid failure rate to be rejected, got %d", code)
+	}
+	if code := serve("POST", "sink=kinesis&failure_rate=1&duration=1h"); code != http.StatusOK {
+		t.Fatalf("expected fault to be set, got %d", code)
+	}
+	if err := l.Log(&spade.Event{}); err != ErrInjected {
+		t.Errorf("expected injected failure, got %v", err)
+	}
+
+	// The duration is capped by MaxDuration.
+	now = now.Add(time.Minute)
+	if err := l.Log(&spade.Event{}); err != nil {
+		t.Errorf("expected fault to have expired, got %v", err)
+	}
+
+	serve("POST", "sink=kinesis&failure_rate=1")
+	serve("DELETE", "sink=kinesis")
+	if err := l.Log(&spade.Event{}); err != nil {
+		t.Errorf("expected fault to be cleared, got %v", err)
+	}
+	if n := len(mem.Events()); n != 2 {
+		t.Errorf("expected 2 events to reach the sink, got %d", n)
+	}
+}
+
+func TestSoak(t *testing.T) {
+	if err := (&Config{Enabled: true, Soak: map[string]SoakConfig{"kinesis": {}}}).Validate(); err == nil {
+		t.Error("expected a soak without latency to be invalid")
+	}
+	if err := (&Config{Enabled: true, Soak: map[string]SoakConfig{"kinesis": {Jitter: "-1s"}}}).Validate(); err == nil {
+		t.Error("expected negative jitter to be invalid")
+	}
+
+	stats, _ := statsd.NewNoop()
+	i, err := New(Config{Enabled: true, Soak: map[string]SoakConfig{
+		"kinesis": {Latency: "50ms", Jitter: "100ms"},
+	}}, stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slept []time.Duration
+	i.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	if err = i.CheckSoak(); err == nil {
+		t.Error("expected a soak of an unwrapped sink to be rejected")
+	}
+	mem := &testkit.MemoryLogger{}
+	soaked := i.Wrap("kinesis", mem)
+	other := i.Wrap("event", mem)
+	if err = i.CheckSoak(); err != nil {
+		t.Errorf("expected soak to be valid, got %v", err)
+	}
+
+	for n := 0; n < 10; n++ {
+		if err = soaked.Log(&spade.Event{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = other.Log(&spade.Event{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(slept) != 10 {
+		t.Fatalf("expected only the soaked sink to sleep, slept %d times", len(slept))
+	}
+	for _, d := range slept {
+		if d < 50*time.Millisecond || d >= 150*time.Millisecond {
+			t.Errorf("expected latency between 50ms and 150ms, got %v", d)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Package clienthello fingerprints TLS clients from the ClientHello that opens
+their handshake. The JA3 and JA4 fingerprints depend on how a client's TLS
+stack is built rather than on anything it sends over HTTP, so they tell real
+app builds apart from scripts imitating their headers.
+
+The ClientHello is captured by wrapping the listener TLS is terminated on, and
+exposed to handlers through the request context:
+
+	server.ConnContext = clienthello.ConnContext
+	err := server.Serve(tls.NewListener(clienthello.Listener(l), config))
+
+	// in a handler
+	if hello := clienthello.FromContext(r.Context()); hello != nil {
+		ja3 := hello.JA3()
+	}
+*/
+package clienthello
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	handshakeTypeClientHello = 1
+
+	extensionServerName          = 0x0000
+	extensionSupportedGroups     = 0x000a
+	extensionECPointFormats      = 0x000b
+	extensionSignatureAlgorithms = 0x000d
+	extensionALPN                = 0x0010
+	extensionSupportedVersions   = 0x002b
+)
+
+var errMalformed = errors.New("malformed ClientHello")
+
+// Hello is a parsed ClientHello. GREASE values are kept as sent.
+type Hello struct {
+	Version             uint16
+	CipherSuites        []uint16
+	Extensions          []uint16
+	SupportedGroups     []uint16
+	PointFormats        []uint8
+	SignatureAlgorithms []uint16
+	SupportedVersions   []uint16
+	ServerName          string
+	ALPN                []string
+}
+
+// reader reads the big-endian fields of a handshake message.
+type reader struct {
+	b   []byte
+	err error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.b) {
+		r.err = errMalformed
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return uint16(b[0])<<8 | uint16(b[1])
+	}
+	return 0
+}
+
+// vector reads a vector prefixed by a length of lenBytes bytes.
+func (r *reader) vector(lenBytes int) *reader {
+	var n int
+	for _, b := range r.bytes(lenBytes) {
+		n = n<<8 | int(b)
+	}
+	return &reader{b: r.bytes(n), err: r.err}
+}
+
+func (r *reader) uint16s() []uint16 {
+	values := make([]uint16, 0, len(r.b)/2)
+	for len(r.b) > 0 && r.err == nil {
+		values = append(values, r.uint16())
+	}
+	return values
+}
+
+// Parse parses a ClientHello handshake message, without its record header.
+func Parse(msg []byte) (*Hello, error) {
+	r := &reader{b: msg}
+	if r.uint8() != handshakeTypeClientHello {
+		return nil, errors.New("not a ClientHello")
+	}
+	body := r.vector(3)
+	h := &Hello{Version: body.uint16()}
+	body.bytes(32) // random
+	body.vector(1) // session ID
+	h.CipherSuites = body.vector(2).uint16s()
+	body.vector(1) // compression methods
+	if body.err != nil {
+		return nil, body.err
+	}
+	if len(body.b) == 0 {
+		// Extensions are optional before TLS 1.3.
+		return h, nil
+	}
+
+	extensions := body.vector(2)
+	for len(extensions.b) > 0 && extensions.err == nil {
+		typ := extensions.uint16()
+		data := extensions.vector(2)
+		h.Extensions = append(h.Extensions, typ)
+		switch typ {
+		case extensionServerName:
+			names := data.vector(2)
+			for len(names.b) > 0 && names.err == nil {
+				nameType := names.uint8()
+				name := names.vector(2)
+				if nameType == 0 {
+					h.ServerName = string(name.b)
+				}
+			}
+			data.err = names.err
+		case extensionSupportedGroups:
+			h.SupportedGroups = data.vector(2).uint16s()
+		case extensionECPointFormats:
+			h.PointFormats = append([]uint8(nil), data.vector(1).b...)
+		case extensionSignatureAlgorithms:
+			h.SignatureAlgorithms = data.vector(2).uint16s()
+		case extensionALPN:
+			protocols := data.vector(2)
+			for len(protocols.b) > 0 && protocols.err == nil {
+				h.ALPN = append(h.ALPN, string(protocols.vector(1).b))
+			}
+			data.err = protocols.err
+		case extensionSupportedVersions:
+			h.SupportedVersions = data.vector(1).uint16s()
+		}
+		if data.err != nil {
+			return nil, data.err
+		}
+	}
+	if extensions.err != nil {
+		return nil, extensions.err
+	}
+	return h, nil
+}
+
+// isGREASE reports whether v is one of the reserved values clients send to
+// keep servers tolerant of unknown ones, which fingerprints ignore.
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func withoutGREASE(values []uint16) []uint16 {
+	kept := make([]uint16, 0, len(values))
+	for _, v := range values {
+		if !isGREASE(v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func join(values []uint16, format func(uint16) string) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = format(v)
+	}
+	return strings.Join(s, ",")
+}
+
+func decimal(v uint16) string { return strconv.Itoa(int(v)) }
+
+func hex4(v uint16) string { return fmt.Sprintf("%04x", v) }
+
+// JA3String returns the string the JA3 fingerprint is the hash of.
+func (h *Hello) JA3String() string {
+	formats := make([]uint16, len(h.PointFormats))
+	for i, f := range h.PointFormats {
+		formats[i] = uint16(f)
+	}
+	return strings.Join([]string{
+		decimal(h.Version),
+		strings.Replace(join(withoutGREASE(h.CipherSuites), decimal), ",", "-", -1),
+		strings.Replace(join(withoutGREASE(h.Extensions), decimal), ",", "-", -1),
+		strings.Replace(join(withoutGREASE(h.SupportedGroups), decimal), ",", "-", -1),
+		strings.Replace(join(formats, decimal), ",", "-", -1),
+	}, ",")
+}
+
+// JA3 returns the JA3 fingerprint of the ClientHello: the hex encoded MD5 of
+// its JA3String.
+func (h *Hello) JA3() string {
+	sum := md5.Sum([]byte(h.JA3String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// JA4 returns the JA4 fingerprint of the ClientHello, e.g.
+// t13d1516h2_8daaf6152771_e5627efa2ab1.
+func (h *Hello) JA4() string {
+	version := h.Version
+	if supported := withoutGREASE(h.SupportedVersions); len(supported) > 0 {
+		version = 0
+		for _, v := range supported {
+			if v > version {
+				version = v
+			}
+		}
+	}
+	versions := map[uint16]string{0x0304: "13", 0x0303: "12", 0x0302: "11", 0x0301: "10", 0x0300: "s3"}
+	v, ok := versions[version]
+	if !ok {
+		v = "00"
+	}
+
+	sni := "i"
+	for _, e := range h.Extensions {
+		if e == extensionServerName {
+			sni = "d"
+		}
+	}
+
+	ciphers := withoutGREASE(h.CipherSuites)
+	extensions := withoutGREASE(h.Extensions)
+	a := fmt.Sprintf("t%s%s%02d%02d%s", v, sni, min99(len(ciphers)), min99(len(extensions)), alpnCode(h.ALPN))
+
+	sort.Slice(ciphers, func(i, j int) bool { return ciphers[i] < ciphers[j] })
+	b := truncatedHash(join(ciphers, hex4), len(ciphers) == 0)
+
+	hashed := make([]uint16, 0, len(extensions))
+	for _, e := range extensions {
+		if e != extensionServerName && e != extensionALPN {
+			hashed = append(hashed, e)
+		}
+	}
+	sort.Slice(hashed, func(i, j int) bool { return hashed[i] < hashed[j] })
+	c := join(hashed, hex4)
+	if len(h.SignatureAlgorithms) > 0 {
+		c += "_" + join(withoutGREASE(h.SignatureAlgorithms), hex4)
+	}
+	return a + "_" + b + "_" + truncatedHash(c, len(hashed) == 0)
+}
+
+func min99(n int) int {
+	if n > 99 {
+		return 99
+	}
+	return n
+}
+
+// alpnCode returns the first and last characters of the first ALPN protocol,
+// or of its hex encoding if either isn't alphanumeric.
+func alpnCode(alpn []string) string {
+	if len(alpn) == 0 || alpn[0] == "" {
+		return "00"
+	}
+	p := alpn[0]
+	if !isAlphanumeric(p[0]) || !isAlphanumeric(p[len(p)-1]) {
+		p = hex.EncodeToString([]byte(p))
+	}
+	return string([]byte{p[0], p[len(p)-1]})
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func truncatedHash(s string, empty bool) string {
+	if empty {
+		return "000000000000"
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,149 @@
+package clienthello
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// vector prefixes b with its length in n bytes.
+func vector(n int, b ...byte) []byte {
+	length := make([]byte, n)
+	for i, l := 0, len(b); i < n; i++ {
+		length[n-1-i] = byte(l >> uint(8*i))
+	}
+	return append(length, b...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func extension(typ uint16, data []byte) []byte {
+	return concat([]byte{byte(typ >> 8), byte(typ)}, vector(2, data...))
+}
+
+// testHello is a ClientHello with GREASE values, SNI and ALPN.
+func testHello() []byte {
+	body := concat(
+		[]byte{0x03, 0x03}, // version
+		make([]byte, 32),   // random
+		vector(1),          // session ID
+		vector(2, 0x0a, 0x0a, 0x13, 0x01, 0xc0, 0x2f), // cipher suites
+		vector(1, 0), // compression methods
+		vector(2, concat(
+			extension(0x0000, vector(2, concat([]byte{0}, vector(2, []byte("example.com")...))...)),
+			extension(0x000a, vector(2, 0x1a, 0x1a, 0x00, 0x1d, 0x00, 0x17)),
+			extension(0x000b, vector(1, 0)),
+			extension(0x000d, vector(2, 0x04, 0x03, 0x08, 0x04)),
+			extension(0x0010, vector(2, concat(vector(1, []byte("h2")...), vector(1, []byte("http/1.1")...))...)),
+			extension(0x002b, vector(1, 0x03, 0x04, 0x03, 0x03)),
+		)...),
+	)
+	return concat([]byte{handshakeTypeClientHello}, vector(3, body...))
+}
+
+func TestParse(t *testing.T) {
+	h, err := Parse(testHello())
+	if err != nil {
+		t.Fatalf("Failed to parse ClientHello: %s", err)
+	}
+	expected := &Hello{
+		Version:             0x0303,
+		CipherSuites:        []uint16{0x0a0a, 0x1301, 0xc02f},
+		Extensions:          []uint16{0x0000, 0x000a, 0x000b, 0x000d, 0x0010, 0x002b},
+		SupportedGroups:     []uint16{0x1a1a, 0x001d, 0x0017},
+		PointFormats:        []uint8{0},
+		SignatureAlgorithms: []uint16{0x0403, 0x0804},
+		SupportedVersions:   []uint16{0x0304, 0x0303},
+		ServerName:          "example.com",
+		ALPN:                []string{"h2", "http/1.1"},
+	}
+	if !reflect.DeepEqual(h, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, h)
+	}
+
+	if s := h.JA3String(); s != "771,4865-49199,0-10-11-13-16-43,29-23,0" {
+		t.Errorf("Unexpected JA3 string %s", s)
+	}
+	if ja3 := h.JA3(); ja3 != "97737df38853b88c4324af06e211c4a1" {
+		t.Errorf("Unexpected JA3 %s", ja3)
+	}
+	if ja4 := h.JA4(); ja4 != "t13d0206h2_c1929292aa6b_fb71836bce29" {
+		t.Errorf("Unexpected JA4 %s", ja4)
+	}
+
+	msg := testHello()
+	for _, truncated := range [][]byte{msg[:3], msg[:40], msg[:len(msg)-1]} {
+		if _, err := Parse(truncated); err == nil {
+			t.Errorf("Expected a %d byte ClientHello to be malformed", len(truncated))
+		}
+	}
+}
+
+func testCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %s", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	defer func() { _ = l.Close() }()
+	hellos := make(chan *Hello, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hellos <- FromContext(r.Context())
+		}),
+		ConnContext: ConnContext,
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{testCertificate(t)}}
+	go func() { _ = server.Serve(tls.NewListener(Listener(l), config)) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true, ServerName: "spade.example.com"},
+	}}
+	resp, err := client.Get("https://" + l.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("Failed to make request: %s", err)
+	}
+	_, _ = ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	hello := <-hellos
+	if hello == nil {
+		t.Fatal("Expected the ClientHello in the request context")
+	}
+	if hello.ServerName != "spade.example.com" || len(hello.JA3()) != 32 {
+		t.Errorf("Unexpected ClientHello %+v", hello)
+	}
+}
@@ -0,0 +1,136 @@
+package clienthello
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+const (
+	recordHeaderLength      = 5
+	recordTypeHandshake     = 0x16
+	maxRecordPayloadLength  = 1 << 14
+	maxCapturedRecordLength = recordHeaderLength + maxRecordPayloadLength
+)
+
+// Listener returns a net.Listener that accepts from inner, capturing the
+// ClientHello each connection opens with. TLS must be terminated on top of
+// it, e.g. with tls.NewListener.
+func Listener(inner net.Listener) net.Listener {
+	return &listener{Listener: inner}
+}
+
+type listener struct {
+	net.Listener
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{Conn: c}, nil
+}
+
+// Conn is a connection accepted by a Listener. It captures the first TLS
+// record read from it, which opens the handshake with the ClientHello.
+type Conn struct {
+	net.Conn
+
+	mu       sync.Mutex
+	captured []byte
+	done     bool
+	parsed   bool
+	hello    *Hello
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.capture(b[:n])
+	}
+	return n, err
+}
+
+// capture appends b to the captured record until it's complete.
+func (c *Conn) capture(b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return
+	}
+	c.captured = append(c.captured, b...)
+	if len(c.captured) < recordHeaderLength {
+		return
+	}
+	length := recordHeaderLength + (int(c.captured[3])<<8 | int(c.captured[4]))
+	switch {
+	case c.captured[0] != recordTypeHandshake || length > maxCapturedRecordLength:
+		c.captured, c.done = nil, true
+	case len(c.captured) >= length:
+		c.captured, c.done = c.captured[:length], true
+	}
+}
+
+// NetConn returns the connection the Conn wraps.
+func (c *Conn) NetConn() net.Conn {
+	return c.Conn
+}
+
+// Hello returns the connection's ClientHello, or nil if it hasn't been read
+// or couldn't be parsed.
+func (c *Conn) Hello() *Hello {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done {
+		return nil
+	}
+	if !c.parsed {
+		c.parsed = true
+		if len(c.captured) > recordHeaderLength {
+			// A ClientHello larger than a record fails to parse, and is
+			// left unfingerprinted.
+			c.hello, _ = Parse(c.captured[recordHeaderLength:])
+		}
+		c.captured = nil
+	}
+	return c.hello
+}
+
+type contextKey struct{}
+
+// netConner is implemented by connections wrapping another, like *tls.Conn.
+type netConner interface {
+	NetConn() net.Conn
+}
+
+// ConnContext adds the connection's ClientHello to the context of its
+// requests. It must be installed as the server's ConnContext.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	for {
+		switch conn := c.(type) {
+		case *Conn:
+			return context.WithValue(ctx, contextKey{}, conn)
+		case netConner:
+			c = conn.NetConn()
+		default:
+			return ctx
+		}
+	}
+}
+
+// NewContext returns a copy of ctx carrying hello, as ConnContext does for
+// the ClientHello of a connection.
+func NewContext(ctx context.Context, hello *Hello) context.Context {
+	return context.WithValue(ctx, contextKey{}, &Conn{done: true, parsed: true, hello: hello})
+}
+
+// FromContext returns the ClientHello of the connection a request's context
+// belongs to, or nil if there is none.
+func FromContext(ctx context.Context) *Hello {
+	conn, ok := ctx.Value(contextKey{}).(*Conn)
+	if !ok {
+		return nil
+	}
+	return conn.Hello()
+}
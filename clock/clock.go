@@ -0,0 +1,195 @@
+/*
+Package clock abstracts the time the edge stamps events with and the timers
+that flush and rotate its sinks, so integration tests can run deterministically
+and recorded traffic can be replayed in simulated time.
+
+Real is the system clock. A Fake only moves when it's told to, firing the
+timers and tickers that come due as it does.
+*/
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and makes timers that fire by it.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a time.Timer made by a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// Ticker is a time.Ticker made by a Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the system clock.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer { return realTimer{time.AfterFunc(d, f)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// Fake is a Clock that only moves when Set or Advance is called.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers map[*fakeTimer]bool
+}
+
+// NewFake returns a Fake set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, timers: make(map[*fakeTimer]bool)}
+}
+
+// Now returns the time the Fake is set to.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the Fake forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set sets the Fake to now, firing the timers and tickers due by then in the
+// order they come due. Functions given to AfterFunc are called before Set
+// returns. Setting the Fake back fires nothing.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	back := now.Before(f.now)
+	f.now = now
+	if back {
+		f.mu.Unlock()
+		return
+	}
+	var due []*fakeTimer
+	for t := range f.timers {
+		if !t.when.After(now) {
+			due = append(due, t)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].when.Before(due[j].when) })
+	var funcs []func()
+	for _, t := range due {
+		if t.fn != nil {
+			funcs = append(funcs, t.fn)
+		} else {
+			select {
+			case t.c <- t.when:
+			default:
+			}
+		}
+		if t.period > 0 {
+			// A slow reader misses ticks, as with a time.Ticker.
+			for !t.when.After(now) {
+				t.when = t.when.Add(t.period)
+			}
+		} else {
+			delete(f.timers, t)
+		}
+	}
+	f.mu.Unlock()
+	for _, fn := range funcs {
+		fn()
+	}
+}
+
+// NewTimer returns a Timer that fires once the Fake reaches d from now.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return f.add(d, 0, nil)
+}
+
+// NewTicker returns a Ticker that fires every d the Fake moves.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return fakeTicker{f.add(d, d, nil)}
+}
+
+// AfterFunc calls fn once the Fake reaches d from now.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	return f.add(d, 0, fn)
+}
+
+func (f *Fake) add(d, period time.Duration, fn func()) *fakeTimer {
+	f.mu.Lock()
+	t := &fakeTimer{
+		fake:   f,
+		c:      make(chan time.Time, 1),
+		fn:     fn,
+		when:   f.now.Add(d),
+		period: period,
+	}
+	f.timers[t] = true
+	f.mu.Unlock()
+	if d <= 0 {
+		f.Set(f.Now())
+	}
+	return t
+}
+
+// fakeTimer is a Timer or Ticker of a Fake.
+type fakeTimer struct {
+	fake   *Fake
+	c      chan time.Time
+	fn     func()
+	when   time.Time
+	period time.Duration
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.fake.mu.Lock()
+	active := t.fake.timers[t]
+	t.when = t.fake.now.Add(d)
+	t.fake.timers[t] = true
+	t.fake.mu.Unlock()
+	if d <= 0 {
+		t.fake.Set(t.fake.Now())
+	}
+	return active
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	active := t.fake.timers[t]
+	delete(t.fake.timers, t)
+	return active
+}
+
+// fakeTicker is a Ticker of a Fake.
+type fakeTicker struct{ *fakeTimer }
+
+func (t fakeTicker) Stop() { t.fakeTimer.Stop() }
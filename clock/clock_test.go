@@ -0,0 +1,59 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	f := NewFake(start)
+	timer := f.NewTimer(time.Minute)
+	ticker := f.NewTicker(20 * time.Second)
+	var calls []string
+	f.AfterFunc(30*time.Second, func() { calls = append(calls, "after") })
+
+	f.Advance(10 * time.Second)
+	select {
+	case <-timer.C():
+		t.Error("Expected the timer not to fire early")
+	case <-ticker.C():
+		t.Error("Expected the ticker not to fire early")
+	default:
+	}
+
+	f.Advance(50 * time.Second)
+	if got := <-timer.C(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the timer to fire at its deadline, got %s", got)
+	}
+	// Ticks a reader misses are dropped, as with a time.Ticker.
+	if got := <-ticker.C(); !got.Equal(start.Add(20 * time.Second)) {
+		t.Errorf("Expected the first tick, got %s", got)
+	}
+	select {
+	case got := <-ticker.C():
+		t.Errorf("Expected missed ticks to be dropped, got %s", got)
+	default:
+	}
+	if len(calls) != 1 || !f.Now().Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the function to be called once, got %v", calls)
+	}
+
+	// A stopped timer doesn't fire, and a reset one fires again.
+	if timer.Stop() {
+		t.Error("Expected a fired timer to be inactive")
+	}
+	timer.Reset(time.Second)
+	ticker.Stop()
+	f.Advance(time.Minute)
+	select {
+	case <-timer.C():
+	default:
+		t.Error("Expected the reset timer to fire")
+	}
+	select {
+	case <-ticker.C():
+		t.Error("Expected the stopped ticker not to fire")
+	default:
+	}
+}
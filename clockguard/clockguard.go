@@ -0,0 +1,264 @@
+/*
+Package clockguard watches the local clock against an NTP server, since a host
+with a skewed clock silently stamps bad times into every event it receives.
+
+Every CheckInterval the server is queried with SNTP and the local clock's
+offset from it sent as the clock.skew_ms gauge. An offset over Threshold
+either way is counted under clock.skew_exceeded and logged, and with
+FailReadiness the edge's healthcheck fails until the clock recovers, so a load
+balancer stops sending it traffic. An unreachable server is counted under
+clock.query_failed but leaves readiness as it was.
+
+The default server is the Amazon Time Sync Service, reachable from every EC2
+instance.
+*/
+package clockguard
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultServer        = "169.254.169.123:123"
+	defaultThreshold     = "1s"
+	defaultCheckInterval = "1m"
+	defaultTimeout       = "2s"
+
+	// ntpEpochOffset is the number of seconds between the NTP epoch, 1900,
+	// and the Unix epoch.
+	ntpEpochOffset = 2208988800
+	ntpPacketSize  = 48
+)
+
+// Config configures the clock guard.
+type Config struct {
+	// Server is the host:port of the NTP server. It defaults to the Amazon
+	// Time Sync Service.
+	Server string
+
+	// Threshold is the largest offset from the server tolerated, e.g. "1s"
+	Threshold string
+
+	// CheckInterval is how often the server is queried, e.g. "1m"
+	CheckInterval string
+
+	// Timeout bounds each query, e.g. "2s"
+	Timeout string
+
+	// FailReadiness fails the healthcheck while the offset is over
+	// Threshold.
+	FailReadiness bool
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Server == "" {
+		c.Server = defaultServer
+	}
+	if _, _, err := net.SplitHostPort(c.Server); err != nil {
+		return fmt.Errorf("Server: %v", err)
+	}
+	for _, d := range []struct {
+		name  string
+		value *string
+		def   string
+	}{
+		{"Threshold", &c.Threshold, defaultThreshold},
+		{"CheckInterval", &c.CheckInterval, defaultCheckInterval},
+		{"Timeout", &c.Timeout, defaultTimeout},
+	} {
+		if *d.value == "" {
+			*d.value = d.def
+		}
+		parsed, err := time.ParseDuration(*d.value)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", *d.value, err)
+		}
+		if parsed <= 0 {
+			return fmt.Errorf("%s must be greater than 0", d.name)
+		}
+	}
+	return nil
+}
+
+// Guard periodically measures the local clock's offset from an NTP server.
+type Guard struct {
+	config    Config
+	stats     statsd.StatSender
+	threshold time.Duration
+	interval  time.Duration
+	timeout   time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	skewed  bool
+	offset  time.Duration
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns a Guard for config. The clock is assumed sane until the first
+// check says otherwise.
+func New(config Config, stats statsd.StatSender) (*Guard, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	g := &Guard{
+		config: config,
+		stats:  stats,
+		now:    time.Now,
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	g.threshold, _ = time.ParseDuration(config.Threshold)
+	g.interval, _ = time.ParseDuration(config.CheckInterval)
+	g.timeout, _ = time.ParseDuration(config.Timeout)
+	return g, nil
+}
+
+// Ready reports whether the edge should take traffic: false only with
+// FailReadiness while the offset is over Threshold.
+func (g *Guard) Ready() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.config.FailReadiness || !g.skewed
+}
+
+// Offset returns the offset measured by the last successful check, positive
+// if the local clock is ahead.
+func (g *Guard) Offset() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.offset
+}
+
+// Check queries the server and records the local clock's offset from it.
+func (g *Guard) Check() error {
+	offset, err := g.query()
+	if err != nil {
+		_ = g.stats.Inc("clock.query_failed", 1, 1)
+		return fmt.Errorf("error querying %s: %v", g.config.Server, err)
+	}
+	abs := offset
+	if abs < 0 {
+		abs = -abs
+	}
+	skewed := abs > g.threshold
+	_ = g.stats.Gauge("clock.skew_ms", int64(abs/time.Millisecond), 1)
+
+	g.mu.Lock()
+	wasSkewed := g.skewed
+	g.offset, g.skewed = offset, skewed
+	g.mu.Unlock()
+
+	switch {
+	case skewed:
+		_ = g.stats.Inc("clock.skew_exceeded", 1, 1)
+		logger.WithField("offset", offset.String()).WithField("server", g.config.Server).
+			Error("Clock skew over threshold")
+	case wasSkewed:
+		logger.WithField("offset", offset.String()).Info("Clock skew back under threshold")
+	}
+	return nil
+}
+
+// query sends an SNTP request to the server and returns the local clock's
+// offset from it, positive if the local clock is ahead.
+func (g *Guard) query() (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", g.config.Server, g.timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+	if err = conn.SetDeadline(time.Now().Add(g.timeout)); err != nil {
+		return 0, err
+	}
+
+	var req [ntpPacketSize]byte
+	req[0] = 0x23 // no leap warning, version 4, client mode
+	sent := g.now()
+	putTimestamp(req[40:], sent)
+	if _, err = conn.Write(req[:]); err != nil {
+		return 0, err
+	}
+	var resp [ntpPacketSize]byte
+	n, err := conn.Read(resp[:])
+	if err != nil {
+		return 0, err
+	}
+	received := g.now()
+	if n < ntpPacketSize {
+		return 0, errors.New("short response")
+	}
+	switch {
+	case resp[0]&0x7 != 4:
+		return 0, fmt.Errorf("unexpected mode %d", resp[0]&0x7)
+	case resp[1] == 0:
+		return 0, errors.New("kiss-of-death response")
+	case binary.BigEndian.Uint64(resp[24:32]) != binary.BigEndian.Uint64(req[40:48]):
+		return 0, errors.New("response doesn't match the request")
+	}
+	serverReceived := getTimestamp(resp[32:40])
+	serverSent := getTimestamp(resp[40:48])
+	// The server's clock is assumed to be midway through the round trip.
+	return (sent.Sub(serverReceived) + received.Sub(serverSent)) / 2, nil
+}
+
+// putTimestamp writes t to b as a 64 bit NTP timestamp.
+func putTimestamp(b []byte, t time.Time) {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	binary.BigEndian.PutUint64(b, seconds<<32|fraction)
+}
+
+// getTimestamp reads a 64 bit NTP timestamp from b.
+func getTimestamp(b []byte) time.Time {
+	ts := binary.BigEndian.Uint64(b)
+	seconds := int64(ts>>32) - ntpEpochOffset
+	nanos := int64((ts & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(seconds, nanos)
+}
+
+// Run checks the clock every CheckInterval until Close is called.
+func (g *Guard) Run() {
+	g.mu.Lock()
+	g.running = true
+	g.mu.Unlock()
+	defer close(g.done)
+	check := func() {
+		if err := g.Check(); err != nil {
+			logger.WithError(err).Warn("Failed to check clock skew")
+		}
+	}
+	check()
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-g.quit:
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (g *Guard) Close() {
+	close(g.quit)
+	g.mu.Lock()
+	running := g.running
+	g.mu.Unlock()
+	if running {
+		<-g.done
+	}
+}
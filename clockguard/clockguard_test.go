@@ -0,0 +1,103 @@
+package clockguard
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// fakeServer answers SNTP requests with its clock set skew ahead of the local
+// one, and stratum 0 if kiss is set.
+type fakeServer struct {
+	conn net.PacketConn
+
+	mu   sync.Mutex
+	skew time.Duration
+	kiss bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	s := &fakeServer{conn: conn}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) set(skew time.Duration, kiss bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skew, s.kiss = skew, kiss
+}
+
+func (s *fakeServer) serve() {
+	var req [ntpPacketSize]byte
+	for {
+		_, addr, err := s.conn.ReadFrom(req[:])
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		skew, kiss := s.skew, s.kiss
+		s.mu.Unlock()
+		var resp [ntpPacketSize]byte
+		resp[0] = 0x24 // version 4, server mode
+		if !kiss {
+			resp[1] = 1
+		}
+		copy(resp[24:32], req[40:48])
+		now := time.Now().Add(skew)
+		putTimestamp(resp[32:40], now)
+		putTimestamp(resp[40:48], now)
+		_, _ = s.conn.WriteTo(resp[:], addr)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := newFakeServer(t)
+	defer func() { _ = server.conn.Close() }()
+	stats, _ := statsd.NewNoop()
+	g, err := New(Config{Server: server.conn.LocalAddr().String(), Threshold: "1s", FailReadiness: true}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create guard: %s", err)
+	}
+
+	for _, tt := range []struct {
+		skew   time.Duration
+		kiss   bool
+		failed bool
+		ready  bool
+	}{
+		{100 * time.Millisecond, false, false, true},
+		{-5 * time.Second, false, false, false},
+		// A failed query leaves readiness as it was.
+		{0, true, true, false},
+		{0, false, false, true},
+	} {
+		server.set(tt.skew, tt.kiss)
+		err = g.Check()
+		if (err != nil) != tt.failed {
+			t.Errorf("Expected a check with skew %s to fail: %v, got %v", tt.skew, tt.failed, err)
+		}
+		if g.Ready() != tt.ready {
+			t.Errorf("Expected readiness %v with skew %s", tt.ready, tt.skew)
+		}
+		if offset := g.Offset(); !tt.failed && (offset > -tt.skew+50*time.Millisecond || offset < -tt.skew-50*time.Millisecond) {
+			t.Errorf("Expected an offset of about %s, got %s", -tt.skew, offset)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	var b [8]byte
+	now := time.Unix(1500000000, 123456789)
+	putTimestamp(b[:], now)
+	if got := getTimestamp(b[:]); got.Sub(now) > time.Nanosecond || now.Sub(got) > time.Nanosecond {
+		t.Errorf("Expected %s to round trip, got %s", now, got)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Command spade_edge runs a write-only API server for data ingest into
+the Spade pipeline. It performs light validation, annotation, and manages
+writes to Kinesis and S3. The service is typically behind an Elastic Load
+Balancer, which handles concerns such as HTTPS. Standard requests result in a
+204 No Content, and the persisted event is annotated with source IP, a
+generated UUID, and server time.
+
+The edge itself is assembled by package edge; this command loads its config,
+binds its ports and runs it.
+*/
+package main
+
+import (
+	"flag"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/cactus/go-statsd-client/statsd"
+
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/breaker"
+	"github.com/twitchscience/spade_edge/config"
+	"github.com/twitchscience/spade_edge/edge"
+	"github.com/twitchscience/spade_edge/metrics"
+	"github.com/twitchscience/spade_edge/sandbox"
+)
+
+var (
+	configFilename = flag.String("config", "conf.json", "config file, s3://bucket/key or ssm:/parameter/name")
+	statsdPrefix   = flag.String("stat_prefix", "", "statsd prefix")
+	edgeType       = flag.String("edge_type", "", "edge type (internal/external)")
+	validateOnly   = flag.Bool("validate_config", false, "validate the config file and exit")
+	selfCheckOnly  = flag.Bool("selfcheck", false, "check the ports and sinks are usable, print a report and exit")
+)
+
+// initStatsd returns a statter sending to the statsd address of config, or
+// else statsdAddress, buffering stats as config says.
+func initStatsd(config *metrics.StatsdConfig, statsdAddress, prefix string) (statsd.Statter, error) {
+	var c metrics.StatsdConfig
+	if config != nil {
+		c = *config
+	}
+	if c.Address == "" {
+		c.Address = statsdAddress
+	}
+	switch {
+	case len(c.Address) == 0:
+		logger.Warn("No statsd address specified, disabling metric statsd")
+		return statsd.NewNoop()
+	case len(prefix) == 0:
+		logger.Warn("No statsd prefix specified, disabling metric statsd")
+		return statsd.NewNoop()
+	default:
+		sender, err := metrics.NewStatsdSender(c)
+		if err != nil {
+			return nil, err
+		}
+		return statsd.NewClientWithSender(sender, prefix)
+	}
+}
+
+func main() {
+	flag.Parse()
+	session, err := session.NewSession()
+	if err != nil {
+		logger.WithError(err).Fatal("Session not created")
+	}
+	cfg, err := config.Load(*configFilename, session)
+	if err != nil {
+		logger.WithError(err).Fatal("Error loading config")
+	}
+	if *selfCheckOnly {
+		if !runSelfChecks(selfChecks(cfg, session), os.Stdout) {
+			os.Exit(1)
+		}
+		return
+	}
+	err = cfg.Validate()
+	if err == nil {
+		err = cfg.CheckPortBindable()
+	}
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid config")
+	}
+	if *validateOnly {
+		logger.WithField("config", *configFilename).Info("Config is valid")
+		return
+	}
+
+	logger.InitWithRollbar("info", cfg.RollbarToken, cfg.RollbarEnvironment)
+	logger.Info("Starting edge")
+	logger.CaptureDefault()
+	defer logger.LogPanic()
+
+	stats, err := initStatsd(cfg.Statsd, os.Getenv("STATSD_HOSTPORT"), *statsdPrefix)
+	if err != nil {
+		logger.WithError(err).Fatal("Statsd configuration error")
+	}
+
+	e, err := edge.New(edge.Options{
+		Config:         cfg,
+		ConfigLocation: *configFilename,
+		Session:        session,
+		Stats:          stats,
+		EdgeType:       *edgeType,
+		Version:        os.Getenv("EDGE_VERSION"),
+	})
+	if err != nil {
+		logger.WithError(err).Fatal("Error creating edge")
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	logger.Go(func() {
+		<-sigc
+		logger.Info("Sigint/term received -- shutting down")
+		e.Close()
+		logger.Info("Exiting main cleanly.")
+		logger.Wait()
+		os.Exit(0)
+	})
+
+	// Every port is bound before privileges are dropped below.
+	if !cfg.DisableHystrixStream {
+		hystrixStreamHandler := breaker.NewStreamHandler(e.Breakers)
+		hystrixStreamHandler.Start()
+		hystrixListener, hystrixErr := net.Listen("tcp", ":81")
+		if hystrixErr != nil {
+			logger.WithError(hystrixErr).Error("Error listening to port 81 with hystrixStreamHandler")
+		} else {
+			logger.Go(func() {
+				serveErr := http.Serve(hystrixListener, hystrixStreamHandler)
+				logger.WithError(serveErr).Error("Error serving hystrixStreamHandler on port 81")
+			})
+		}
+	}
+
+	if e.Chaos != nil {
+		http.Handle("/debug/chaos", e.Chaos)
+		logger.Warn("Fault injection is enabled on port 7766")
+	}
+	if e.KillSwitch != nil {
+		http.Handle("/debug/killswitch", e.KillSwitch)
+	}
+	if e.Status != nil {
+		http.Handle("/status.json", e.Status)
+	}
+	if e.TopK != nil {
+		http.Handle("/debug/topk", e.TopK)
+	}
+	if e.OriginLearner != nil {
+		http.Handle("/debug/origins", e.OriginLearner)
+	}
+	if e.RunRate != nil {
+		http.Handle("/debug/runrate", e.RunRate)
+	}
+	if e.EventTap != nil {
+		http.Handle("/debug/events", e.EventTap)
+		logger.Warn("The event tap is enabled on port 7766")
+	}
+	http.HandleFunc("/version", e.ServeVersion)
+	pprofListener, err := net.Listen("tcp", ":7766")
+	if err != nil {
+		logger.WithError(err).Error("Error listening to port 7766 for pprof")
+	} else {
+		logger.Go(func() {
+			logger.WithError(http.Serve(pprofListener, http.DefaultServeMux)).
+				Error("Serving pprof failed")
+		})
+	}
+
+	listeners, err := edge.Listen(cfg.Addresses())
+	if err != nil {
+		logger.Errorf("Error creating listener: %v", err)
+		return
+	}
+	challenges, err := e.ListenChallenges()
+	if err != nil {
+		logger.Errorf("Error listening for ACME challenges: %v", err)
+		return
+	}
+
+	if cfg.Sandbox != nil {
+		applied, sandboxErr := sandbox.Apply(*cfg.Sandbox, cfg.WritableDirs())
+		if sandboxErr != nil {
+			logger.WithError(sandboxErr).WithField("applied", applied).Fatal("Error sandboxing edge")
+		}
+		logger.WithField("restrictions", applied).Info("Sandbox applied")
+	}
+
+	err = e.Serve(challenges, listeners...)
+	logger.WithError(err).Error("Error serving")
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	"github.com/twitchscience/spade_edge/config"
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/metrics"
+)
+
+// selfCheckPrefix is the S3 key prefix of the objects written to probe
+// bucket access.
+const selfCheckPrefix = "spade-edge-selfcheck/"
+
+type selfCheck struct {
+	name  string
+	check func() error
+}
+
+// errSkipped marks checks that don't apply to the config.
+var errSkipped = errors.New("skipped")
+
+// selfChecks returns the checks verifying that an edge running with c can
+// bind its ports and reach its sinks.
+func selfChecks(c *config.Config, sess *session.Session) []selfCheck {
+	host, _ := os.Hostname()
+	probeID := host + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	checks := []selfCheck{{"config", c.Validate}}
+	for _, addr := range c.Addresses() {
+		addr := addr
+		checks = append(checks, selfCheck{"port " + addr, func() error { return checkBindable(addr) }})
+	}
+	checks = append(checks, selfCheck{"port :7766 (pprof)", func() error { return checkBindable(":7766") }})
+	if !c.DisableHystrixStream {
+		checks = append(checks, selfCheck{"port :81 (hystrix)", func() error { return checkBindable(":81") }})
+	}
+
+	s3Client := s3.New(sess)
+	for _, s3Logger := range []struct {
+		name   string
+		config *loggers.S3LoggerConfig
+	}{
+		{"event", c.EventsLogger},
+		{"fallback", c.FallbackLogger},
+	} {
+		s3Config := s3Logger.config
+		name := fmt.Sprintf("s3 %s logger", s3Logger.name)
+		if s3Config == nil {
+			checks = append(checks, selfCheck{name, func() error { return errSkipped }})
+			continue
+		}
+		checks = append(checks, selfCheck{name + " (" + s3Config.Bucket + ")", func() error {
+			return checkS3(s3Client, s3Config.Bucket, selfCheckPrefix+probeID)
+		}})
+	}
+
+	kinesisCheck := selfCheck{"kinesis", func() error { return errSkipped }}
+	if c.EventStream != nil {
+		streamName, format := c.EventStream.StreamName, c.EventStream.Format
+		kinesisCheck = selfCheck{"kinesis (" + streamName + ")", func() error {
+			return checkKinesis(kinesis.New(sess), streamName, format, probeID)
+		}}
+	}
+	checks = append(checks, kinesisCheck)
+
+	statsdAddress := os.Getenv("STATSD_HOSTPORT")
+	if c.Statsd != nil && c.Statsd.Address != "" {
+		statsdAddress = c.Statsd.Address
+	}
+	checks = append(checks,
+		selfCheck{"sqs", func() error {
+			_, err := sqs.New(sess).ListQueues(&sqs.ListQueuesInput{})
+			return err
+		}},
+		selfCheck{"statsd", func() error { return checkStatsd(statsdAddress) }},
+	)
+	return checks
+}
+
+func checkBindable(addr string) error {
+	l, err := net.Listen(config.ListenNetwork(addr), addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+// checkS3 puts a probe object in the bucket and deletes it again.
+func checkS3(client *s3.S3, bucket, key string) error {
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader([]byte("spade edge self-check\n")),
+	})
+	if err != nil {
+		return fmt.Errorf("error putting s3://%s/%s: %v", bucket, key, err)
+	}
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting s3://%s/%s: %v", bucket, key, err)
+	}
+	return nil
+}
+
+// checkKinesis puts an empty glob, which consumers skip, on the stream. Avro
+// consumers can't decode one, so avro streams are only described.
+func checkKinesis(client *kinesis.Kinesis, streamName, format, partitionKey string) error {
+	if format == "avro" {
+		_, err := client.DescribeStream(&kinesis.DescribeStreamInput{StreamName: aws.String(streamName)})
+		return err
+	}
+	glob, err := loggers.EmptyGlob()
+	if err != nil {
+		return err
+	}
+	_, err = client.PutRecord(&kinesis.PutRecordInput{
+		StreamName:   aws.String(streamName),
+		PartitionKey: aws.String(partitionKey),
+		Data:         glob,
+	})
+	return err
+}
+
+// checkStatsd connects to statsd and sends it a probe stat. Over UDP this
+// can't confirm the stat arrived; over TCP and unix sockets it confirms statsd
+// is listening.
+func checkStatsd(address string) error {
+	if address == "" {
+		return errSkipped
+	}
+	conn, err := metrics.DialStatsd(address)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	stat := "selfcheck"
+	if *statsdPrefix != "" {
+		stat = *statsdPrefix + "." + stat
+	}
+	_, err = fmt.Fprintf(conn, "%s:1|c\n", stat)
+	return err
+}
+
+// runSelfChecks runs the checks, writing a report to w, and returns whether
+// they all passed or were skipped.
+func runSelfChecks(checks []selfCheck, w io.Writer) bool {
+	passed := true
+	for _, c := range checks {
+		start := time.Now()
+		err := c.check()
+		elapsed := time.Since(start).Round(time.Millisecond)
+		switch err {
+		case nil:
+			fmt.Fprintf(w, "ok    %-40s %v\n", c.name, elapsed)
+		case errSkipped:
+			fmt.Fprintf(w, "skip  %s\n", c.name)
+		default:
+			passed = false
+			fmt.Fprintf(w, "FAIL  %-40s %v: %v\n", c.name, elapsed, err)
+		}
+	}
+	return passed
+}
@@ -0,0 +1,1215 @@
+/*
+Package config defines the configuration of the spade edge and how it is
+loaded, defaulted and validated. It is used by the edge binary and can be
+reused by tests and tooling that need to read or check an edge config.
+
+A config is read from a JSON or YAML file, an S3 object or an SSM parameter.
+String values may reference environment variables as ${ENV_VAR}, and any
+value can be overridden with a SPADE_EDGE_* environment variable (see
+EnvOverridePrefix).
+*/
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v2"
+
+	"github.com/twitchscience/spade_edge/abuse"
+	"github.com/twitchscience/spade_edge/accounting"
+	"github.com/twitchscience/spade_edge/admission"
+	"github.com/twitchscience/spade_edge/advisor"
+	"github.com/twitchscience/spade_edge/aggregator"
+	"github.com/twitchscience/spade_edge/alerts"
+	"github.com/twitchscience/spade_edge/anomaly"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/breaker"
+	"github.com/twitchscience/spade_edge/canary"
+	"github.com/twitchscience/spade_edge/chaos"
+	"github.com/twitchscience/spade_edge/clockguard"
+	"github.com/twitchscience/spade_edge/connlimit"
+	"github.com/twitchscience/spade_edge/emf"
+	"github.com/twitchscience/spade_edge/enrich"
+	"github.com/twitchscience/spade_edge/eventtap"
+	"github.com/twitchscience/spade_edge/features"
+	"github.com/twitchscience/spade_edge/gctune"
+	"github.com/twitchscience/spade_edge/killswitch"
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/metrics"
+	"github.com/twitchscience/spade_edge/proxyproto"
+	"github.com/twitchscience/spade_edge/reputation"
+	"github.com/twitchscience/spade_edge/requests"
+	"github.com/twitchscience/spade_edge/retry"
+	"github.com/twitchscience/spade_edge/rollup"
+	"github.com/twitchscience/spade_edge/runrate"
+	"github.com/twitchscience/spade_edge/sandbox"
+	"github.com/twitchscience/spade_edge/status"
+	"github.com/twitchscience/spade_edge/topk"
+	"github.com/twitchscience/spade_edge/transform"
+	"github.com/twitchscience/spade_edge/wal"
+	"github.com/twitchscience/spade_edge/watchdog"
+)
+
+// EnvOverridePrefix is the prefix of environment variables that override
+// config values. The rest of the variable name is the path to the value, with
+// nested fields separated by a double underscore and matched case-insensitively,
+// e.g. SPADE_EDGE_PORT=:8080 or SPADE_EDGE_EVENTSTREAM__STREAMNAME=spade-edge.
+// Values for string fields are used verbatim; values for any other field
+// (numbers, booleans, lists, objects) are decoded as JSON.
+const EnvOverridePrefix = "SPADE_EDGE_"
+
+// DefaultPort is the address the edge listens on if Port is not set.
+const DefaultPort = ":80"
+
+// envVarPattern matches ${ENV_VAR} references inside config string values.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Config is the configuration of a spade edge.
+type Config struct {
+	// LoggingDir is where the S3 loggers write files before uploading them
+	LoggingDir string
+
+	// Port is the address to listen on for event requests, e.g. ":80"
+	Port string
+
+	// ListenAddresses, if set, are listened on instead of Port, e.g.
+	// ["0.0.0.0:80", "[::]:80"] to serve both IPv4 and IPv6
+	ListenAddresses []string
+
+	// TLS, if set, terminates TLS on the listen addresses, and fingerprints
+	// the TLS clients of requests
+	TLS *TLSConfig
+
+	// CorsOrigins are glob patterns of the origins allowed to make CORS requests
+	CorsOrigins []string
+
+	// CorsGroups, if set, give groups of origins their own allowed and
+	// exposed headers and let them send credentials
+	CorsGroups requests.CORSGroups
+
+	// OriginLearning, if set, counts the origins calling the edge that a CORS
+	// allowlist being considered would reject, without changing responses,
+	// and serves them at /debug/origins on the debug port
+	OriginLearning *requests.OriginLearningConfig
+
+	// RedirectHosts are glob patterns of the hosts /r may redirect clicks to
+	RedirectHosts []string
+
+	// EventsLogger configures the S3 logger that every event is written to
+	EventsLogger *loggers.S3LoggerConfig
+
+	// FallbackLogger configures the S3 logger events go to when Kinesis fails
+	FallbackLogger *loggers.S3LoggerConfig
+
+	// FallbackChain, if set instead of FallbackLogger, is the ordered list of
+	// sinks events go to when Kinesis fails, each falling back to the next
+	FallbackChain []*FallbackStage
+
+	// Alerts, if set, posts to webhooks when sinks are unhealthy, the spool
+	// grows, requests panic or the config fails to reload
+	Alerts *alerts.Config
+
+	// FallbackNotification, if set, sends a notification when the Kinesis
+	// loggers start writing events to their fallback and when they stop
+	FallbackNotification *loggers.FallbackNotificationConfig
+
+	// UploadIntegrity, if set, sets Content-MD5 on the S3 uploads of log files
+	// and uploads a manifest of each file's hashes and line count
+	UploadIntegrity *loggers.IntegrityConfig
+
+	// FileHeaders, if set, starts the log files the S3 loggers upload with a
+	// record of the edge and a snapshot of its config
+	FileHeaders bool
+
+	// EventStream configures the Kinesis logger
+	EventStream *loggers.KinesisLoggerConfig
+
+	// Relay, if set instead of EventStream, forwards events to another
+	// spade_edge, for edges that can't reach AWS
+	Relay *loggers.RelayConfig
+
+	// RollbarToken and RollbarEnvironment configure error reporting to Rollbar
+	RollbarToken       string
+	RollbarEnvironment string
+
+	// EventInURISamplingRate is the sample rate of the event_in_URI stat
+	EventInURISamplingRate float32
+
+	// TrackingPaths, if set, replaces the exact paths and path prefixes events
+	// are accepted on, which default to /, /track, /track/ and /v1/*
+	TrackingPaths *requests.TrackingPaths
+
+	// PathLifecycles retire paths gradually, warning clients once they're
+	// deprecated and answering them with a 410 once they're gone
+	PathLifecycles requests.PathLifecycles
+
+	// ValidateEndpoint, if set, serves an endpoint that decodes requests like
+	// the tracking paths and answers with what it found, without logging them
+	ValidateEndpoint *requests.ValidateConfig
+
+	// MaxRequestBytes, if set, rejects request bodies larger than that with a
+	// 413 as they are read
+	MaxRequestBytes int64
+
+	// MaxURIBytes, if set, rejects requests to the tracking paths with longer
+	// URIs with a 414, to drive clients sending events in the query string to
+	// POST them
+	MaxURIBytes int
+
+	// StrictContentTypes rejects POSTs that aren't form, JSON, plain text or
+	// multipart bodies with a 415 instead of making the best of them
+	StrictContentTypes bool
+
+	// Multipart, if set, overrides the caps on multipart/form-data bodies
+	Multipart *requests.MultipartConfig
+
+	// Fingerprint, if set, sets a keyed hash of the client's network and
+	// headers on events
+	Fingerprint *requests.FingerprintConfig
+
+	// Capture, if set, sets the Accept-Language and Referer headers of
+	// requests on their events
+	Capture *requests.CaptureConfig
+
+	// TimeBuckets, if set, sets the hour, date or epoch milliseconds events
+	// are received at on them, for partitioning downstream
+	TimeBuckets *requests.TimeBucketsConfig
+
+	// Aborts, if set, decides what happens to the events of requests whose
+	// client disconnects before being answered
+	Aborts *requests.AbortConfig
+
+	// MaxDataValues is the most repeated data values of a request that are
+	// logged, each as its own event. Only the first is logged if it's unset.
+	MaxDataValues int
+
+	// CrossDomainPolicy is the content served at /crossdomain.xml
+	CrossDomainPolicy string
+
+	// CrossDomainPolicyLocation and RobotsTxtLocation are files, S3 objects or
+	// SSM parameters (see Fetch) to serve at /crossdomain.xml and /robots.txt
+	// instead of CrossDomainPolicy and the default robots.txt
+	CrossDomainPolicyLocation string
+	RobotsTxtLocation         string
+
+	// Breakers configures a circuit breaker per sink, keyed by logger type
+	// ("event", "fallback" or "kinesis") or FallbackChain stage name, along
+	// with the sink's timeout and concurrency limit. Sinks without an entry
+	// are unguarded.
+	Breakers map[string]*breaker.Config
+
+	// Retries configures how failed writes are retried per sink, keyed like
+	// Breakers. Retries happen inside the breaker, so a write and its retries
+	// count as a single call. Sinks without an entry aren't retried.
+	Retries map[string]*retry.Config
+
+	// DisableHystrixStream turns off the Hystrix dashboard stream on port 81
+	DisableHystrixStream bool
+
+	// ConfigRefreshInterval is how often the config location is polled for
+	// changes to hot-reloadable fields. Polling is disabled if empty.
+	ConfigRefreshInterval string
+
+	// Features configures percentage rollouts of edge features, keyed by
+	// feature name (see the features package)
+	Features map[string]*features.Flag
+
+	// Sandbox configures the privileges dropped once ports are bound
+	Sandbox *sandbox.Config
+
+	// JWTAuth requires producers to send a service JWT on some endpoints
+	JWTAuth *auth.JWTConfig
+
+	// HMACAuth requires producers to sign requests to some endpoints
+	HMACAuth *auth.HMACConfig
+
+	// RelayReceiver, if set, accepts the events peer edges relay to /relay
+	RelayReceiver *requests.RelayReceiverConfig
+
+	// Abuse configures honeypot endpoints and the abuse deny list
+	Abuse *abuse.Config
+
+	// Reputation scores client IPs with a reputation provider and acts on
+	// their score
+	Reputation *reputation.Config
+
+	// ResponseHeaders are extra headers sent on responses, keyed by endpoint
+	// group ("all", "tracking", "static" or "health")
+	ResponseHeaders requests.ResponseHeaders
+
+	// Transforms are rules applied to event properties before logging
+	Transforms []transform.Rule
+
+	// Enrichment, if set, merges the rows of a lookup table into the
+	// properties of events with a matching key, after Transforms
+	Enrichment *enrich.Config
+
+	// EventNames configures the normalization of event names before logging
+	EventNames *transform.NameConfig
+
+	// Naming, if set, checks event names against a naming convention after
+	// EventNames, tagging or rejecting the events that violate it
+	Naming *requests.NamingConfig
+
+	// KillSwitch, if set, drops the events with blocked names, which can also
+	// be blocked through the debug port
+	KillSwitch *killswitch.Config
+
+	// Admission configures load shedding when the edge is overloaded
+	Admission *admission.Config
+
+	// ConnLimits, if set, closes connections sending requests too slowly and
+	// caps the connections per remote IP
+	ConnLimits *connlimit.Config
+
+	// ProxyProtocol, if set, reads the PROXY protocol headers connections
+	// open with on the listen addresses, taking client addresses from them
+	ProxyProtocol *proxyproto.Config
+
+	// AsyncLogging, if set, writes events to the sinks from a worker pool
+	// instead of on the request goroutine
+	AsyncLogging *requests.AsyncConfig
+
+	// SyncAcks lets requests with an X-Spade-Ack: sync header wait for their
+	// events to be written to the EventStream before they're answered
+	SyncAcks bool
+
+	// Sequence, if set, stamps logged events with a per-instance sequence
+	// number, so downstream can detect lost events. It turns on Heartbeat.
+	Sequence *requests.SequenceConfig
+
+	// Heartbeat, if set, logs a heartbeat event with the instance, version and
+	// accepted counts every interval, so downstream can detect dead edges
+	Heartbeat *requests.HeartbeatConfig
+
+	// ClockGuard, if set, watches the clock's offset from an NTP server and
+	// can fail the healthcheck while it's skewed
+	ClockGuard *clockguard.Config
+
+	// GC, if set, tunes the garbage collector and allocates a memory ballast
+	// at startup, and sends collector stats under gc.*
+	GC *gctune.Config
+
+	// Watchdog, if set, captures CPU, heap and goroutine profiles when request
+	// latency or the number of goroutines crosses a threshold
+	Watchdog *watchdog.Config
+
+	// WAL, if set, appends every accepted event to a local write-ahead log
+	// before it's written to the sinks, and replays the events a previous run
+	// didn't write on startup
+	WAL *wal.Config
+
+	// StatsAggregation, if set, batches counters and gauges in memory and
+	// sends them to statsd on an interval
+	StatsAggregation *aggregator.Config
+
+	// Stats overrides stat sample rates and names
+	Stats *metrics.Config
+
+	// Statsd configures the connection to statsd: its address, which
+	// overrides STATSD_HOSTPORT, and how stats are buffered
+	Statsd *metrics.StatsdConfig
+
+	// StatCardinality bounds the distinct values of stats named after client
+	// input, like requests.hosts.<host>. The defaults apply if it is unset.
+	StatCardinality *metrics.CardinalityConfig
+
+	// Canary, if set, regularly sends a synthetic event through the edge and
+	// checks that the sinks accept it
+	Canary *canary.Config
+
+	// VolumeAnomalies, if set, alarms when the volume of accepted events, in
+	// total or of the busiest event names, suddenly changes
+	VolumeAnomalies *anomaly.Config
+
+	// ThroughputAdvisor, if set, recommends shard counts for the Kinesis
+	// streams from their sustained throughput, and optionally reshards them
+	ThroughputAdvisor *advisor.Config
+
+	// Status, if set, serves a JSON report of the edge's health at
+	// /status.json on the debug port
+	Status *status.Config
+
+	// TopK, if set, serves the heaviest event names, origins and client IP
+	// prefixes of the last few minutes at /debug/topk on the debug port
+	TopK *topk.Config
+
+	// RunRate, if set, keeps daily counts of events in the URI, oversized
+	// URIs, bad-client bodies and truncated User-Agents in a local file, and
+	// serves them at /debug/runrate on the debug port
+	RunRate *runrate.Config
+
+	// Accounting, if set, counts the events and payload bytes each tenant
+	// sends of each event name, and writes them to S3 or Kinesis for
+	// chargeback
+	Accounting *accounting.Config
+
+	// Chaos, if set, allows injecting sink failures through the debug port,
+	// and adds its Soak latency to sink writes. It is refused when
+	// RollbarEnvironment is a production environment.
+	Chaos *chaos.Config
+
+	// EventTap, if set, keeps the last accepted events carrying an api_key or
+	// tag property in memory, and serves them to QA at /debug/events on the
+	// debug port. It is refused when RollbarEnvironment is a production
+	// environment, unless its AllowInProduction is set.
+	EventTap *eventtap.Config
+
+	// Rollup, if set, counts the configured events at the edge and logs one
+	// summary event per window instead of each event
+	Rollup *rollup.Config
+
+	// Pixel, if set, detects pixel requests without a cache buster and can
+	// redirect them to a unique URL
+	Pixel *requests.PixelConfig
+
+	// BadClient, if set, warns clients whose bodies start with a stray
+	// "data=" and can reject them after a sunset date
+	BadClient *requests.BadClientConfig
+
+	// Envelope, if set, pins the serialization version of logged events
+	Envelope *loggers.EnvelopeConfig
+
+	// ParquetLogger, if set, also writes every event to Parquet files that are
+	// uploaded to S3 under date and hour partitions
+	ParquetLogger *loggers.ParquetLoggerConfig
+
+	// UserAgentDictionary, if set, replaces the User-Agents of events with
+	// their hashes and writes the hashes' User-Agents to S3 once per rotation
+	UserAgentDictionary *loggers.UserAgentDictionaryConfig
+
+	// CloudWatch, if set, sends stats to CloudWatch as well as, or instead
+	// of, statsd
+	CloudWatch *emf.Config
+
+	// EdgePolicies override settings for the edges of a type, internal or
+	// external. See ForEdgeType.
+	EdgePolicies map[string]*EdgePolicy
+}
+
+// ValidationError lists every problem found when validating a Config.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d config error(s): %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Load fetches the config stored at location (see Fetch) and parses it.
+func Load(location string, sess client.ConfigProvider) (*Config, error) {
+	b, err := Fetch(location, sess)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(location, b)
+}
+
+// Parse decodes b as JSON, or as YAML if filename ends in .yaml/.yml,
+// expands ${ENV_VAR} references in string values, applies SPADE_EDGE_*
+// overrides and fills in defaults. The result still needs to be validated.
+func Parse(filename string, b []byte) (*Config, error) {
+	var err error
+	var tree interface{}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &tree)
+		tree = normalizeYAML(tree)
+	default:
+		err = json.Unmarshal(b, &tree)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", filename, err)
+	}
+
+	b, err = json.Marshal(expandEnvVars(tree))
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	err = json.Unmarshal(b, c)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %v", filename, err)
+	}
+	err = c.applyEnvOverrides(os.Environ())
+	if err != nil {
+		return nil, err
+	}
+	c.applyDefaults()
+	return c, nil
+}
+
+func (c *Config) applyDefaults() {
+	if c.Port == "" {
+		c.Port = DefaultPort
+	}
+	for _, b := range c.Breakers {
+		if b != nil {
+			_ = b.Validate() // fills in defaults, errors are reported by Validate
+		}
+	}
+	for _, r := range c.Retries {
+		if r != nil {
+			_ = r.Validate() // fills in defaults, errors are reported by Validate
+		}
+	}
+}
+
+// Validate checks every field of the config and returns a *ValidationError
+// listing all problems found, or nil if the config is valid.
+func (c *Config) Validate() error {
+	errs := &ValidationError{}
+
+	if _, _, err := net.SplitHostPort(c.Port); err != nil {
+		errs.add("Port: %v", err)
+	}
+
+	seen := make(map[string]bool, len(c.ListenAddresses))
+	for _, addr := range c.ListenAddresses {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs.add("ListenAddresses: %v", err)
+		} else if seen[addr] {
+			errs.add("ListenAddresses: %s is listed twice", addr)
+		}
+		seen[addr] = true
+	}
+
+	if c.TLS != nil {
+		if err := c.TLS.Validate(); err != nil {
+			errs.add("TLS: %v", err)
+		}
+	}
+
+	for _, origin := range c.CorsOrigins {
+		if _, err := glob.Compile(strings.TrimSpace(origin)); err != nil {
+			errs.add("CorsOrigins: invalid pattern %s: %v", origin, err)
+		}
+	}
+
+	if err := c.CorsGroups.Validate(); err != nil {
+		errs.add("CorsGroups: %v", err)
+	}
+
+	if c.OriginLearning != nil {
+		if err := c.OriginLearning.Validate(); err != nil {
+			errs.add("OriginLearning: %v", err)
+		}
+	}
+
+	for _, host := range c.RedirectHosts {
+		if _, err := glob.Compile(strings.TrimSpace(host)); err != nil {
+			errs.add("RedirectHosts: invalid pattern %s: %v", host, err)
+		}
+	}
+
+	if c.EventInURISamplingRate < 0 || c.EventInURISamplingRate > 1 {
+		errs.add("EventInURISamplingRate must be between 0 and 1")
+	}
+
+	if c.MaxDataValues < 0 {
+		errs.add("MaxDataValues must not be negative")
+	}
+
+	if c.MaxRequestBytes < 0 {
+		errs.add("MaxRequestBytes must not be negative")
+	}
+
+	if c.MaxURIBytes < 0 {
+		errs.add("MaxURIBytes must not be negative")
+	}
+
+	if c.TrackingPaths != nil {
+		if err := c.TrackingPaths.Validate(); err != nil {
+			errs.add("TrackingPaths: %v", err)
+		}
+	}
+
+	if err := c.PathLifecycles.Validate(); err != nil {
+		errs.add("PathLifecycles: %v", err)
+	}
+
+	if c.ValidateEndpoint != nil {
+		if err := c.ValidateEndpoint.Validate(); err != nil {
+			errs.add("ValidateEndpoint: %v", err)
+		}
+	}
+
+	if c.Fingerprint != nil {
+		if err := c.Fingerprint.Validate(); err != nil {
+			errs.add("Fingerprint: %v", err)
+		}
+	}
+
+	if c.Capture != nil {
+		if err := c.Capture.Validate(); err != nil {
+			errs.add("Capture: %v", err)
+		}
+	}
+
+	if c.TimeBuckets != nil {
+		if err := c.TimeBuckets.Validate(); err != nil {
+			errs.add("TimeBuckets: %v", err)
+		}
+	}
+
+	if c.Multipart != nil {
+		if err := c.Multipart.Validate(); err != nil {
+			errs.add("Multipart: %v", err)
+		}
+	}
+
+	for _, s3 := range []struct {
+		name   string
+		config *loggers.S3LoggerConfig
+	}{
+		{"EventsLogger", c.EventsLogger},
+		{"FallbackLogger", c.FallbackLogger},
+	} {
+		if s3.config == nil {
+			continue
+		}
+		if err := s3.config.Validate(); err != nil {
+			errs.add("%s: %v", s3.name, err)
+		}
+		if c.LoggingDir == "" {
+			errs.add("LoggingDir is required when %s is set", s3.name)
+		}
+	}
+
+	c.validateFallbackChain(errs)
+
+	if c.Alerts != nil {
+		if err := c.Alerts.Validate(); err != nil {
+			errs.add("Alerts: %v", err)
+		}
+	}
+
+	if c.FallbackNotification != nil {
+		if c.EventStream == nil {
+			errs.add("FallbackNotification requires an EventStream")
+		}
+		if err := c.FallbackNotification.Validate(); err != nil {
+			errs.add("FallbackNotification: %v", err)
+		}
+	}
+
+	if c.UploadIntegrity != nil {
+		if err := c.UploadIntegrity.Validate(); err != nil {
+			errs.add("UploadIntegrity: %v", err)
+		}
+	}
+
+	if c.EventStream != nil {
+		if err := c.EventStream.Validate(); err != nil {
+			errs.add("EventStream: %v", err)
+		}
+	}
+
+	if c.Relay != nil {
+		if c.EventStream != nil {
+			errs.add("Relay and EventStream can't both be set")
+		}
+		if err := c.Relay.Validate(); err != nil {
+			errs.add("Relay: %v", err)
+		}
+	}
+
+	if c.CrossDomainPolicy != "" && c.CrossDomainPolicyLocation != "" {
+		errs.add("CrossDomainPolicy and CrossDomainPolicyLocation can't both be set")
+	}
+
+	stages := c.fallbackStageNames()
+	var breakerNames []string
+	for name := range c.Breakers {
+		breakerNames = append(breakerNames, name)
+	}
+	sort.Strings(breakerNames)
+	for _, name := range breakerNames {
+		b := c.Breakers[name]
+		switch name {
+		case "event", "fallback", "kinesis", "relay":
+		default:
+			if !stages[name] {
+				errs.add("Breakers: unknown sink %s", name)
+				continue
+			}
+		}
+		if b == nil {
+			continue
+		}
+		if err := b.Validate(); err != nil {
+			errs.add("Breakers[%s]: %v", name, err)
+		}
+	}
+
+	var retryNames []string
+	for name := range c.Retries {
+		retryNames = append(retryNames, name)
+	}
+	sort.Strings(retryNames)
+	for _, name := range retryNames {
+		r := c.Retries[name]
+		switch name {
+		case "event", "fallback", "kinesis", "relay":
+		default:
+			if !stages[name] {
+				errs.add("Retries: unknown sink %s", name)
+				continue
+			}
+		}
+		if r == nil {
+			continue
+		}
+		if err := r.Validate(); err != nil {
+			errs.add("Retries[%s]: %v", name, err)
+		}
+	}
+
+	var featureNames []string
+	for name := range c.Features {
+		featureNames = append(featureNames, name)
+	}
+	sort.Strings(featureNames)
+	for _, name := range featureNames {
+		f := c.Features[name]
+		if f == nil {
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			errs.add("Features[%s]: %v", name, err)
+		}
+	}
+
+	if c.Sandbox != nil {
+		if err := c.Sandbox.Validate(); err != nil {
+			errs.add("Sandbox: %v", err)
+		}
+	}
+
+	if c.JWTAuth != nil {
+		if err := c.JWTAuth.Validate(); err != nil {
+			errs.add("JWTAuth: %v", err)
+		}
+	}
+
+	if c.HMACAuth != nil {
+		if err := c.HMACAuth.Validate(); err != nil {
+			errs.add("HMACAuth: %v", err)
+		}
+	}
+
+	if c.RelayReceiver != nil {
+		if err := c.RelayReceiver.Validate(); err != nil {
+			errs.add("RelayReceiver: %v", err)
+		}
+	}
+
+	if c.Abuse != nil {
+		if err := c.Abuse.Validate(); err != nil {
+			errs.add("Abuse: %v", err)
+		}
+	}
+
+	if c.Reputation != nil {
+		if err := c.Reputation.Validate(); err != nil {
+			errs.add("Reputation: %v", err)
+		}
+	}
+
+	if err := c.ResponseHeaders.Validate(); err != nil {
+		errs.add("ResponseHeaders: %v", err)
+	}
+
+	if c.Enrichment != nil {
+		if err := c.Enrichment.Validate(); err != nil {
+			errs.add("Enrichment: %v", err)
+		}
+	}
+
+	if c.Naming != nil {
+		if err := c.Naming.Validate(); err != nil {
+			errs.add("Naming: %v", err)
+		}
+	}
+
+	if c.KillSwitch != nil {
+		if err := c.KillSwitch.Validate(); err != nil {
+			errs.add("KillSwitch: %v", err)
+		}
+	}
+
+	for i := range c.Transforms {
+		if err := c.Transforms[i].Validate(); err != nil {
+			errs.add("Transforms[%d]: %v", i, err)
+		}
+	}
+
+	if c.EventNames != nil {
+		if err := c.EventNames.Validate(); err != nil {
+			errs.add("EventNames: %v", err)
+		}
+	}
+
+	if c.Admission != nil {
+		if err := c.Admission.Validate(); err != nil {
+			errs.add("Admission: %v", err)
+		}
+	}
+
+	if c.ConnLimits != nil {
+		if err := c.ConnLimits.Validate(); err != nil {
+			errs.add("ConnLimits: %v", err)
+		}
+	}
+
+	if c.ProxyProtocol != nil {
+		if err := c.ProxyProtocol.Validate(); err != nil {
+			errs.add("ProxyProtocol: %v", err)
+		}
+		addrs := make(map[string]bool)
+		for _, addr := range c.Addresses() {
+			addrs[addr] = true
+		}
+		for addr := range c.ProxyProtocol.Listeners {
+			if !addrs[addr] {
+				errs.add("ProxyProtocol: %s is not a listen address", addr)
+			}
+		}
+	}
+
+	if c.AsyncLogging != nil {
+		if err := c.AsyncLogging.Validate(); err != nil {
+			errs.add("AsyncLogging: %v", err)
+		}
+	}
+
+	if c.SyncAcks && c.EventStream == nil {
+		errs.add("SyncAcks requires an EventStream")
+	}
+
+	if c.Sequence != nil {
+		if err := c.Sequence.Validate(); err != nil {
+			errs.add("Sequence: %v", err)
+		}
+		// Without heartbeats, the numbers of an idle edge stop and its last
+		// records' loss can't be told apart.
+		if c.Heartbeat == nil {
+			c.Heartbeat = &requests.HeartbeatConfig{}
+		}
+	}
+
+	if c.Heartbeat != nil {
+		if err := c.Heartbeat.Validate(); err != nil {
+			errs.add("Heartbeat: %v", err)
+		}
+	}
+
+	if c.ClockGuard != nil {
+		if err := c.ClockGuard.Validate(); err != nil {
+			errs.add("ClockGuard: %v", err)
+		}
+	}
+
+	if c.GC != nil {
+		if err := c.GC.Validate(); err != nil {
+			errs.add("GC: %v", err)
+		}
+	}
+
+	if c.Watchdog != nil {
+		if c.Watchdog.Dir == "" && c.LoggingDir != "" {
+			c.Watchdog.Dir = filepath.Join(c.LoggingDir, "profiles")
+		}
+		if err := c.Watchdog.Validate(); err != nil {
+			errs.add("Watchdog: %v", err)
+		}
+	}
+
+	if c.WAL != nil {
+		if err := c.WAL.Validate(); err != nil {
+			errs.add("WAL: %v", err)
+		}
+	}
+
+	if c.StatsAggregation != nil {
+		if err := c.StatsAggregation.Validate(); err != nil {
+			errs.add("StatsAggregation: %v", err)
+		}
+	}
+
+	if c.Stats != nil {
+		if err := c.Stats.Validate(); err != nil {
+			errs.add("Stats: %v", err)
+		}
+	}
+
+	if c.Statsd != nil {
+		if err := c.Statsd.Validate(); err != nil {
+			errs.add("Statsd: %v", err)
+		}
+	}
+
+	if c.StatCardinality != nil {
+		if err := c.StatCardinality.Validate(); err != nil {
+			errs.add("StatCardinality: %v", err)
+		}
+	}
+
+	if c.Canary != nil {
+		if err := c.Canary.Validate(); err != nil {
+			errs.add("Canary: %v", err)
+		}
+	}
+
+	if c.VolumeAnomalies != nil {
+		if err := c.VolumeAnomalies.Validate(); err != nil {
+			errs.add("VolumeAnomalies: %v", err)
+		}
+	}
+
+	if c.ThroughputAdvisor != nil {
+		if len(c.ThroughputAdvisor.Streams) == 0 && c.EventStream != nil {
+			c.ThroughputAdvisor.Streams = []string{c.EventStream.StreamName}
+		}
+		if len(c.ThroughputAdvisor.Streams) == 0 {
+			errs.add("ThroughputAdvisor: Streams is required without an EventStream")
+		} else if err := c.ThroughputAdvisor.Validate(); err != nil {
+			errs.add("ThroughputAdvisor: %v", err)
+		}
+	}
+
+	if c.Status != nil {
+		if err := c.Status.Validate(); err != nil {
+			errs.add("Status: %v", err)
+		}
+	}
+
+	if c.TopK != nil {
+		if err := c.TopK.Validate(); err != nil {
+			errs.add("TopK: %v", err)
+		}
+	}
+
+	if c.RunRate != nil {
+		if err := c.RunRate.Validate(); err != nil {
+			errs.add("RunRate: %v", err)
+		}
+	}
+
+	if c.Accounting != nil {
+		if err := c.Accounting.Validate(); err != nil {
+			errs.add("Accounting: %v", err)
+		}
+	}
+
+	if c.Chaos != nil {
+		if err := c.Chaos.Validate(); err != nil {
+			errs.add("Chaos: %v", err)
+		}
+		if isProduction(c.RollbarEnvironment) {
+			errs.add("Chaos: fault injection can't be enabled in %s", c.RollbarEnvironment)
+		}
+	}
+
+	if c.EventTap != nil {
+		if err := c.EventTap.Validate(); err != nil {
+			errs.add("EventTap: %v", err)
+		}
+		if isProduction(c.RollbarEnvironment) && !c.EventTap.AllowInProduction {
+			errs.add("EventTap: the event tap can't be enabled in %s without AllowInProduction", c.RollbarEnvironment)
+		}
+	}
+
+	if c.Rollup != nil {
+		if err := c.Rollup.Validate(); err != nil {
+			errs.add("Rollup: %v", err)
+		}
+	}
+
+	if c.Pixel != nil {
+		if err := c.Pixel.Validate(); err != nil {
+			errs.add("Pixel: %v", err)
+		}
+	}
+
+	if c.BadClient != nil {
+		if err := c.BadClient.Validate(); err != nil {
+			errs.add("BadClient: %v", err)
+		}
+	}
+
+	if c.Envelope != nil {
+		if err := c.Envelope.Validate(); err != nil {
+			errs.add("Envelope: %v", err)
+		}
+	}
+
+	if c.ParquetLogger != nil {
+		if err := c.ParquetLogger.Validate(); err != nil {
+			errs.add("ParquetLogger: %v", err)
+		}
+		if c.LoggingDir == "" {
+			errs.add("LoggingDir is required when ParquetLogger is set")
+		}
+	}
+
+	if c.UserAgentDictionary != nil {
+		if err := c.UserAgentDictionary.Validate(); err != nil {
+			errs.add("UserAgentDictionary: %v", err)
+		}
+		if c.LoggingDir == "" {
+			errs.add("LoggingDir is required when UserAgentDictionary is set")
+		}
+		// S3 loggers name their files after their buckets.
+		others := []*loggers.S3LoggerConfig{c.EventsLogger, c.FallbackLogger}
+		for _, stage := range c.FallbackChain {
+			if stage != nil {
+				others = append(others, stage.S3)
+			}
+		}
+		for _, other := range others {
+			if other != nil && other.Bucket == c.UserAgentDictionary.S3.Bucket {
+				errs.add("UserAgentDictionary's Bucket must differ from those of the other S3 loggers")
+				break
+			}
+		}
+	}
+
+	if c.CloudWatch != nil {
+		if err := c.CloudWatch.Validate(); err != nil {
+			errs.add("CloudWatch: %v", err)
+		}
+	}
+
+	validateEdgePolicies(c.EdgePolicies, errs)
+
+	if c.ConfigRefreshInterval != "" {
+		d, err := time.ParseDuration(c.ConfigRefreshInterval)
+		if err != nil {
+			errs.add("ConfigRefreshInterval: error parsing %s as a time.Duration: %v", c.ConfigRefreshInterval, err)
+		} else if d <= 0 {
+			errs.add("ConfigRefreshInterval must be greater than 0")
+		}
+	}
+
+	if len(errs.Problems) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// isProduction returns whether env names a production environment.
+func isProduction(env string) bool {
+	switch strings.ToLower(env) {
+	case "prod", "production":
+		return true
+	}
+	return false
+}
+
+// Addresses returns the addresses the edge listens on: ListenAddresses, or
+// Port if there are none.
+func (c *Config) Addresses() []string {
+	if len(c.ListenAddresses) > 0 {
+		return c.ListenAddresses
+	}
+	return []string{c.Port}
+}
+
+// WritableDirs returns the directories the edge writes to, which stay
+// writable when it's sandboxed: LoggingDir, the WAL and spools, the ACME
+// certificate cache, the directory of the run-rate store and the watchdog's
+// profiles.
+func (c *Config) WritableDirs() []string {
+	var dirs []string
+	add := func(dir string) {
+		if dir == "" {
+			return
+		}
+		for _, d := range dirs {
+			if d == dir {
+				return
+			}
+		}
+		dirs = append(dirs, dir)
+	}
+	add(c.LoggingDir)
+	if c.WAL != nil {
+		add(c.WAL.Dir)
+	}
+	for _, stage := range c.FallbackChain {
+		if stage != nil && stage.Spool != nil {
+			add(stage.Spool.Dir)
+		}
+	}
+	if c.TLS != nil && c.TLS.ACME != nil {
+		add(c.TLS.ACME.CacheDir)
+	}
+	if c.RunRate != nil && c.RunRate.Path != "" {
+		add(filepath.Dir(c.RunRate.Path))
+	}
+	if c.Watchdog != nil {
+		add(c.Watchdog.Dir)
+	}
+	return dirs
+}
+
+// ListenNetwork returns the network to listen on addr with: "tcp4" or "tcp6"
+// if its host is an IPv4 or IPv6 address, so the wildcard addresses of both
+// families can be bound side by side on a dual-stack host, or "tcp".
+func ListenNetwork(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "tcp"
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return "tcp"
+	case ip.To4() != nil:
+		return "tcp4"
+	default:
+		return "tcp6"
+	}
+}
+
+// CheckPortBindable verifies that the configured addresses can all be
+// listened on at once. It must be called before the edge itself binds them.
+func (c *Config) CheckPortBindable() error {
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}()
+	for _, addr := range c.Addresses() {
+		l, err := net.Listen(ListenNetwork(addr), addr)
+		if err != nil {
+			return fmt.Errorf("Port %s is not bindable: %v", addr, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return nil
+}
+
+// normalizeYAML converts the map[interface{}]interface{} values produced by
+// the yaml package into map[string]interface{} so the tree can be re-encoded
+// as JSON.
+func normalizeYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = normalizeYAML(val)
+		}
+	}
+	return v
+}
+
+func expandEnvVars(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		return envVarPattern.ReplaceAllStringFunc(t, func(ref string) string {
+			return os.Getenv(envVarPattern.FindStringSubmatch(ref)[1])
+		})
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = expandEnvVars(val)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = expandEnvVars(val)
+		}
+	}
+	return v
+}
+
+// applyEnvOverrides sets the values of SPADE_EDGE_* variables in environ
+// into c, allocating nested structs and maps as needed.
+func (c *Config) applyEnvOverrides(environ []string) error {
+	for _, kv := range environ {
+		if !strings.HasPrefix(kv, EnvOverridePrefix) {
+			continue
+		}
+		eq := strings.Index(kv, "=")
+		if eq < 0 {
+			continue
+		}
+		name := kv[:eq]
+		path := strings.Split(name[len(EnvOverridePrefix):], "__")
+		if err := setValue(reflect.ValueOf(c).Elem(), path, kv[eq+1:]); err != nil {
+			return fmt.Errorf("error applying %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// setValue walks path from v, matching struct fields and map keys
+// case-insensitively like encoding/json does, and sets the value found there.
+// String values are used verbatim, anything else is decoded as JSON.
+func setValue(v reflect.Value, path []string, raw string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), path, raw)
+	}
+
+	if len(path) == 0 {
+		if v.Kind() == reflect.String {
+			v.SetString(raw)
+			return nil
+		}
+		return json.Unmarshal([]byte(raw), v.Addr().Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if strings.EqualFold(v.Type().Field(i).Name, path[0]) {
+				return setValue(v.Field(i), path[1:], raw)
+			}
+		}
+		return fmt.Errorf("unknown field %s", path[0])
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(strings.ToLower(path[0]))
+		for _, k := range v.MapKeys() {
+			if strings.EqualFold(k.String(), path[0]) {
+				key = k
+			}
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if current := v.MapIndex(key); current.IsValid() {
+			elem.Set(current)
+		}
+		if err := setValue(elem, path[1:], raw); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return fmt.Errorf("%s has no field %s", v.Type(), path[0])
+}
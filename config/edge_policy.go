@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/abuse"
+	"github.com/twitchscience/spade_edge/admission"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/requests"
+)
+
+// EdgePolicy holds the settings of one edge type that differ from the rest of
+// the config, so a single config can describe both the internal and external
+// fleets. Each field that is set replaces the config field of the same name.
+type EdgePolicy struct {
+	MaxRequestBytes int64
+	MaxURIBytes     int
+	TrackingPaths   *requests.TrackingPaths
+	JWTAuth         *auth.JWTConfig
+	HMACAuth        *auth.HMACConfig
+	Abuse           *abuse.Config
+	Admission       *admission.Config
+}
+
+// Validate verifies that a EdgePolicy is valid and fills in defaults
+func (p *EdgePolicy) Validate() error {
+	if p.MaxRequestBytes < 0 {
+		return errors.New("MaxRequestBytes must not be negative")
+	}
+	if p.MaxURIBytes < 0 {
+		return errors.New("MaxURIBytes must not be negative")
+	}
+	for _, field := range []struct {
+		name   string
+		set    bool
+		config interface {
+			Validate() error
+		}
+	}{
+		{"TrackingPaths", p.TrackingPaths != nil, p.TrackingPaths},
+		{"JWTAuth", p.JWTAuth != nil, p.JWTAuth},
+		{"HMACAuth", p.HMACAuth != nil, p.HMACAuth},
+		{"Abuse", p.Abuse != nil, p.Abuse},
+		{"Admission", p.Admission != nil, p.Admission},
+	} {
+		if !field.set {
+			continue
+		}
+		if err := field.config.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", field.name, err)
+		}
+	}
+	return nil
+}
+
+func validateEdgePolicies(policies map[string]*EdgePolicy, errs *ValidationError) {
+	for edgeType, policy := range policies {
+		if edgeType != spade.INTERNAL_EDGE && edgeType != spade.EXTERNAL_EDGE {
+			errs.add("EdgePolicies: unknown edge type %s", edgeType)
+			continue
+		}
+		if policy == nil {
+			continue
+		}
+		if err := policy.Validate(); err != nil {
+			errs.add("EdgePolicies[%s]: %v", edgeType, err)
+		}
+	}
+}
+
+// ForEdgeType returns a copy of c with the policy for edgeType applied.
+func (c *Config) ForEdgeType(edgeType string) *Config {
+	applied := *c
+	p := c.EdgePolicies[edgeType]
+	if p == nil {
+		return &applied
+	}
+	if p.MaxRequestBytes != 0 {
+		applied.MaxRequestBytes = p.MaxRequestBytes
+	}
+	if p.MaxURIBytes != 0 {
+		applied.MaxURIBytes = p.MaxURIBytes
+	}
+	if p.TrackingPaths != nil {
+		applied.TrackingPaths = p.TrackingPaths
+	}
+	if p.JWTAuth != nil {
+		applied.JWTAuth = p.JWTAuth
+	}
+	if p.HMACAuth != nil {
+		applied.HMACAuth = p.HMACAuth
+	}
+	if p.Abuse != nil {
+		applied.Abuse = p.Abuse
+	}
+	if p.Admission != nil {
+		applied.Admission = p.Admission
+	}
+	return &applied
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/wal"
+)
+
+// FallbackStage configures a sink of the FallbackChain. Exactly one of
+// Kinesis, S3 and Spool is set.
+type FallbackStage struct {
+	// Name identifies the stage in stats, Breakers and Retries
+	Name string
+
+	// Kinesis, if set, writes events to another Kinesis stream. Events it
+	// fails to write, even after its retries, go to the next stage.
+	Kinesis *loggers.KinesisLoggerConfig
+
+	// S3, if set, writes events to log files uploaded to S3
+	S3 *loggers.S3LoggerConfig
+
+	// Spool, if set, appends events to a log on local disk, which is
+	// replayed to the EventStream when the edge restarts
+	Spool *wal.Config
+}
+
+// Validate verifies that a FallbackStage is valid and fills in defaults
+func (s *FallbackStage) Validate() error {
+	if s.Name == "" {
+		return errors.New("Name is required")
+	}
+	switch s.Name {
+	case "event", "fallback", "kinesis", "relay":
+		return fmt.Errorf("%s is the name of a built-in sink", s.Name)
+	}
+	set := 0
+	for _, sink := range []bool{s.Kinesis != nil, s.S3 != nil, s.Spool != nil} {
+		if sink {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of Kinesis, S3 and Spool must be set")
+	}
+	switch {
+	case s.Kinesis != nil:
+		if err := s.Kinesis.Validate(); err != nil {
+			return fmt.Errorf("Kinesis: %v", err)
+		}
+	case s.S3 != nil:
+		if err := s.S3.Validate(); err != nil {
+			return fmt.Errorf("S3: %v", err)
+		}
+	case s.Spool != nil:
+		if err := s.Spool.Validate(); err != nil {
+			return fmt.Errorf("Spool: %v", err)
+		}
+	}
+	return nil
+}
+
+// fallbackStageNames returns the names of the FallbackChain's stages.
+func (c *Config) fallbackStageNames() map[string]bool {
+	names := make(map[string]bool, len(c.FallbackChain))
+	for _, stage := range c.FallbackChain {
+		if stage != nil {
+			names[stage.Name] = true
+		}
+	}
+	return names
+}
+
+// validateFallbackChain verifies the FallbackChain, adding its problems to errs.
+func (c *Config) validateFallbackChain(errs *ValidationError) {
+	if len(c.FallbackChain) == 0 {
+		return
+	}
+	if c.EventStream == nil {
+		errs.add("FallbackChain requires an EventStream")
+	}
+	if c.FallbackLogger != nil {
+		errs.add("FallbackChain and FallbackLogger can't both be set")
+	}
+	names := make(map[string]bool, len(c.FallbackChain))
+	spools := 0
+	for i, stage := range c.FallbackChain {
+		if stage == nil {
+			errs.add("FallbackChain[%d]: missing stage", i)
+			continue
+		}
+		if err := stage.Validate(); err != nil {
+			errs.add("FallbackChain[%d]: %v", i, err)
+			continue
+		}
+		if names[stage.Name] {
+			errs.add("FallbackChain[%d]: duplicate Name %s", i, stage.Name)
+		}
+		names[stage.Name] = true
+		if stage.S3 != nil && c.LoggingDir == "" {
+			errs.add("LoggingDir is required when FallbackChain[%d] is an S3 stage", i)
+		}
+		if stage.Spool == nil {
+			continue
+		}
+		if spools++; spools > 1 {
+			errs.add("FallbackChain[%d]: only one stage can be a Spool", i)
+		}
+		if c.WAL != nil && stage.Spool.Dir == c.WAL.Dir {
+			errs.add("FallbackChain[%d]: Spool and WAL can't share a Dir", i)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secret values in Sanitized configs.
+const redacted = "REDACTED"
+
+// secretFields are the names of config fields holding secrets, wherever
+// they're nested.
+var secretFields = map[string]bool{
+	"RollbarToken": true, // the Rollbar access token
+	"Secrets":      true, // HMACAuth signing secrets
+	"Secret":       true, // the Relay signing secret
+	"Peers":        true, // RelayReceiver signing secrets
+	"Key":          true, // the Fingerprint hash key
+	"Token":        true, // the EventTap bearer token
+	"WebhookURL":   true, // notification webhooks, which often embed a token
+	"Webhooks":     true, // alert webhooks, whose URLs, headers and templates hold tokens
+}
+
+// Sanitized returns the config as a JSON-like tree with its secrets and the
+// credentials of URLs redacted, for reporting it.
+func (c *Config) Sanitized() interface{} {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	var tree interface{}
+	if err = json.Unmarshal(b, &tree); err != nil {
+		return nil
+	}
+	return sanitize(tree)
+}
+
+func sanitize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if secretFields[k] && child != nil {
+				v[k] = redacted
+			} else {
+				v[k] = sanitize(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = sanitize(child)
+		}
+	case string:
+		if strings.Contains(v, "@") {
+			if u, err := url.Parse(v); err == nil && u.User != nil && u.Host != "" {
+				u.User = url.User(redacted)
+				return u.String()
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// snapshotFields are the fields kept in Snapshots: those deciding which
+// events are accepted, how they're rewritten and where they're written.
+var snapshotFields = []string{
+	"EventStream",
+	"EventsLogger",
+	"FallbackLogger",
+	"FallbackChain",
+	"ParquetLogger",
+	"Envelope",
+	"TrackingPaths",
+	"MaxRequestBytes",
+	"MaxURIBytes",
+	"Transforms",
+	"EventNames",
+	"Features",
+}
+
+// Snapshot identifies a config, to tell which one was live when events were
+// written.
+type Snapshot struct {
+	// Hash is the hex SHA-256 of the sanitized config, so configs differing
+	// only in their secrets hash the same.
+	Hash string `json:"hash"`
+
+	// Fields are the sanitized values of the snapshotFields, leaving out
+	// sections that aren't configured.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// Snapshot returns the config's Snapshot.
+func (c *Config) Snapshot() *Snapshot {
+	sanitized := c.Sanitized()
+	// Maps marshal with sorted keys, so equal configs hash the same.
+	b, _ := json.Marshal(sanitized)
+	sum := sha256.Sum256(b)
+	s := &Snapshot{Hash: hex.EncodeToString(sum[:]), Fields: make(map[string]interface{})}
+	tree, _ := sanitized.(map[string]interface{})
+	for _, field := range snapshotFields {
+		if v := tree[field]; v != nil {
+			s.Fields[field] = v
+		}
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+const (
+	s3Scheme    = "s3://"
+	ssmScheme   = "ssm:"
+	httpScheme  = "http://"
+	httpsScheme = "https://"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Fetch returns the raw config stored at location, which is either
+// s3://bucket/key, ssm:/parameter/name (a SecureString is decrypted), an http
+// or https URL, or a path to a local file. sess is only used for AWS
+// locations.
+func Fetch(location string, sess client.ConfigProvider) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(location, s3Scheme):
+		path := location[len(s3Scheme):]
+		slash := strings.Index(path, "/")
+		if slash < 1 || slash == len(path)-1 {
+			return nil, fmt.Errorf("%s is not of the form s3://bucket/key", location)
+		}
+		out, err := s3.New(sess).GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(path[:slash]),
+			Key:    aws.String(path[slash+1:]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching %s: %v", location, err)
+		}
+		defer func() { _ = out.Body.Close() }()
+		return ioutil.ReadAll(out.Body)
+	case strings.HasPrefix(location, ssmScheme):
+		name := location[len(ssmScheme):]
+		out, err := ssm.New(sess).GetParameters(&ssm.GetParametersInput{
+			Names:          []*string{aws.String(name)},
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching %s: %v", location, err)
+		}
+		if len(out.Parameters) != 1 {
+			return nil, fmt.Errorf("parameter %s not found", name)
+		}
+		return []byte(aws.StringValue(out.Parameters[0].Value)), nil
+	case strings.HasPrefix(location, httpScheme), strings.HasPrefix(location, httpsScheme):
+		resp, err := httpClient.Get(location)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching %s: %v", location, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error fetching %s: unexpected status %d", location, resp.StatusCode)
+		}
+		return ioutil.ReadAll(resp.Body)
+	default:
+		return ioutil.ReadFile(location)
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/twitchscience/spade_edge/acme"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+// TLSConfig configures TLS termination on the listen addresses.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate chain and its
+	// private key, served to clients asking for no other host. They're
+	// required unless ACME is set.
+	CertFile string
+	KeyFile  string
+
+	// ACME, if set, obtains certificates for its Hosts and those of tenants
+	// without a CertFile from an ACME CA
+	ACME *acme.Config
+
+	// Tenants, if set, routes the requests for tenants' vanity tracking
+	// domains by their TLS server name, keyed by tenant name
+	Tenants map[string]*TenantConfig
+}
+
+// TenantConfig configures a tenant served under its own tracking domains.
+type TenantConfig struct {
+	// Hosts are the server names of the tenant's tracking domains
+	Hosts []string
+
+	// CertFile and KeyFile, if set, are the certificate chain and key served
+	// for Hosts, instead of the edge's own
+	CertFile string
+	KeyFile  string
+
+	// EventStream, if set, is the Kinesis stream the tenant's events are
+	// written to instead of the edge's own
+	EventStream *loggers.KinesisLoggerConfig
+}
+
+// Validate verifies that a TLSConfig is valid
+func (c *TLSConfig) Validate() error {
+	if c.ACME == nil && (c.CertFile == "" || c.KeyFile == "") {
+		return errors.New("CertFile and KeyFile are required")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("CertFile and KeyFile must both be set, or neither")
+	}
+	if c.ACME != nil {
+		if err := c.ACME.Validate(); err != nil {
+			return fmt.Errorf("ACME: %v", err)
+		}
+		if len(c.ACMEHosts()) == 0 {
+			return errors.New("ACME: no Hosts, and no Tenants without a CertFile")
+		}
+	}
+	hosts := make(map[string]string)
+	for name, tenant := range c.Tenants {
+		if tenant == nil || len(tenant.Hosts) == 0 {
+			return fmt.Errorf("Tenants: %s has no Hosts", name)
+		}
+		for _, host := range tenant.Hosts {
+			host = strings.ToLower(host)
+			if other, ok := hosts[host]; ok {
+				return fmt.Errorf("Tenants: %s is a host of both %s and %s", host, other, name)
+			}
+			hosts[host] = name
+		}
+		if (tenant.CertFile == "") != (tenant.KeyFile == "") {
+			return fmt.Errorf("Tenants: %s needs both CertFile and KeyFile, or neither", name)
+		}
+		if tenant.EventStream != nil {
+			if err := tenant.EventStream.Validate(); err != nil {
+				return fmt.Errorf("Tenants: %s: EventStream: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// TenantHosts returns the tenants keyed by the lowercased server names of
+// their hosts.
+func (c *TLSConfig) TenantHosts() map[string]string {
+	hosts := make(map[string]string)
+	for name, tenant := range c.Tenants {
+		for _, host := range tenant.Hosts {
+			hosts[strings.ToLower(host)] = name
+		}
+	}
+	return hosts
+}
+
+// ACMEHosts returns the hosts certificates are obtained for: the ACME Hosts
+// and those of the tenants without a certificate of their own.
+func (c *TLSConfig) ACMEHosts() []string {
+	if c.ACME == nil {
+		return nil
+	}
+	hosts := append([]string(nil), c.ACME.Hosts...)
+	for _, tenant := range c.Tenants {
+		if tenant != nil && tenant.CertFile == "" {
+			hosts = append(hosts, tenant.Hosts...)
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,284 @@
+/*
+Package connlimit protects the edge from clients that tie up connections by
+sending requests slowly, e.g. a byte at a time, which the server's overall
+ReadTimeout only bounds per request. A Limiter enforces, per connection, a
+deadline for sending a request's headers and a minimum rate for sending its
+body, and caps the connections each remote IP can hold open.
+
+The Limiter wraps the server's net.Listener, and learns where requests start
+and end from the server's ConnState hook, so both must be installed:
+
+	server.ConnState = limiter.ConnState
+	err := server.Serve(limiter.Listener(l))
+*/
+package connlimit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+const defaultMinReadRateGrace = "5s"
+
+// ErrSlowBody is returned by reads of connections closed for sending a body
+// slower than the minimum rate.
+var ErrSlowBody = errors.New("request body sent too slowly")
+
+// Config configures the per-connection limits. Each limit is disabled if
+// it's left unset.
+type Config struct {
+	// HeaderTimeout is the longest a client may take to send a request's
+	// headers, from their first byte, e.g. "5s". Idle keep-alive connections
+	// aren't affected.
+	HeaderTimeout string
+
+	// MinReadRate is the slowest a client may send a request body, in bytes
+	// per second, once MinReadRateGrace has passed since the headers.
+	MinReadRate int
+
+	// MinReadRateGrace is how long a body may be sent at any rate, e.g. "5s"
+	MinReadRateGrace string
+
+	// MaxConnsPerIP is the most connections one remote IP may have open.
+	// Behind a load balancer that doesn't preserve client addresses, the
+	// remote IP is the load balancer's.
+	MaxConnsPerIP int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.MinReadRate < 0 {
+		return errors.New("MinReadRate must not be negative")
+	}
+	if c.MaxConnsPerIP < 0 {
+		return errors.New("MaxConnsPerIP must not be negative")
+	}
+	if c.MinReadRateGrace == "" {
+		c.MinReadRateGrace = defaultMinReadRateGrace
+	}
+	for _, d := range []string{c.HeaderTimeout, c.MinReadRateGrace} {
+		if d == "" {
+			continue
+		}
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// A Limiter enforces the limits of a Config on the connections of a server.
+type Limiter struct {
+	headerTimeout time.Duration
+	minReadRate   float64
+	grace         time.Duration
+	maxConnsPerIP int
+	stats         statsd.StatSender
+	now           func() time.Time
+
+	mu    sync.Mutex
+	perIP map[string]int
+}
+
+// New returns a Limiter for config.
+func New(config Config, stats statsd.StatSender) (*Limiter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	l := &Limiter{
+		minReadRate:   float64(config.MinReadRate),
+		maxConnsPerIP: config.MaxConnsPerIP,
+		stats:         stats,
+		now:           time.Now,
+		perIP:         make(map[string]int),
+	}
+	if config.HeaderTimeout != "" {
+		l.headerTimeout, _ = time.ParseDuration(config.HeaderTimeout)
+	}
+	l.grace, _ = time.ParseDuration(config.MinReadRateGrace)
+	return l, nil
+}
+
+// Listener returns a net.Listener that accepts from inner, closing
+// connections from IPs that already have MaxConnsPerIP open.
+func (l *Limiter) Listener(inner net.Listener) net.Listener {
+	return &listener{Listener: inner, limiter: l}
+}
+
+type listener struct {
+	net.Listener
+	limiter *Limiter
+}
+
+func (ln *listener) Accept() (net.Conn, error) {
+	for {
+		c, err := ln.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		ip := remoteIP(c)
+		if !ln.limiter.acquire(ip) {
+			_ = ln.limiter.stats.Inc("connlimit.ip_limited", 1, 0.1)
+			_ = c.Close()
+			continue
+		}
+		return &conn{Conn: c, limiter: ln.limiter, ip: ip}, nil
+	}
+}
+
+func remoteIP(c net.Conn) string {
+	host, _, err := net.SplitHostPort(c.RemoteAddr().String())
+	if err != nil {
+		return c.RemoteAddr().String()
+	}
+	return host
+}
+
+// acquire counts a connection from ip, reporting false if it's over the cap.
+func (l *Limiter) acquire(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxConnsPerIP > 0 && l.perIP[ip] >= l.maxConnsPerIP {
+		return false
+	}
+	l.perIP[ip]++
+	return true
+}
+
+func (l *Limiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.perIP[ip] <= 1 {
+		delete(l.perIP, ip)
+	} else {
+		l.perIP[ip]--
+	}
+}
+
+// ConnState tracks where requests start and end on the connections from
+// Listener. It must be installed as the server's ConnState.
+func (l *Limiter) ConnState(c net.Conn, state http.ConnState) {
+	lc, ok := unwrap(c)
+	if !ok {
+		return
+	}
+	switch state {
+	case http.StateActive:
+		// The headers have been read; the body, if any, follows.
+		lc.setPhase(phaseBody)
+	case http.StateIdle:
+		lc.setPhase(phaseWaiting)
+	case http.StateHijacked, http.StateClosed:
+		lc.setPhase(phaseDone)
+		lc.releaseOnce.Do(func() { l.release(lc.ip) })
+	}
+}
+
+// unwrap returns the Limiter's connection beneath c, which may be wrapped in
+// connections that expose the one they wrap with NetConn, like *tls.Conn.
+func unwrap(c net.Conn) (*conn, bool) {
+	for {
+		switch wrapped := c.(type) {
+		case *conn:
+			return wrapped, true
+		case interface{ NetConn() net.Conn }:
+			c = wrapped.NetConn()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// The phases of a connection, which decide the limits its reads are held to.
+const (
+	phaseWaiting = iota // for the first byte of a request
+	phaseHeaders
+	phaseBody
+	phaseDone
+)
+
+type conn struct {
+	net.Conn
+	limiter     *Limiter
+	ip          string
+	releaseOnce sync.Once
+
+	mu          sync.Mutex
+	phase       int
+	phaseStart  time.Time
+	bodyBytes   int64
+	headerTimer *time.Timer
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		if rateErr := c.read(n); rateErr != nil {
+			return n, rateErr
+		}
+	}
+	return n, err
+}
+
+// read accounts for n bytes read, returning ErrSlowBody if the connection was
+// closed for sending its body too slowly.
+func (c *conn) read(n int) error {
+	l := c.limiter
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.phase {
+	case phaseWaiting:
+		c.phase = phaseHeaders
+		if l.headerTimeout > 0 {
+			c.headerTimer = time.AfterFunc(l.headerTimeout, c.headerTimeout)
+		}
+	case phaseBody:
+		if l.minReadRate == 0 {
+			return nil
+		}
+		c.bodyBytes += int64(n)
+		elapsed := l.now().Sub(c.phaseStart)
+		if elapsed > l.grace && float64(c.bodyBytes)/elapsed.Seconds() < l.minReadRate {
+			_ = l.stats.Inc("connlimit.slow_body", 1, 1)
+			c.phase = phaseDone
+			_ = c.Conn.Close()
+			return ErrSlowBody
+		}
+	}
+	return nil
+}
+
+// headerTimeout closes the connection if it's still sending headers.
+func (c *conn) headerTimeout() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.phase != phaseHeaders {
+		return
+	}
+	_ = c.limiter.stats.Inc("connlimit.header_timeout", 1, 1)
+	c.phase = phaseDone
+	_ = c.Conn.Close()
+}
+
+func (c *conn) setPhase(phase int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.headerTimer != nil {
+		c.headerTimer.Stop()
+		c.headerTimer = nil
+	}
+	if c.phase == phaseDone {
+		return
+	}
+	c.phase = phase
+	c.phaseStart = c.limiter.now()
+	c.bodyBytes = 0
+}
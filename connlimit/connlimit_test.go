@@ -0,0 +1,114 @@
+package connlimit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// serve serves a handler reading whole bodies behind a Limiter for config,
+// returning its address and a function to stop it.
+func serve(t *testing.T, config Config) (string, func()) {
+	noop, _ := statsd.NewNoop()
+	limiter, err := New(config, noop)
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %s", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = ioutil.ReadAll(r.Body)
+		}),
+		ConnState: limiter.ConnState,
+	}
+	go func() { _ = server.Serve(limiter.Listener(l)) }()
+	return l.Addr().String(), func() { _ = l.Close() }
+}
+
+// closedWithin reports whether the server closes c within d.
+func closedWithin(c net.Conn, d time.Duration) bool {
+	_ = c.SetReadDeadline(time.Now().Add(d))
+	_, err := ioutil.ReadAll(c)
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return false
+	}
+	return true
+}
+
+func TestHeaderTimeout(t *testing.T) {
+	addr, stop := serve(t, Config{HeaderTimeout: "50ms"})
+	defer stop()
+
+	idle, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = idle.Close() }()
+	slow, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = slow.Close() }()
+	_, _ = slow.Write([]byte("GET / HTTP/1.1\r\n"))
+
+	if !closedWithin(slow, time.Second) {
+		t.Error("Expected a connection sending headers slowly to be closed")
+	}
+	if closedWithin(idle, 200*time.Millisecond) {
+		t.Error("Expected an idle connection to be left open")
+	}
+}
+
+func TestMinReadRate(t *testing.T) {
+	addr, stop := serve(t, Config{MinReadRate: 1000, MinReadRateGrace: "50ms"})
+	defer stop()
+
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = c.Close() }()
+	_, _ = fmt.Fprintf(c, "POST / HTTP/1.1\r\nHost: edge\r\nContent-Length: 100000\r\n\r\n")
+	closed := make(chan bool)
+	go func() { closed <- closedWithin(c, 2*time.Second) }()
+	for i := 0; i < 20; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if _, err := c.Write([]byte("x")); err != nil {
+			break
+		}
+	}
+	if !<-closed {
+		t.Error("Expected a connection sending its body slowly to be closed")
+	}
+}
+
+func TestMaxConnsPerIP(t *testing.T) {
+	addr, stop := serve(t, Config{MaxConnsPerIP: 1})
+	defer stop()
+
+	first, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = first.Close() }()
+	second, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = second.Close() }()
+
+	if !closedWithin(second, time.Second) {
+		t.Error("Expected a second connection from the same IP to be closed")
+	}
+	if closedWithin(first, 100*time.Millisecond) {
+		t.Error("Expected the first connection to be left open")
+	}
+}
@@ -0,0 +1,213 @@
+package edge
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/alerts"
+	"github.com/twitchscience/spade_edge/config"
+	"github.com/twitchscience/spade_edge/requests"
+)
+
+// configWatcher polls the config location and applies changes to the
+// hot-reloadable fields of the running edge: CorsOrigins, CorsGroups,
+// PathLifecycles, EventInURISamplingRate, RedirectHosts, Features,
+// ResponseHeaders, Transforms, EventNames and the static content settings.
+// Changes to any other field are logged and require a restart. The static
+// content locations are reloaded on every poll.
+type configWatcher struct {
+	location string
+	sess     client.ConfigProvider
+	handler  *requests.SpadeHandler
+	stats    statsd.StatSender
+	current  config.Config
+	edgeType string
+	last     []byte
+	closed   chan struct{}
+
+	// applied is called with the current config after each change applied
+	// to it.
+	applied func(*config.Config)
+
+	// alerter, if set, is told of configs that fail to reload.
+	alerter *alerts.Alerter
+}
+
+func newConfigWatcher(location string, sess client.ConfigProvider, handler *requests.SpadeHandler,
+	stats statsd.StatSender, current config.Config, edgeType string, applied func(*config.Config)) *configWatcher {
+	return &configWatcher{
+		location: location,
+		sess:     sess,
+		handler:  handler,
+		stats:    stats,
+		current:  current,
+		edgeType: edgeType,
+		closed:   make(chan struct{}),
+		applied:  applied,
+	}
+}
+
+func (w *configWatcher) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closed:
+			return
+		case <-ticker.C:
+		}
+		w.refresh()
+		if err := loadStaticContent(w.handler, &w.current, w.sess); err != nil {
+			logger.WithError(err).Warn("Error reloading static content")
+			_ = w.stats.Inc("config.refresh.static_error", 1, 1)
+		}
+	}
+}
+
+// close stops run.
+func (w *configWatcher) close() {
+	close(w.closed)
+}
+
+func (w *configWatcher) refresh() {
+	b, err := config.Fetch(w.location, w.sess)
+	if err != nil {
+		logger.WithError(err).WithField("location", w.location).Warn("Error fetching config")
+		_ = w.stats.Inc("config.refresh.fetch_error", 1, 1)
+		w.alert(fmt.Sprintf("Error fetching config: %v", err))
+		return
+	}
+	if w.last != nil && bytes.Equal(b, w.last) {
+		return
+	}
+
+	next, err := config.Parse(w.location, b)
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		logger.WithError(err).WithField("location", w.location).Error("Ignoring invalid config")
+		_ = w.stats.Inc("config.refresh.invalid", 1, 1)
+		w.alert(fmt.Sprintf("Ignoring invalid config: %v", err))
+		return
+	}
+	w.last = b
+	previous := w.current
+	w.apply(next.ForEdgeType(w.edgeType))
+	if !reflect.DeepEqual(previous, w.current) {
+		w.applied(&w.current)
+	}
+}
+
+func (w *configWatcher) alert(message string) {
+	if w.alerter != nil {
+		w.alerter.Fire(alerts.ConfigReloadFailed, w.location, message)
+	}
+}
+
+func (w *configWatcher) apply(next *config.Config) {
+	if !reflect.DeepEqual(next.CorsOrigins, w.current.CorsOrigins) {
+		w.handler.SetCORSOrigins(next.CorsOrigins)
+		w.logChange("CorsOrigins", w.current.CorsOrigins, next.CorsOrigins)
+		w.current.CorsOrigins = next.CorsOrigins
+	}
+	if !reflect.DeepEqual(next.CorsGroups, w.current.CorsGroups) {
+		w.handler.SetCORSGroups(next.CorsGroups)
+		w.logChange("CorsGroups", w.current.CorsGroups, next.CorsGroups)
+		w.current.CorsGroups = next.CorsGroups
+	}
+	if !reflect.DeepEqual(next.PathLifecycles, w.current.PathLifecycles) {
+		w.handler.SetPathLifecycles(next.PathLifecycles)
+		w.logChange("PathLifecycles", w.current.PathLifecycles, next.PathLifecycles)
+		w.current.PathLifecycles = next.PathLifecycles
+	}
+	if next.EventInURISamplingRate != w.current.EventInURISamplingRate {
+		w.handler.SetEventInURISamplingRate(next.EventInURISamplingRate)
+		w.logChange("EventInURISamplingRate", w.current.EventInURISamplingRate, next.EventInURISamplingRate)
+		w.current.EventInURISamplingRate = next.EventInURISamplingRate
+	}
+	if !reflect.DeepEqual(next.RedirectHosts, w.current.RedirectHosts) {
+		w.handler.SetRedirectHosts(next.RedirectHosts)
+		w.logChange("RedirectHosts", w.current.RedirectHosts, next.RedirectHosts)
+		w.current.RedirectHosts = next.RedirectHosts
+	}
+	if !reflect.DeepEqual(next.Features, w.current.Features) {
+		w.handler.Features.Update(next.Features)
+		w.logChange("Features", w.current.Features, next.Features)
+		w.current.Features = next.Features
+	}
+	if !reflect.DeepEqual(next.Transforms, w.current.Transforms) {
+		if err := w.handler.Transformer.Update(next.Transforms); err != nil {
+			logger.WithError(err).Error("Error updating transforms")
+		} else {
+			w.logChange("Transforms", w.current.Transforms, next.Transforms)
+			w.current.Transforms = next.Transforms
+		}
+	}
+	if !reflect.DeepEqual(next.EventNames, w.current.EventNames) {
+		if err := w.handler.Transformer.UpdateNames(next.EventNames); err != nil {
+			logger.WithError(err).Error("Error updating event name normalization")
+		} else {
+			w.logChange("EventNames", w.current.EventNames, next.EventNames)
+			w.current.EventNames = next.EventNames
+		}
+	}
+	if !reflect.DeepEqual(next.ResponseHeaders, w.current.ResponseHeaders) {
+		w.handler.SetResponseHeaders(next.ResponseHeaders)
+		w.logChange("ResponseHeaders", w.current.ResponseHeaders, next.ResponseHeaders)
+		w.current.ResponseHeaders = next.ResponseHeaders
+	}
+	if next.CrossDomainPolicy != w.current.CrossDomainPolicy ||
+		next.CrossDomainPolicyLocation != w.current.CrossDomainPolicyLocation ||
+		next.RobotsTxtLocation != w.current.RobotsTxtLocation {
+		w.logChange("StaticContent",
+			[]string{w.current.CrossDomainPolicyLocation, w.current.RobotsTxtLocation},
+			[]string{next.CrossDomainPolicyLocation, next.RobotsTxtLocation})
+		w.current.CrossDomainPolicy = next.CrossDomainPolicy
+		w.current.CrossDomainPolicyLocation = next.CrossDomainPolicyLocation
+		w.current.RobotsTxtLocation = next.RobotsTxtLocation
+	}
+
+	if !reflect.DeepEqual(*next, w.current) {
+		logger.WithField("location", w.location).
+			Warn("Config changed in fields that are not hot-reloadable; restart to apply them")
+		_ = w.stats.Inc("config.refresh.restart_required", 1, 1)
+	}
+}
+
+// loadStaticContent fetches the crossdomain.xml and robots.txt content
+// configured in c and sets it on the handler.
+func loadStaticContent(handler *requests.SpadeHandler, c *config.Config, sess client.ConfigProvider) error {
+	policy := []byte(c.CrossDomainPolicy)
+	if c.CrossDomainPolicyLocation != "" {
+		b, err := config.Fetch(c.CrossDomainPolicyLocation, sess)
+		if err != nil {
+			return fmt.Errorf("error fetching cross domain policy: %v", err)
+		}
+		policy = b
+	}
+	robots := requests.DefaultRobotsTxt
+	if c.RobotsTxtLocation != "" {
+		b, err := config.Fetch(c.RobotsTxtLocation, sess)
+		if err != nil {
+			return fmt.Errorf("error fetching robots.txt: %v", err)
+		}
+		robots = b
+	}
+	handler.SetCrossDomainPolicy(policy)
+	handler.SetRobotsTxt(robots)
+	return nil
+}
+
+func (w *configWatcher) logChange(field string, from, to interface{}) {
+	logger.WithField("field", field).
+		WithField("old", from).
+		WithField("new", to).
+		Info("Applied config change")
+	_ = w.stats.Inc("config.refresh.applied."+field, 1, 1)
+}
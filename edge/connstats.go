@@ -0,0 +1,126 @@
+package edge
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// tlsVersions names the TLS versions in handshake stats.
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "tls1_0",
+	tls.VersionTLS11: "tls1_1",
+	tls.VersionTLS12: "tls1_2",
+	tls.VersionTLS13: "tls1_3",
+}
+
+// connInfo is what connStats knows of an open connection.
+type connInfo struct {
+	opened   time.Time
+	requests int64
+	active   bool
+}
+
+// connStats tracks connection reuse from the server's ConnState hook, under
+// conn.*: requests on new and reused connections, the connections open and
+// active, how long connections last and how many requests they serve, and
+// the TLS handshakes that complete, are resumed and fail.
+type connStats struct {
+	stats statsd.StatSender
+	now   func() time.Time
+
+	mu     sync.Mutex
+	conns  map[net.Conn]*connInfo
+	active int64
+}
+
+func newConnStats(stats statsd.StatSender) *connStats {
+	return &connStats{
+		stats: stats,
+		now:   time.Now,
+		conns: make(map[net.Conn]*connInfo),
+	}
+}
+
+// ConnState must be installed as the server's ConnState.
+func (s *connStats) ConnState(c net.Conn, state http.ConnState) {
+	s.mu.Lock()
+	info := s.conns[c]
+	switch state {
+	case http.StateNew:
+		s.conns[c] = &connInfo{opened: s.now()}
+		open := int64(len(s.conns))
+		s.mu.Unlock()
+		_ = s.stats.Inc("conn.new", 1, 0.1)
+		_ = s.stats.Gauge("conn.open", open, 0.1)
+		return
+	case http.StateActive:
+		if info == nil {
+			s.mu.Unlock()
+			return
+		}
+		info.requests++
+		requests := info.requests
+		if !info.active {
+			info.active = true
+			s.active++
+		}
+		active := s.active
+		s.mu.Unlock()
+		_ = s.stats.Gauge("conn.active", active, 0.1)
+		if requests > 1 {
+			_ = s.stats.Inc("conn.request.reused", 1, 0.1)
+			return
+		}
+		_ = s.stats.Inc("conn.request.new", 1, 0.1)
+		// The handshake completes before the first request is read.
+		if tlsConn, ok := c.(*tls.Conn); ok {
+			s.countHandshake(tlsConn.ConnectionState())
+		}
+	case http.StateIdle:
+		if info != nil && info.active {
+			info.active = false
+			s.active--
+		}
+		active := s.active
+		s.mu.Unlock()
+		_ = s.stats.Gauge("conn.active", active, 0.1)
+	case http.StateHijacked, http.StateClosed:
+		if info == nil {
+			s.mu.Unlock()
+			return
+		}
+		delete(s.conns, c)
+		if info.active {
+			s.active--
+		}
+		open, active := int64(len(s.conns)), s.active
+		s.mu.Unlock()
+		_ = s.stats.Gauge("conn.open", open, 0.1)
+		_ = s.stats.Gauge("conn.active", active, 0.1)
+		_ = s.stats.TimingDuration("conn.duration", s.now().Sub(info.opened), 0.1)
+		_ = s.stats.Timing("conn.requests", info.requests, 0.1)
+		if tlsConn, ok := c.(*tls.Conn); ok && info.requests == 0 && !tlsConn.ConnectionState().HandshakeComplete {
+			_ = s.stats.Inc("conn.tls.handshake_failed", 1, 1)
+		}
+	default:
+		s.mu.Unlock()
+	}
+}
+
+// countHandshake counts a completed TLS handshake by version, and whether it
+// resumed a session.
+func (s *connStats) countHandshake(state tls.ConnectionState) {
+	version, ok := tlsVersions[state.Version]
+	if !ok {
+		version = "other"
+	}
+	_ = s.stats.Inc("conn.tls.handshake."+version, 1, 0.1)
+	if state.DidResume {
+		_ = s.stats.Inc("conn.tls.resumed", 1, 0.1)
+	}
+}
@@ -0,0 +1,1291 @@
+/*
+Package edge assembles a spade edge from its config: the event loggers, the
+SpadeHandler serving the HTTP API and the background work around them. The
+spade_edge command is a thin wrapper around it, and other programs can embed
+an edge the same way, registering their own routes on Edge.Handler.
+
+A minimal embedding looks like
+
+	cfg, err := config.Load("conf.json", sess)
+	...
+	e, err := edge.New(edge.Options{Config: cfg, Session: sess, EdgeType: spade.INTERNAL_EDGE})
+	...
+	e.Handler.Handle("/custom", customRoute)
+	defer e.Close()
+	err = e.ListenAndServe()
+*/
+package edge
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/netutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/cactus/go-statsd-client/statsd"
+
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/abuse"
+	"github.com/twitchscience/spade_edge/accounting"
+	"github.com/twitchscience/spade_edge/acme"
+	"github.com/twitchscience/spade_edge/admission"
+	"github.com/twitchscience/spade_edge/advisor"
+	"github.com/twitchscience/spade_edge/aggregator"
+	"github.com/twitchscience/spade_edge/alerts"
+	"github.com/twitchscience/spade_edge/anomaly"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/breaker"
+	"github.com/twitchscience/spade_edge/canary"
+	"github.com/twitchscience/spade_edge/chaos"
+	"github.com/twitchscience/spade_edge/clienthello"
+	"github.com/twitchscience/spade_edge/clock"
+	"github.com/twitchscience/spade_edge/clockguard"
+	"github.com/twitchscience/spade_edge/config"
+	"github.com/twitchscience/spade_edge/connlimit"
+	"github.com/twitchscience/spade_edge/emf"
+	"github.com/twitchscience/spade_edge/enrich"
+	"github.com/twitchscience/spade_edge/eventtap"
+	"github.com/twitchscience/spade_edge/features"
+	"github.com/twitchscience/spade_edge/gctune"
+	"github.com/twitchscience/spade_edge/killswitch"
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/metrics"
+	"github.com/twitchscience/spade_edge/notify"
+	"github.com/twitchscience/spade_edge/proxyproto"
+	"github.com/twitchscience/spade_edge/reputation"
+	"github.com/twitchscience/spade_edge/requests"
+	"github.com/twitchscience/spade_edge/retry"
+	"github.com/twitchscience/spade_edge/rollup"
+	"github.com/twitchscience/spade_edge/runrate"
+	"github.com/twitchscience/spade_edge/status"
+	"github.com/twitchscience/spade_edge/topk"
+	"github.com/twitchscience/spade_edge/transform"
+	"github.com/twitchscience/spade_edge/wal"
+	"github.com/twitchscience/spade_edge/watchdog"
+)
+
+// MaxConnections is the most connections Serve accepts at once.
+const MaxConnections = 8000
+
+// Options are what an Edge needs besides its config.
+type Options struct {
+	// Config is the edge's config. New validates it after applying the
+	// policy for EdgeType.
+	Config *config.Config
+
+	// ConfigLocation is where Config was loaded from. If it's set along with
+	// Config.ConfigRefreshInterval, the location is polled for changes.
+	ConfigLocation string
+
+	// Session creates the AWS clients of the sinks.
+	Session *session.Session
+
+	// Stats receives the edge's stats, through the statters configured by
+	// CloudWatch, StatsAggregation and Stats. It defaults to a noop statter.
+	Stats statsd.Statter
+
+	// EdgeType is spade.INTERNAL_EDGE or spade.EXTERNAL_EDGE.
+	EdgeType string
+
+	// InstanceID goes into the UUIDs of events. It defaults to the EC2
+	// instance ID.
+	InstanceID string
+
+	// Version is the edge version recorded in event envelopes.
+	Version string
+
+	// Clock stamps events and times the flushes and rotations of the sinks.
+	// It defaults to clock.Real; a clock.Fake makes integration tests
+	// deterministic.
+	Clock clock.Clock
+
+	// Breakers registers the edge's circuit breakers, so a
+	// breaker.StreamHandler can report them. It defaults to a registry of
+	// the edge's own.
+	Breakers *breaker.Registry
+}
+
+// An Edge is a configured spade edge.
+type Edge struct {
+	// Handler serves the edge's HTTP API.
+	Handler *requests.SpadeHandler
+
+	// Loggers are the sinks events are written to.
+	Loggers *requests.EdgeLoggers
+
+	// Stats is the statter the edge sends its stats to.
+	Stats statsd.Statter
+
+	// Chaos, if fault injection is configured, should be served on a
+	// debug port so faults can be injected.
+	Chaos *chaos.Injector
+
+	// KillSwitch, if configured, should be served on a debug port so event
+	// names can be blocked.
+	KillSwitch *killswitch.Switch
+
+	// Status, if the status report is configured, should be served on a
+	// debug port as /status.json.
+	Status *status.Reporter
+
+	// TopK, if top talker tracking is configured, should be served on a debug
+	// port.
+	TopK *topk.Tracker
+
+	// OriginLearner, if origin learning is configured, should be served on a
+	// debug port.
+	OriginLearner *requests.OriginLearner
+
+	// RunRate, if run-rate counting is configured, should be served on a
+	// debug port.
+	RunRate *runrate.Store
+
+	// EventTap, if configured, should be served on a debug port as
+	// /debug/events.
+	EventTap *eventtap.Tap
+
+	// Breakers holds the edge's circuit breakers, for a
+	// breaker.StreamHandler to report.
+	Breakers *breaker.Registry
+
+	cfg            *config.Config
+	configLocation string
+	instanceID     string
+	version        string
+	edgeType       string
+	session        *session.Session
+	env            *loggers.Env
+	rollup         *rollup.Rollup
+	canary         *canary.Canary
+	gc             *gctune.Tuner
+	advisor        *advisor.Advisor
+	alerter        *alerts.Alerter
+	watchdog       *watchdog.Watchdog
+	watcher        *configWatcher
+	httpHandler    http.Handler
+	connLimiter    *connlimit.Limiter
+	tlsConfig      *tls.Config
+	acme           *acme.Manager
+	spool          *loggers.SpoolLogger
+	// snapshot is the *config.Snapshot of the live config.
+	snapshot atomic.Value
+
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// New creates the edge configured by opts.Config, with its loggers open but
+// nothing served yet.
+func New(opts Options) (*Edge, error) {
+	if opts.Config == nil {
+		return nil, errors.New("a config is required")
+	}
+	if opts.EdgeType != spade.INTERNAL_EDGE && opts.EdgeType != spade.EXTERNAL_EDGE {
+		return nil, fmt.Errorf("invalid edge type %q", opts.EdgeType)
+	}
+	cfg := opts.Config.ForEdgeType(opts.EdgeType)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	e := &Edge{
+		cfg:            cfg,
+		configLocation: opts.ConfigLocation,
+		version:        opts.Version,
+		edgeType:       opts.EdgeType,
+		session:        opts.Session,
+		Stats:          opts.Stats,
+		Breakers:       opts.Breakers,
+	}
+	var err error
+	if e.Stats == nil {
+		e.Stats, _ = statsd.NewNoop()
+	}
+	if err = e.initStats(); err != nil {
+		return nil, err
+	}
+	if cfg.GC != nil {
+		if e.gc, err = gctune.New(*cfg.GC, e.Stats); err != nil {
+			return nil, fmt.Errorf("error tuning the garbage collector: %v", err)
+		}
+	}
+	if cfg.ThroughputAdvisor != nil {
+		e.advisor, err = advisor.New(*cfg.ThroughputAdvisor, kinesis.New(e.session), cloudwatch.New(e.session), e.Stats)
+		if err != nil {
+			return nil, fmt.Errorf("error creating throughput advisor: %v", err)
+		}
+	}
+
+	var envelope loggers.EnvelopeConfig
+	if cfg.Envelope != nil {
+		envelope = *cfg.Envelope
+	}
+	e.env = &loggers.Env{Clock: opts.Clock}
+	if e.env.Envelope, err = loggers.NewEnvelope(envelope, opts.Version, e.Stats); err != nil {
+		return nil, fmt.Errorf("error configuring event serialization: %v", err)
+	}
+	if e.env.Clock == nil {
+		e.env.Clock = clock.Real
+	}
+	if e.Breakers == nil {
+		e.Breakers = breaker.NewRegistry()
+	}
+
+	instanceID := opts.InstanceID
+	if instanceID == "" {
+		instanceID, err = ec2metadata.New(opts.Session).GetMetadata("instance-id")
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving instance-id from metadata service: %v", err)
+		}
+	}
+	e.instanceID = instanceID
+	e.setSnapshot(cfg)
+
+	if cfg.Status != nil {
+		e.Status, err = status.New(*cfg.Status, status.BuildInfo{
+			Version:    opts.Version,
+			EdgeType:   opts.EdgeType,
+			InstanceID: instanceID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating status report: %v", err)
+		}
+		e.Status.SetConfig(cfg.Sanitized())
+	}
+	if cfg.Alerts != nil {
+		if e.alerter, err = alerts.New(*cfg.Alerts, instanceID, e.Stats); err != nil {
+			return nil, fmt.Errorf("error creating alerts: %v", err)
+		}
+		if e.Status != nil {
+			e.alerter.WatchSinks(func() []status.SinkStatus { return e.Status.Report().Sinks })
+		}
+	}
+
+	if err = e.initLoggers(); err != nil {
+		return nil, err
+	}
+	if e.alerter != nil && e.spool != nil {
+		e.alerter.WatchSpool(e.spool.DiskUsage)
+	}
+	if cfg.Rollup != nil {
+		e.rollup, err = rollup.New(*cfg.Rollup, e.Stats)
+		if err != nil {
+			e.Loggers.Close()
+			return nil, fmt.Errorf("error creating event rollup: %v", err)
+		}
+	}
+
+	e.Handler = requests.NewSpadeHandler(
+		e.Stats,
+		e.Loggers,
+		instanceID,
+		cfg.CorsOrigins,
+		cfg.EventInURISamplingRate,
+		cfg.CrossDomainPolicy,
+		opts.EdgeType,
+		true,
+	)
+	e.Handler.Time = e.env.Clock.Now
+	if e.Status != nil {
+		splits := e.Handler.Splits
+		e.Status.AddTotals("splitRequests", func() interface{} { return splits.Totals() })
+	}
+	if err = e.initHandler(); err != nil {
+		e.Loggers.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// initStats wraps e.Stats in the statters the config asks for.
+func (e *Edge) initStats() error {
+	var err error
+	if e.cfg.CloudWatch != nil {
+		if e.cfg.CloudWatch.ReplaceStatsd {
+			_ = e.Stats.Close()
+			e.Stats, _ = statsd.NewNoop()
+		}
+		e.Stats, err = emf.New(*e.cfg.CloudWatch, e.Stats, cloudwatch.New(e.session))
+		if err != nil {
+			return fmt.Errorf("error configuring CloudWatch stats: %v", err)
+		}
+	}
+	if e.cfg.StatsAggregation != nil {
+		e.Stats, err = aggregator.New(*e.cfg.StatsAggregation, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating stats aggregator: %v", err)
+		}
+	}
+	// Sample rates and names are resolved before aggregation so disabled
+	// stats aren't aggregated either.
+	if e.cfg.Stats != nil {
+		e.Stats, err = metrics.New(*e.cfg.Stats, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error configuring stats: %v", err)
+		}
+	}
+	return nil
+}
+
+// initLoggers creates the configured sinks.
+func (e *Edge) initLoggers() error {
+	cfg := e.cfg
+	sqsClient := sqs.New(e.session)
+	s3Uploader := e.newUploader("")
+
+	// Orphans must be claimed before any S3 logger creates its files.
+	s3Configs := []*loggers.S3LoggerConfig{cfg.EventsLogger, cfg.FallbackLogger}
+	if cfg.UserAgentDictionary != nil {
+		s3Configs = append(s3Configs, &cfg.UserAgentDictionary.S3)
+	}
+	for _, stage := range cfg.FallbackChain {
+		s3Configs = append(s3Configs, stage.S3)
+	}
+	recovered := make(map[string]bool, len(s3Configs))
+	for _, s3Config := range s3Configs {
+		if s3Config == nil || recovered[s3Config.Bucket] {
+			continue
+		}
+		recovered[s3Config.Bucket] = true
+		uploader, err := e.withFailover(*s3Config, s3Uploader)
+		if err != nil {
+			return err
+		}
+		if _, err = loggers.RecoverOrphans(*s3Config, cfg.LoggingDir, uploader, e.Stats); err != nil {
+			return fmt.Errorf("error recovering orphaned files for %s: %v", s3Config.Bucket, err)
+		}
+	}
+
+	e.Loggers = requests.NewEdgeLoggers()
+	e.Loggers.Env = e.env
+	eventLogger, err := e.newS3Logger("event", cfg.EventsLogger, nil, sqsClient, s3Uploader)
+	if err != nil {
+		return err
+	}
+	e.Loggers.S3EventLogger = eventLogger
+	if cfg.EventsLogger != nil {
+		if e.Loggers.S3EventLogger, err = e.withBreaker("event", e.Loggers.S3EventLogger); err != nil {
+			return err
+		}
+	}
+
+	if cfg.UserAgentDictionary != nil {
+		dictionaryLogger, dictErr := e.newS3Logger("user agent dictionary", &cfg.UserAgentDictionary.S3,
+			loggers.MarshalUserAgent, sqsClient, s3Uploader)
+		if dictErr != nil {
+			return dictErr
+		}
+		e.Loggers.UserAgents, err = loggers.NewUserAgentDictionary(*cfg.UserAgentDictionary, dictionaryLogger, e.Stats, e.env)
+		if err != nil {
+			return fmt.Errorf("error creating user agent dictionary: %v", err)
+		}
+	}
+
+	if cfg.ParquetLogger != nil {
+		parquetLogger, parquetErr := loggers.NewParquetLogger(*cfg.ParquetLogger, cfg.LoggingDir, s3Uploader, e.Stats, e.env)
+		if parquetErr != nil {
+			return fmt.Errorf("error creating parquet logger: %v", parquetErr)
+		}
+		if cfg.EventsLogger == nil {
+			e.Loggers.S3EventLogger = parquetLogger
+		} else {
+			e.Loggers.S3EventLogger = loggers.NewTeeLogger(e.Loggers.S3EventLogger, parquetLogger)
+		}
+	}
+
+	var fallbackLogger loggers.SpadeEdgeLogger = loggers.UndefinedLogger{}
+	switch {
+	case cfg.Relay != nil:
+		if fallbackLogger, err = e.newFallbackLogger(sqsClient, s3Uploader); err != nil {
+			return err
+		}
+		relayLogger, relayErr := loggers.NewRelayLogger(*cfg.Relay, fallbackLogger, e.Stats, e.env)
+		if relayErr != nil {
+			return fmt.Errorf("error creating relay logger: %v", relayErr)
+		}
+		if buffered, ok := relayLogger.(loggers.BufferedLogger); ok && e.Status != nil {
+			e.Status.AddDepth("relay", buffered.Buffered)
+		}
+		if e.Loggers.KinesisEventLogger, err = e.withBreaker("relay", relayLogger); err != nil {
+			return err
+		}
+	case cfg.EventStream == nil:
+		logger.Warn("No kinesis logger specified")
+	default:
+		if fallbackLogger, err = e.newFallbackLogger(sqsClient, s3Uploader); err != nil {
+			return err
+		}
+		if cfg.FallbackNotification != nil {
+			notifier, notifyErr := notify.New(cfg.FallbackNotification.Targets, e.instanceID, sqsClient,
+				sns.New(e.session), e.Stats)
+			if notifyErr != nil {
+				return fmt.Errorf("error creating fallback notifier: %v", notifyErr)
+			}
+			fallbackLogger, err = loggers.NewFallbackNotifier(*cfg.FallbackNotification, fallbackLogger, notifier, e.Stats,
+				e.env)
+			if err != nil {
+				return fmt.Errorf("error creating fallback notifier: %v", err)
+			}
+		}
+		kinesisLogger, kinesisErr :=
+			loggers.NewKinesisLogger(kinesis.New(e.session), *cfg.EventStream, fallbackLogger, e.Stats, e.env)
+		if kinesisErr != nil {
+			return fmt.Errorf("error creating Kinesis logger: %v", kinesisErr)
+		}
+		if buffered, ok := kinesisLogger.(loggers.BufferedLogger); ok && e.Status != nil {
+			e.Status.AddDepth("kinesis", buffered.Buffered)
+		}
+		if acker, ok := kinesisLogger.(loggers.AckLogger); ok && cfg.SyncAcks {
+			e.Loggers.Acker = acker
+		}
+		if e.Loggers.KinesisEventLogger, err = e.withBreaker("kinesis", kinesisLogger); err != nil {
+			return err
+		}
+	}
+	if err = e.initTenantLoggers(fallbackLogger); err != nil {
+		return err
+	}
+
+	if cfg.Sequence != nil {
+		if e.Loggers.Sequencer, err = requests.NewSequencer(*cfg.Sequence, e.instanceID, e.Stats); err != nil {
+			return fmt.Errorf("error creating sequencer: %v", err)
+		}
+	}
+
+	if cfg.EventStream != nil && cfg.EventStream.FallbackMaxEventAge != "" {
+		// Replayed events get the same cutoff as those on their way to the
+		// fallback logger.
+		e.Loggers.MaxReplayAge, _ = time.ParseDuration(cfg.EventStream.FallbackMaxEventAge)
+	}
+	if cfg.WAL != nil {
+		walConfig, keyErr := e.fetchKey(*cfg.WAL)
+		if keyErr != nil {
+			return fmt.Errorf("error fetching write-ahead log key: %v", keyErr)
+		}
+		if e.Loggers.WAL, err = wal.Open(walConfig, e.Stats, "wal."); err != nil {
+			return fmt.Errorf("error opening write-ahead log: %v", err)
+		}
+	}
+
+	if cfg.AsyncLogging != nil {
+		e.Loggers.StartAsync(*cfg.AsyncLogging, e.Stats)
+		if e.Status != nil {
+			e.Status.AddDepth("async", e.Loggers.QueueDepth)
+		}
+	}
+	return nil
+}
+
+// newFallbackLogger creates the logger the Kinesis loggers fall back to: the
+// FallbackChain, or else the FallbackLogger.
+func (e *Edge) newFallbackLogger(sqs sqsiface.SQSAPI,
+	s3Uploader s3manageriface.UploaderAPI) (loggers.SpadeEdgeLogger, error) {
+	if len(e.cfg.FallbackChain) > 0 {
+		return e.newFallbackChain(sqs, s3Uploader)
+	}
+	fallbackLogger, err := e.newS3Logger("fallback", e.cfg.FallbackLogger, nil, sqs, s3Uploader)
+	if err != nil || e.cfg.FallbackLogger == nil {
+		return fallbackLogger, err
+	}
+	if fallbackLogger, err = e.withBreaker("fallback", fallbackLogger); err != nil {
+		return nil, err
+	}
+	if e.Status != nil {
+		fallbackLogger = e.Status.Wrap("fallback", fallbackLogger)
+	}
+	return fallbackLogger, nil
+}
+
+// newFallbackChain creates the FallbackChain's stages, last first, each
+// falling back to the next, and returns the first, handed the events the
+// Kinesis loggers fail to write. Each stage is guarded by the breaker and
+// retries configured under its name, so an open circuit sends events on to
+// the next stage at once.
+func (e *Edge) newFallbackChain(sqs sqsiface.SQSAPI,
+	s3Uploader s3manageriface.UploaderAPI) (loggers.SpadeEdgeLogger, error) {
+	chain := e.cfg.FallbackChain
+	var next loggers.SpadeEdgeLogger
+	for i := len(chain) - 1; i >= 0; i-- {
+		stage := chain[i]
+		var handoff loggers.SpadeEdgeLogger = loggers.UndefinedLogger{}
+		if next != nil {
+			handoff = loggers.NewHandoffLogger(stage.Name, chain[i+1].Name, next, e.Stats)
+		}
+
+		var l loggers.SpadeEdgeLogger
+		var err error
+		switch {
+		case stage.Kinesis != nil:
+			// Events the stream fails to write after retrying are handed
+			// off by the stream itself, which mustn't close the next stage.
+			l, err = loggers.NewKinesisLogger(kinesis.New(e.session), *stage.Kinesis, sharedLogger{handoff}, e.Stats,
+				e.env)
+			if err != nil {
+				err = fmt.Errorf("error creating Kinesis logger of fallback stage %s: %v", stage.Name, err)
+			}
+		case stage.S3 != nil:
+			l, err = e.newS3Logger(stage.Name, stage.S3, nil, sqs, s3Uploader)
+		case stage.Spool != nil:
+			spoolConfig, keyErr := e.fetchKey(*stage.Spool)
+			if keyErr != nil {
+				return nil, fmt.Errorf("error fetching key of fallback stage %s: %v", stage.Name, keyErr)
+			}
+			if e.spool, err = loggers.NewSpoolLogger(spoolConfig, e.Stats); err != nil {
+				err = fmt.Errorf("error opening spool of fallback stage %s: %v", stage.Name, err)
+			}
+			l = e.spool
+		}
+		if err != nil {
+			return nil, err
+		}
+		if l, err = e.withBreaker(stage.Name, l); err != nil {
+			return nil, err
+		}
+		if e.Status != nil {
+			l = e.Status.Wrap(stage.Name, l)
+		}
+		if next != nil {
+			l = loggers.NewFallbackLogger(stage.Name, l, handoff, e.Stats)
+		}
+		next = l
+	}
+	return loggers.NewHandoffLogger("kinesis", chain[0].Name, next, e.Stats), nil
+}
+
+// initTenantLoggers creates the event streams of the tenants with their own,
+// which fall back to the edge's own fallback logger.
+func (e *Edge) initTenantLoggers(fallbackLogger loggers.SpadeEdgeLogger) error {
+	if e.cfg.TLS == nil {
+		return nil
+	}
+	for name, tenant := range e.cfg.TLS.Tenants {
+		if tenant.EventStream == nil {
+			continue
+		}
+		kinesisLogger, err := loggers.NewKinesisLogger(kinesis.New(e.session), *tenant.EventStream,
+			sharedLogger{fallbackLogger}, e.Stats, e.env)
+		if err != nil {
+			return fmt.Errorf("error creating Kinesis logger of tenant %s: %v", name, err)
+		}
+		tenantLoggers := &requests.TenantLoggers{}
+		if acker, ok := kinesisLogger.(loggers.AckLogger); ok && e.cfg.SyncAcks {
+			tenantLoggers.Acker = acker
+		}
+		if tenantLoggers.KinesisEventLogger, err = e.withBreaker("kinesis", kinesisLogger); err != nil {
+			return err
+		}
+		if e.Loggers.Tenants == nil {
+			e.Loggers.Tenants = make(map[string]*requests.TenantLoggers)
+		}
+		e.Loggers.Tenants[name] = tenantLoggers
+	}
+	return nil
+}
+
+// sharedLogger is a logger shared with another logger that closes it.
+type sharedLogger struct {
+	loggers.SpadeEdgeLogger
+}
+
+func (sharedLogger) Close() {}
+
+func (l sharedLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	return loggers.LogDurably(l.SpadeEdgeLogger, e, serialized, done)
+}
+
+// newS3Logger creates an S3 logger for s3Config printing events with
+// printFunc, or as JSON if it's nil.
+func (e *Edge) newS3Logger(loggerType string,
+	s3Config *loggers.S3LoggerConfig,
+	printFunc loggers.EventToStringFunc,
+	sqs sqsiface.SQSAPI,
+	s3Uploader s3manageriface.UploaderAPI) (loggers.SpadeEdgeLogger, error) {
+	if s3Config == nil {
+		logger.Warnf("No %s logger specified", loggerType)
+		return loggers.UndefinedLogger{}, nil
+	}
+
+	s3Uploader, err := e.withFailover(*s3Config, s3Uploader)
+	if err != nil {
+		return nil, err
+	}
+	if e.cfg.FileHeaders {
+		// Orphans recovered from a previous run are uploaded without one,
+		// since this run's config may not be the one they were written with.
+		s3Uploader = loggers.NewHeaderUploader(s3Uploader, func() interface{} { return e.versionInfo() })
+	}
+	// A nil printFunc writes events as JSON, reusing the serialization
+	// shared by all the sinks.
+	s3Logger, err := loggers.NewS3Logger(*s3Config, e.cfg.LoggingDir, printFunc, sqs, s3Uploader, e.env)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s logger: %v", loggerType, err)
+	}
+	return s3Logger, nil
+}
+
+// newUploader returns an S3 uploader for region, or the session's region if
+// it's empty, that verifies uploads if the config asks for it.
+func (e *Edge) newUploader(region string) s3manageriface.UploaderAPI {
+	s3Client := s3.New(e.session)
+	if region != "" {
+		s3Client = s3.New(e.session, aws.NewConfig().WithRegion(region))
+	}
+	var uploader s3manageriface.UploaderAPI = s3manager.NewUploaderWithClient(s3Client)
+	if e.cfg.UploadIntegrity != nil {
+		s3Client.Handlers.Build.PushBack(loggers.SetContentMD5)
+		// The config was validated, so this can't fail.
+		uploader, _ = loggers.NewManifestUploader(uploader, *e.cfg.UploadIntegrity, e.Stats)
+	}
+	return uploader
+}
+
+// withFailover wraps the uploader of an S3 logger for s3Config to fail over
+// to its FailoverBuckets, if it has any.
+func (e *Edge) withFailover(s3Config loggers.S3LoggerConfig,
+	uploader s3manageriface.UploaderAPI) (s3manageriface.UploaderAPI, error) {
+	if len(s3Config.FailoverBuckets) == 0 {
+		return uploader, nil
+	}
+	failover, err := loggers.NewFailoverUploader(uploader, s3Config, e.newUploader, e.Stats, e.env)
+	if err != nil {
+		return nil, fmt.Errorf("error creating failover uploader for %s: %v", s3Config.Bucket, err)
+	}
+	return failover, nil
+}
+
+// fetchKey returns c with the key at its KeyLocation, if it has one, fetched
+// into its Key.
+func (e *Edge) fetchKey(c wal.Config) (wal.Config, error) {
+	if c.KeyLocation == "" {
+		return c, nil
+	}
+	b, err := config.Fetch(c.KeyLocation, e.session)
+	if err != nil {
+		return c, err
+	}
+	c.Key, c.KeyLocation = strings.TrimSpace(string(b)), ""
+	return c, c.Validate()
+}
+
+// withBreaker guards the logger with a circuit breaker if one is configured for loggerType.
+// Retries, if configured, happen inside the breaker.
+func (e *Edge) withBreaker(loggerType string, l loggers.SpadeEdgeLogger) (loggers.SpadeEdgeLogger, error) {
+	l, err := e.withRetry(loggerType, l)
+	if err != nil {
+		return nil, err
+	}
+	breakerConfig, ok := e.cfg.Breakers[loggerType]
+	if !ok || breakerConfig == nil {
+		return l, nil
+	}
+	b, err := breaker.New(loggerType, *breakerConfig, e.Stats)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s circuit breaker: %v", loggerType, err)
+	}
+	e.Breakers.Register(b)
+	return loggers.NewBreakerLogger(l, b), nil
+}
+
+// withRetry retries failed writes to the logger if retries are configured for loggerType.
+func (e *Edge) withRetry(loggerType string, l loggers.SpadeEdgeLogger) (loggers.SpadeEdgeLogger, error) {
+	retryConfig, ok := e.cfg.Retries[loggerType]
+	if !ok || retryConfig == nil {
+		return l, nil
+	}
+	r, err := retry.New(loggerType, *retryConfig, e.Stats)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s retrier: %v", loggerType, err)
+	}
+	return loggers.NewRetryLogger(l, r), nil
+}
+
+// initHandler configures e.Handler and what wraps it.
+func (e *Edge) initHandler() error {
+	cfg, handler := e.cfg, e.Handler
+	var err error
+	handler.Features = features.NewSet(cfg.Features)
+	handler.SetResponseHeaders(cfg.ResponseHeaders)
+	handler.SetCORSGroups(cfg.CorsGroups)
+	handler.SetRedirectHosts(cfg.RedirectHosts)
+	handler.Transformer, err = transform.New(cfg.Transforms)
+	if err != nil {
+		return fmt.Errorf("error creating transformer: %v", err)
+	}
+	if err = handler.Transformer.UpdateNames(cfg.EventNames); err != nil {
+		return fmt.Errorf("error configuring event name normalization: %v", err)
+	}
+	if err = loadStaticContent(handler, cfg, e.session); err != nil {
+		return fmt.Errorf("error loading static content: %v", err)
+	}
+	if cfg.JWTAuth != nil {
+		handler.Authenticator, err = auth.NewJWTVerifier(*cfg.JWTAuth)
+		if err != nil {
+			return fmt.Errorf("error creating JWT verifier: %v", err)
+		}
+	}
+	if cfg.HMACAuth != nil {
+		handler.SignatureVerifier, err = auth.NewHMACVerifier(*cfg.HMACAuth)
+		if err != nil {
+			return fmt.Errorf("error creating HMAC verifier: %v", err)
+		}
+	}
+	if cfg.RelayReceiver != nil {
+		handler.Relay, err = requests.NewRelayReceiver(*cfg.RelayReceiver)
+		if err != nil {
+			return fmt.Errorf("error creating relay receiver: %v", err)
+		}
+	}
+	if cfg.Abuse != nil {
+		handler.Abuse, err = abuse.NewTracker(*cfg.Abuse)
+		if err != nil {
+			return fmt.Errorf("error creating abuse tracker: %v", err)
+		}
+	}
+	if cfg.Reputation != nil {
+		handler.Reputation, err = reputation.New(*cfg.Reputation, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating reputation checker: %v", err)
+		}
+	}
+	if cfg.StatCardinality != nil {
+		handler.Dimensions, err = metrics.NewCardinalityLimiter(*cfg.StatCardinality)
+		if err != nil {
+			return fmt.Errorf("error creating stat cardinality limiter: %v", err)
+		}
+	}
+
+	handler.MaxDataValues = cfg.MaxDataValues
+	handler.MaxRequestBytes = cfg.MaxRequestBytes
+	handler.MaxURIBytes = cfg.MaxURIBytes
+	if cfg.TrackingPaths != nil {
+		handler.SetTrackingPaths(*cfg.TrackingPaths)
+	}
+	handler.SetPathLifecycles(cfg.PathLifecycles)
+	if cfg.ValidateEndpoint != nil {
+		handler.Handle(cfg.ValidateEndpoint.Path, handler.ServeValidate)
+	}
+	handler.StrictContentTypes = cfg.StrictContentTypes
+	if cfg.Multipart != nil {
+		handler.Multipart = *cfg.Multipart
+	}
+	if cfg.Aborts != nil {
+		handler.Aborts = *cfg.Aborts
+	}
+	if cfg.Fingerprint != nil {
+		handler.Fingerprinter, err = requests.NewFingerprinter(*cfg.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("error creating fingerprinter: %v", err)
+		}
+	}
+	if cfg.Capture != nil {
+		handler.Capture = *cfg.Capture
+	}
+	if cfg.TimeBuckets != nil {
+		handler.TimeBuckets = *cfg.TimeBuckets
+	}
+	if cfg.Pixel != nil {
+		handler.Pixel, err = requests.NewPixelPolicy(*cfg.Pixel)
+		if err != nil {
+			return fmt.Errorf("error creating pixel policy: %v", err)
+		}
+	}
+	if cfg.BadClient != nil {
+		handler.BadClient, err = requests.NewBadClientPolicy(*cfg.BadClient)
+		if err != nil {
+			return fmt.Errorf("error creating bad client policy: %v", err)
+		}
+	}
+	if cfg.ClockGuard != nil {
+		if handler.Clock, err = clockguard.New(*cfg.ClockGuard, e.Stats); err != nil {
+			return fmt.Errorf("error creating clock guard: %v", err)
+		}
+	}
+	if cfg.Naming != nil {
+		if handler.Naming, err = requests.NewNamingPolicy(*cfg.Naming); err != nil {
+			return fmt.Errorf("error creating naming policy: %v", err)
+		}
+	}
+	if e.rollup != nil {
+		handler.Rollup = e.rollup
+	}
+	if cfg.VolumeAnomalies != nil {
+		handler.Volume, err = anomaly.New(*cfg.VolumeAnomalies, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating volume anomaly detector: %v", err)
+		}
+	}
+	if cfg.TopK != nil {
+		e.TopK, err = topk.New(*cfg.TopK)
+		if err != nil {
+			return fmt.Errorf("error creating top-K tracker: %v", err)
+		}
+		handler.TopK = e.TopK
+	}
+	if cfg.OriginLearning != nil {
+		e.OriginLearner, err = requests.NewOriginLearner(*cfg.OriginLearning)
+		if err != nil {
+			return fmt.Errorf("error creating origin learner: %v", err)
+		}
+		handler.OriginLearner = e.OriginLearner
+	}
+	if cfg.RunRate != nil {
+		e.RunRate, err = runrate.Open(*cfg.RunRate)
+		if err != nil {
+			return fmt.Errorf("error opening run-rate store: %v", err)
+		}
+		handler.RunRate = e.RunRate
+	}
+	if cfg.EventTap != nil {
+		e.EventTap, err = eventtap.New(*cfg.EventTap)
+		if err != nil {
+			return fmt.Errorf("error creating event tap: %v", err)
+		}
+		handler.EventTap = e.EventTap
+	}
+	if cfg.Enrichment != nil {
+		handler.Enricher, err = enrich.New(*cfg.Enrichment, func(source string) ([]byte, error) {
+			return config.Fetch(source, e.session)
+		}, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error loading enrichment table: %v", err)
+		}
+	}
+	if cfg.KillSwitch != nil {
+		e.KillSwitch, err = killswitch.New(*cfg.KillSwitch, func(source string) ([]byte, error) {
+			return config.Fetch(source, e.session)
+		}, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error loading kill switch: %v", err)
+		}
+		handler.KillSwitch = e.KillSwitch
+	}
+	if cfg.Accounting != nil {
+		var uploader s3manageriface.UploaderAPI
+		var putter accounting.RecordPutter
+		if cfg.Accounting.Bucket != "" {
+			uploader = e.newUploader("")
+		} else {
+			putter = kinesis.New(e.session)
+		}
+		handler.Accounting, err = accounting.New(*cfg.Accounting, e.instanceID, uploader, putter, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating accounting: %v", err)
+		}
+	}
+	if cfg.Heartbeat != nil {
+		handler.Heartbeat, err = requests.NewHeartbeater(*cfg.Heartbeat, e.instanceID, e.version, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating heartbeats: %v", err)
+		}
+	}
+
+	if e.configLocation != "" && cfg.ConfigRefreshInterval != "" {
+		e.watcher = newConfigWatcher(e.configLocation, e.session, handler, e.Stats, *cfg, handler.EdgeType,
+			e.setSnapshot)
+		e.watcher.alerter = e.alerter
+	}
+
+	if cfg.Chaos != nil {
+		e.Chaos, err = chaos.New(*cfg.Chaos, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating fault injector: %v", err)
+		}
+		e.Loggers.S3EventLogger = e.Chaos.Wrap("event", e.Loggers.S3EventLogger)
+		e.Loggers.KinesisEventLogger = e.Chaos.Wrap("kinesis", e.Loggers.KinesisEventLogger)
+		if err = e.Chaos.CheckSoak(); err != nil {
+			return fmt.Errorf("error creating fault injector: %v", err)
+		}
+	}
+
+	if cfg.Canary != nil {
+		// The sinks are wrapped before the edge is served, so no events are
+		// being logged yet.
+		e.canary, err = canary.New(*cfg.Canary, handler, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating canary: %v", err)
+		}
+		if cfg.EventsLogger != nil {
+			e.Loggers.S3EventLogger = e.canary.Wrap("event", e.Loggers.S3EventLogger)
+		}
+		if cfg.EventStream != nil {
+			e.Loggers.KinesisEventLogger = e.canary.Wrap("kinesis", e.Loggers.KinesisEventLogger)
+		}
+	}
+	if e.Status != nil {
+		if cfg.EventsLogger != nil || cfg.ParquetLogger != nil {
+			e.Loggers.S3EventLogger = e.Status.Wrap("event", e.Loggers.S3EventLogger)
+		}
+		if cfg.EventStream != nil {
+			e.Loggers.KinesisEventLogger = e.Status.Wrap("kinesis", e.Loggers.KinesisEventLogger)
+		}
+		if cfg.Relay != nil {
+			e.Loggers.KinesisEventLogger = e.Status.Wrap("relay", e.Loggers.KinesisEventLogger)
+		}
+	}
+
+	e.httpHandler = handler
+	if cfg.Watchdog != nil {
+		var uploader s3manageriface.UploaderAPI
+		if cfg.Watchdog.Bucket != "" {
+			uploader = e.newUploader("")
+		}
+		e.watchdog, err = watchdog.New(*cfg.Watchdog, uploader, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating profiling watchdog: %v", err)
+		}
+		e.httpHandler = e.watchdog.Handler(e.httpHandler)
+	}
+	if cfg.Admission != nil {
+		controller, admissionErr := admission.New(*cfg.Admission, e.Stats)
+		if admissionErr != nil {
+			return fmt.Errorf("error creating admission controller: %v", admissionErr)
+		}
+		e.httpHandler = controller.Handler(e.httpHandler)
+	}
+	if e.alerter != nil {
+		e.httpHandler = recoverPanics(e.httpHandler, e.alerter, e.Stats)
+	}
+	if cfg.ConnLimits != nil {
+		e.connLimiter, err = connlimit.New(*cfg.ConnLimits, e.Stats)
+		if err != nil {
+			return fmt.Errorf("error creating connection limiter: %v", err)
+		}
+	}
+	if cfg.TLS != nil {
+		if err = e.initTLS(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initTLS loads the edge's and tenants' certificates and routes the requests
+// sent to tenants' hosts to the tenants. Hosts without a certificate file get
+// theirs from the ACME CA if one is configured.
+func (e *Edge) initTLS() error {
+	e.tlsConfig = &tls.Config{NextProtos: []string{"http/1.1"}}
+	if e.cfg.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(e.cfg.TLS.CertFile, e.cfg.TLS.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error loading TLS certificate: %v", err)
+		}
+		e.tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(e.cfg.TLS.Tenants) > 0 {
+		e.Handler.TenantHosts = e.cfg.TLS.TenantHosts()
+	}
+	certs := make(map[string]*tls.Certificate)
+	for name, tenant := range e.cfg.TLS.Tenants {
+		if tenant.CertFile == "" {
+			continue
+		}
+		cert, err := tls.LoadX509KeyPair(tenant.CertFile, tenant.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error loading TLS certificate of tenant %s: %v", name, err)
+		}
+		for _, host := range tenant.Hosts {
+			certs[strings.ToLower(host)] = &cert
+		}
+	}
+	if c := e.cfg.TLS.ACME; c != nil {
+		var cache acme.Cache = acme.DirCache(c.CacheDir)
+		if c.CacheBucket != "" {
+			cache = &acme.S3Cache{Client: s3.New(e.session), Bucket: c.CacheBucket, Prefix: c.CachePrefix}
+		}
+		acmeConfig := *c
+		acmeConfig.Hosts = e.cfg.TLS.ACMEHosts()
+		var err error
+		if e.acme, err = acme.New(acmeConfig, cache, e.Stats); err != nil {
+			return fmt.Errorf("error creating ACME manager: %v", err)
+		}
+		if c.Challenge == acme.ChallengeTLSALPN {
+			e.tlsConfig.NextProtos = append(e.tlsConfig.NextProtos, acme.ALPNProto)
+		}
+	}
+	// Server names without a certificate of their own get the edge's.
+	e.tlsConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if cert := certs[strings.ToLower(hello.ServerName)]; cert != nil {
+			return cert, nil
+		}
+		if e.acme != nil {
+			return e.acme.GetCertificate(hello)
+		}
+		return nil, nil
+	}
+	return nil
+}
+
+// HTTPHandler returns the handler to serve the edge with: the SpadeHandler,
+// timed by the watchdog, behind admission control and with its panics alerted
+// on if they're configured.
+func (e *Edge) HTTPHandler() http.Handler {
+	return e.httpHandler
+}
+
+// Start starts the edge's background work: replaying the write-ahead log and
+// the spool, rolling up events, logging heartbeats, sending canary events,
+// watching event volume and latency, flushing accounting records, reloading
+// the enrichment table and kill switch, checking clock skew, reporting
+// collector stats, polling for config changes, renewing ACME certificates,
+// advising on stream throughput, checking alert conditions and writing
+// run-rate counts. Serve starts it, so it only needs to be called when
+// serving HTTPHandler some other way.
+func (e *Edge) Start() {
+	e.startOnce.Do(func() {
+		if e.Loggers.WAL != nil {
+			logger.Go(e.replayWAL)
+		}
+		if e.spool != nil {
+			logger.Go(e.replaySpool)
+		}
+		if e.rollup != nil {
+			logger.Go(func() { e.rollup.Run(e.Handler.LogSummary) })
+		}
+		if e.Handler.Heartbeat != nil {
+			logger.Go(func() { e.Handler.Heartbeat.Run(e.Handler.LogSummary) })
+		}
+		if e.canary != nil {
+			logger.Go(e.canary.Run)
+		}
+		if e.Handler.Volume != nil {
+			logger.Go(e.Handler.Volume.Run)
+		}
+		if e.Handler.Accounting != nil {
+			logger.Go(e.Handler.Accounting.Run)
+		}
+		if e.Handler.Enricher != nil {
+			logger.Go(e.Handler.Enricher.Run)
+		}
+		if e.KillSwitch != nil {
+			logger.Go(e.KillSwitch.Run)
+		}
+		if e.Handler.Clock != nil {
+			logger.Go(e.Handler.Clock.Run)
+		}
+		if e.gc != nil {
+			logger.Go(e.gc.Run)
+		}
+		if e.watchdog != nil {
+			logger.Go(e.watchdog.Run)
+		}
+		if e.watcher != nil {
+			interval, _ := time.ParseDuration(e.cfg.ConfigRefreshInterval)
+			logger.Go(func() { e.watcher.run(interval) })
+		}
+		if e.acme != nil {
+			logger.Go(e.acme.Run)
+		}
+		if e.advisor != nil {
+			logger.Go(e.advisor.Run)
+		}
+		if e.alerter != nil {
+			logger.Go(e.alerter.Run)
+		}
+		if e.RunRate != nil {
+			logger.Go(e.RunRate.Run)
+		}
+	})
+}
+
+func (e *Edge) replayWAL() {
+	replayed, expired, err := e.Loggers.ReplayWAL()
+	_ = e.Stats.Inc("wal.replayed", int64(replayed), 1)
+	_ = e.Stats.Inc("wal.replay.expired", int64(expired), 1)
+	if err != nil {
+		logger.WithError(err).WithField("replayed", replayed).Error("Failed to replay write-ahead log")
+		return
+	}
+	if replayed > 0 {
+		logger.WithField("replayed", replayed).Info("Replayed write-ahead log")
+	}
+}
+
+func (e *Edge) replaySpool() {
+	replayed, expired, err := e.Loggers.ReplaySpool(e.spool)
+	_ = e.Stats.Inc("logger.spool.replayed", int64(replayed), 1)
+	_ = e.Stats.Inc("logger.spool.replay.expired", int64(expired), 1)
+	if err != nil {
+		logger.WithError(err).WithField("replayed", replayed).Error("Failed to replay spool")
+		return
+	}
+	if replayed > 0 {
+		logger.WithField("replayed", replayed).Info("Replayed spool")
+	}
+}
+
+// ListenChallenges listens on the HTTPAddress ACME http-01 challenges are
+// answered on, if they're configured, returning nil otherwise. Like Listen,
+// it must be called before privileges are dropped.
+func (e *Edge) ListenChallenges() (net.Listener, error) {
+	if e.acme == nil || e.cfg.TLS.ACME.Challenge != acme.ChallengeHTTP {
+		return nil, nil
+	}
+	addr := e.cfg.TLS.ACME.HTTPAddress
+	return listen(config.ListenNetwork(addr), addr)
+}
+
+// Serve starts the edge and serves it on each of listeners, accepting at most
+// MaxConnections at once on each, until one of them fails. Connections are
+// counted per listener under listener.<address>, and PROXY protocol modes are
+// looked up by each listener's address. ACME http-01 challenges are answered
+// on challenges, from ListenChallenges; Serve doesn't listen on anything
+// itself.
+func (e *Edge) Serve(challenges net.Listener, listeners ...net.Listener) error {
+	addrs := make([]string, len(listeners))
+	for i, l := range listeners {
+		addrs[i] = l.Addr().String()
+	}
+	return e.serve(challenges, listeners, addrs)
+}
+
+// serve serves the edge on listeners, which listen on the configured
+// addresses addrs, and ACME http-01 challenges on challenges.
+func (e *Edge) serve(challenges net.Listener, listeners []net.Listener, addrs []string) error {
+	if len(listeners) == 0 {
+		return errors.New("no listeners to serve")
+	}
+	httpChallenges := e.acme != nil && e.cfg.TLS.ACME.Challenge == acme.ChallengeHTTP
+	if httpChallenges && challenges == nil {
+		return errors.New("no listener for ACME http-01 challenges")
+	}
+	e.Start()
+	server := &http.Server{
+		Handler:        e.httpHandler,
+		ReadTimeout:    15 * time.Second,
+		WriteTimeout:   20 * time.Second,
+		MaxHeaderBytes: 1 << 20, // 1MB
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return clienthello.ConnContext(proxyproto.ConnContext(ctx, c), c)
+		},
+	}
+	conns := newConnStats(e.Stats)
+	server.ConnState = conns.ConnState
+	if e.connLimiter != nil {
+		server.ConnState = func(c net.Conn, state http.ConnState) {
+			conns.ConnState(c, state)
+			e.connLimiter.ConnState(c, state)
+		}
+	}
+	errs := make(chan error, len(listeners)+1)
+	if httpChallenges {
+		challengeServer := &http.Server{
+			Handler:      e.acme.HTTPHandler(),
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 20 * time.Second,
+		}
+		logger.Go(func() { errs <- challengeServer.Serve(challenges) })
+	}
+	for i, l := range listeners {
+		// The connections of the limit listener don't expose the ones they
+		// wrap, so it goes beneath those the ConnState hooks look for.
+		wrapped := netutil.LimitListener(newStatsListener(l, e.Stats), MaxConnections)
+		if pp := e.cfg.ProxyProtocol; pp != nil {
+			wrapped = proxyproto.Listener(wrapped, pp.ModeFor(addrs[i]), pp.Timeout(), e.Stats)
+		}
+		if e.connLimiter != nil {
+			wrapped = e.connLimiter.Listener(wrapped)
+		}
+		if e.tlsConfig != nil {
+			wrapped = tls.NewListener(clienthello.Listener(wrapped), e.tlsConfig)
+		}
+		logger.Go(func() { errs <- server.Serve(wrapped) })
+	}
+	return <-errs
+}
+
+// ListenAndServe serves the edge on the configured addresses.
+func (e *Edge) ListenAndServe() error {
+	addrs := e.cfg.Addresses()
+	listeners, err := Listen(addrs)
+	if err != nil {
+		return err
+	}
+	challenges, err := e.ListenChallenges()
+	if err != nil {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+		return err
+	}
+	return e.serve(challenges, listeners, addrs)
+}
+
+// Close stops the background work, flushes and closes the loggers and closes
+// the statter. The edge must no longer be serving requests.
+func (e *Edge) Close() {
+	e.closeOnce.Do(func() {
+		if e.watcher != nil {
+			e.watcher.close()
+		}
+		if e.acme != nil {
+			e.acme.Close()
+		}
+		if e.advisor != nil {
+			e.advisor.Close()
+		}
+		if e.alerter != nil {
+			e.alerter.Close()
+		}
+		if e.canary != nil {
+			e.canary.Close()
+		}
+		if e.Handler.Volume != nil {
+			e.Handler.Volume.Close()
+		}
+		if e.Handler.Enricher != nil {
+			e.Handler.Enricher.Close()
+		}
+		if e.KillSwitch != nil {
+			e.KillSwitch.Close()
+		}
+		if e.Handler.Clock != nil {
+			e.Handler.Clock.Close()
+		}
+		if e.Handler.Accounting != nil {
+			// Write the last window's records.
+			e.Handler.Accounting.Close()
+		}
+		if e.watchdog != nil {
+			e.watchdog.Close()
+		}
+		if e.rollup != nil {
+			// Emit the last window's summaries while the loggers are open.
+			e.rollup.Close()
+		}
+		if e.Handler.Heartbeat != nil {
+			e.Handler.Heartbeat.Close()
+		}
+		e.Loggers.Close()
+		if e.RunRate != nil {
+			if err := e.RunRate.Close(); err != nil {
+				logger.WithError(err).Error("Error writing run-rate counts")
+			}
+		}
+		if e.gc != nil {
+			e.gc.Close()
+		}
+		if err := e.Stats.Close(); err != nil {
+			logger.WithError(err).Error("Error closing statsd client")
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package edge
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/acme"
+	"github.com/twitchscience/spade_edge/alerts"
+	"github.com/twitchscience/spade_edge/config"
+	"github.com/twitchscience/spade_edge/notify"
+	"github.com/twitchscience/spade_edge/requests"
+)
+
+func newTestEdge(t *testing.T, edgeType string) (*Edge, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err)
+	}
+	return New(Options{
+		Config:     &config.Config{Port: ":8888"},
+		Session:    sess,
+		EdgeType:   edgeType,
+		InstanceID: "i-test",
+	})
+}
+
+func TestNew(t *testing.T) {
+	if _, err := newTestEdge(t, "neither"); err == nil {
+		t.Error("Expected an invalid edge type to be rejected")
+	}
+
+	e, err := newTestEdge(t, spade.INTERNAL_EDGE)
+	if err != nil {
+		t.Fatalf("Failed to create edge: %s", err)
+	}
+	defer e.Close()
+	e.Handler.Handle("/custom", func(w http.ResponseWriter, r *http.Request, context *requests.RequestContext) int {
+		w.WriteHeader(http.StatusAccepted)
+		return http.StatusAccepted
+	})
+	for path, code := range map[string]int{
+		"/healthcheck": http.StatusOK,
+		"/custom":      http.StatusAccepted,
+	} {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv"+path, nil)
+		e.HTTPHandler().ServeHTTP(recorder, req)
+		if recorder.Code != code {
+			t.Errorf("%s: expected %d, got %d", path, code, recorder.Code)
+		}
+	}
+}
+
+func TestServeVersion(t *testing.T) {
+	e, err := newTestEdge(t, spade.INTERNAL_EDGE)
+	if err != nil {
+		t.Fatalf("Failed to create edge: %s", err)
+	}
+	defer e.Close()
+	recorder := httptest.NewRecorder()
+	e.ServeVersion(recorder, httptest.NewRequest("GET", "/version", nil))
+	var info map[string]interface{}
+	if err = json.Unmarshal(recorder.Body.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to unmarshal version: %s", err)
+	}
+	if info["configHash"] != e.cfg.Snapshot().Hash || info["instanceId"] != "i-test" || info["config"] != nil {
+		t.Errorf("Expected the instance and the config hash, got %v", info)
+	}
+}
+
+func TestServeListeners(t *testing.T) {
+	e, err := newTestEdge(t, spade.INTERNAL_EDGE)
+	if err != nil {
+		t.Fatalf("Failed to create edge: %s", err)
+	}
+	defer e.Close()
+	addrs := []string{"127.0.0.1:0"}
+	if l, listenErr := net.Listen("tcp6", "[::1]:0"); listenErr == nil {
+		_ = l.Close()
+		addrs = append(addrs, "[::1]:0")
+	}
+	listeners, err := Listen(addrs)
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	go func() { _ = e.Serve(nil, listeners...) }()
+	defer func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}()
+
+	for _, l := range listeners {
+		resp, getErr := http.Get("http://" + l.Addr().String() + "/healthcheck")
+		if getErr != nil {
+			t.Errorf("Failed to request %s: %s", l.Addr(), getErr)
+			continue
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected 200 from %s, got %d", l.Addr(), resp.StatusCode)
+		}
+	}
+}
+
+func TestServeACMEChallenges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	sess, err := session.NewSession()
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err)
+	}
+	e, err := New(Options{
+		Config: &config.Config{Port: ":8888", TLS: &config.TLSConfig{ACME: &acme.Config{
+			Hosts:        []string{"edge.example.com"},
+			AcceptTOS:    true,
+			DirectoryURL: "http://127.0.0.1:1/directory",
+			Challenge:    acme.ChallengeHTTP,
+			HTTPAddress:  "127.0.0.1:0",
+			CacheDir:     dir,
+		}}},
+		Session:    sess,
+		EdgeType:   spade.INTERNAL_EDGE,
+		InstanceID: "i-test",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create edge: %s", err)
+	}
+	defer e.Close()
+	challenges, err := e.ListenChallenges()
+	if err != nil || challenges == nil {
+		t.Fatalf("Failed to listen for challenges: %v", err)
+	}
+	defer func() { _ = challenges.Close() }()
+	listeners, err := Listen([]string{"127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	defer func() { _ = listeners[0].Close() }()
+
+	// Serve binds nothing of its own, since it runs once privileges are
+	// dropped.
+	listen = func(network, addr string) (net.Listener, error) {
+		t.Errorf("Unexpected listen on %s", addr)
+		return nil, errors.New("unexpected listen")
+	}
+	defer func() { listen = net.Listen }()
+	if err = e.Serve(nil, listeners...); err == nil {
+		t.Error("Expected serving ACME http-01 challenges without a listener to fail")
+	}
+	go func() { _ = e.Serve(challenges, listeners...) }()
+
+	resp, err := http.Get("http://" + challenges.Addr().String() + "/.well-known/acme-challenge/unknown")
+	if err != nil {
+		t.Fatalf("Failed to request a challenge: %s", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a 404 for an unknown challenge, got %d", resp.StatusCode)
+	}
+}
+
+func TestConnStats(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ConnState = newConnStats(statter).ConnState
+	server.StartTLS()
+
+	client := server.Client()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Failed to request %s: %s", server.URL, err)
+		}
+		_ = resp.Body.Close()
+	}
+	server.Close()
+
+	sent := rs.GetSent()
+	for _, stat := range []string{"conn.new", "conn.request.new", "conn.request.reused", "conn.tls.handshake.tls1_3"} {
+		if got := sent.CollectNamed(stat); len(got) != 1 || got[0].Value != "1" {
+			t.Errorf("Expected %s to be counted once, got %v", stat, got)
+		}
+	}
+	if got := sent.CollectNamed("conn.requests"); len(got) != 1 || got[0].Value != "2" {
+		t.Errorf("Expected a connection with 2 requests, got %v", got)
+	}
+	if got := sent.CollectNamed("conn.duration"); len(got) != 1 {
+		t.Errorf("Expected a connection duration, got %v", got)
+	}
+	if got := sent.CollectNamed("conn.open"); len(got) == 0 || got[len(got)-1].Value != "0" {
+		t.Errorf("Expected no connections left open, got %v", got)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	alerter, err := alerts.New(alerts.Config{Webhooks: []*notify.WebhookConfig{{URL: "http://localhost"}}}, "i-test", stats)
+	if err != nil {
+		t.Fatalf("Failed to create alerter: %s", err)
+	}
+	handler := recoverPanics(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}), alerter, stats)
+	func() {
+		defer func() {
+			if rec := recover(); rec != http.ErrAbortHandler {
+				t.Errorf("Expected the response to be aborted, got %v", rec)
+			}
+		}()
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+	}()
+	if got := len(sender.GetSent().CollectNamed("requests.panics")); got != 1 {
+		t.Errorf("Expected the panic to be counted, got %d", got)
+	}
+}
+
+func TestConfigWatcherApply(t *testing.T) {
+	e, err := newTestEdge(t, spade.INTERNAL_EDGE)
+	if err != nil {
+		t.Fatalf("Failed to create edge: %s", err)
+	}
+	defer e.Close()
+	dir, err := ioutil.TempDir("", "config_watcher")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	location := dir + "/config.json"
+	write := func(contents string) {
+		if err = ioutil.WriteFile(location, []byte(contents), 0644); err != nil {
+			t.Fatalf("Failed to write config: %s", err)
+		}
+	}
+	current, err := config.Parse(location, []byte(`{"Port": ":8888"}`))
+	if err == nil {
+		err = current.Validate()
+	}
+	if err != nil {
+		t.Fatalf("Failed to parse config: %s", err)
+	}
+
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	applied := 0
+	w := newConfigWatcher(location, nil, e.Handler, statter, *current, spade.INTERNAL_EDGE,
+		func(*config.Config) { applied++ })
+	allowed := func(origin string) bool {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("OPTIONS", "http://spade.twitch.tv/track", nil)
+		req.Header.Set("Origin", origin)
+		e.HTTPHandler().ServeHTTP(recorder, req)
+		return recorder.Header().Get("Access-Control-Allow-Origin") == origin
+	}
+	counted := func(stat string) int {
+		return len(rs.GetSent().CollectNamed(stat))
+	}
+
+	// A config with no changes applies nothing.
+	write(`{"Port": ":8888"}`)
+	w.refresh()
+	for _, stat := range rs.GetSent() {
+		t.Errorf("Expected no stats for an unchanged config, got %s", stat.Stat)
+	}
+	if applied != 0 || allowed("https://www.twitch.tv") {
+		t.Errorf("Expected the unchanged config to leave the handler alone, applied %d", applied)
+	}
+
+	// A changed config applies its hot-reloadable fields and asks for a
+	// restart for the others.
+	write(`{"Port": ":9999", "CorsOrigins": ["https://*.twitch.tv"]}`)
+	w.refresh()
+	if !allowed("https://www.twitch.tv") || allowed("https://evil.example.com") {
+		t.Error("Expected the new CorsOrigins to be set on the handler")
+	}
+	if applied != 1 || counted("config.refresh.applied.CorsOrigins") != 1 ||
+		counted("config.refresh.restart_required") != 1 {
+		t.Errorf("Expected the CorsOrigins change and a restart to be counted, got %v", rs.GetSent())
+	}
+	if len(w.current.CorsOrigins) != 1 || w.current.Port != ":8888" {
+		t.Errorf("Expected only the hot-reloadable fields to be applied, got %+v", w.current)
+	}
+
+	// The same config fetched again isn't even parsed.
+	sent := len(rs.GetSent())
+	w.refresh()
+	if applied != 1 || len(rs.GetSent()) != sent {
+		t.Errorf("Expected the same config to be skipped, applied %d, got %v", applied, rs.GetSent())
+	}
+
+	// An invalid config is ignored.
+	write(`{"Port": ":9999", "CorsOrigins": ["https://[www"]}`)
+	w.refresh()
+	if counted("config.refresh.invalid") != 1 || counted("config.refresh.applied.CorsOrigins") != 1 {
+		t.Errorf("Expected the invalid config to be counted and not applied, got %v", rs.GetSent())
+	}
+	if applied != 1 || !allowed("https://www.twitch.tv") || w.current.CorsOrigins[0] != "https://*.twitch.tv" {
+		t.Error("Expected the invalid config to leave the handler alone")
+	}
+}
@@ -0,0 +1,81 @@
+package edge
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/spade_edge/config"
+)
+
+// listen is net.Listen, replaced by tests.
+var listen = net.Listen
+
+// Listen listens on each of addrs, IPv4 and IPv6 addresses only over their
+// own family, closing those it listened on if any of them fails.
+func Listen(addrs []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addrs))
+	for _, addr := range addrs {
+		l, err := listen(config.ListenNetwork(addr), addr)
+		if err != nil {
+			for _, opened := range listeners {
+				_ = opened.Close()
+			}
+			return nil, err
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
+
+var statNameReplacer = strings.NewReplacer(".", "_", ":", "_", "[", "", "]", "")
+
+// statsListener counts the connections accepted on a listener and gauges
+// those open under listener.<address>.
+type statsListener struct {
+	net.Listener
+	prefix string
+	stats  statsd.StatSender
+
+	mu   sync.Mutex
+	open int64
+}
+
+func newStatsListener(l net.Listener, stats statsd.StatSender) *statsListener {
+	return &statsListener{
+		Listener: l,
+		prefix:   "listener." + statNameReplacer.Replace(l.Addr().String()) + ".",
+		stats:    stats,
+	}
+}
+
+func (l *statsListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		_ = l.stats.Inc(l.prefix+"accept_error", 1, 1)
+		return nil, err
+	}
+	_ = l.stats.Inc(l.prefix+"accepted", 1, 0.1)
+	l.addOpen(1)
+	return &statsConn{Conn: c, listener: l}, nil
+}
+
+func (l *statsListener) addOpen(n int64) {
+	l.mu.Lock()
+	l.open += n
+	open := l.open
+	l.mu.Unlock()
+	_ = l.stats.Gauge(l.prefix+"open", open, 0.1)
+}
+
+type statsConn struct {
+	net.Conn
+	listener  *statsListener
+	closeOnce sync.Once
+}
+
+func (c *statsConn) Close() error {
+	c.closeOnce.Do(func() { c.listener.addOpen(-1) })
+	return c.Conn.Close()
+}
@@ -0,0 +1,35 @@
+package edge
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/alerts"
+)
+
+// recoverPanics logs and counts the panics of the requests next serves, under
+// requests.panics, and tells alerter of them. It then panics again with
+// http.ErrAbortHandler, so the server still aborts the response but doesn't
+// log the panic twice.
+func recoverPanics(next http.Handler, alerter *alerts.Alerter, stats statsd.StatSender) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			logger.WithField("path", r.URL.Path).WithField("stack", string(debug.Stack())).
+				Errorf("Recovered panic serving request: %v", rec)
+			_ = stats.Inc("requests.panics", 1, 1)
+			alerter.Fire(alerts.PanicRecovered, "requests", fmt.Sprintf("Panic serving %s: %v", r.URL.Path, rec))
+			panic(http.ErrAbortHandler)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,50 @@
+package edge
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/twitchscience/spade_edge/config"
+)
+
+// versionInfo identifies the running edge and its live config.
+type versionInfo struct {
+	Version    string `json:"version"`
+	EdgeType   string `json:"edgeType"`
+	InstanceID string `json:"instanceId"`
+	ConfigHash string `json:"configHash"`
+
+	// Config holds the snapshot's fields in log file headers.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// setSnapshot records c as the live config.
+func (e *Edge) setSnapshot(c *config.Config) {
+	e.snapshot.Store(c.Snapshot())
+}
+
+func (e *Edge) versionInfo() *versionInfo {
+	snapshot := e.snapshot.Load().(*config.Snapshot)
+	return &versionInfo{
+		Version:    e.version,
+		EdgeType:   e.edgeType,
+		InstanceID: e.instanceID,
+		ConfigHash: snapshot.Hash,
+		Config:     snapshot.Fields,
+	}
+}
+
+// ServeVersion serves the edge's version and the hash of its live config as
+// JSON. It should be served on a debug port as /version.
+func (e *Edge) ServeVersion(w http.ResponseWriter, r *http.Request) {
+	info := e.versionInfo()
+	info.Config = nil
+	b, err := json.Marshal(info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,420 @@
+/*
+Package emf sends stats to CloudWatch, for deployments without statsd.
+
+Counters, gauges and timers are aggregated in memory and flushed on an
+interval, either as CloudWatch Embedded Metric Format (EMF) log lines written
+to stdout, which the CloudWatch agent turns into metrics, or with the
+PutMetricData API. Stats can be sent to CloudWatch alongside statsd or instead
+of it.
+
+EMF documents can't describe a timer's statistics, so each timer is written
+as a sample of at most maxSamples of the interval's timings. PutMetricData is
+sent the exact minimum, maximum, sum and count instead.
+*/
+package emf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	// OutputLog writes EMF log lines to stdout.
+	OutputLog = "log"
+	// OutputAPI calls PutMetricData.
+	OutputAPI = "api"
+
+	defaultFlushInterval        = "1m"
+	defaultMaxRequestsPerSecond = 10
+
+	// maxSamples bounds the values of a timer in an EMF document, and
+	// maxDocumentMetrics the metrics in one; both are CloudWatch limits.
+	maxSamples         = 100
+	maxDocumentMetrics = 100
+	// maxRequestMetrics is the most metrics PutMetricData accepts at once.
+	maxRequestMetrics = 20
+)
+
+// Config configures sending stats to CloudWatch.
+type Config struct {
+	// Namespace is the CloudWatch namespace of the metrics
+	Namespace string
+
+	// Output is OutputLog, the default, or OutputAPI
+	Output string
+
+	// Dimensions are added to every metric, e.g. {"Service": "spade-edge"}
+	Dimensions map[string]string
+
+	// Stats are the prefixes of the stats sent to CloudWatch. If empty,
+	// every stat is sent.
+	Stats []string
+
+	// FlushInterval is how often metrics are sent. It defaults to "1m".
+	FlushInterval string
+
+	// MaxRequestsPerSecond limits PutMetricData calls. It defaults to 10.
+	MaxRequestsPerSecond float64
+
+	// ReplaceStatsd sends stats only to CloudWatch.
+	ReplaceStatsd bool
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Namespace == "" {
+		return errors.New("Namespace is required")
+	}
+	switch c.Output {
+	case "":
+		c.Output = OutputLog
+	case OutputLog, OutputAPI:
+	default:
+		return fmt.Errorf("Output must be %q or %q, not %q", OutputLog, OutputAPI, c.Output)
+	}
+	if c.FlushInterval == "" {
+		c.FlushInterval = defaultFlushInterval
+	}
+	interval, err := time.ParseDuration(c.FlushInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FlushInterval, err)
+	}
+	if interval < time.Second {
+		return errors.New("FlushInterval must be at least 1s")
+	}
+	if c.MaxRequestsPerSecond == 0 {
+		c.MaxRequestsPerSecond = defaultMaxRequestsPerSecond
+	}
+	if c.MaxRequestsPerSecond < 0 {
+		return errors.New("MaxRequestsPerSecond must be greater than 0")
+	}
+	return nil
+}
+
+// timer holds the timings of a stat in the current interval.
+type timer struct {
+	min, max, sum float64
+	count         int64
+	samples       []float64
+}
+
+func (t *timer) add(v float64) {
+	if t.count == 0 || v < t.min {
+		t.min = v
+	}
+	if t.count == 0 || v > t.max {
+		t.max = v
+	}
+	t.sum += v
+	t.count++
+	// Reservoir sampling keeps each timing with the same probability.
+	if len(t.samples) < maxSamples {
+		t.samples = append(t.samples, v)
+	} else if i := rand.Int63n(t.count); i < maxSamples {
+		t.samples[i] = v
+	}
+}
+
+// Statter is a statsd.Statter that sends counters, gauges and timers to
+// CloudWatch as well as to the wrapped Statter.
+type Statter struct {
+	statsd.Statter
+	config   Config
+	interval time.Duration
+	client   cloudwatchiface.CloudWatchAPI
+	out      io.Writer
+	now      func() time.Time
+	sleep    func(time.Duration)
+
+	mu       sync.Mutex
+	counters map[string]float64
+	gauges   map[string]float64
+	timers   map[string]*timer
+
+	closed chan struct{}
+	done   sync.WaitGroup
+}
+
+// New returns a Statter sending stats to CloudWatch and to statter. client
+// is only used, and may only be nil, if the output isn't OutputAPI.
+func New(config Config, statter statsd.Statter, client cloudwatchiface.CloudWatchAPI) (*Statter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Output == OutputAPI && client == nil {
+		return nil, errors.New("a CloudWatch client is required for the api output")
+	}
+	interval, _ := time.ParseDuration(config.FlushInterval)
+	s := &Statter{
+		Statter:  statter,
+		config:   config,
+		interval: interval,
+		client:   client,
+		out:      os.Stdout,
+		now:      time.Now,
+		sleep:    time.Sleep,
+		counters: make(map[string]float64),
+		gauges:   make(map[string]float64),
+		timers:   make(map[string]*timer),
+		closed:   make(chan struct{}),
+	}
+	s.done.Add(1)
+	logger.Go(func() {
+		defer s.done.Done()
+		s.flushLoop()
+	})
+	return s, nil
+}
+
+// exported returns whether stat is sent to CloudWatch.
+func (s *Statter) exported(stat string, rate float32) bool {
+	if rate <= 0 {
+		return false
+	}
+	if len(s.config.Stats) == 0 {
+		return true
+	}
+	for _, prefix := range s.config.Stats {
+		if strings.HasPrefix(stat, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Inc adds value to a counter.
+func (s *Statter) Inc(stat string, value int64, rate float32) error {
+	if s.exported(stat, rate) {
+		s.mu.Lock()
+		s.counters[stat] += float64(value)
+		s.mu.Unlock()
+	}
+	return s.Statter.Inc(stat, value, rate)
+}
+
+// Dec subtracts value from a counter.
+func (s *Statter) Dec(stat string, value int64, rate float32) error {
+	return s.Inc(stat, -value, rate)
+}
+
+// Gauge sets a gauge, which is sent with the last value set each interval.
+func (s *Statter) Gauge(stat string, value int64, rate float32) error {
+	if s.exported(stat, rate) {
+		s.mu.Lock()
+		s.gauges[stat] = float64(value)
+		s.mu.Unlock()
+	}
+	return s.Statter.Gauge(stat, value, rate)
+}
+
+// GaugeDelta changes a gauge by value.
+func (s *Statter) GaugeDelta(stat string, value int64, rate float32) error {
+	if s.exported(stat, rate) {
+		s.mu.Lock()
+		s.gauges[stat] += float64(value)
+		s.mu.Unlock()
+	}
+	return s.Statter.GaugeDelta(stat, value, rate)
+}
+
+// Timing records a timing in milliseconds.
+func (s *Statter) Timing(stat string, delta int64, rate float32) error {
+	s.addTiming(stat, float64(delta), rate)
+	return s.Statter.Timing(stat, delta, rate)
+}
+
+// TimingDuration records a timing.
+func (s *Statter) TimingDuration(stat string, delta time.Duration, rate float32) error {
+	s.addTiming(stat, float64(delta)/float64(time.Millisecond), rate)
+	return s.Statter.TimingDuration(stat, delta, rate)
+}
+
+func (s *Statter) addTiming(stat string, ms float64, rate float32) {
+	if !s.exported(stat, rate) {
+		return
+	}
+	s.mu.Lock()
+	t, ok := s.timers[stat]
+	if !ok {
+		t = &timer{}
+		s.timers[stat] = t
+	}
+	t.add(ms)
+	s.mu.Unlock()
+}
+
+// metric is a stat to send to CloudWatch.
+type metric struct {
+	name  string
+	unit  string
+	value float64
+	timer *timer
+}
+
+// swap returns the interval's metrics, sorted by name, and starts a new
+// interval. Gauges keep their value.
+func (s *Statter) swap() []metric {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	metrics := make([]metric, 0, len(s.counters)+len(s.gauges)+len(s.timers))
+	for name, v := range s.counters {
+		metrics = append(metrics, metric{name: name, unit: cloudwatch.StandardUnitCount, value: v})
+	}
+	for name, v := range s.gauges {
+		metrics = append(metrics, metric{name: name, unit: cloudwatch.StandardUnitNone, value: v})
+	}
+	for name, t := range s.timers {
+		metrics = append(metrics, metric{name: name, unit: cloudwatch.StandardUnitMilliseconds, timer: t})
+	}
+	s.counters = make(map[string]float64, len(s.counters))
+	s.timers = make(map[string]*timer, len(s.timers))
+	sort.Sort(byName(metrics))
+	return metrics
+}
+
+type byName []metric
+
+func (m byName) Len() int           { return len(m) }
+func (m byName) Less(i, j int) bool { return m[i].name < m[j].name }
+func (m byName) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// Flush sends the stats aggregated so far.
+func (s *Statter) Flush() {
+	metrics := s.swap()
+	if len(metrics) == 0 {
+		return
+	}
+	now := s.now()
+	if s.config.Output == OutputAPI {
+		s.putMetricData(metrics, now)
+	} else {
+		s.writeDocuments(metrics, now)
+	}
+}
+
+// writeDocuments writes metrics as EMF log lines.
+func (s *Statter) writeDocuments(metrics []metric, now time.Time) {
+	dimensionKeys := make([]string, 0, len(s.config.Dimensions))
+	for k := range s.config.Dimensions {
+		dimensionKeys = append(dimensionKeys, k)
+	}
+	sort.Strings(dimensionKeys)
+
+	for start := 0; start < len(metrics); start += maxDocumentMetrics {
+		end := start + maxDocumentMetrics
+		if end > len(metrics) {
+			end = len(metrics)
+		}
+		doc := make(map[string]interface{}, end-start+len(dimensionKeys)+1)
+		definitions := make([]map[string]string, 0, end-start)
+		for _, m := range metrics[start:end] {
+			definitions = append(definitions, map[string]string{"Name": m.name, "Unit": m.unit})
+			if m.timer != nil {
+				doc[m.name] = m.timer.samples
+			} else {
+				doc[m.name] = m.value
+			}
+		}
+		for k, v := range s.config.Dimensions {
+			doc[k] = v
+		}
+		doc["_aws"] = map[string]interface{}{
+			"Timestamp": now.UnixNano() / int64(time.Millisecond),
+			"CloudWatchMetrics": []map[string]interface{}{{
+				"Namespace":  s.config.Namespace,
+				"Dimensions": [][]string{dimensionKeys},
+				"Metrics":    definitions,
+			}},
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			logger.WithError(err).Error("Error encoding EMF document")
+			continue
+		}
+		if _, err = s.out.Write(append(b, '\n')); err != nil {
+			logger.WithError(err).Error("Error writing EMF document")
+		}
+	}
+}
+
+// putMetricData sends metrics with PutMetricData, pacing the requests to
+// MaxRequestsPerSecond.
+func (s *Statter) putMetricData(metrics []metric, now time.Time) {
+	var dimensions []*cloudwatch.Dimension
+	for k, v := range s.config.Dimensions {
+		dimensions = append(dimensions, &cloudwatch.Dimension{Name: aws.String(k), Value: aws.String(v)})
+	}
+	pause := time.Duration(float64(time.Second) / s.config.MaxRequestsPerSecond)
+
+	for start := 0; start < len(metrics); start += maxRequestMetrics {
+		if start > 0 {
+			s.sleep(pause)
+		}
+		end := start + maxRequestMetrics
+		if end > len(metrics) {
+			end = len(metrics)
+		}
+		data := make([]*cloudwatch.MetricDatum, 0, end-start)
+		for _, m := range metrics[start:end] {
+			datum := &cloudwatch.MetricDatum{
+				MetricName: aws.String(m.name),
+				Dimensions: dimensions,
+				Timestamp:  aws.Time(now),
+				Unit:       aws.String(m.unit),
+			}
+			if m.timer != nil {
+				datum.StatisticValues = &cloudwatch.StatisticSet{
+					Minimum:     aws.Float64(m.timer.min),
+					Maximum:     aws.Float64(m.timer.max),
+					Sum:         aws.Float64(m.timer.sum),
+					SampleCount: aws.Float64(float64(m.timer.count)),
+				}
+			} else {
+				datum.Value = aws.Float64(m.value)
+			}
+			data = append(data, datum)
+		}
+		_, err := s.client.PutMetricData(&cloudwatch.PutMetricDataInput{
+			Namespace:  aws.String(s.config.Namespace),
+			MetricData: data,
+		})
+		if err != nil {
+			logger.WithError(err).WithField("metrics", len(data)).Warn("Error sending metrics to CloudWatch")
+		}
+	}
+}
+
+func (s *Statter) flushLoop() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+			s.Flush()
+		}
+	}
+}
+
+// Close flushes the remaining stats and closes the wrapped Statter.
+func (s *Statter) Close() error {
+	close(s.closed)
+	s.done.Wait()
+	s.Flush()
+	return s.Statter.Close()
+}
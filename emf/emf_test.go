@@ -0,0 +1,112 @@
+package emf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func newTestStatter(t *testing.T, config Config, client cloudwatchiface.CloudWatchAPI) (*Statter, *bytes.Buffer) {
+	noop, _ := statsd.NewNoop()
+	// Flush by hand rather than on the interval.
+	config.FlushInterval = "1h"
+	s, err := New(config, noop, client)
+	if err != nil {
+		t.Fatalf("Failed to create statter: %s", err)
+	}
+	var out bytes.Buffer
+	s.out = &out
+	s.now = func() time.Time { return time.Unix(1500000000, 0) }
+	s.sleep = func(time.Duration) {}
+	return s, &out
+}
+
+func TestLogOutput(t *testing.T) {
+	s, out := newTestStatter(t, Config{
+		Namespace:  "SpadeEdge",
+		Dimensions: map[string]string{"Service": "edge"},
+		Stats:      []string{"requests.", "latency"},
+	}, nil)
+	_ = s.Inc("requests.success", 2, 1)
+	_ = s.Inc("requests.success", 1, 0.1)
+	_ = s.Inc("requests.skipped", 1, 0)
+	_ = s.Inc("other", 1, 1)
+	_ = s.Timing("latency", 7, 1)
+	_ = s.TimingDuration("latency", 3*time.Millisecond, 1)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close statter: %s", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode %s: %s", out.Bytes(), err)
+	}
+	if doc["requests.success"] != 3.0 || doc["Service"] != "edge" || doc["other"] != nil {
+		t.Errorf("Unexpected metric values in %s", out.Bytes())
+	}
+	if !reflect.DeepEqual(doc["latency"], []interface{}{7.0, 3.0}) {
+		t.Errorf("Expected latency samples [7 3], got %v", doc["latency"])
+	}
+	expected := map[string]interface{}{
+		"Timestamp": 1500000000000.0,
+		"CloudWatchMetrics": []interface{}{map[string]interface{}{
+			"Namespace":  "SpadeEdge",
+			"Dimensions": []interface{}{[]interface{}{"Service"}},
+			"Metrics": []interface{}{
+				map[string]interface{}{"Name": "latency", "Unit": "Milliseconds"},
+				map[string]interface{}{"Name": "requests.success", "Unit": "Count"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(doc["_aws"], expected) {
+		t.Errorf("Expected metadata %v, got %v", expected, doc["_aws"])
+	}
+}
+
+type fakeCloudWatch struct {
+	cloudwatchiface.CloudWatchAPI
+	inputs []*cloudwatch.PutMetricDataInput
+}
+
+func (f *fakeCloudWatch) PutMetricData(input *cloudwatch.PutMetricDataInput) (*cloudwatch.PutMetricDataOutput, error) {
+	f.inputs = append(f.inputs, input)
+	return &cloudwatch.PutMetricDataOutput{}, nil
+}
+
+func TestAPIOutput(t *testing.T) {
+	client := &fakeCloudWatch{}
+	s, _ := newTestStatter(t, Config{Namespace: "SpadeEdge", Output: OutputAPI}, client)
+	for i := 0; i < maxRequestMetrics+1; i++ {
+		_ = s.Inc(fmt.Sprintf("stat%02d", i), 1, 1)
+	}
+	_ = s.Timing("zlatency", 5, 1)
+	_ = s.Timing("zlatency", 9, 1)
+	s.Flush()
+
+	if len(client.inputs) != 2 {
+		t.Fatalf("Expected metrics to be sent in 2 requests, got %d", len(client.inputs))
+	}
+	last := client.inputs[1].MetricData
+	timing := last[len(last)-1]
+	expected := &cloudwatch.StatisticSet{
+		Minimum:     aws.Float64(5),
+		Maximum:     aws.Float64(9),
+		Sum:         aws.Float64(14),
+		SampleCount: aws.Float64(2),
+	}
+	if aws.StringValue(timing.MetricName) != "zlatency" || !reflect.DeepEqual(timing.StatisticValues, expected) {
+		t.Errorf("Expected zlatency statistics %v, got %v", expected, timing)
+	}
+
+	if _, err := New(Config{Namespace: "SpadeEdge", Output: OutputAPI}, s, nil); err == nil {
+		t.Error("Expected the api output to require a client")
+	}
+}
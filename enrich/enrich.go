@@ -0,0 +1,266 @@
+/*
+Package enrich joins static metadata into events at the edge, like campaign
+names for the campaign ids clients send, so real-time consumers of the event
+stream don't each need to look them up.
+
+The metadata is a CSV table with a header row, loaded from S3, HTTP or a
+local file and reloaded every RefreshInterval. An event whose Key property
+matches a row of the table's KeyColumn gets the row's Columns merged into its
+properties. Properties the event already has are left alone, so clients can
+override the table.
+*/
+package enrich
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultRefreshInterval = "5m"
+	defaultMaxRows         = 100000
+)
+
+// Config configures an enrichment lookup table.
+type Config struct {
+	// Source is where the table is loaded from: s3://bucket/key, an http or
+	// https URL, or a local path.
+	Source string
+
+	// Key is the event property looked up in the table, e.g. "campaign_id"
+	Key string
+
+	// KeyColumn is the column of the table Key is matched against. It
+	// defaults to the first column.
+	KeyColumn string
+
+	// Columns are the columns merged into events. They default to every
+	// column but KeyColumn.
+	Columns []string
+
+	// Prefix is prepended to the names of merged properties, e.g. "campaign_"
+	Prefix string
+
+	// Events are the event names enriched; empty enriches all events.
+	Events []string
+
+	// RefreshInterval is how often the table is reloaded, e.g. "5m"
+	RefreshInterval string
+
+	// MaxRows bounds the size of the table. It defaults to 100000.
+	MaxRows int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Source == "" || c.Key == "" {
+		return errors.New("Source and Key are required")
+	}
+	if c.RefreshInterval == "" {
+		c.RefreshInterval = defaultRefreshInterval
+	}
+	d, err := time.ParseDuration(c.RefreshInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.RefreshInterval, err)
+	}
+	if d <= 0 {
+		return errors.New("RefreshInterval must be greater than 0")
+	}
+	if c.MaxRows == 0 {
+		c.MaxRows = defaultMaxRows
+	}
+	if c.MaxRows < 0 {
+		return errors.New("MaxRows must be greater than 0")
+	}
+	return nil
+}
+
+// FetchFunc returns the contents of a Source.
+type FetchFunc func(source string) ([]byte, error)
+
+// Enricher merges the rows of a lookup table into events.
+type Enricher struct {
+	config   Config
+	fetch    FetchFunc
+	stats    statsd.StatSender
+	interval time.Duration
+	events   map[string]bool
+
+	mu    sync.RWMutex
+	table map[string]map[string]string
+
+	runMu   sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns an Enricher for config, loading its table with fetch.
+func New(config Config, fetch FetchFunc, stats statsd.StatSender) (*Enricher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	e := &Enricher{
+		config: config,
+		fetch:  fetch,
+		stats:  stats,
+		events: make(map[string]bool, len(config.Events)),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	e.interval, _ = time.ParseDuration(config.RefreshInterval)
+	for _, name := range config.Events {
+		e.events[name] = true
+	}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload loads the table again, keeping the one loaded before if it fails.
+func (e *Enricher) Reload() error {
+	b, err := e.fetch(e.config.Source)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %v", e.config.Source, err)
+	}
+	table, err := e.parse(b)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", e.config.Source, err)
+	}
+	e.mu.Lock()
+	e.table = table
+	e.mu.Unlock()
+	_ = e.stats.Gauge("enrich.rows", int64(len(table)), 1)
+	return nil
+}
+
+// parse parses a CSV table into a map of the merged properties of each key.
+func (e *Enricher) parse(b []byte) (map[string]map[string]string, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %v", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[column] = i
+	}
+	keyColumn := 0
+	if e.config.KeyColumn != "" {
+		var ok bool
+		if keyColumn, ok = index[e.config.KeyColumn]; !ok {
+			return nil, fmt.Errorf("no %s column", e.config.KeyColumn)
+		}
+	}
+	columns := e.config.Columns
+	if len(columns) == 0 {
+		for i, column := range header {
+			if i != keyColumn {
+				columns = append(columns, column)
+			}
+		}
+	}
+	for _, column := range columns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("no %s column", column)
+		}
+	}
+
+	table := make(map[string]map[string]string)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return table, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(table) >= e.config.MaxRows {
+			return nil, fmt.Errorf("more than %d rows", e.config.MaxRows)
+		}
+		row := make(map[string]string, len(columns))
+		for _, column := range columns {
+			row[e.config.Prefix+column] = record[index[column]]
+		}
+		table[record[keyColumn]] = row
+	}
+}
+
+// Apply merges the table into the base64 encoded event (or array of events)
+// in data, returning it re-encoded. Events joined are counted under
+// enrich.joined and events whose key isn't in the table under enrich.missing.
+// data is returned as is if nothing is joined or an error occurs.
+func (e *Enricher) Apply(data string) string {
+	e.mu.RLock()
+	table := e.table
+	e.mu.RUnlock()
+	enriched, _, err := transform.Edit(data, func(name string, properties map[string]interface{}) bool {
+		if len(e.events) > 0 && !e.events[name] {
+			return false
+		}
+		key, ok := properties[e.config.Key]
+		if !ok || key == nil {
+			return false
+		}
+		row, ok := table[fmt.Sprint(key)]
+		if !ok {
+			_ = e.stats.Inc("enrich.missing", 1, 0.1)
+			return false
+		}
+		_ = e.stats.Inc("enrich.joined", 1, 0.1)
+		changed := false
+		for property, value := range row {
+			if _, ok := properties[property]; !ok {
+				properties[property] = value
+				changed = true
+			}
+		}
+		return changed
+	})
+	if err != nil {
+		_ = e.stats.Inc("enrich.error", 1, 0.1)
+	}
+	return enriched
+}
+
+// Run reloads the table every RefreshInterval until Close is called.
+func (e *Enricher) Run() {
+	e.runMu.Lock()
+	e.running = true
+	e.runMu.Unlock()
+	defer close(e.done)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.quit:
+			return
+		case <-ticker.C:
+			if err := e.Reload(); err != nil {
+				_ = e.stats.Inc("enrich.reload_failed", 1, 1)
+				logger.WithError(err).Error("Failed to reload enrichment table")
+			}
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (e *Enricher) Close() {
+	close(e.quit)
+	e.runMu.Lock()
+	running := e.running
+	e.runMu.Unlock()
+	if running {
+		<-e.done
+	}
+}
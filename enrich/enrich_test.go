@@ -0,0 +1,97 @@
+package enrich
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func encode(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+func decode(t *testing.T, data string) string {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("Failed to decode %q: %s", data, err)
+	}
+	return string(b)
+}
+
+const campaigns = `id,name,owner
+17,Summer Sale,marketing
+42,"Launch, Phase 2",growth
+`
+
+func TestApply(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	table := campaigns
+	fetch := func(source string) ([]byte, error) {
+		if table == "" {
+			return nil, errors.New("not found")
+		}
+		return []byte(table), nil
+	}
+	e, err := New(Config{
+		Source:  "s3://metadata/campaigns.csv",
+		Key:     "campaign_id",
+		Columns: []string{"name"},
+		Prefix:  "campaign_",
+		Events:  []string{"click"},
+	}, fetch, stats)
+	if err != nil {
+		t.Fatalf("Failed to create enricher: %s", err)
+	}
+
+	for _, tt := range []struct{ in, out string }{
+		{`{"event":"click","properties":{"campaign_id":42}}`,
+			`{"event":"click","properties":{"campaign_id":42,"campaign_name":"Launch, Phase 2"}}`},
+		{`[{"event":"click","properties":{"campaign_id":"17","campaign_name":"mine"}}]`,
+			`[{"event":"click","properties":{"campaign_id":"17","campaign_name":"mine"}}]`},
+		{`{"event":"view","properties":{"campaign_id":17}}`, `{"event":"view","properties":{"campaign_id":17}}`},
+		{`{"event":"click","properties":{"campaign_id":99}}`, `{"event":"click","properties":{"campaign_id":99}}`},
+	} {
+		if got := decode(t, e.Apply(encode(tt.in))); got != tt.out {
+			t.Errorf("Expected %s to be enriched to %s, got %s", tt.in, tt.out, got)
+		}
+	}
+
+	// A failed reload keeps the table loaded before, and a successful one
+	// replaces it.
+	table = ""
+	if err = e.Reload(); err == nil {
+		t.Error("Expected the failed fetch to be returned")
+	}
+	table = "id,name\n99,Winter Sale\n"
+	if err = e.Reload(); err != nil {
+		t.Fatalf("Failed to reload: %s", err)
+	}
+	in := encode(`{"event":"click","properties":{"campaign_id":99}}`)
+	if got := decode(t, e.Apply(in)); got != `{"event":"click","properties":{"campaign_id":99,"campaign_name":"Winter Sale"}}` {
+		t.Errorf("Expected the reloaded table to be used, got %s", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	fetch := func(string) ([]byte, error) { return []byte(campaigns), nil }
+	for _, c := range []Config{
+		{KeyColumn: "campaign"},
+		{Columns: []string{"budget"}},
+		{MaxRows: 1},
+	} {
+		c.Source, c.Key = "campaigns.csv", "campaign_id"
+		if _, err := New(c, fetch, stats); err == nil {
+			t.Errorf("Expected %+v to fail to load", c)
+		}
+	}
+	e, err := New(Config{Source: "campaigns.csv", Key: "owner", KeyColumn: "owner"}, fetch, stats)
+	if err != nil {
+		t.Fatalf("Failed to create enricher: %s", err)
+	}
+	if row := e.table["growth"]; len(row) != 2 || row["id"] != "42" || row["name"] != "Launch, Phase 2" {
+		t.Errorf("Expected every other column by default, got %v", row)
+	}
+}
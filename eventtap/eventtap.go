@@ -0,0 +1,258 @@
+/*
+Package eventtap keeps the last accepted events carrying an api_key or tag
+property in memory, so QA can confirm a test event made it through the edge.
+
+Events are read through an admin handler served on the internal debug port,
+which requires the configured Token as a bearer token:
+
+	GET /debug/events?api_key=qa-1234
+	GET /debug/events?tag=release-check&cursor=41&wait=30s
+
+Each event is numbered with a cursor. Passing the cursor of the last event
+seen returns only later events, and wait long-polls until one arrives.
+*/
+package eventtap
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultCapacity = 1000
+	defaultMaxWait  = "1m"
+)
+
+// defaultProperties are the properties events are matched on.
+var defaultProperties = []string{"api_key", "tag"}
+
+// Config configures the event tap.
+type Config struct {
+	// Token is the bearer token readers must present.
+	Token string
+
+	// Capacity is how many events are kept. It defaults to 1000.
+	Capacity int
+
+	// Properties are the properties events are kept and looked up by. They
+	// default to api_key and tag.
+	Properties []string
+
+	// MaxWait caps how long a read long-polls, e.g. "1m"
+	MaxWait string
+
+	// AllowInProduction lets the tap run when RollbarEnvironment is a
+	// production environment, where it's refused by default.
+	AllowInProduction bool
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Token == "" {
+		return errors.New("Token is required")
+	}
+	if c.Capacity == 0 {
+		c.Capacity = defaultCapacity
+	}
+	if c.Capacity < 0 {
+		return errors.New("Capacity must be positive")
+	}
+	if len(c.Properties) == 0 {
+		c.Properties = defaultProperties
+	}
+	if c.MaxWait == "" {
+		c.MaxWait = defaultMaxWait
+	}
+	if _, err := time.ParseDuration(c.MaxWait); err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.MaxWait, err)
+	}
+	return nil
+}
+
+// Event is an event kept by the tap.
+type Event struct {
+	Cursor     int64                  `json:"cursor"`
+	ReceivedAt time.Time              `json:"receivedAt"`
+	UUID       string                 `json:"uuid"`
+	Name       string                 `json:"event"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Tap keeps the last Capacity events carrying one of the Properties in a ring.
+type Tap struct {
+	config  Config
+	maxWait time.Duration
+
+	mu     sync.Mutex
+	ring   []Event
+	cursor int64
+	// added is closed, and replaced, when events are added, to wake up
+	// long-polling reads.
+	added chan struct{}
+}
+
+// New returns a Tap for config.
+func New(config Config) (*Tap, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	maxWait, _ := time.ParseDuration(config.MaxWait)
+	return &Tap{
+		config:  config,
+		maxWait: maxWait,
+		ring:    make([]Event, config.Capacity),
+		added:   make(chan struct{}),
+	}, nil
+}
+
+// Observe keeps the events of an accepted spade.Event that carry one of the
+// Properties.
+func (t *Tap) Observe(event *spade.Event) {
+	var kept []Event
+	_, _, _ = transform.Edit(event.Data, func(name string, properties map[string]interface{}) bool {
+		if t.matchable(properties) {
+			kept = append(kept, Event{
+				ReceivedAt: event.ReceivedAt,
+				UUID:       event.Uuid,
+				Name:       name,
+				Properties: properties,
+			})
+		}
+		return false
+	})
+	if len(kept) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range kept {
+		t.cursor++
+		e.Cursor = t.cursor
+		t.ring[t.cursor%int64(len(t.ring))] = e
+	}
+	close(t.added)
+	t.added = make(chan struct{})
+}
+
+func (t *Tap) matchable(properties map[string]interface{}) bool {
+	for _, p := range t.config.Properties {
+		if _, ok := properties[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Events returns the kept events after cursor whose property equals value,
+// oldest first, the cursor of the last event kept, and a channel closed when
+// more are kept.
+func (t *Tap) Events(property, value string, cursor int64) ([]Event, int64, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	oldest := t.cursor - int64(len(t.ring)) + 1
+	if cursor >= oldest {
+		oldest = cursor + 1
+	}
+	if oldest < 1 {
+		oldest = 1
+	}
+	var events []Event
+	for c := oldest; c <= t.cursor; c++ {
+		e := t.ring[c%int64(len(t.ring))]
+		if v, ok := e.Properties[property]; ok && fmt.Sprint(v) == value {
+			events = append(events, e)
+		}
+	}
+	return events, t.cursor, t.added
+}
+
+// ServeHTTP serves the kept events matching the query's property as JSON,
+// along with the cursor to read later events from. If there are none and the
+// wait parameter is set, it waits up to MaxWait for one.
+func (t *Tap) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !t.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	query := r.URL.Query()
+	var property, value string
+	for _, p := range t.config.Properties {
+		if v := query.Get(p); v != "" {
+			property, value = p, v
+			break
+		}
+	}
+	if property == "" {
+		http.Error(w, fmt.Sprintf("one of %v is required", t.config.Properties), http.StatusBadRequest)
+		return
+	}
+	var cursor int64
+	if v := query.Get("cursor"); v != "" {
+		var err error
+		if cursor, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, fmt.Sprintf("invalid cursor %q", v), http.StatusBadRequest)
+			return
+		}
+	}
+	var wait time.Duration
+	if v := query.Get("wait"); v != "" {
+		var err error
+		if wait, err = time.ParseDuration(v); err != nil || wait < 0 {
+			http.Error(w, fmt.Sprintf("invalid wait %q", v), http.StatusBadRequest)
+			return
+		}
+		if wait > t.maxWait {
+			wait = t.maxWait
+		}
+	}
+
+	events, last, added := t.Events(property, value, cursor)
+	if len(events) == 0 && wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+	poll:
+		for len(events) == 0 {
+			select {
+			case <-added:
+				events, last, added = t.Events(property, value, cursor)
+			case <-timer.C:
+				break poll
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+	if events == nil {
+		events = []Event{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	_ = json.NewEncoder(w).Encode(struct {
+		Cursor int64   `json:"cursor"`
+		Events []Event `json:"events"`
+	}{last, events})
+}
+
+// authorized returns whether r presents the Token as a bearer token.
+func (t *Tap) authorized(r *http.Request) bool {
+	authz := r.Header.Get("Authorization")
+	if len(authz) < 7 || authz[:7] != "Bearer " {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(authz[7:]), []byte(t.config.Token)) == 1
+}
@@ -0,0 +1,121 @@
+package eventtap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+type response struct {
+	Cursor int64   `json:"cursor"`
+	Events []Event `json:"events"`
+}
+
+func observe(tap *Tap, data string) {
+	tap.Observe(&spade.Event{Uuid: "uuid", Data: base64.StdEncoding.EncodeToString([]byte(data))})
+}
+
+func get(t *testing.T, tap *Tap, query, token string) (int, response) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/debug/events?"+query, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	tap.ServeHTTP(rec, r)
+	var resp response
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response %q: %s", rec.Body, err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func TestTap(t *testing.T) {
+	tap, err := New(Config{Token: "secret", Capacity: 3})
+	if err != nil {
+		t.Fatalf("Failed to create tap: %s", err)
+	}
+	observe(tap, `[{"event":"play","properties":{"api_key":"qa"}},{"event":"pause","properties":{}}]`)
+	observe(tap, `{"event":"play","properties":{"api_key":"other","tag":7}}`)
+
+	if code, _ := get(t, tap, "api_key=qa", ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected reads without the token to be refused, got %d", code)
+	}
+	if code, _ := get(t, tap, "api_key=qa", "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("Expected reads with the wrong token to be refused, got %d", code)
+	}
+	if code, _ := get(t, tap, "cursor=1", "secret"); code != http.StatusBadRequest {
+		t.Errorf("Expected reads without a property to be rejected, got %d", code)
+	}
+
+	_, resp := get(t, tap, "api_key=qa", "secret")
+	if resp.Cursor != 2 || len(resp.Events) != 1 || resp.Events[0].Name != "play" || resp.Events[0].Cursor != 1 {
+		t.Errorf("Expected the qa event, got %+v", resp)
+	}
+	if _, resp = get(t, tap, "tag=7", "secret"); len(resp.Events) != 1 || resp.Events[0].Cursor != 2 {
+		t.Errorf("Expected the tagged event, got %+v", resp)
+	}
+	if _, resp = get(t, tap, "api_key=qa&cursor=1", "secret"); len(resp.Events) != 0 || resp.Cursor != 2 {
+		t.Errorf("Expected no events after the cursor, got %+v", resp)
+	}
+
+	// Older events are dropped as the ring fills.
+	for i := 0; i < 3; i++ {
+		observe(tap, `{"event":"play","properties":{"api_key":"other"}}`)
+	}
+	if _, resp = get(t, tap, "api_key=qa", "secret"); len(resp.Events) != 0 || resp.Cursor != 5 {
+		t.Errorf("Expected the qa event to be dropped, got %+v", resp)
+	}
+}
+
+func TestLongPoll(t *testing.T) {
+	tap, err := New(Config{Token: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create tap: %s", err)
+	}
+	observe(tap, `{"event":"play","properties":{"tag":"old"}}`)
+
+	done := make(chan response)
+	go func() {
+		_, resp := get(t, tap, "tag=new&cursor=1&wait=10s", "secret")
+		done <- resp
+	}()
+	time.Sleep(10 * time.Millisecond)
+	observe(tap, `{"event":"play","properties":{"tag":"other"}}`)
+	observe(tap, `{"event":"pause","properties":{"tag":"new"}}`)
+	select {
+	case resp := <-done:
+		if len(resp.Events) != 1 || resp.Events[0].Name != "pause" || resp.Cursor != 3 {
+			t.Errorf("Expected the new event, got %+v", resp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the read to return once a matching event was kept")
+	}
+
+	start := time.Now()
+	if _, resp := get(t, tap, "tag=none&wait=20ms", "secret"); len(resp.Events) != 0 {
+		t.Errorf("Expected no events, got %+v", resp)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("Expected the read to wait")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	if err := (&Config{}).Validate(); err == nil {
+		t.Error("Expected a config without a Token to be invalid")
+	}
+	if err := (&Config{Token: "t", MaxWait: "soon"}).Validate(); err == nil {
+		t.Error("Expected an invalid MaxWait to be invalid")
+	}
+	c := Config{Token: "t"}
+	if err := c.Validate(); err != nil || c.Capacity != defaultCapacity || len(c.Properties) != 2 {
+		t.Errorf("Expected defaults to be filled in, got %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Package features provides percentage-based feature flags used to ramp new edge
+behavior gradually. Whether a feature is enabled for a request is decided by
+hashing the feature name with a request key (the client IP), so a given client
+sees a consistent decision while the rollout percentage stays the same.
+*/
+package features
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// HandleLargeEvents controls whether requests larger than the request limit
+// are split into their individual events instead of being rejected.
+const HandleLargeEvents = "handle_large_events"
+
+// Flag configures the rollout of a single feature.
+type Flag struct {
+	// Percent is the percentage of request keys the feature is enabled for,
+	// between 0 and 100
+	Percent float64
+}
+
+// Validate verifies that a Flag is valid
+func (f *Flag) Validate() error {
+	if f.Percent < 0 || f.Percent > 100 {
+		return fmt.Errorf("Percent must be between 0 and 100, got %v", f.Percent)
+	}
+	return nil
+}
+
+// Set is a set of feature flags that can be replaced while in use.
+type Set struct {
+	mu    sync.RWMutex
+	flags map[string]Flag
+}
+
+// NewSet returns a Set containing the given flags. Nil entries are ignored.
+func NewSet(flags map[string]*Flag) *Set {
+	s := &Set{}
+	s.Update(flags)
+	return s
+}
+
+// Update replaces all flags in the set. Nil entries are ignored.
+func (s *Set) Update(flags map[string]*Flag) {
+	m := make(map[string]Flag, len(flags))
+	for name, f := range flags {
+		if f != nil {
+			m[name] = *f
+		}
+	}
+	s.mu.Lock()
+	s.flags = m
+	s.mu.Unlock()
+}
+
+// Enabled reports whether the named feature is enabled for key. If the
+// feature has no flag in the set, def is returned.
+func (s *Set) Enabled(name string, key []byte, def bool) bool {
+	s.mu.RLock()
+	f, ok := s.flags[name]
+	s.mu.RUnlock()
+	if !ok {
+		return def
+	}
+
+	switch {
+	case f.Percent <= 0:
+		return false
+	case f.Percent >= 100:
+		return true
+	}
+	return float64(bucket(name, key)) < f.Percent*100
+}
+
+// bucket maps name and key to one of 10000 buckets, so rollouts have a
+// resolution of a hundredth of a percent.
+func bucket(name string, key []byte) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write(key)
+	return h.Sum32() % 10000
+}
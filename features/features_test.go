@@ -0,0 +1,55 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEnabledDefaults(t *testing.T) {
+	s := NewSet(map[string]*Flag{"off": {Percent: 0}, "on": {Percent: 100}, "nil": nil})
+	key := []byte("10.0.0.1")
+	if !s.Enabled("missing", key, true) || s.Enabled("missing", key, false) {
+		t.Error("Expected the default for a feature without a flag")
+	}
+	if !s.Enabled("nil", key, true) {
+		t.Error("Expected nil flags to be ignored")
+	}
+	if s.Enabled("off", key, true) {
+		t.Error("Expected 0% feature to be disabled")
+	}
+	if !s.Enabled("on", key, false) {
+		t.Error("Expected 100% feature to be enabled")
+	}
+}
+
+func TestEnabledPercentage(t *testing.T) {
+	s := NewSet(map[string]*Flag{"ramp": {Percent: 25}})
+	enabled := 0
+	for i := 0; i < 10000; i++ {
+		key := []byte(fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+		first := s.Enabled("ramp", key, false)
+		if first != s.Enabled("ramp", key, false) {
+			t.Fatalf("Expected a stable decision for %s", key)
+		}
+		if first {
+			enabled++
+		}
+	}
+	if enabled < 2200 || enabled > 2800 {
+		t.Errorf("Expected about 25%% of keys to be enabled, got %d of 10000", enabled)
+	}
+
+	s.Update(map[string]*Flag{"ramp": {Percent: 100}})
+	if !s.Enabled("ramp", []byte("10.0.0.1"), false) {
+		t.Error("Expected update to take effect")
+	}
+}
+
+func TestFlagValidate(t *testing.T) {
+	if err := (&Flag{Percent: 101}).Validate(); err == nil {
+		t.Error("Expected Percent above 100 to be rejected")
+	}
+	if err := (&Flag{Percent: 50}).Validate(); err != nil {
+		t.Errorf("Expected valid flag, got %s", err)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Package gctune tunes the garbage collector for the instance the edge runs on,
+so it can be configured per instance type rather than with GOGC and
+GOMEMLIMIT set by a wrapper script, and reports how the collector is doing.
+
+A ballast is a large allocation that's never touched, so it costs address
+space but no resident memory. It raises the heap size the collector paces
+itself against, so a small live heap isn't collected on every burst of
+requests. A MemoryLimit with GCPercent -1 does the same job more
+predictably.
+*/
+package gctune
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const defaultStatsInterval = "10s"
+
+// Config configures the garbage collector.
+type Config struct {
+	// GCPercent sets the collector's target, like GOGC. -1 turns the
+	// collector off until MemoryLimit is reached, and 0 leaves GOGC as is.
+	GCPercent int
+
+	// MemoryLimit is the soft limit, in bytes, the collector keeps the Go
+	// runtime's memory under, like GOMEMLIMIT. 0 leaves it as is.
+	MemoryLimit int64
+
+	// Ballast is the size, in bytes, of a ballast allocation.
+	Ballast int64
+
+	// StatsInterval is how often collector stats are sent, e.g. "10s"
+	StatsInterval string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.GCPercent < -1 {
+		return errors.New("GCPercent must be -1 or more")
+	}
+	if c.MemoryLimit < 0 || c.Ballast < 0 {
+		return errors.New("MemoryLimit and Ballast must not be negative")
+	}
+	if c.GCPercent == -1 && c.MemoryLimit == 0 {
+		return errors.New("GCPercent -1 requires a MemoryLimit")
+	}
+	if c.StatsInterval == "" {
+		c.StatsInterval = defaultStatsInterval
+	}
+	d, err := time.ParseDuration(c.StatsInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.StatsInterval, err)
+	}
+	if d <= 0 {
+		return errors.New("StatsInterval must be greater than 0")
+	}
+	return nil
+}
+
+// Tuner holds the collector settings and ballast, and reports collector
+// stats.
+type Tuner struct {
+	stats    statsd.StatSender
+	interval time.Duration
+	ballast  []byte
+
+	// numGC is the number of collections already reported.
+	numGC uint32
+
+	mu      sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New applies config to the runtime and allocates the ballast.
+func New(config Config, stats statsd.StatSender) (*Tuner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	t := &Tuner{
+		stats: stats,
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	t.interval, _ = time.ParseDuration(config.StatsInterval)
+	if config.GCPercent != 0 {
+		debug.SetGCPercent(config.GCPercent)
+	}
+	if config.MemoryLimit > 0 {
+		debug.SetMemoryLimit(config.MemoryLimit)
+	}
+	if config.Ballast > 0 {
+		t.ballast = make([]byte, config.Ballast)
+	}
+	logger.WithField("gc_percent", config.GCPercent).
+		WithField("memory_limit", config.MemoryLimit).
+		WithField("ballast", config.Ballast).
+		Info("Tuned garbage collector")
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	t.numGC = m.NumGC
+	return t, nil
+}
+
+// Report sends the collections since the last report, timing each pause
+// under gc.pause and counting them under gc.count, and gauges the heap under
+// gc.heap_alloc and gc.next_gc. Only the last 256 pauses are kept by the
+// runtime, so pauses are dropped if there were more since the last report.
+func (t *Tuner) Report() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	collections := m.NumGC - t.numGC
+	_ = t.stats.Inc("gc.count", int64(collections), 1)
+	for i := uint32(0); i < collections && i < uint32(len(m.PauseNs)); i++ {
+		pause := m.PauseNs[(m.NumGC-i+255)%256]
+		_ = t.stats.TimingDuration("gc.pause", time.Duration(pause), 1)
+	}
+	t.numGC = m.NumGC
+	_ = t.stats.Gauge("gc.heap_alloc", int64(m.HeapAlloc), 1)
+	_ = t.stats.Gauge("gc.next_gc", int64(m.NextGC), 1)
+	_ = t.stats.Gauge("gc.cpu_fraction_ppm", int64(m.GCCPUFraction*1e6), 1)
+}
+
+// Run reports every interval until Close is called.
+func (t *Tuner) Run() {
+	t.mu.Lock()
+	t.running = true
+	t.mu.Unlock()
+	defer close(t.done)
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.quit:
+			return
+		case <-ticker.C:
+			t.Report()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return, and releases the ballast.
+func (t *Tuner) Close() {
+	close(t.quit)
+	t.mu.Lock()
+	running := t.running
+	t.mu.Unlock()
+	if running {
+		<-t.done
+	}
+	runtime.KeepAlive(t.ballast)
+	t.ballast = nil
+}
@@ -0,0 +1,57 @@
+package gctune
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+)
+
+func TestTuner(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(rs, "")
+	tuner, err := New(Config{GCPercent: 400, Ballast: 1 << 20}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create tuner: %s", err)
+	}
+	if previous := debug.SetGCPercent(100); previous != 400 {
+		t.Errorf("Expected GCPercent 400, got %d", previous)
+	}
+	if len(tuner.ballast) != 1<<20 {
+		t.Errorf("Expected a 1MB ballast, got %d bytes", len(tuner.ballast))
+	}
+
+	runtime.GC()
+	runtime.GC()
+	tuner.Report()
+	counts := make(map[string]int)
+	for _, stat := range rs.GetSent() {
+		counts[stat.Stat]++
+		if stat.Stat == "gc.count" && stat.Value != "2" {
+			t.Errorf("Expected 2 collections, got %s", stat.Value)
+		}
+	}
+	if counts["gc.count"] != 1 || counts["gc.pause"] != 2 || counts["gc.heap_alloc"] != 1 {
+		t.Errorf("Unexpected stats %v", counts)
+	}
+	tuner.Close()
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []Config{
+		{GCPercent: -2},
+		{GCPercent: -1},
+		{Ballast: -1},
+		{StatsInterval: "often"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+	c := Config{GCPercent: -1, MemoryLimit: 1 << 30}
+	if err := c.Validate(); err != nil || c.StatsInterval != "10s" {
+		t.Errorf("Expected a valid config with defaults, got %+v, %v", c, err)
+	}
+}
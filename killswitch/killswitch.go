@@ -0,0 +1,339 @@
+/*
+Package killswitch drops events by name at the edge, so a buggy client release
+flooding an event can be cut off without a deploy. Dropped events are
+answered with success, so clients don't retry them.
+
+Names are blocked by an entry matching them exactly, or ending in * and
+matching their prefix. Entries come from the config, from a Source listing
+one per line that's reloaded every RefreshInterval, and from an admin handler
+served on the internal debug port:
+
+	POST   /debug/killswitch?event=video-play
+	POST   /debug/killswitch?event=debug_*
+	DELETE /debug/killswitch?event=video-play
+	GET    /debug/killswitch
+
+Entries added through the admin handler are kept until they're deleted or the
+edge restarts.
+*/
+package killswitch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const defaultRefreshInterval = "1m"
+
+// statName replaces the characters of an entry that can't be in a stat name.
+var statName = strings.NewReplacer(".", "_", "*", "")
+
+// Config configures the event names dropped at the edge.
+type Config struct {
+	// Events are the blocked names, or prefixes ending in *.
+	Events []string
+
+	// Source, if set, lists more entries, one per line, with blank lines
+	// and lines starting with # ignored. It's s3://bucket/key, an http or
+	// https URL, or a local path.
+	Source string
+
+	// RefreshInterval is how often Source is reloaded, e.g. "1m"
+	RefreshInterval string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	for _, entry := range c.Events {
+		if err := validateEntry(entry); err != nil {
+			return err
+		}
+	}
+	if c.RefreshInterval == "" {
+		c.RefreshInterval = defaultRefreshInterval
+	}
+	d, err := time.ParseDuration(c.RefreshInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.RefreshInterval, err)
+	}
+	if d <= 0 {
+		return errors.New("RefreshInterval must be greater than 0")
+	}
+	return nil
+}
+
+func validateEntry(entry string) error {
+	if entry == "" || entry == "*" {
+		return fmt.Errorf("invalid entry %q: it would block every event", entry)
+	}
+	if strings.Contains(strings.TrimSuffix(entry, "*"), "*") {
+		return fmt.Errorf("invalid entry %q: * is only allowed at the end", entry)
+	}
+	return nil
+}
+
+// FetchFunc returns the contents of a Source.
+type FetchFunc func(source string) ([]byte, error)
+
+// list is a set of entries split into exact names and prefixes.
+type list struct {
+	names    map[string]bool
+	prefixes []string
+}
+
+func newList(entries []string) list {
+	l := list{names: make(map[string]bool)}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, "*") {
+			l.prefixes = append(l.prefixes, strings.TrimSuffix(entry, "*"))
+		} else {
+			l.names[entry] = true
+		}
+	}
+	return l
+}
+
+// match returns the entry blocking name, if any.
+func (l list) match(name string) (string, bool) {
+	if l.names[name] {
+		return name, true
+	}
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return prefix + "*", true
+		}
+	}
+	return "", false
+}
+
+func (l list) entries() []string {
+	entries := make([]string, 0, len(l.names)+len(l.prefixes))
+	for name := range l.names {
+		entries = append(entries, name)
+	}
+	for _, prefix := range l.prefixes {
+		entries = append(entries, prefix+"*")
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// Switch drops the events whose names are blocked.
+type Switch struct {
+	config   Config
+	fetch    FetchFunc
+	stats    statsd.StatSender
+	interval time.Duration
+	static   list
+
+	mu     sync.RWMutex
+	source list
+	admin  map[string]bool
+	merged list
+
+	runMu   sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns a Switch for config, loading its Source with fetch.
+func New(config Config, fetch FetchFunc, stats statsd.StatSender) (*Switch, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Switch{
+		config: config,
+		fetch:  fetch,
+		stats:  stats,
+		static: newList(config.Events),
+		admin:  make(map[string]bool),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	s.interval, _ = time.ParseDuration(config.RefreshInterval)
+	s.merge()
+	if config.Source != "" {
+		if err := s.Reload(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Reload loads Source again, keeping the entries loaded before if it fails.
+func (s *Switch) Reload() error {
+	b, err := s.fetch(s.config.Source)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %v", s.config.Source, err)
+	}
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err = validateEntry(line); err != nil {
+			return fmt.Errorf("error parsing %s: %v", s.config.Source, err)
+		}
+		entries = append(entries, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %v", s.config.Source, err)
+	}
+	s.mu.Lock()
+	s.source = newList(entries)
+	s.mu.Unlock()
+	s.merge()
+	return nil
+}
+
+// merge rebuilds the list matched against from every source of entries.
+func (s *Switch) merge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := append(s.static.entries(), s.source.entries()...)
+	for entry := range s.admin {
+		entries = append(entries, entry)
+	}
+	s.merged = newList(entries)
+	_ = s.stats.Gauge("killswitch.entries", int64(len(s.merged.names)+len(s.merged.prefixes)), 1)
+}
+
+// Add blocks the names entry matches until it's deleted.
+func (s *Switch) Add(entry string) error {
+	if err := validateEntry(entry); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.admin[entry] = true
+	s.mu.Unlock()
+	s.merge()
+	logger.WithField("entry", entry).Warn("Blocking events")
+	return nil
+}
+
+// Delete removes an entry added with Add. Entries from the config or Source
+// can't be deleted.
+func (s *Switch) Delete(entry string) {
+	s.mu.Lock()
+	_, ok := s.admin[entry]
+	delete(s.admin, entry)
+	s.mu.Unlock()
+	if ok {
+		s.merge()
+		logger.WithField("entry", entry).Warn("Unblocked events")
+	}
+}
+
+// Entries returns the entries in effect by where they came from.
+func (s *Switch) Entries() map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	admin := make([]string, 0, len(s.admin))
+	for entry := range s.admin {
+		admin = append(admin, entry)
+	}
+	sort.Strings(admin)
+	return map[string][]string{
+		"config": s.static.entries(),
+		"source": s.source.entries(),
+		"admin":  admin,
+	}
+}
+
+// Filter removes the blocked events from the base64 encoded event (or array
+// of events) in data and returns the rest re-encoded, or "" if every event
+// was blocked. Dropped events are counted under killswitch.dropped and
+// killswitch.dropped.<entry>. data is returned as is if nothing is blocked or
+// it can't be decoded.
+func (s *Switch) Filter(data string) string {
+	s.mu.RLock()
+	merged := s.merged
+	s.mu.RUnlock()
+	if len(merged.names) == 0 && len(merged.prefixes) == 0 {
+		return data
+	}
+	rest, dropped, err := transform.Remove(data, func(name string) bool {
+		entry, ok := merged.match(name)
+		if ok {
+			_ = s.stats.Inc("killswitch.dropped."+statName.Replace(entry), 1, 1)
+		}
+		return ok
+	})
+	if err != nil {
+		return data
+	}
+	if dropped > 0 {
+		_ = s.stats.Inc("killswitch.dropped", int64(dropped), 1)
+	}
+	return rest
+}
+
+// ServeHTTP adds, deletes and lists entries.
+func (s *Switch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if err := s.Add(r.FormValue("event")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "DELETE":
+		s.Delete(r.FormValue("event"))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Entries())
+}
+
+// Run reloads Source every RefreshInterval until Close is called. It returns
+// at once if there's no Source.
+func (s *Switch) Run() {
+	s.runMu.Lock()
+	s.running = true
+	s.runMu.Unlock()
+	defer close(s.done)
+	if s.config.Source == "" {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			if err := s.Reload(); err != nil {
+				_ = s.stats.Inc("killswitch.reload_failed", 1, 1)
+				logger.WithError(err).Error("Failed to reload kill switch")
+			}
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (s *Switch) Close() {
+	close(s.quit)
+	s.runMu.Lock()
+	running := s.running
+	s.runMu.Unlock()
+	if running {
+		<-s.done
+	}
+}
@@ -0,0 +1,109 @@
+package killswitch
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func encode(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+func decode(t *testing.T, data string) string {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("Failed to decode %q: %s", data, err)
+	}
+	return string(b)
+}
+
+func TestFilter(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	source := "# flooding since 2.3.1\nvideo-play\n\n"
+	fetch := func(string) ([]byte, error) {
+		if source == "" {
+			return nil, errors.New("not found")
+		}
+		return []byte(source), nil
+	}
+	s, err := New(Config{Events: []string{"debug_*"}, Source: "s3://config/killswitch"}, fetch, stats)
+	if err != nil {
+		t.Fatalf("Failed to create kill switch: %s", err)
+	}
+
+	for _, tt := range []struct{ in, out string }{
+		{`{"event":"video-play","properties":{}}`, ""},
+		{`{"event":"debug_trace","properties":{}}`, ""},
+		{`[{"event":"video-play"},{"event":"follow"},{"event":"debug_"}]`, `[{"event":"follow"}]`},
+		{`{"event":"follow","properties":{}}`, `{"event":"follow","properties":{}}`},
+	} {
+		out := s.Filter(encode(tt.in))
+		if out != "" {
+			out = decode(t, out)
+		}
+		if out != tt.out {
+			t.Errorf("Expected %s to be filtered to %q, got %q", tt.in, tt.out, out)
+		}
+	}
+	if data := s.Filter("!!!!"); data != "!!!!" {
+		t.Errorf("Expected undecodable data to be kept, got %q", data)
+	}
+
+	// A failed reload keeps the entries loaded before.
+	source = ""
+	if err = s.Reload(); err == nil {
+		t.Error("Expected the failed fetch to be returned")
+	}
+	if s.Filter(encode(`{"event":"video-play"}`)) != "" {
+		t.Error("Expected the previous source to be kept")
+	}
+	source = "follow"
+	if err = s.Reload(); err != nil {
+		t.Fatalf("Failed to reload: %s", err)
+	}
+	if s.Filter(encode(`{"event":"video-play"}`)) == "" || s.Filter(encode(`{"event":"follow"}`)) != "" {
+		t.Error("Expected the reloaded source to replace the previous one")
+	}
+	source = "*"
+	if err = s.Reload(); err == nil {
+		t.Error("Expected a source blocking every event to be rejected")
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	s, err := New(Config{Events: []string{"debug_*"}}, nil, stats)
+	if err != nil {
+		t.Fatalf("Failed to create kill switch: %s", err)
+	}
+	for _, tt := range []struct {
+		method, query string
+		code          int
+		body          string
+	}{
+		{"POST", "event=video-play", http.StatusOK, `{"admin":["video-play"],"config":["debug_*"],"source":[]}`},
+		{"POST", "event=*", http.StatusBadRequest, ""},
+		{"POST", "event=a*b", http.StatusBadRequest, ""},
+		{"DELETE", "event=debug_*", http.StatusOK, `{"admin":["video-play"],"config":["debug_*"],"source":[]}`},
+		{"DELETE", "event=video-play", http.StatusOK, `{"admin":[],"config":["debug_*"],"source":[]}`},
+		{"PUT", "", http.StatusMethodNotAllowed, ""},
+	} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(tt.method, "/debug/killswitch?"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("Expected %s %s to return %d, got %d", tt.method, tt.query, tt.code, rec.Code)
+		}
+		if tt.body != "" && strings.TrimSpace(rec.Body.String()) != tt.body {
+			t.Errorf("Expected %s %s to list %s, got %s", tt.method, tt.query, tt.body, rec.Body)
+		}
+	}
+	if s.Filter(encode(`{"event":"video-play"}`)) == "" {
+		t.Error("Expected a deleted entry to stop blocking")
+	}
+}
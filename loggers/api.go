@@ -9,3 +9,65 @@ type SpadeEdgeLogger interface {
 	Log(event *spade.Event) error
 	Close()
 }
+
+// A SerializedLogger is a SpadeEdgeLogger that can also store an event
+// already serialized by SerializeEvent, so an event written to several sinks
+// is only serialized once.
+type SerializedLogger interface {
+	SpadeEdgeLogger
+	LogSerialized(event *spade.Event, serialized []byte) error
+}
+
+// A BufferedLogger is a SpadeEdgeLogger that holds events in memory before
+// writing them.
+type BufferedLogger interface {
+	SpadeEdgeLogger
+
+	// Buffered returns the number of events waiting to be written.
+	Buffered() int
+}
+
+// A DurableLogger is a SpadeEdgeLogger that holds events in memory and can
+// tell callers when one is actually written, for callers that must keep the
+// event until then, e.g. in a write-ahead log.
+type DurableLogger interface {
+	SpadeEdgeLogger
+
+	// LogDurably logs event like LogSerialized and, if it returns nil,
+	// calls done once: with nil once the event is written, or handed to a
+	// fallback that wrote it, and with an error if it was lost. done may be
+	// nil.
+	LogDurably(event *spade.Event, serialized []byte, done func(error)) error
+}
+
+// An AckLogger can write an event durably before returning, for callers
+// that must know it was rather than that it was buffered.
+type AckLogger interface {
+	// LogAcked writes event and returns where it was written, e.g. the
+	// sequence number of its Kinesis record.
+	LogAcked(event *spade.Event) (string, error)
+}
+
+// LogSerialized logs event to logger, handing it the serialized event if it
+// is a SerializedLogger. serialized may be nil, in which case the logger
+// serializes the event itself.
+func LogSerialized(logger SpadeEdgeLogger, event *spade.Event, serialized []byte) error {
+	if sl, ok := logger.(SerializedLogger); ok && serialized != nil {
+		return sl.LogSerialized(event, serialized)
+	}
+	return logger.Log(event)
+}
+
+// LogDurably logs event to logger like LogSerialized, calling done once it's
+// written if logger is a DurableLogger. Other loggers write events before
+// returning, so done is called as soon as they return nil. done may be nil.
+func LogDurably(logger SpadeEdgeLogger, event *spade.Event, serialized []byte, done func(error)) error {
+	if dl, ok := logger.(DurableLogger); ok {
+		return dl.LogDurably(event, serialized, done)
+	}
+	err := LogSerialized(logger, event, serialized)
+	if err == nil && done != nil {
+		done(nil)
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package loggers
+
+import (
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/breaker"
+)
+
+type breakerLogger struct {
+	logger  SpadeEdgeLogger
+	breaker breaker.Breaker
+}
+
+// NewBreakerLogger returns a SpadeEdgeLogger that sends events to logger
+// through the given circuit breaker. While the breaker is open, Log returns
+// breaker.ErrOpen without calling the wrapped logger.
+func NewBreakerLogger(logger SpadeEdgeLogger, b breaker.Breaker) SpadeEdgeLogger {
+	return &breakerLogger{
+		logger:  logger,
+		breaker: b,
+	}
+}
+
+func (bl *breakerLogger) Log(e *spade.Event) error {
+	return bl.breaker.Do(func() error {
+		return bl.logger.Log(e)
+	})
+}
+
+func (bl *breakerLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return bl.breaker.Do(func() error {
+		return LogSerialized(bl.logger, e, serialized)
+	})
+}
+
+func (bl *breakerLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	return bl.breaker.Do(func() error {
+		return LogDurably(bl.logger, e, serialized, done)
+	})
+}
+
+func (bl *breakerLogger) Close() {
+	bl.logger.Close()
+}
@@ -0,0 +1,102 @@
+package loggers
+
+import (
+	"fmt"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/breaker"
+)
+
+const fallbackStatsPrefix = "logger.fallback."
+
+type handoffLogger struct {
+	next    SpadeEdgeLogger
+	stat    string
+	statter statsd.StatSender
+}
+
+// NewHandoffLogger returns a SpadeEdgeLogger that hands the events the sink
+// named from failed to write to next, the sink named to, counting them under
+// logger.fallback.<from>.<to> and those next fails to write under
+// logger.fallback.<from>.<to>.failed.
+func NewHandoffLogger(from, to string, next SpadeEdgeLogger, statter statsd.StatSender) SpadeEdgeLogger {
+	return &handoffLogger{
+		next:    next,
+		stat:    fallbackStatsPrefix + from + "." + to,
+		statter: statter,
+	}
+}
+
+func (hl *handoffLogger) Log(e *spade.Event) error {
+	return hl.LogSerialized(e, nil)
+}
+
+func (hl *handoffLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return hl.LogDurably(e, serialized, nil)
+}
+
+// LogDurably hands the event to next durably, so done is called once next
+// has written it.
+func (hl *handoffLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	_ = hl.statter.Inc(hl.stat, 1, 1)
+	err := LogDurably(hl.next, e, serialized, done)
+	if err != nil {
+		_ = hl.statter.Inc(hl.stat+".failed", 1, 1)
+	}
+	return err
+}
+
+func (hl *handoffLogger) Close() {
+	hl.next.Close()
+}
+
+type fallbackLogger struct {
+	name     string
+	primary  SpadeEdgeLogger
+	fallback SpadeEdgeLogger
+	statter  statsd.StatSender
+}
+
+// NewFallbackLogger returns a SpadeEdgeLogger that writes events to primary,
+// the sink named name, and those it fails to write to fallback. Events
+// primary's circuit breaker rejects are also counted under
+// logger.fallback.<name>.circuit_open. Closing it closes primary, which may
+// flush events to fallback, then fallback.
+func NewFallbackLogger(name string, primary, fallback SpadeEdgeLogger, statter statsd.StatSender) SpadeEdgeLogger {
+	return &fallbackLogger{
+		name:     name,
+		primary:  primary,
+		fallback: fallback,
+		statter:  statter,
+	}
+}
+
+func (fl *fallbackLogger) Log(e *spade.Event) error {
+	return fl.LogSerialized(e, nil)
+}
+
+func (fl *fallbackLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return fl.LogDurably(e, serialized, nil)
+}
+
+// LogDurably writes the event durably to primary, or to fallback if primary
+// fails to take it, so done is called once whichever took it has written it.
+func (fl *fallbackLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	err := LogDurably(fl.primary, e, serialized, done)
+	if err == nil {
+		return nil
+	}
+	if err == breaker.ErrOpen {
+		_ = fl.statter.Inc(fallbackStatsPrefix+fl.name+".circuit_open", 1, 1)
+	}
+	if fallbackErr := LogDurably(fl.fallback, e, serialized, done); fallbackErr != nil {
+		return fmt.Errorf("%s failed with `%s` and falling back failed with `%s`", fl.name, err, fallbackErr)
+	}
+	return nil
+}
+
+func (fl *fallbackLogger) Close() {
+	fl.primary.Close()
+	fl.fallback.Close()
+}
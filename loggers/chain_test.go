@@ -0,0 +1,149 @@
+package loggers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/breaker"
+	"github.com/twitchscience/spade_edge/wal"
+)
+
+type failingLogger struct {
+	err    error
+	closed bool
+}
+
+func (f *failingLogger) Log(e *spade.Event) error {
+	return f.err
+}
+
+func (f *failingLogger) Close() {
+	f.closed = true
+}
+
+func TestFallbackChain(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	spool, err := NewSpoolLogger(wal.Config{Dir: dir, Sync: wal.SyncNever}, stats)
+	if err != nil {
+		t.Fatalf("Failed to open spool: %s", err)
+	}
+
+	secondary := &failingLogger{err: breaker.ErrOpen}
+	chain := NewHandoffLogger("kinesis", "secondary",
+		NewFallbackLogger("secondary", secondary, NewHandoffLogger("secondary", "spool", spool, stats), stats),
+		stats)
+	for i := 0; i < 3; i++ {
+		if err = chain.Log(&spade.Event{Uuid: "a"}); err != nil {
+			t.Fatalf("Expected the spool to take the event, got %s", err)
+		}
+	}
+	for stat, expected := range map[string]int{
+		"logger.fallback.kinesis.secondary":      3,
+		"logger.fallback.secondary.spool":        3,
+		"logger.fallback.secondary.circuit_open": 3,
+		"logger.spool.appended":                  3,
+	} {
+		if got := len(sender.GetSent().CollectNamed(stat)); got != expected {
+			t.Errorf("Expected %d %s, got %d", expected, stat, got)
+		}
+	}
+
+	chain.Close()
+	if !secondary.closed {
+		t.Error("Expected the chain's stages to be closed")
+	}
+
+	// The next run replays what was spooled.
+	spool, err = NewSpoolLogger(wal.Config{Dir: dir, Sync: wal.SyncNever}, stats)
+	if err != nil {
+		t.Fatalf("Failed to reopen spool: %s", err)
+	}
+	defer spool.Close()
+	replayed := 0
+	if err = spool.Replay(func(e *spade.Event) error {
+		replayed++
+		return nil
+	}); err != nil || replayed != 3 {
+		t.Errorf("Expected 3 events to be replayed, got %d, %v", replayed, err)
+	}
+}
+
+func TestFallbackChainExhausted(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	last := &failingLogger{err: errors.New("disk full")}
+	chain := NewFallbackLogger("secondary", &failingLogger{err: errors.New("throttled")},
+		NewHandoffLogger("secondary", "last", last, stats), stats)
+	if err := chain.Log(&spade.Event{}); err == nil {
+		t.Error("Expected an error when every stage fails")
+	}
+	if got := len(sender.GetSent().CollectNamed("logger.fallback.secondary.last.failed")); got != 1 {
+		t.Errorf("Expected the failed handoff to be counted, got %d", got)
+	}
+}
+
+// durableLogger holds the callbacks of the events logged to it until flush.
+type durableLogger struct {
+	done []func(error)
+}
+
+func (d *durableLogger) Log(e *spade.Event) error {
+	return d.LogDurably(e, nil, nil)
+}
+
+func (d *durableLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	d.done = append(d.done, done)
+	return nil
+}
+
+func (d *durableLogger) flush(err error) {
+	for _, done := range d.done {
+		callDone(done, err)
+	}
+	d.done = nil
+}
+
+func (d *durableLogger) Close() {}
+
+func TestDurableChain(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	first, second := &durableLogger{}, &durableLogger{}
+	chain := NewHandoffLogger("kinesis", "relay", NewTeeLogger(first,
+		NewFallbackLogger("secondary", &failingLogger{err: errors.New("throttled")}, second, stats)), stats)
+
+	var calls int
+	var result error
+	if err := LogDurably(chain, &spade.Event{}, nil, func(err error) { calls, result = calls+1, err }); err != nil {
+		t.Fatalf("Failed to log: %s", err)
+	}
+	first.flush(nil)
+	if calls != 0 {
+		t.Fatal("Expected done to wait for every logger of the tee")
+	}
+	lost := errors.New("lost")
+	second.flush(lost)
+	if calls != 1 || result != lost {
+		t.Errorf("Expected done called once with the error, got %d calls with %v", calls, result)
+	}
+
+	calls = 0
+	tee := NewTeeLogger(&durableLogger{}, &failingLogger{err: lost})
+	if err := LogDurably(tee, &spade.Event{}, nil, func(error) { calls++ }); err != lost {
+		t.Errorf("Expected the tee to fail, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected done not to be called for a failed write, got %d calls", calls)
+	}
+}
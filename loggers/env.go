@@ -0,0 +1,37 @@
+package loggers
+
+import (
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+// Env is what the loggers of an edge share. A nil Env serializes events in
+// the latest envelope, without an edge version, and tells the time by
+// clock.Real.
+type Env struct {
+	// Envelope is how events are serialized.
+	Envelope *Envelope
+
+	// Clock is what the loggers tell the time, and flush and rotate, by.
+	// Latencies are still timed by the system clock.
+	Clock clock.Clock
+
+	// upstreamHops are those of the batches relayed to the edge, see
+	// ObserveRelayHops.
+	upstreamHops upstreamHops
+}
+
+// envelope returns the envelope events are serialized in.
+func (env *Env) envelope() *Envelope {
+	if env == nil || env.Envelope == nil {
+		return defaultEnvelope
+	}
+	return env.Envelope
+}
+
+// clock returns the clock the loggers tell the time by.
+func (env *Env) clock() clock.Clock {
+	if env == nil || env.Clock == nil {
+		return clock.Real
+	}
+	return env.Clock
+}
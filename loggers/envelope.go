@@ -0,0 +1,91 @@
+package loggers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// Serialization versions of the events the edge writes. Bump
+// LatestSerialization when changing what is written, so downstream
+// processors can tell records apart.
+const (
+	// SerializationV1 is spade.Event as is.
+	SerializationV1 = 1
+
+	// SerializationV2 adds edgeVersion and serializationVersion to
+	// SerializationV1.
+	SerializationV2 = 2
+
+	// LatestSerialization is the version written unless pinned.
+	LatestSerialization = SerializationV2
+)
+
+// EnvelopeConfig configures the serialization of events.
+type EnvelopeConfig struct {
+	// Version pins the serialization version written, e.g. while consumers
+	// migrate. It defaults to LatestSerialization.
+	Version int
+}
+
+// Validate verifies that an EnvelopeConfig is valid and fills in defaults
+func (c *EnvelopeConfig) Validate() error {
+	if c.Version == 0 {
+		c.Version = LatestSerialization
+	}
+	if c.Version < SerializationV1 || c.Version > LatestSerialization {
+		return fmt.Errorf("Version must be between %d and %d", SerializationV1, LatestSerialization)
+	}
+	return nil
+}
+
+// Envelope is how an edge serializes events.
+type Envelope struct {
+	version     int
+	edgeVersion string
+	stats       statsd.StatSender
+	stat        string
+}
+
+// defaultEnvelope is the envelope of loggers without an Env: the latest
+// serialization, without an edge version, uncounted.
+var defaultEnvelope *Envelope
+
+func init() {
+	noop, _ := statsd.NewNoop()
+	defaultEnvelope = &Envelope{version: LatestSerialization, stats: noop,
+		stat: "serialization.v" + strconv.Itoa(LatestSerialization)}
+}
+
+// NewEnvelope returns the Envelope config describes. The edgeVersion is
+// written in SerializationV2 and later, and the number of events written per
+// version is counted under serialization.v<version>.
+func NewEnvelope(config EnvelopeConfig, edgeVersion string, stats statsd.StatSender) (*Envelope, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		version:     config.Version,
+		edgeVersion: edgeVersion,
+		stats:       stats,
+		stat:        "serialization.v" + strconv.Itoa(config.Version),
+	}, nil
+}
+
+// envelopedEvent is a SerializationV2 event.
+type envelopedEvent struct {
+	*spade.Event
+	EdgeVersion          string `json:"edgeVersion"`
+	SerializationVersion int    `json:"serializationVersion"`
+}
+
+// wrap returns the value to serialize for e.
+func (env *Envelope) wrap(e *spade.Event) interface{} {
+	_ = env.stats.Inc(env.stat, 1, 0.01)
+	if env.version == SerializationV1 {
+		return e
+	}
+	return envelopedEvent{Event: e, EdgeVersion: env.edgeVersion, SerializationVersion: env.version}
+}
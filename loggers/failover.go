@@ -0,0 +1,213 @@
+package loggers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+const (
+	failoverStatsPrefix      = "logger.s3.failover."
+	defaultFailoverThreshold = 3
+	defaultFailoverCooldown  = "5m"
+)
+
+// FailoverBucket is a bucket S3 log files are uploaded to when the buckets
+// before it are failing.
+type FailoverBucket struct {
+	Bucket string
+
+	// Region is the bucket's region, if it's not the edge's
+	Region string
+}
+
+// failoverSuffix is added to the keys of files uploaded to a failover bucket,
+// followed by the bucket they were meant for, so processing knows where they
+// belong, e.g. 1496323800.node.0123456789abcdef.failover-spade-edge-events.log.gz
+const failoverSuffix = ".failover-"
+
+func validateFailover(c *S3LoggerConfig) error {
+	if c.FailoverThreshold == 0 {
+		c.FailoverThreshold = defaultFailoverThreshold
+	}
+	if c.FailoverThreshold < 0 {
+		return errors.New("FailoverThreshold must be greater than 0")
+	}
+	if c.FailoverCooldown == "" {
+		c.FailoverCooldown = defaultFailoverCooldown
+	}
+	if d, err := time.ParseDuration(c.FailoverCooldown); err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FailoverCooldown, err)
+	} else if d <= 0 {
+		return errors.New("FailoverCooldown must be greater than 0")
+	}
+	seen := map[string]bool{c.Bucket: true}
+	for _, b := range c.FailoverBuckets {
+		if b.Bucket == "" {
+			return errors.New("FailoverBuckets: Bucket is required")
+		}
+		if seen[b.Bucket] {
+			return fmt.Errorf("FailoverBuckets: %s is listed twice", b.Bucket)
+		}
+		seen[b.Bucket] = true
+	}
+	return nil
+}
+
+type failoverTarget struct {
+	bucket   string
+	uploader s3manageriface.UploaderAPI
+
+	// failures counts consecutive failures; the target is skipped until
+	// unhealthyUntil once they reach the threshold.
+	failures       int
+	unhealthyUntil time.Time
+}
+
+type failoverUploader struct {
+	primary   string
+	threshold int
+	cooldown  time.Duration
+	stats     statsd.StatSender
+	clock     clock.Clock
+
+	mu      sync.Mutex
+	targets []*failoverTarget
+}
+
+// NewFailoverUploader returns an uploader for the files of an S3 logger for
+// config, which uploads them to config.Bucket with primary, or while it's
+// failing, to the first healthy bucket of config.FailoverBuckets with the
+// uploader regional returns for its region. A bucket is unhealthy once
+// FailoverThreshold uploads in a row fail with a 5xx or network error, and is
+// skipped until FailoverCooldown passes.
+func NewFailoverUploader(
+	primary s3manageriface.UploaderAPI,
+	config S3LoggerConfig,
+	regional func(region string) s3manageriface.UploaderAPI,
+	stats statsd.StatSender,
+	env *Env,
+) (s3manageriface.UploaderAPI, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	u := &failoverUploader{
+		primary:   config.Bucket,
+		threshold: config.FailoverThreshold,
+		stats:     stats,
+		clock:     env.clock(),
+		targets:   []*failoverTarget{{bucket: config.Bucket, uploader: primary}},
+	}
+	u.cooldown, _ = time.ParseDuration(config.FailoverCooldown)
+	for _, b := range config.FailoverBuckets {
+		uploader := primary
+		if b.Region != "" {
+			uploader = regional(b.Region)
+		}
+		u.targets = append(u.targets, &failoverTarget{bucket: b.Bucket, uploader: uploader})
+	}
+	return u, nil
+}
+
+// healthy returns the targets to try in order: the healthy ones, or every
+// one if none are.
+func (u *failoverUploader) healthy() []*failoverTarget {
+	now := u.clock.Now()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	healthy := make([]*failoverTarget, 0, len(u.targets))
+	for _, t := range u.targets {
+		if !now.Before(t.unhealthyUntil) {
+			healthy = append(healthy, t)
+		}
+	}
+	if len(healthy) == 0 {
+		return append(healthy, u.targets...)
+	}
+	return healthy
+}
+
+func (u *failoverUploader) record(t *failoverTarget, err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err == nil {
+		t.failures = 0
+		return
+	}
+	t.failures++
+	if t.failures == u.threshold {
+		t.unhealthyUntil = u.clock.Now().Add(u.cooldown)
+		t.failures = 0
+		logger.WithError(err).WithField("bucket", t.bucket).Warn("S3 bucket failing, failing over")
+		_ = u.stats.Inc(failoverStatsPrefix+"unhealthy", 1, 1)
+	}
+}
+
+// shouldFailover reports whether err is a server or network error, which
+// another bucket might not have.
+func shouldFailover(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() >= 500
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == "RequestError"
+	}
+	return false
+}
+
+func (u *failoverUploader) Upload(input *s3manager.UploadInput,
+	options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	var err error
+	for i, t := range u.healthy() {
+		if i > 0 {
+			seeker, ok := input.Body.(io.Seeker)
+			if !ok {
+				break
+			}
+			if _, err = seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+		attempt := *input
+		attempt.Bucket = aws.String(t.bucket)
+		if t.bucket != u.primary {
+			attempt.Key = aws.String(failoverKey(aws.StringValue(input.Key), u.primary))
+		}
+
+		var output *s3manager.UploadOutput
+		output, err = t.uploader.Upload(&attempt, options...)
+		if err == nil || shouldFailover(err) {
+			u.record(t, err)
+		}
+		if err == nil {
+			if t.bucket != u.primary {
+				_ = u.stats.Inc(failoverStatsPrefix+"uploaded", 1, 1)
+			}
+			return output, nil
+		}
+		if !shouldFailover(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// failoverKey marks key as uploaded to a failover bucket instead of primary.
+func failoverKey(key, primary string) string {
+	const ext = ".log.gz"
+	if strings.HasSuffix(key, ext) {
+		return strings.TrimSuffix(key, ext) + failoverSuffix + primary + ext
+	}
+	return key + failoverSuffix + primary
+}
@@ -0,0 +1,132 @@
+package loggers
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+type failingUploader struct {
+	recordingUploader
+	err error
+}
+
+func (u *failingUploader) Upload(input *s3manager.UploadInput, options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	if u.err != nil {
+		u.Lock()
+		u.inputs = append(u.inputs, input)
+		u.Unlock()
+		return nil, u.err
+	}
+	return u.recordingUploader.Upload(input, options...)
+}
+
+func TestFailoverUploader(t *testing.T) {
+	primary := &failingUploader{err: awserr.NewRequestFailure(awserr.New("InternalError", "", nil), 500, "id")}
+	east := &failingUploader{}
+	stats, _ := statsd.NewNoop()
+	config := S3LoggerConfig{
+		Bucket:            "events",
+		MaxLines:          1,
+		MaxAge:            "1m",
+		FailoverBuckets:   []FailoverBucket{{Bucket: "events-east", Region: "us-east-1"}},
+		FailoverThreshold: 2,
+	}
+	regional := func(region string) s3manageriface.UploaderAPI {
+		if region != "us-east-1" {
+			t.Errorf("Unexpected region %s", region)
+		}
+		return east
+	}
+	api, err := NewFailoverUploader(primary, config, regional, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create uploader: %s", err)
+	}
+	u := api.(*failoverUploader)
+	fake := clock.NewFake(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+	u.clock = fake
+
+	upload := func() error {
+		_, err := u.Upload(&s3manager.UploadInput{
+			Bucket: aws.String("events"),
+			Key:    aws.String("20170601/asg/1.node.0.log.gz"),
+			Body:   bytes.NewReader([]byte("body")),
+		})
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		if err = upload(); err != nil {
+			t.Fatalf("Failed to upload: %s", err)
+		}
+	}
+	if len(primary.inputs) != 2 || len(east.inputs) != 2 {
+		t.Fatalf("Expected both files to be tried on both buckets, got %d and %d", len(primary.inputs), len(east.inputs))
+	}
+	if k := aws.StringValue(east.inputs[0].Key); k != "20170601/asg/1.node.0.failover-events.log.gz" {
+		t.Errorf("Unexpected failover key %s", k)
+	}
+	if b := aws.StringValue(east.inputs[0].Bucket); b != "events-east" || string(east.bodies[0]) != "body" {
+		t.Errorf("Expected the whole file in events-east, got %q in %s", east.bodies[0], b)
+	}
+
+	// The primary is now unhealthy, so it's skipped until the cooldown passes.
+	_ = upload()
+	if len(primary.inputs) != 2 || len(east.inputs) != 3 {
+		t.Errorf("Expected the unhealthy primary to be skipped, got %d uploads", len(primary.inputs))
+	}
+	fake.Advance(5 * time.Minute)
+	primary.err = nil
+	_ = upload()
+	if len(primary.inputs) != 3 || len(east.inputs) != 3 {
+		t.Errorf("Expected the primary to be used after the cooldown, got %d uploads", len(primary.inputs))
+	}
+}
+
+func TestFailoverClientErrors(t *testing.T) {
+	primary := &failingUploader{err: awserr.NewRequestFailure(awserr.New("AccessDenied", "", nil), 403, "id")}
+	secondary := &failingUploader{}
+	stats, _ := statsd.NewNoop()
+	config := S3LoggerConfig{
+		Bucket:          "events",
+		MaxLines:        1,
+		MaxAge:          "1m",
+		FailoverBuckets: []FailoverBucket{{Bucket: "events-backup", Region: "us-east-1"}},
+	}
+	regional := func(string) s3manageriface.UploaderAPI { return secondary }
+	u, err := NewFailoverUploader(primary, config, regional, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create uploader: %s", err)
+	}
+	_, err = u.Upload(&s3manager.UploadInput{Key: aws.String("key.log.gz"), Body: bytes.NewReader(nil)})
+	if err != primary.err {
+		t.Errorf("Expected the 403 to be returned, got %v", err)
+	}
+	if len(secondary.inputs) != 0 {
+		t.Error("Expected a 403 not to fail over")
+	}
+
+	if !shouldFailover(awserr.New("RequestError", "send request failed", errors.New("timeout"))) {
+		t.Error("Expected network errors to fail over")
+	}
+}
+
+func TestValidateFailover(t *testing.T) {
+	for _, buckets := range [][]FailoverBucket{
+		{{Bucket: "events"}},
+		{{Bucket: "backup"}, {Bucket: "backup"}},
+		{{Region: "us-east-1"}},
+	} {
+		config := S3LoggerConfig{Bucket: "events", MaxLines: 1, MaxAge: "1m", FailoverBuckets: buckets}
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected %v to be invalid", buckets)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package loggers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+	"github.com/twitchscience/spade_edge/notify"
+)
+
+// The events fallback notifications are sent for.
+const (
+	FallbackEngaged   = "fallback_engaged"
+	FallbackRecovered = "fallback_recovered"
+)
+
+const (
+	defaultFallbackQuietPeriod = "1m"
+	defaultFallbackMinInterval = "10m"
+)
+
+// FallbackNotificationConfig configures the notifications sent when the
+// Kinesis loggers start and stop writing events to their fallback.
+type FallbackNotificationConfig struct {
+	// Targets are where the notifications are sent
+	Targets notify.Config
+
+	// QuietPeriod is how long the fallback must go without events for it to
+	// have recovered, e.g. "1m"
+	QuietPeriod string
+
+	// MinInterval is the least time between notifications, e.g. "10m".
+	// Changes in between are held back, and those that are undone before it
+	// passes are only counted.
+	MinInterval string
+}
+
+// Validate verifies that a FallbackNotificationConfig is valid and fills in
+// defaults
+func (c *FallbackNotificationConfig) Validate() error {
+	if err := c.Targets.Validate(); err != nil {
+		return fmt.Errorf("Targets: %v", err)
+	}
+	if c.QuietPeriod == "" {
+		c.QuietPeriod = defaultFallbackQuietPeriod
+	}
+	if c.MinInterval == "" {
+		c.MinInterval = defaultFallbackMinInterval
+	}
+	for _, d := range []string{c.QuietPeriod, c.MinInterval} {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed <= 0 {
+			return errors.New("QuietPeriod and MinInterval must be greater than 0")
+		}
+	}
+	return nil
+}
+
+type fallbackNotifier struct {
+	fallback    SpadeEdgeLogger
+	notifier    notify.Notifier
+	statter     statsd.StatSender
+	clock       clock.Clock
+	quietPeriod time.Duration
+	minInterval time.Duration
+
+	// Updated by the loggers writing to the fallback.
+	events int64
+	failed int64
+
+	// Only used by the checking loop.
+	seen      int64
+	lastEvent time.Time
+	engaged   bool
+	since     time.Time
+	changes   int64
+	sent      struct {
+		at      time.Time
+		engaged bool
+		events  int64
+		failed  int64
+		changes int64
+	}
+
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewFallbackNotifier returns a SpadeEdgeLogger writing events to fallback
+// that sends a FallbackEngaged notification when events start arriving and a
+// FallbackRecovered one when they've stopped for the QuietPeriod, with the
+// numbers of events written and failed since the last notification. At most
+// one notification is sent per MinInterval; if the fallback engages and
+// recovers in between, only the number of those changes is reported, with
+// the next notification.
+func NewFallbackNotifier(config FallbackNotificationConfig, fallback SpadeEdgeLogger, notifier notify.Notifier,
+	statter statsd.StatSender, env *Env) (SpadeEdgeLogger, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	fn := &fallbackNotifier{
+		fallback: fallback,
+		notifier: notifier,
+		statter:  statter,
+		clock:    env.clock(),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	fn.quietPeriod, _ = time.ParseDuration(config.QuietPeriod)
+	fn.minInterval, _ = time.ParseDuration(config.MinInterval)
+	interval := fn.quietPeriod / 4
+	if interval > time.Second {
+		interval = time.Second
+	}
+	logger.Go(func() { fn.run(interval) })
+	return fn, nil
+}
+
+func (fn *fallbackNotifier) Log(e *spade.Event) error {
+	return fn.LogSerialized(e, nil)
+}
+
+func (fn *fallbackNotifier) LogSerialized(e *spade.Event, serialized []byte) error {
+	return fn.LogDurably(e, serialized, nil)
+}
+
+// LogDurably hands the event to the fallback durably, so done is called once
+// the fallback has written it.
+func (fn *fallbackNotifier) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	atomic.AddInt64(&fn.events, 1)
+	err := LogDurably(fn.fallback, e, serialized, done)
+	if err != nil {
+		atomic.AddInt64(&fn.failed, 1)
+	}
+	return err
+}
+
+func (fn *fallbackNotifier) run(interval time.Duration) {
+	defer close(fn.done)
+	ticker := fn.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fn.quit:
+			return
+		case <-ticker.C():
+			fn.check(fn.clock.Now())
+		}
+	}
+}
+
+// check moves between engaged and recovered as events arrive and stop, and
+// sends the current state if it differs from the last one sent and
+// MinInterval has passed.
+func (fn *fallbackNotifier) check(now time.Time) {
+	events := atomic.LoadInt64(&fn.events)
+	if events != fn.seen {
+		fn.seen = events
+		fn.lastEvent = now
+		if !fn.engaged {
+			fn.engaged = true
+			fn.since = now
+			fn.changes++
+		}
+	} else if fn.engaged && now.Sub(fn.lastEvent) >= fn.quietPeriod {
+		fn.engaged = false
+		fn.changes++
+	}
+	if fn.engaged == fn.sent.engaged || now.Sub(fn.sent.at) < fn.minInterval {
+		return
+	}
+
+	failed := atomic.LoadInt64(&fn.failed)
+	n := &notify.Notification{
+		Event: FallbackRecovered,
+		Time:  now,
+		Counts: map[string]int64{
+			"events": events - fn.sent.events,
+			"failed": failed - fn.sent.failed,
+			// The changes beyond this one since the last notification.
+			"suppressed_changes": fn.changes - fn.sent.changes - 1,
+		},
+	}
+	if fn.engaged {
+		n.Event = FallbackEngaged
+		n.Message = fmt.Sprintf("Kinesis has been falling back since %s", fn.since.Format(time.RFC3339))
+	} else {
+		n.Message = fmt.Sprintf("Kinesis stopped falling back at %s", fn.lastEvent.Format(time.RFC3339))
+	}
+	fn.sent.at = now
+	fn.sent.engaged = fn.engaged
+	fn.sent.events = events
+	fn.sent.failed = failed
+	fn.sent.changes = fn.changes
+	if err := fn.notifier.Notify(n); err != nil {
+		logger.WithError(err).WithField("event", n.Event).Error("Failed to send fallback notification")
+		return
+	}
+	_ = fn.statter.Inc("logger.fallback.notifications."+n.Event, 1, 1)
+}
+
+func (fn *fallbackNotifier) Close() {
+	fn.stopOnce.Do(func() {
+		close(fn.quit)
+		<-fn.done
+		fn.fallback.Close()
+	})
+}
@@ -0,0 +1,65 @@
+package loggers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/notify"
+)
+
+type recordingNotifier []*notify.Notification
+
+func (r *recordingNotifier) Notify(n *notify.Notification) error {
+	*r = append(*r, n)
+	return nil
+}
+
+func TestFallbackNotifier(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	notes := &recordingNotifier{}
+	fn := &fallbackNotifier{
+		fallback:    &failingLogger{},
+		notifier:    notes,
+		statter:     stats,
+		quietPeriod: time.Minute,
+		minInterval: 10 * time.Minute,
+	}
+	now := time.Unix(1500000000, 0)
+	step := func(d time.Duration, events int) {
+		for i := 0; i < events; i++ {
+			_ = fn.Log(&spade.Event{})
+		}
+		now = now.Add(d)
+		fn.check(now)
+	}
+	expect := func(event string, events, suppressed int64) {
+		if len(*notes) == 0 {
+			t.Fatalf("Expected a %s notification", event)
+		}
+		n := (*notes)[0]
+		*notes = (*notes)[1:]
+		if n.Event != event || n.Counts["events"] != events || n.Counts["suppressed_changes"] != suppressed {
+			t.Errorf("Expected %s with %d events and %d suppressed changes, got %+v", event, events, suppressed, n)
+		}
+	}
+
+	step(time.Second, 0)
+	if len(*notes) != 0 {
+		t.Fatalf("Expected no notification while Kinesis is healthy, got %+v", (*notes)[0])
+	}
+	step(time.Second, 5)
+	expect(FallbackEngaged, 5, 0)
+	step(30*time.Second, 5)
+	step(time.Minute, 0)
+	// The recovery comes too soon after the last notification.
+	if len(*notes) != 0 {
+		t.Fatalf("Expected the recovery to be held back, got %+v", (*notes)[0])
+	}
+	// Flapping in the meantime is only counted.
+	step(time.Minute, 1)
+	step(time.Minute, 0)
+	step(10*time.Minute, 0)
+	expect(FallbackRecovered, 6, 2)
+}
@@ -0,0 +1,130 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+)
+
+// HeaderKey is the key of the header record at the start of the files
+// uploaded by a header uploader, so readers can tell it from events.
+const HeaderKey = "spade_edge_header"
+
+type headerUploader struct {
+	s3manageriface.UploaderAPI
+	header func() interface{}
+}
+
+// NewHeaderUploader returns an uploader that starts each gzipped file
+// uploaded with inner with a record {"spade_edge_header": header()}. The
+// record is a gzip member of its own, so files decompress to it followed by
+// their lines. Other files are uploaded as they are.
+func NewHeaderUploader(inner s3manageriface.UploaderAPI, header func() interface{}) s3manageriface.UploaderAPI {
+	return &headerUploader{UploaderAPI: inner, header: header}
+}
+
+func (u *headerUploader) Upload(input *s3manager.UploadInput,
+	options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	if input.ContentType == nil || *input.ContentType != "application/x-gzip" {
+		return u.UploaderAPI.Upload(input, options...)
+	}
+	prefix, err := u.record()
+	if err != nil {
+		return nil, err
+	}
+	body, ok := input.Body.(io.ReadSeeker)
+	if !ok {
+		b, readErr := ioutil.ReadAll(input.Body)
+		if readErr != nil {
+			return nil, readErr
+		}
+		body = bytes.NewReader(b)
+	}
+	withHeader := *input
+	if withHeader.Body, err = newPrefixedReader(prefix, body); err != nil {
+		return nil, err
+	}
+	return u.UploaderAPI.Upload(&withHeader, options...)
+}
+
+// record returns the gzipped header record.
+func (u *headerUploader) record() ([]byte, error) {
+	line, err := json.Marshal(map[string]interface{}{HeaderKey: u.header()})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// prefixedReader reads prefix and then body, seeking through both, so the
+// uploader can retry it and the manifest uploader hash it.
+type prefixedReader struct {
+	prefix []byte
+	body   io.ReadSeeker
+	// start is the body's offset when it was given, and size the length of
+	// both from there.
+	start  int64
+	size   int64
+	offset int64
+}
+
+func newPrefixedReader(prefix []byte, body io.ReadSeeker) (*prefixedReader, error) {
+	start, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = body.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &prefixedReader{prefix: prefix, body: body, start: start, size: int64(len(prefix)) + end - start}, nil
+}
+
+func (r *prefixedReader) Read(p []byte) (int, error) {
+	if r.offset < int64(len(r.prefix)) {
+		n := copy(p, r.prefix[r.offset:])
+		r.offset += int64(n)
+		return n, nil
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *prefixedReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("seek before the start")
+	}
+	bodyOffset := offset - int64(len(r.prefix))
+	if bodyOffset < 0 {
+		bodyOffset = 0
+	}
+	if _, err := r.body.Seek(r.start+bodyOffset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	r.offset = offset
+	return offset, nil
+}
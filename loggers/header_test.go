@@ -0,0 +1,98 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func TestHeaderUploader(t *testing.T) {
+	inner := &recordingUploader{}
+	stats, _ := statsd.NewNoop()
+	manifests, _ := NewManifestUploader(inner, IntegrityConfig{}, stats)
+	hash := "abc"
+	u := NewHeaderUploader(manifests, func() interface{} { return map[string]string{"configHash": hash} })
+
+	body := bytes.NewReader(gzipped(t, "a\nb\n"))
+	_, err := u.Upload(&s3manager.UploadInput{
+		Key:         aws.String("1.log.gz"),
+		ContentType: aws.String("application/x-gzip"),
+		Body:        body,
+	})
+	if err != nil {
+		t.Fatalf("Failed to upload: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(inner.bodies[0]))
+	if err != nil {
+		t.Fatalf("Expected a gzipped file, got %s", err)
+	}
+	lines, _ := ioutil.ReadAll(zr)
+	parts := strings.SplitN(string(lines), "\n", 2)
+	var header map[string]map[string]string
+	if err = json.Unmarshal([]byte(parts[0]), &header); err != nil || header[HeaderKey]["configHash"] != "abc" {
+		t.Errorf("Expected the header record first, got %q", parts[0])
+	}
+	if len(parts) < 2 || parts[1] != "a\nb\n" {
+		t.Errorf("Expected the file's lines after the header, got %q", lines)
+	}
+	var m Manifest
+	_ = json.Unmarshal(inner.bodies[1], &m)
+	if m.Bytes != int64(len(inner.bodies[0])) || m.Lines == nil || *m.Lines != 3 {
+		t.Errorf("Expected the manifest to describe the file with its header, got %+v", m)
+	}
+
+	// The header is built for each file.
+	hash = "def"
+	inner.inputs, inner.bodies = nil, nil
+	_, _ = u.Upload(&s3manager.UploadInput{
+		Key:         aws.String("2.log.gz"),
+		ContentType: aws.String("application/x-gzip"),
+		Body:        bytes.NewReader(gzipped(t, "c\n")),
+	})
+	zr, _ = gzip.NewReader(bytes.NewReader(inner.bodies[0]))
+	if lines, _ = ioutil.ReadAll(zr); !strings.Contains(string(lines), `"def"`) {
+		t.Errorf("Expected the header of the live config, got %q", lines)
+	}
+
+	inner.inputs, inner.bodies = nil, nil
+	_, _ = u.Upload(&s3manager.UploadInput{
+		Key:         aws.String("1.parquet"),
+		ContentType: aws.String("application/octet-stream"),
+		Body:        bytes.NewReader([]byte("PAR1")),
+	})
+	if string(inner.bodies[0]) != "PAR1" {
+		t.Errorf("Expected other files to be uploaded unchanged, got %q", inner.bodies[0])
+	}
+}
+
+func TestPrefixedReader(t *testing.T) {
+	body := strings.NewReader("xxbody")
+	_, _ = body.Seek(2, io.SeekStart)
+	r, err := newPrefixedReader([]byte("head:"), body)
+	if err != nil {
+		t.Fatalf("Failed to create reader: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		b, _ := ioutil.ReadAll(r)
+		if string(b) != "head:body" {
+			t.Errorf("Expected the prefix and the body, got %q", b)
+		}
+		if n, _ := r.Seek(0, io.SeekStart); n != 0 {
+			t.Errorf("Expected to seek to the start, got %d", n)
+		}
+	}
+	if n, _ := r.Seek(-3, io.SeekEnd); n != 6 {
+		t.Errorf("Expected to seek to 6, got %d", n)
+	}
+	if b, _ := ioutil.ReadAll(r); string(b) != "ody" {
+		t.Errorf("Expected the end of the body, got %q", b)
+	}
+}
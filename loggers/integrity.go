@@ -0,0 +1,225 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	integrityStatsPrefix  = "logger.s3.integrity."
+	defaultManifestPrefix = "manifests/"
+)
+
+// IntegrityConfig configures the verification of uploaded log files.
+type IntegrityConfig struct {
+	// ManifestPrefix is prepended to the key of an uploaded file to get the
+	// key of its manifest. It defaults to manifests/.
+	ManifestPrefix string
+}
+
+// Validate verifies that an IntegrityConfig is valid and fills in defaults
+func (c *IntegrityConfig) Validate() error {
+	if c.ManifestPrefix == "" {
+		c.ManifestPrefix = defaultManifestPrefix
+	}
+	if strings.HasPrefix(c.ManifestPrefix, "/") {
+		return errors.New("ManifestPrefix must not start with /")
+	}
+	return nil
+}
+
+// SetContentMD5 is a request handler that sets the Content-MD5 header of
+// S3 object and part uploads, so S3 rejects bodies corrupted in transit. It's
+// added to an S3 client with
+//
+//	client.Handlers.Build.PushBack(loggers.SetContentMD5)
+func SetContentMD5(r *request.Request) {
+	if r.Operation.Name != "PutObject" && r.Operation.Name != "UploadPart" || r.Body == nil {
+		return
+	}
+	start, err := r.Body.Seek(0, io.SeekCurrent)
+	if err == nil {
+		h := md5.New()
+		if _, err = io.Copy(h, r.Body); err == nil {
+			_, err = r.Body.Seek(start, io.SeekStart)
+			r.HTTPRequest.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(h.Sum(nil)))
+		}
+	}
+	if err != nil {
+		r.Error = awserr.New("ContentMD5", "failed to hash body", err)
+	}
+}
+
+// Manifest describes an uploaded log file, so downstream processing can
+// verify it was uploaded whole.
+type Manifest struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Bytes  int64  `json:"bytes"`
+	MD5    string `json:"md5"`
+	SHA256 string `json:"sha256"`
+
+	// Lines is the number of lines of gzipped files, once decompressed.
+	Lines *int64 `json:"lines,omitempty"`
+}
+
+type manifestUploader struct {
+	s3manageriface.UploaderAPI
+	prefix string
+	stats  statsd.StatSender
+}
+
+// NewManifestUploader returns an uploader that hashes each file uploaded with
+// inner, sets the hashes as the object's sha256 and md5 metadata, and uploads
+// a JSON Manifest of it under config.ManifestPrefix once the file is uploaded.
+func NewManifestUploader(inner s3manageriface.UploaderAPI, config IntegrityConfig,
+	stats statsd.StatSender) (s3manageriface.UploaderAPI, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifestUploader{UploaderAPI: inner, prefix: config.ManifestPrefix, stats: stats}, nil
+}
+
+func (u *manifestUploader) Upload(input *s3manager.UploadInput,
+	options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	body, ok := input.Body.(io.ReadSeeker)
+	if !ok {
+		b, err := ioutil.ReadAll(input.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+	manifest := &Manifest{Bucket: aws.StringValue(input.Bucket), Key: aws.StringValue(input.Key)}
+	if err := u.describe(body, aws.StringValue(input.ContentType), manifest); err != nil {
+		return nil, err
+	}
+
+	withHashes := *input
+	withHashes.Body = body
+	withHashes.Metadata = map[string]*string{
+		"md5":    aws.String(manifest.MD5),
+		"sha256": aws.String(manifest.SHA256),
+	}
+	for k, v := range input.Metadata {
+		withHashes.Metadata[k] = v
+	}
+	output, err := u.UploaderAPI.Upload(&withHashes, options...)
+	if err != nil {
+		return output, err
+	}
+
+	b, _ := json.Marshal(manifest)
+	_, err = u.UploaderAPI.Upload(&s3manager.UploadInput{
+		Bucket:      input.Bucket,
+		Key:         aws.String(u.prefix + manifest.Key + ".json"),
+		ACL:         input.ACL,
+		ContentType: aws.String("application/json"),
+		Body:        bytes.NewReader(b),
+	}, options...)
+	if err != nil {
+		// The file is uploaded, so failing the upload would only get it
+		// uploaded again; downstream treats it as unverified instead.
+		logger.WithError(err).WithField("key", manifest.Key).Error("Error uploading manifest")
+		_ = u.stats.Inc(integrityStatsPrefix+"manifest_failed", 1, 1)
+	}
+	return output, nil
+}
+
+// describe fills in the size, hashes and, for gzipped files, line count of
+// body, leaving it at its start.
+func (u *manifestUploader) describe(body io.ReadSeeker, contentType string, m *Manifest) error {
+	md5Hash, sha256Hash := md5.New(), sha256.New()
+	var lines *lineCounter
+	var w io.Writer = io.MultiWriter(md5Hash, sha256Hash)
+	if contentType == "application/x-gzip" {
+		lines = newLineCounter()
+		w = io.MultiWriter(w, lines)
+	}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return err
+	}
+	if _, err = body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	m.Bytes = n
+	m.MD5 = hexSum(md5Hash)
+	m.SHA256 = hexSum(sha256Hash)
+	if lines != nil {
+		count, countErr := lines.close()
+		if countErr != nil {
+			// Upload it anyway: the manifest without a line count flags it.
+			logger.WithError(countErr).WithField("key", m.Key).Warn("Uploading a corrupt gzip file")
+			_ = u.stats.Inc(integrityStatsPrefix+"corrupt", 1, 1)
+		} else {
+			m.Lines = &count
+		}
+	}
+	return nil
+}
+
+func hexSum(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lineCounter counts the lines of the gzipped stream written to it.
+type lineCounter struct {
+	pw    *io.PipeWriter
+	count chan int64
+	err   chan error
+}
+
+func newLineCounter() *lineCounter {
+	pr, pw := io.Pipe()
+	c := &lineCounter{pw: pw, count: make(chan int64, 1), err: make(chan error, 1)}
+	logger.Go(func() {
+		var lines int64
+		zr, err := gzip.NewReader(pr)
+		if err == nil {
+			buf := make([]byte, 32*1024)
+			for {
+				n, readErr := zr.Read(buf)
+				lines += int64(bytes.Count(buf[:n], []byte{'\n'}))
+				if readErr != nil {
+					if readErr != io.EOF {
+						err = readErr
+					}
+					break
+				}
+			}
+		}
+		// Drain the rest so writes never block on a corrupt stream.
+		_, _ = io.Copy(ioutil.Discard, pr)
+		c.count <- lines
+		c.err <- err
+	})
+	return c
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	return c.pw.Write(p)
+}
+
+func (c *lineCounter) close() (int64, error) {
+	_ = c.pw.Close()
+	return <-c.count, <-c.err
+}
@@ -0,0 +1,119 @@
+package loggers
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+type recordingUploader struct {
+	sync.Mutex
+	inputs []*s3manager.UploadInput
+	bodies [][]byte
+}
+
+func (u *recordingUploader) Upload(input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	u.Lock()
+	defer u.Unlock()
+	b, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	u.inputs = append(u.inputs, input)
+	u.bodies = append(u.bodies, b)
+	return &s3manager.UploadOutput{}, nil
+}
+
+func TestManifestUploader(t *testing.T) {
+	inner := &recordingUploader{}
+	stats, _ := statsd.NewNoop()
+	u, err := NewManifestUploader(inner, IntegrityConfig{}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create uploader: %s", err)
+	}
+
+	body := gzipped(t, "a\nb\nc\n")
+	upload := func(key string, body []byte) Manifest {
+		inner.inputs, inner.bodies = nil, nil
+		_, err = u.Upload(&s3manager.UploadInput{
+			Bucket:      aws.String("bucket"),
+			Key:         aws.String(key),
+			ContentType: aws.String("application/x-gzip"),
+			Body:        bytes.NewReader(body),
+		})
+		if err != nil {
+			t.Fatalf("Failed to upload: %s", err)
+		}
+		if len(inner.inputs) != 2 {
+			t.Fatalf("Expected the file and its manifest to be uploaded, got %d uploads", len(inner.inputs))
+		}
+		if !bytes.Equal(inner.bodies[0], body) {
+			t.Error("Expected the file to be uploaded unchanged")
+		}
+		if k := aws.StringValue(inner.inputs[1].Key); k != "manifests/"+key+".json" {
+			t.Errorf("Unexpected manifest key %s", k)
+		}
+		var m Manifest
+		if err = json.Unmarshal(inner.bodies[1], &m); err != nil {
+			t.Fatalf("Failed to unmarshal manifest: %s", err)
+		}
+		if aws.StringValue(inner.inputs[0].Metadata["sha256"]) != m.SHA256 {
+			t.Error("Expected the file's sha256 metadata to match its manifest")
+		}
+		return m
+	}
+
+	m := upload("20170601/asg/1.node.0.log.gz", body)
+	sum := md5.Sum(body)
+	if m.Bytes != int64(len(body)) || m.Lines == nil || *m.Lines != 3 || m.MD5 != hex.EncodeToString(sum[:]) {
+		t.Errorf("Unexpected manifest %+v", m)
+	}
+
+	if m = upload("20170601/asg/2.node.0.log.gz", body[:len(body)-8]); m.Lines != nil {
+		t.Errorf("Expected a corrupt file's manifest to have no line count, got %d", *m.Lines)
+	}
+}
+
+func TestSetContentMD5(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Content-MD5")
+	}))
+	defer server.Close()
+
+	client := s3.New(session.New(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-west-2"),
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+		S3ForcePathStyle: aws.Bool(true),
+		DisableSSL:       aws.Bool(true),
+	}))
+	client.Handlers.Build.PushBack(SetContentMD5)
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+		Body:   strings.NewReader("body"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to put object: %s", err)
+	}
+	sum := md5.Sum([]byte("body"))
+	if header != base64.StdEncoding.EncodeToString(sum[:]) {
+		t.Errorf("Expected the body's Content-MD5, got %q", header)
+	}
+}
@@ -3,7 +3,6 @@ package loggers
 import (
 	"bytes"
 	"compress/flate"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -15,12 +14,17 @@ import (
 	"github.com/cactus/go-statsd-client/statsd"
 	"github.com/twitchscience/aws_utils/logger"
 	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/avro"
+	"github.com/twitchscience/spade_edge/clock"
 )
 
 const (
 	maxBatchLength          = 500
 	maxBatchSize            = 5 * 1024 * 1024
 	compressionVersion byte = 1
+
+	formatJSON = "json"
+	formatAvro = "avro"
 )
 
 var (
@@ -28,7 +32,8 @@ var (
 )
 
 // KinesisLoggerConfig is used to configure a new SpadeEdgeLogger that writes to
-// an AWS Kinesis stream. There are no default values and all fields are required.
+// an AWS Kinesis stream. There are no default values and all fields are required unless
+// noted otherwise.
 type KinesisLoggerConfig struct {
 	// StreamName is the name of the Kinesis stream we are producing events into
 	StreamName string
@@ -51,6 +56,11 @@ type KinesisLoggerConfig struct {
 	// GlobAge is the max age of the oldest record in the glob
 	GlobAge string
 
+	// IdleFlush, if set, submits the partial glob and batch once no event has
+	// arrived for this long, e.g. "250ms", so events logged during a lull
+	// aren't held for GlobAge and BatchAge. It is optional.
+	IdleFlush string
+
 	// BufferLength is the length of the buffer in front of the kinesis production code. If the buffer fills
 	// up events will be written to the fallback logger
 	BufferLength uint
@@ -61,6 +71,30 @@ type KinesisLoggerConfig struct {
 
 	// RetryDelay is how long to delay between retries on failed attempts to write to kinesis
 	RetryDelay string
+
+	// RetryPolicies, if set, override MaxAttemptsPerRecord and RetryDelay for the failures of
+	// an error class (throttled, internal_error, validation, network or unknown) or a Kinesis
+	// error code, which takes precedence. It is optional.
+	RetryPolicies map[string]*KinesisRetryPolicy
+
+	// FallbackMaxEventAge, if set, drops events older than this instead of writing them to the
+	// fallback logger, e.g. "6h". Unlike the other fields it is optional.
+	FallbackMaxEventAge string
+
+	// Format is how globs are encoded: "json", a compressed JSON array of events, or "avro",
+	// an Avro array of events in the Confluent wire format. It is optional and defaults to json.
+	Format string
+
+	// SchemaRegistryURL is the schema registry the avro glob schema is registered with on
+	// startup. It is required for the avro format.
+	SchemaRegistryURL string
+
+	// SchemaSubject is the subject the avro glob schema is registered under. It is optional
+	// and defaults to "<StreamName>-value".
+	SchemaSubject string
+
+	// Verify, if set, compares a sample of globs with a candidate encoding. It is optional.
+	Verify *VerifyConfig
 }
 
 // Validate verifies that a KinesisLoggerConfig is valid, and updates any internal members
@@ -99,6 +133,68 @@ func (c *KinesisLoggerConfig) Validate() error {
 		return errors.New("MaxAttemptsPerRecord must be a positive value")
 	}
 
+	globAge, err := time.ParseDuration(c.GlobAge)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.GlobAge, err)
+	}
+
+	if globAge <= 0 {
+		return errors.New("GlobAge must be greater than 0")
+	}
+
+	if c.GlobLength <= 0 {
+		return errors.New("GlobLength must be a positive value")
+	}
+
+	if c.GlobSize <= 0 {
+		return errors.New("GlobSize must be a positive value")
+	}
+
+	switch c.Format {
+	case "":
+		c.Format = formatJSON
+	case formatJSON:
+	case formatAvro:
+		if c.SchemaRegistryURL == "" {
+			return errors.New("SchemaRegistryURL is required for the avro format")
+		}
+		if c.SchemaSubject == "" {
+			c.SchemaSubject = c.StreamName + "-value"
+		}
+	default:
+		return fmt.Errorf("unknown Format %s", c.Format)
+	}
+
+	if c.Verify != nil {
+		if err = c.Verify.Validate(c); err != nil {
+			return fmt.Errorf("Verify: %v", err)
+		}
+	}
+
+	if err = validateRetryPolicies(c); err != nil {
+		return err
+	}
+
+	if c.IdleFlush != "" {
+		idleFlush, err := time.ParseDuration(c.IdleFlush)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", c.IdleFlush, err)
+		}
+		if idleFlush <= 0 {
+			return errors.New("IdleFlush must be greater than 0")
+		}
+	}
+
+	if c.FallbackMaxEventAge != "" {
+		maxAge, err := time.ParseDuration(c.FallbackMaxEventAge)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FallbackMaxEventAge, err)
+		}
+		if maxAge <= 0 {
+			return errors.New("FallbackMaxEventAge must be greater than 0")
+		}
+	}
+
 	return nil
 }
 
@@ -106,25 +202,45 @@ type kinesisBatchEntry struct {
 	data        []byte
 	distkey     string
 	numRequests int
+	// done are the done callbacks of the glob's events, in order, or nil
+	// if none of them has one.
+	done []func(error)
 }
 
 type kinesisLogger struct {
 	client     *kinesis.Kinesis
-	incoming   chan *spade.Event
+	putRecord  func(*kinesis.PutRecordInput) (*kinesis.PutRecordOutput, error)
+	putRecords func(*kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error)
+	// policies are the RetryPolicies, by error class or code.
+	policies   map[string]retryPolicy
+	incoming   chan globEvent
 	batch      []kinesisBatchEntry
 	compressed chan kinesisBatchEntry
-	glob       []*spade.Event
-	globSize   int
-	batchSize  int
-	statter    statsd.Statter
-	fallback   SpadeEdgeLogger
-	config     KinesisLoggerConfig
+	// idle tells submitLoop to flush its batch, after compressLoop submitted
+	// its partial glob because no event arrived for IdleFlush.
+	idle      chan struct{}
+	glob      []globEvent
+	globSize  int
+	batchSize int
+	statter   statsd.Statter
+	fallback  SpadeEdgeLogger
+	config    KinesisLoggerConfig
+	maxAge    time.Duration
+	env       *Env
+	clock     clock.Clock
+	// avroGlobs is set if globs are encoded as avro with the registered
+	// schema schemaID
+	avroGlobs  bool
+	schemaID   int32
+	verifier   *verifier
 	compressor *flate.Writer
+	encoder    *jsonEncoder
 	sync.WaitGroup
 }
 
 // NewKinesisLogger creates a new SpadeEdgeLogger that writes to an AWS Kinesis stream and starts the main loop
-func NewKinesisLogger(client *kinesis.Kinesis, config KinesisLoggerConfig, fallback SpadeEdgeLogger, statter statsd.Statter) (SpadeEdgeLogger, error) {
+func NewKinesisLogger(client *kinesis.Kinesis, config KinesisLoggerConfig, fallback SpadeEdgeLogger, statter statsd.Statter,
+	env *Env) (SpadeEdgeLogger, error) {
 	err := config.Validate()
 	if err != nil {
 		return nil, err
@@ -132,12 +248,42 @@ func NewKinesisLogger(client *kinesis.Kinesis, config KinesisLoggerConfig, fallb
 
 	kl := &kinesisLogger{
 		client:     client,
-		incoming:   make(chan *spade.Event, config.BufferLength),
+		putRecord:  client.PutRecord,
+		putRecords: client.PutRecords,
+		policies:   config.retryPolicies(),
+		incoming:   make(chan globEvent, config.BufferLength),
 		compressed: make(chan kinesisBatchEntry),
+		idle:       make(chan struct{}),
 		batch:      make([]kinesisBatchEntry, 0, config.BatchLength),
 		config:     config,
 		fallback:   fallback,
 		statter:    statter,
+		env:        env,
+		clock:      env.clock(),
+		encoder:    newJSONEncoder(),
+		avroGlobs:  config.Format == formatAvro,
+	}
+	if kl.avroGlobs {
+		kl.schemaID, err = avro.NewRegistry(config.SchemaRegistryURL).Register(config.SchemaSubject, avro.GlobSchema)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.Verify != nil {
+		schemaID := kl.schemaID
+		if config.Verify.Format == formatAvro && !kl.avroGlobs {
+			subject := config.SchemaSubject
+			if subject == "" {
+				subject = config.StreamName + "-value"
+			}
+			if schemaID, err = avro.NewRegistry(config.SchemaRegistryURL).Register(subject, avro.GlobSchema); err != nil {
+				return nil, err
+			}
+		}
+		kl.verifier = newVerifier(*config.Verify, schemaID, client, statter, env)
+	}
+	if config.FallbackMaxEventAge != "" {
+		kl.maxAge, _ = time.ParseDuration(config.FallbackMaxEventAge)
 	}
 
 	kl.Add(2)
@@ -146,6 +292,11 @@ func NewKinesisLogger(client *kinesis.Kinesis, config KinesisLoggerConfig, fallb
 	return kl, nil
 }
 
+// Buffered returns the number of events waiting to be globbed.
+func (kl *kinesisLogger) Buffered() int {
+	return len(kl.incoming)
+}
+
 // addToBatch adds an event to the current batch if there is space, or flushes
 // the current batch and starts a new one.
 func (kl *kinesisLogger) addToBatch(e kinesisBatchEntry) {
@@ -157,10 +308,42 @@ func (kl *kinesisLogger) addToBatch(e kinesisBatchEntry) {
 	kl.batchSize += s
 }
 
+// globEvent is an event waiting to be globbed, along with its serialization
+// if the caller already had one, and the callback to call once it's written
+// if it was logged durably.
+type globEvent struct {
+	event      *spade.Event
+	serialized []byte
+	done       func(error)
+}
+
+// globDone returns the done callbacks of the glob's events, or nil if none of
+// them has one.
+func (kl *kinesisLogger) globDone() []func(error) {
+	var done []func(error)
+	for i, e := range kl.glob {
+		if e.done == nil {
+			continue
+		}
+		if done == nil {
+			done = make([]func(error), len(kl.glob))
+		}
+		done[i] = e.done
+	}
+	return done
+}
+
+// callDone calls done with err, if it's set.
+func callDone(done func(error), err error) {
+	if done != nil {
+		done(err)
+	}
+}
+
 // addToGlob adds an event to the current glob if there is space, or submits
 // the current glob to be batched
-func (kl *kinesisLogger) addToGlob(e *spade.Event) {
-	s := len(e.Data)
+func (kl *kinesisLogger) addToGlob(e globEvent) {
+	s := len(e.event.Data)
 	if s+kl.globSize > kl.config.GlobSize || len(kl.glob) == kl.config.GlobLength {
 		kl.compress()
 	}
@@ -173,7 +356,9 @@ func (kl *kinesisLogger) compress() {
 	if err != nil {
 		logger.WithError(err).Error("Failed to compress globs")
 		for _, e := range kl.glob {
-			_ = kl.logToFallback(e)
+			if err = kl.logToFallback(e.event, e.serialized, e.done); err != nil {
+				callDone(e.done, err)
+			}
 		}
 	}
 	kl.glob = kl.glob[:0]
@@ -186,17 +371,16 @@ func (kl *kinesisLogger) _compress() (err error) {
 	if len(kl.glob) == 0 {
 		return
 	}
+	if kl.avroGlobs {
+		kl.encodeAvro()
+		return
+	}
 
 	_ = buffer.WriteByte(compressionVersion)
 	kl.compressor.Reset(&buffer)
 
 	start := time.Now()
-	uncompressed, err := json.Marshal(kl.glob)
-	if err != nil {
-		return
-	}
-
-	_, err = kl.compressor.Write(uncompressed)
+	uncompressedSize, err := kl.writeGlob()
 	if err != nil {
 		return
 	}
@@ -209,21 +393,116 @@ func (kl *kinesisLogger) _compress() (err error) {
 	_ = kl.statter.TimingDuration(kinesisStatsPrefix+"compress.duration", time.Since(start), 1)
 
 	compressed := buffer.Bytes()
+	kl.verify(compressed, formatJSON)
 	kl.compressed <- kinesisBatchEntry{
 		data:        compressed,
-		distkey:     kl.glob[0].Uuid,
+		distkey:     kl.glob[0].event.Uuid,
 		numRequests: len(kl.glob),
+		done:        kl.globDone(),
 	}
 
-	_ = kl.statter.Inc(kinesisStatsPrefix+"compress.uncompressed_size", int64(len(uncompressed)), 1)
+	_ = kl.statter.Inc(kinesisStatsPrefix+"compress.uncompressed_size", int64(uncompressedSize), 1)
 	_ = kl.statter.Inc(kinesisStatsPrefix+"compress.compressed_size", int64(len(compressed)), 1)
 
 	return
 }
 
+// encodeAvro submits the glob encoded as avro. Consumers decode avro records
+// with a registry-aware deserializer, so they aren't compressed.
+func (kl *kinesisLogger) encodeAvro() {
+	events := make([]*spade.Event, len(kl.glob))
+	for i, e := range kl.glob {
+		events[i] = e.event
+	}
+	data := avro.AppendGlob(avro.AppendFrame(make([]byte, 0, kl.globSize*2), kl.schemaID), events)
+	kl.verify(data, formatAvro)
+	kl.compressed <- kinesisBatchEntry{
+		data:        data,
+		distkey:     kl.glob[0].event.Uuid,
+		numRequests: len(kl.glob),
+		done:        kl.globDone(),
+	}
+	_ = kl.statter.Inc(kinesisStatsPrefix+"avro.size", int64(len(data)), 1)
+}
+
+// verify compares a sample of the glob's records, in format, with the
+// verifier's candidate in the background.
+func (kl *kinesisLogger) verify(record []byte, format string) {
+	if kl.verifier == nil || !kl.verifier.sampled() {
+		return
+	}
+	events := make([]*spade.Event, len(kl.glob))
+	for i, e := range kl.glob {
+		events[i] = e.event
+	}
+	kl.Add(1)
+	logger.Go(func() {
+		defer kl.Done()
+		kl.verifier.verify(record, format, events)
+	})
+}
+
+// writeGlob writes the glob to the compressor as a JSON array of its events,
+// serializing those that weren't already, and returns the uncompressed size.
+func (kl *kinesisLogger) writeGlob() (int, error) {
+	size := 0
+	write := func(b []byte) error {
+		n, err := kl.compressor.Write(b)
+		size += n
+		return err
+	}
+
+	if err := write([]byte("[")); err != nil {
+		return size, err
+	}
+	for i, e := range kl.glob {
+		if i > 0 {
+			if err := write([]byte(",")); err != nil {
+				return size, err
+			}
+		}
+		serialized := e.serialized
+		if serialized == nil {
+			var err error
+			if serialized, err = kl.encoder.encode(kl.env.envelope().wrap(e.event)); err != nil {
+				return size, err
+			}
+		}
+		if err := write(serialized); err != nil {
+			return size, err
+		}
+	}
+	return size, write([]byte("]"))
+}
+
+// EmptyGlob returns a compressed glob holding no events. Consumers of the
+// stream skip it, so it can be used to probe write access to a stream.
+func EmptyGlob() ([]byte, error) {
+	return compressGlob([]byte("[]"))
+}
+
+// compressGlob compresses a glob's JSON array, written in parts.
+func compressGlob(parts ...[]byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	_ = buffer.WriteByte(compressionVersion)
+	compressor, err := flate.NewWriter(&buffer, flate.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if _, err = compressor.Write(part); err != nil {
+			return nil, err
+		}
+	}
+	if err = compressor.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
 func (kl *kinesisLogger) compressLoop() {
 	globAge, _ := time.ParseDuration(kl.config.GlobAge)
-	timer := time.NewTimer(globAge)
+	timer := kl.clock.NewTimer(globAge)
 
 	defer kl.Done()
 	defer timer.Stop()
@@ -243,10 +522,33 @@ func (kl *kinesisLogger) compressLoop() {
 
 	defer kl.compress()
 
+	// idleTimer is reset by every event, and fires once none has arrived for
+	// IdleFlush. idleTimeout is nil without an IdleFlush, so it never fires.
+	var idleTimer clock.Timer
+	var idleTimeout <-chan time.Time
+	idleFlush, _ := time.ParseDuration(kl.config.IdleFlush)
+	if idleFlush > 0 {
+		idleTimer = kl.clock.NewTimer(idleFlush)
+		idleTimer.Stop()
+		defer idleTimer.Stop()
+		idleTimeout = idleTimer.C()
+	}
+	// received is set if events arrived since the last idle flush, since a
+	// stopped timer may still fire once.
+	received := false
+
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
+			kl.compress()
+		case <-idleTimeout:
+			if !received {
+				continue
+			}
+			received = false
+			_ = kl.statter.Inc(kinesisStatsPrefix+"idle_flush", 1, 1)
 			kl.compress()
+			kl.idle <- struct{}{}
 		case e, ok := <-kl.incoming:
 			if !ok {
 				return
@@ -255,13 +557,17 @@ func (kl *kinesisLogger) compressLoop() {
 			if len(kl.glob) == 1 {
 				timer.Reset(globAge)
 			}
+			if idleTimer != nil {
+				received = true
+				idleTimer.Reset(idleFlush)
+			}
 		}
 	}
 }
 
 func (kl *kinesisLogger) submitLoop() {
 	batchAge, _ := time.ParseDuration(kl.config.BatchAge)
-	flushTimer := time.NewTimer(batchAge)
+	flushTimer := kl.clock.NewTimer(batchAge)
 
 	defer kl.Done()
 	defer flushTimer.Stop()
@@ -269,7 +575,9 @@ func (kl *kinesisLogger) submitLoop() {
 
 	for {
 		select {
-		case <-flushTimer.C:
+		case <-flushTimer.C():
+			kl.flush()
+		case <-kl.idle:
 			kl.flush()
 		case e, ok := <-kl.compressed:
 			if !ok {
@@ -290,11 +598,13 @@ func (kl *kinesisLogger) flush() {
 
 	totalNumRequests := 0
 	records := make([]*kinesis.PutRecordsRequestEntry, len(kl.batch))
+	done := make([][]func(error), len(kl.batch))
 	for i, e := range kl.batch {
 		records[i] = &kinesis.PutRecordsRequestEntry{
 			PartitionKey: aws.String(e.distkey),
 			Data:         e.data,
 		}
+		done[i] = e.done
 		totalNumRequests += e.numRequests
 	}
 	logger.WithField("num_requests_flushed", totalNumRequests).Info("Flushed requests to kinesis.")
@@ -303,7 +613,7 @@ func (kl *kinesisLogger) flush() {
 	kl.batchSize = 0
 
 	kl.Add(1)
-	logger.Go(func() { kl.putRecords(records) })
+	logger.Go(func() { kl.sendRecords(records, done) })
 }
 
 func advancePartitionKey(rec *kinesis.PutRecordsRequestEntry, attempt int) {
@@ -316,99 +626,139 @@ func advancePartitionKey(rec *kinesis.PutRecordsRequestEntry, attempt int) {
 	}
 }
 
-func (kl *kinesisLogger) putRecords(records []*kinesis.PutRecordsRequestEntry) {
-	retryDelay, _ := time.ParseDuration(kl.config.RetryDelay)
-
+// sendRecords puts records, calling the done callbacks of their events, by
+// record, once each is written to the stream or its fallback. done may be
+// nil if no event has one.
+func (kl *kinesisLogger) sendRecords(records []*kinesis.PutRecordsRequestEntry, done [][]func(error)) {
 	defer kl.Done()
 
 	args := &kinesis.PutRecordsInput{
 		StreamName: aws.String(kl.config.StreamName),
 		Records:    records,
 	}
-
-	for attempt := 1; attempt <= kl.config.MaxAttemptsPerRecord; attempt++ {
+	// pending are the done callbacks of args.Records.
+	pending := make([][]func(error), len(records))
+	copy(pending, done)
+
+	// exhausted are the records that ran out of attempts, and exhaustedDone
+	// their done callbacks.
+	var exhausted []*kinesis.PutRecordsRequestEntry
+	var exhaustedDone [][]func(error)
+	for attempt := 1; len(args.Records) > 0; attempt++ {
 		_ = kl.statter.Inc(kinesisStatsPrefix+"putrecords.attempted", 1, 1)
-		_ = kl.statter.Inc(kinesisStatsPrefix+"putrecords.length", int64(len(records)), 1)
+		_ = kl.statter.Inc(kinesisStatsPrefix+"putrecords.length", int64(len(args.Records)), 1)
 
 		t0 := time.Now()
-		res, err := kl.client.PutRecords(args)
+		res, err := kl.putRecords(args)
 		_ = kl.statter.TimingDuration(kinesisStatsPrefix+"putrecords", time.Since(t0), 1)
 
 		if err != nil {
+			class, code := classifyError(err)
+			policy := kl.retryPolicy(class, code)
 			logger.WithError(err).
+				WithField("class", class).
 				WithField("attempt", attempt).
-				WithField("max_attempts", kl.config.MaxAttemptsPerRecord).
+				WithField("max_attempts", policy.maxAttempts).
 				Warn("PutRecords failure")
 			_ = kl.statter.Inc(kinesisStatsPrefix+"putrecords.errors", 1, 1)
-			time.Sleep(retryDelay)
+			_ = kl.statter.Inc(kinesisStatsPrefix+"putrecords.errors."+class, 1, 1)
+			_ = kl.statter.Inc(errorCodeStat(code), 1, 1)
+			if attempt >= policy.maxAttempts {
+				exhausted = append(exhausted, args.Records...)
+				exhaustedDone = append(exhaustedDone, pending...)
+				break
+			}
+			time.Sleep(policy.delay)
 			continue
 		}
 
-		// Find all failed records and update the slice to contain only failures
+		// Find all failed records and update the slice to contain only those
+		// to retry, waiting for the longest delay of their policies.
 		i := 0
+		var delay time.Duration
 		for j, result := range res.Records {
 			shard := aws.StringValue(result.ShardId)
 			if shard == "" {
 				shard = "unknown"
 			}
 
-			if aws.StringValue(result.ErrorCode) != "" {
-				switch aws.StringValue(result.ErrorCode) {
-				case "ProvisionedThroughputExceededException":
-					_ = kl.statter.Inc(kinesisStatsPrefix+"records_failed.throttled", 1, 1)
-					_ = kl.statter.Inc(kinesisStatsPrefix+fmt.Sprintf("byshard.%s.records_failed.throttled", shard), 1, 1)
-				case "InternalFailure":
-					_ = kl.statter.Inc(kinesisStatsPrefix+"records_failed.internal_error", 1, 1)
-					_ = kl.statter.Inc(kinesisStatsPrefix+fmt.Sprintf("byshard.%s.records_failed.internal_error", shard), 1, 1)
-				default:
-					// Something undocumented
-					_ = kl.statter.Inc(kinesisStatsPrefix+"records_failed.unknown_reason", 1, 1)
-					_ = kl.statter.Inc(kinesisStatsPrefix+fmt.Sprintf("byshard.%s.records_failed.unknown_reason", shard), 1, 1)
-				}
-				// generate a new uuid for the retry
-				advancePartitionKey(args.Records[j], attempt)
-
-				args.Records[i] = args.Records[j]
-				i++
-			} else {
+			code := aws.StringValue(result.ErrorCode)
+			if code == "" {
 				_ = kl.statter.Inc(kinesisStatsPrefix+"records_succeeded", 1, 1)
 				_ = kl.statter.Inc(kinesisStatsPrefix+fmt.Sprintf("byshard.%s.records_succeeded", shard), 1, 1)
+				for _, d := range pending[j] {
+					callDone(d, nil)
+				}
+				continue
+			}
+			class := classifyErrorCode(code)
+			stat := class
+			if class == errorUnknown {
+				// Something undocumented, counted as it always has been.
+				stat = "unknown_reason"
+			}
+			_ = kl.statter.Inc(kinesisStatsPrefix+"records_failed."+stat, 1, 1)
+			_ = kl.statter.Inc(kinesisStatsPrefix+fmt.Sprintf("byshard.%s.records_failed.%s", shard, stat), 1, 1)
+			_ = kl.statter.Inc(errorCodeStat(code), 1, 1)
+
+			policy := kl.retryPolicy(class, code)
+			if attempt >= policy.maxAttempts {
+				exhausted = append(exhausted, args.Records[j])
+				exhaustedDone = append(exhaustedDone, pending[j])
+				continue
 			}
+			if policy.delay > delay {
+				delay = policy.delay
+			}
+			// generate a new uuid for the retry
+			advancePartitionKey(args.Records[j], attempt)
+
+			args.Records[i] = args.Records[j]
+			pending[i] = pending[j]
+			i++
 		}
 		args.Records = args.Records[:i]
+		pending = pending[:i]
 
-		if len(args.Records) == 0 {
-			// No records need to be retried.
-			return
+		if len(args.Records) > 0 {
+			time.Sleep(delay)
 		}
-
-		time.Sleep(retryDelay)
+	}
+	if len(exhausted) == 0 {
+		return
 	}
 
 	// We ran out of retries for some records, write them to fallback logger
-	logger.WithField("num_errors", len(args.Records)).
-		WithField("num_attempts", len(records)).
+	logger.WithField("num_errors", len(exhausted)).
+		WithField("num_records", len(records)).
 		Error("Failed sending records to kinesis")
 
 	// Failed records written to the fallback log. We know we can UnMarshal back into spade.Event
 	// because that is what we started with. This is potentially wasteful but this should be the
 	// rare case, so the code optimized for the common case
-	for _, record := range args.Records {
+	for i, record := range exhausted {
 		events, err := spade.Deglob(record.Data)
 		if err != nil {
 			logger.WithError(err).Error("Error calling Deglob")
+			for _, d := range exhaustedDone[i] {
+				callDone(d, err)
+			}
 			continue
 		}
-		for _, e := range events {
-			err = kl.logToFallback(e)
-			if err != nil {
+		for j, e := range events {
+			var done func(error)
+			if j < len(exhaustedDone[i]) {
+				done = exhaustedDone[i][j]
+			}
+			if err = kl.logToFallback(e, nil, done); err != nil {
 				logger.WithError(err).Error("Error logging failed kinesis event to fallback logger")
+				callDone(done, err)
 			}
 		}
 	}
 }
 
-func (kl *kinesisLogger) addToChannel(e *spade.Event) error {
+func (kl *kinesisLogger) addToChannel(e globEvent) error {
 	select {
 	case kl.incoming <- e:
 		_ = kl.statter.Inc(kinesisStatsPrefix+"caller.submitted", 1, 0.1)
@@ -419,8 +769,16 @@ func (kl *kinesisLogger) addToChannel(e *spade.Event) error {
 	}
 }
 
-func (kl *kinesisLogger) logToFallback(e *spade.Event) error {
-	err := kl.fallback.Log(e)
+// logToFallback hands e to the fallback logger, which calls done, if it's
+// set, once it's written e. done isn't called if an error is returned.
+func (kl *kinesisLogger) logToFallback(e *spade.Event, serialized []byte, done func(error)) error {
+	if kl.maxAge > 0 && kl.clock.Now().Sub(e.ReceivedAt) > kl.maxAge {
+		// Reprocessing stale events does more harm than losing them.
+		_ = kl.statter.Inc(kinesisStatsPrefix+"fallback.expired", 1, 0.1)
+		callDone(done, nil)
+		return nil
+	}
+	err := LogDurably(kl.fallback, e, serialized, done)
 	_ = kl.statter.Inc(kinesisStatsPrefix+"fallback.added", 1, 0.1)
 	if err != nil {
 		_ = kl.statter.Inc(kinesisStatsPrefix+"fallback.errors", 1, 0.1)
@@ -432,13 +790,26 @@ func (kl *kinesisLogger) logToFallback(e *spade.Event) error {
 // Log will attempt to queue up an event to be published into Kinesis.
 // If an error is returned, the caller should assume the event was dropped
 func (kl *kinesisLogger) Log(e *spade.Event) error {
-	err := kl.addToChannel(e)
+	return kl.LogSerialized(e, nil)
+}
+
+// LogSerialized is Log for an event already serialized by SerializeEvent,
+// which is globbed and handed to the fallback logger as is.
+func (kl *kinesisLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return kl.LogDurably(e, serialized, nil)
+}
+
+// LogDurably is LogSerialized, calling done once the event is put in a
+// record or written to the fallback logger, or with an error once it's lost.
+// It's never called if the edge dies first.
+func (kl *kinesisLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	err := kl.addToChannel(globEvent{event: e, serialized: serialized, done: done})
 	if err == nil {
 		return nil
 	}
 	logger.WithError(err).Error("Problem adding event to channel")
 
-	fallbackErr := kl.logToFallback(e)
+	fallbackErr := kl.logToFallback(e, serialized, done)
 	if fallbackErr == nil {
 		return nil
 	}
@@ -446,6 +817,49 @@ func (kl *kinesisLogger) Log(e *spade.Event) error {
 	return fmt.Errorf("submitting to channel failed with `%s` and fallback logger failed with `%s`", err, fallbackErr)
 }
 
+// LogAcked writes e to the stream in a record of its own, bypassing the
+// buffer, and returns the record's sequence number once Kinesis has it. It's
+// attempted up to MaxAttemptsPerRecord times; events that fail aren't handed
+// to the fallback logger, since the caller is told they failed.
+func (kl *kinesisLogger) LogAcked(e *spade.Event) (string, error) {
+	var data []byte
+	if kl.avroGlobs {
+		data = avro.AppendGlob(avro.AppendFrame(nil, kl.schemaID), []*spade.Event{e})
+	} else {
+		serialized, err := kl.env.SerializeEvent(e)
+		if err != nil {
+			return "", err
+		}
+		if data, err = compressGlob([]byte("["), serialized, []byte("]")); err != nil {
+			return "", err
+		}
+	}
+
+	input := &kinesis.PutRecordInput{
+		StreamName:   aws.String(kl.config.StreamName),
+		PartitionKey: aws.String(e.Uuid),
+		Data:         data,
+	}
+	for attempt := 1; ; attempt++ {
+		t0 := time.Now()
+		res, err := kl.putRecord(input)
+		_ = kl.statter.TimingDuration(kinesisStatsPrefix+"putrecord", time.Since(t0), 1)
+		if err == nil {
+			_ = kl.statter.Inc(kinesisStatsPrefix+"putrecord.succeeded", 1, 1)
+			return aws.StringValue(res.SequenceNumber), nil
+		}
+		class, code := classifyError(err)
+		_ = kl.statter.Inc(kinesisStatsPrefix+"putrecord.errors", 1, 1)
+		_ = kl.statter.Inc(kinesisStatsPrefix+"putrecord.errors."+class, 1, 1)
+		_ = kl.statter.Inc(errorCodeStat(code), 1, 1)
+		policy := kl.retryPolicy(class, code)
+		if attempt >= policy.maxAttempts {
+			return "", err
+		}
+		time.Sleep(policy.delay)
+	}
+}
+
 func (kl *kinesisLogger) Close() {
 	close(kl.incoming)
 	kl.Wait()
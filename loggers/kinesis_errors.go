@@ -0,0 +1,164 @@
+package loggers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// The classes Kinesis errors are counted and retried by.
+const (
+	// errorThrottled is the stream or KMS key being over its limits.
+	errorThrottled = "throttled"
+	// errorInternal is Kinesis failing on its side.
+	errorInternal = "internal_error"
+	// errorValidation is a request Kinesis won't accept however often it's
+	// retried, like one for a missing stream or a disabled KMS key.
+	errorValidation = "validation"
+	// errorNetwork is a request that didn't get a response.
+	errorNetwork = "network"
+	// errorUnknown is anything else.
+	errorUnknown = "unknown"
+)
+
+var errorClasses = []string{errorThrottled, errorInternal, errorValidation, errorNetwork, errorUnknown}
+
+// kinesisErrorClasses classifies the error codes of Kinesis requests and
+// records.
+var kinesisErrorClasses = map[string]string{
+	"ProvisionedThroughputExceededException": errorThrottled,
+	"LimitExceededException":                 errorThrottled,
+	"KMSThrottlingException":                 errorThrottled,
+	"ThrottlingException":                    errorThrottled,
+
+	"InternalFailure":     errorInternal,
+	"InternalServerError": errorInternal,
+	"ServiceUnavailable":  errorInternal,
+
+	"ValidationException":       errorValidation,
+	"InvalidArgumentException":  errorValidation,
+	"ResourceNotFoundException": errorValidation,
+	"ResourceInUseException":    errorValidation,
+	"AccessDeniedException":     errorValidation,
+	"KMSAccessDeniedException":  errorValidation,
+	"KMSDisabledException":      errorValidation,
+	"KMSInvalidStateException":  errorValidation,
+	"KMSNotFoundException":      errorValidation,
+	"KMSOptInRequired":          errorValidation,
+
+	"RequestError": errorNetwork,
+}
+
+// classifyErrorCode returns the class of a Kinesis error code.
+func classifyErrorCode(code string) string {
+	if class, ok := kinesisErrorClasses[code]; ok {
+		return class
+	}
+	return errorUnknown
+}
+
+// classifyError returns the class of the error of a Kinesis request, and its
+// error code, or "" if it has none.
+func classifyError(err error) (class, code string) {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return errorUnknown, ""
+	}
+	code = aerr.Code()
+	class = classifyErrorCode(code)
+	if rf, ok := err.(awserr.RequestFailure); ok && class == errorUnknown && rf.StatusCode() >= 500 {
+		class = errorInternal
+	}
+	return class, code
+}
+
+// errorCodeStat returns the stat an error code is counted under.
+func errorCodeStat(code string) string {
+	if code == "" {
+		code = "none"
+	}
+	return kinesisStatsPrefix + "error_code." + strings.Replace(code, ".", "_", -1)
+}
+
+// KinesisRetryPolicy configures how the records and requests that fail with
+// an error of a class or code are retried.
+type KinesisRetryPolicy struct {
+	// MaxAttempts is the most times a record is sent, including the first.
+	// It defaults to the stream's MaxAttemptsPerRecord.
+	MaxAttempts int
+
+	// Delay is how long to wait before retrying, e.g. "500ms". It defaults
+	// to the stream's RetryDelay.
+	Delay string
+}
+
+// retryPolicy is a parsed KinesisRetryPolicy.
+type retryPolicy struct {
+	maxAttempts int
+	delay       time.Duration
+}
+
+// validateRetryPolicies verifies the RetryPolicies of c, keyed by error class
+// or code.
+func validateRetryPolicies(c *KinesisLoggerConfig) error {
+	for key, p := range c.RetryPolicies {
+		if p == nil {
+			return fmt.Errorf("RetryPolicies: %s has no policy", key)
+		}
+		if !isErrorClass(key) && kinesisErrorClasses[key] == "" {
+			return fmt.Errorf("RetryPolicies: %s is neither an error class (%s) nor a Kinesis error code",
+				key, strings.Join(errorClasses, ", "))
+		}
+		if p.MaxAttempts < 0 {
+			return fmt.Errorf("RetryPolicies: %s: MaxAttempts must be a positive value", key)
+		}
+		if p.Delay != "" {
+			if _, err := time.ParseDuration(p.Delay); err != nil {
+				return fmt.Errorf("RetryPolicies: %s: error parsing %s as a time.Duration: %v", key, p.Delay, err)
+			}
+		}
+	}
+	return nil
+}
+
+func isErrorClass(s string) bool {
+	for _, class := range errorClasses {
+		if s == class {
+			return true
+		}
+	}
+	return false
+}
+
+// retryPolicies returns the retry policy of each of the config's keys, with
+// the stream's defaults filled in.
+func (c *KinesisLoggerConfig) retryPolicies() map[string]retryPolicy {
+	delay, _ := time.ParseDuration(c.RetryDelay)
+	policies := make(map[string]retryPolicy, len(c.RetryPolicies))
+	for key, p := range c.RetryPolicies {
+		policy := retryPolicy{maxAttempts: c.MaxAttemptsPerRecord, delay: delay}
+		if p.MaxAttempts > 0 {
+			policy.maxAttempts = p.MaxAttempts
+		}
+		if p.Delay != "" {
+			policy.delay, _ = time.ParseDuration(p.Delay)
+		}
+		policies[key] = policy
+	}
+	return policies
+}
+
+// retryPolicy returns how failures with code, of class, are retried: by the
+// policy of the code, or else of the class, or else the stream's.
+func (kl *kinesisLogger) retryPolicy(class, code string) retryPolicy {
+	if p, ok := kl.policies[code]; ok {
+		return p
+	}
+	if p, ok := kl.policies[class]; ok {
+		return p
+	}
+	delay, _ := time.ParseDuration(kl.config.RetryDelay)
+	return retryPolicy{maxAttempts: kl.config.MaxAttemptsPerRecord, delay: delay}
+}
@@ -1,10 +1,22 @@
 package loggers
 
 import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"net"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/avro"
+	"github.com/twitchscience/spade_edge/clock"
 )
 
 func TestAdvancingPartitionKey(t *testing.T) {
@@ -25,3 +37,418 @@ func TestAdvancingPartitionKey(t *testing.T) {
 		}
 	}
 }
+
+func TestCompressMixedGlob(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	var scratch bytes.Buffer
+	compressor, _ := flate.NewWriter(&scratch, flate.BestSpeed)
+	kl := &kinesisLogger{
+		compressed: make(chan kinesisBatchEntry, 1),
+		config:     KinesisLoggerConfig{GlobLength: 10, GlobSize: 1024},
+		statter:    stats,
+		compressor: compressor,
+		encoder:    newJSONEncoder(),
+	}
+
+	var events []*spade.Event
+	for i, uuid := range []string{"i-test-1", "i-test-2", "i-test-3"} {
+		e := spade.NewEvent(time.Unix(1500000000, 0).UTC(), net.ParseIP("222.222.222.222"),
+			"222.222.222.222", uuid, "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE)
+		events = append(events, e)
+		// Only some events arrive serialized; the rest are serialized
+		// when globbed.
+		var serialized []byte
+		if i%2 == 0 {
+			serialized, _ = SerializeEvent(e)
+		}
+		kl.addToGlob(globEvent{event: e, serialized: serialized})
+	}
+	if err := kl._compress(); err != nil {
+		t.Fatalf("Failed to compress glob: %s", err)
+	}
+
+	entry := <-kl.compressed
+	if entry.distkey != "i-test-1" || entry.numRequests != 3 {
+		t.Errorf("Unexpected batch entry %s with %d requests", entry.distkey, entry.numRequests)
+	}
+	deglobbed, err := spade.Deglob(entry.data)
+	if err != nil {
+		t.Fatalf("Failed to deglob: %s", err)
+	}
+	if !reflect.DeepEqual(deglobbed, events) {
+		t.Errorf("Expected %v, got %v", events, deglobbed)
+	}
+}
+
+type countingLogger struct {
+	logged int
+}
+
+func (c *countingLogger) Log(e *spade.Event) error {
+	c.logged++
+	return nil
+}
+
+func (c *countingLogger) Close() {}
+
+func TestFallbackDropsStaleEvents(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	fallback := &countingLogger{}
+	now := time.Unix(1500000000, 0)
+	kl := &kinesisLogger{
+		fallback: fallback,
+		statter:  stats,
+		maxAge:   time.Hour,
+		clock:    clock.NewFake(now),
+	}
+
+	for _, age := range []time.Duration{time.Minute, 2 * time.Hour} {
+		e := &spade.Event{ReceivedAt: now.Add(-age)}
+		if err := kl.logToFallback(e, nil, nil); err != nil {
+			t.Fatalf("Unexpected error logging to fallback: %s", err)
+		}
+	}
+	if fallback.logged != 1 {
+		t.Errorf("Expected only the fresh event to reach the fallback, got %d", fallback.logged)
+	}
+}
+
+func TestAvroGlob(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	kl := &kinesisLogger{
+		compressed: make(chan kinesisBatchEntry, 1),
+		config:     KinesisLoggerConfig{GlobLength: 10, GlobSize: 1024},
+		statter:    stats,
+		avroGlobs:  true,
+		schemaID:   3,
+	}
+	e := spade.NewEvent(time.Unix(1500000000, 0).UTC(), nil, "", "i-test-1", "", "", spade.INTERNAL_EDGE)
+	kl.addToGlob(globEvent{event: e})
+	if err := kl._compress(); err != nil {
+		t.Fatalf("Failed to encode glob: %s", err)
+	}
+
+	entry := <-kl.compressed
+	expected := avro.AppendGlob(avro.AppendFrame(nil, 3), []*spade.Event{e})
+	if !bytes.Equal(entry.data, expected) || entry.numRequests != 1 {
+		t.Errorf("Expected avro glob %v, got %v", expected, entry.data)
+	}
+}
+
+func TestLogAcked(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	var inputs []*kinesis.PutRecordInput
+	kl := &kinesisLogger{
+		config:  KinesisLoggerConfig{StreamName: "spade-downstream", MaxAttemptsPerRecord: 2, RetryDelay: "1ms"},
+		statter: stats,
+		putRecord: func(input *kinesis.PutRecordInput) (*kinesis.PutRecordOutput, error) {
+			inputs = append(inputs, input)
+			if len(inputs) == 1 {
+				return nil, errors.New("throttled")
+			}
+			return &kinesis.PutRecordOutput{SequenceNumber: aws.String("49590338271490256608559692538361571095921575989136588898")}, nil
+		},
+	}
+	e := spade.NewEvent(time.Unix(1500000000, 0).UTC(), net.ParseIP("222.222.222.222"),
+		"222.222.222.222", "i-test-1", "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE)
+
+	sequence, err := kl.LogAcked(e)
+	if err != nil || sequence != "49590338271490256608559692538361571095921575989136588898" {
+		t.Fatalf("Expected the second attempt's sequence number, got %q (%v)", sequence, err)
+	}
+	if len(inputs) != 2 || aws.StringValue(inputs[1].PartitionKey) != "i-test-1" {
+		t.Fatalf("Unexpected PutRecord calls %v", inputs)
+	}
+	deglobbed, err := spade.Deglob(inputs[1].Data)
+	if err != nil {
+		t.Fatalf("Failed to deglob: %s", err)
+	}
+	if !reflect.DeepEqual(deglobbed, []*spade.Event{e}) {
+		t.Errorf("Expected %v, got %v", e, deglobbed)
+	}
+
+	inputs = nil
+	kl.config.MaxAttemptsPerRecord = 1
+	if _, err = kl.LogAcked(e); err == nil {
+		t.Error("Expected the failed attempt's error")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, tt := range []struct {
+		format, candidate string
+		identical         bool
+	}{
+		{formatJSON, formatJSON, true},
+		{formatJSON, formatAvro, false},
+		{formatAvro, formatJSON, false},
+	} {
+		sender := statsdtest.NewRecordingSender()
+		stats, _ := statsd.NewClientWithSender(sender, "")
+		var scratch bytes.Buffer
+		compressor, _ := flate.NewWriter(&scratch, flate.BestSpeed)
+		var shadowed []*kinesis.PutRecordInput
+		kl := &kinesisLogger{
+			compressed: make(chan kinesisBatchEntry, 1),
+			config:     KinesisLoggerConfig{GlobLength: 10, GlobSize: 1024},
+			statter:    stats,
+			compressor: compressor,
+			encoder:    newJSONEncoder(),
+			avroGlobs:  tt.format == formatAvro,
+			verifier: &verifier{
+				config:  VerifyConfig{SampleRate: 1, Format: tt.candidate, ShadowStream: "spade-shadow"},
+				statter: stats,
+				putRecord: func(input *kinesis.PutRecordInput) (*kinesis.PutRecordOutput, error) {
+					shadowed = append(shadowed, input)
+					return &kinesis.PutRecordOutput{}, nil
+				},
+				random: func() float64 { return 0 },
+			},
+		}
+		for _, uuid := range []string{"i-test-1", "i-test-2"} {
+			e := spade.NewEvent(time.Unix(1500000000, 0).UTC(), net.ParseIP("222.222.222.222"),
+				"222.222.222.222", uuid, "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE)
+			kl.addToGlob(globEvent{event: e})
+		}
+		if err := kl._compress(); err != nil {
+			t.Fatalf("Failed to compress glob: %s", err)
+		}
+		<-kl.compressed
+		kl.Wait()
+
+		sent := sender.GetSent().CollectNamed(verifyStatsPrefix + "globs")
+		if len(sent) != 1 || len(sender.GetSent().CollectNamed(verifyStatsPrefix+"diverged")) != 0 {
+			t.Errorf("%s to %s: expected a verified glob that didn't diverge, got %v", tt.format, tt.candidate,
+				sender.GetSent())
+		}
+		if identical := len(sender.GetSent().CollectNamed(verifyStatsPrefix + "identical")); identical == 1 != tt.identical {
+			t.Errorf("%s to %s: expected identical records: %v, got %d", tt.format, tt.candidate, tt.identical, identical)
+		}
+		if len(shadowed) != 1 || aws.StringValue(shadowed[0].StreamName) != "spade-shadow" {
+			t.Fatalf("%s to %s: expected the candidate written to the shadow stream, got %v", tt.format, tt.candidate,
+				shadowed)
+		}
+		if n, err := globLength(shadowed[0].Data, tt.candidate); n != 2 || err != nil {
+			t.Errorf("%s to %s: expected a candidate of 2 records, got %d, %v", tt.format, tt.candidate, n, err)
+		}
+	}
+}
+
+func TestVerifyDivergence(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	v := &verifier{config: VerifyConfig{SampleRate: 1, Format: formatJSON}, statter: stats}
+	e := spade.NewEvent(time.Unix(1500000000, 0).UTC(), nil, "", "i-test-1", "", "", spade.INTERNAL_EDGE)
+	record, _ := compressGlob([]byte("[]"))
+	v.verify(record, formatJSON, []*spade.Event{e})
+	if len(sender.GetSent().CollectNamed(verifyStatsPrefix+"diverged")) != 1 {
+		t.Errorf("Expected a record missing an event to diverge, got %v", sender.GetSent())
+	}
+}
+
+func TestIdleFlush(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	fake := clock.NewFake(time.Unix(1500000000, 0))
+	kl := &kinesisLogger{
+		incoming:   make(chan globEvent, 1),
+		compressed: make(chan kinesisBatchEntry),
+		idle:       make(chan struct{}),
+		config:     KinesisLoggerConfig{GlobLength: 10, GlobSize: 1024, GlobAge: "1h", IdleFlush: "100ms"},
+		statter:    stats,
+		clock:      fake,
+		encoder:    newJSONEncoder(),
+	}
+	kl.Add(1)
+	go kl.compressLoop()
+
+	kl.incoming <- globEvent{event: spade.NewEvent(fake.Now(), net.ParseIP("222.222.222.222"),
+		"222.222.222.222", "i-test-1", "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE)}
+	// The idle timer starts once compressLoop has the event, so keep moving
+	// the clock until the partial glob is submitted.
+	deadline := time.After(5 * time.Second)
+	var entry kinesisBatchEntry
+wait:
+	for {
+		select {
+		case entry = <-kl.compressed:
+			break wait
+		case <-deadline:
+			t.Fatal("Expected the partial glob to be submitted once idle")
+		case <-time.After(time.Millisecond):
+			fake.Advance(100 * time.Millisecond)
+		}
+	}
+	if entry.numRequests != 1 || entry.distkey != "i-test-1" {
+		t.Errorf("Expected a glob of the event, got %+v", entry)
+	}
+	select {
+	case <-kl.idle:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the batch to be flushed once idle")
+	}
+
+	// Without new events, nothing more is flushed.
+	fake.Advance(time.Second)
+	close(kl.incoming)
+	if _, ok := <-kl.compressed; ok {
+		t.Error("Expected nothing more to be submitted")
+	}
+	kl.Wait()
+}
+
+func TestSendRecordsRetryPolicies(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	serialized, _ := SerializeEvent(spade.NewEvent(time.Unix(1500000000, 0).UTC(), net.ParseIP("222.222.222.222"),
+		"222.222.222.222", "i-test-1", "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE))
+	glob, _ := compressGlob([]byte("["), serialized, []byte("]"))
+
+	config := KinesisLoggerConfig{
+		StreamName:           "spade-downstream",
+		MaxAttemptsPerRecord: 2,
+		RetryDelay:           "1ms",
+		RetryPolicies: map[string]*KinesisRetryPolicy{
+			"throttled":                 {MaxAttempts: 3},
+			"InternalFailure":           {MaxAttempts: 1},
+			"ResourceNotFoundException": {MaxAttempts: 1},
+		},
+	}
+	if err := validateRetryPolicies(&config); err != nil {
+		t.Fatalf("Expected valid retry policies: %s", err)
+	}
+	fallback := &countingLogger{}
+	var attempts []int
+	kl := &kinesisLogger{
+		config:   config,
+		policies: config.retryPolicies(),
+		statter:  stats,
+		fallback: fallback,
+		putRecords: func(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+			attempts = append(attempts, len(input.Records))
+			out := &kinesis.PutRecordsOutput{}
+			for i := range input.Records {
+				entry := &kinesis.PutRecordsResultEntry{}
+				switch {
+				case len(attempts) == 1 && i == 1:
+					entry.ErrorCode = aws.String("InternalFailure")
+				case len(attempts) == 1 && i == 2:
+				default:
+					entry.ErrorCode = aws.String("ProvisionedThroughputExceededException")
+				}
+				out.Records = append(out.Records, entry)
+			}
+			return out, nil
+		},
+	}
+	records := make([]*kinesis.PutRecordsRequestEntry, 3)
+	for i := range records {
+		records[i] = &kinesis.PutRecordsRequestEntry{PartitionKey: aws.String("i-test-1"), Data: glob}
+	}
+	kl.Add(1)
+	kl.sendRecords(records, nil)
+
+	// The internal failure isn't retried, and the throttled record is tried
+	// three times.
+	if !reflect.DeepEqual(attempts, []int{3, 1, 1}) {
+		t.Errorf("Expected attempts of 3, 1 and 1 records, got %v", attempts)
+	}
+	if fallback.logged != 2 {
+		t.Errorf("Expected both failed records to fall back, got %d", fallback.logged)
+	}
+	for stat, expected := range map[string]int{
+		"logger.kinesis.records_failed.throttled":                          3,
+		"logger.kinesis.records_failed.internal_error":                     1,
+		"logger.kinesis.records_succeeded":                                 1,
+		"logger.kinesis.error_code.ProvisionedThroughputExceededException": 3,
+	} {
+		if got := len(sender.GetSent().CollectNamed(stat)); got != expected {
+			t.Errorf("Expected %d %s, got %d", expected, stat, got)
+		}
+	}
+
+	// Requests failing with a code whose policy allows one attempt aren't
+	// retried.
+	sender.ClearSent()
+	attempts = nil
+	kl.putRecords = func(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+		attempts = append(attempts, len(input.Records))
+		return nil, awserr.New("ResourceNotFoundException", "no stream", nil)
+	}
+	kl.Add(1)
+	kl.sendRecords(records[:1], nil)
+	if len(attempts) != 1 || fallback.logged != 3 {
+		t.Errorf("Expected a single attempt before falling back, got %v", attempts)
+	}
+	if got := len(sender.GetSent().CollectNamed("logger.kinesis.putrecords.errors.validation")); got != 1 {
+		t.Errorf("Expected the validation error to be counted, got %d", got)
+	}
+}
+
+func TestSendRecordsDone(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	var globs [][]byte
+	for _, uuid := range []string{"i-test-1", "i-test-2"} {
+		serialized, _ := SerializeEvent(spade.NewEvent(time.Unix(1500000000, 0).UTC(), net.ParseIP("222.222.222.222"),
+			"222.222.222.222", uuid, "eyJldmVudCI6ImEifQ==", "", spade.INTERNAL_EDGE))
+		glob, _ := compressGlob([]byte("["), serialized, []byte("]"))
+		globs = append(globs, glob)
+	}
+	fallback := &countingLogger{}
+	kl := &kinesisLogger{
+		config:   KinesisLoggerConfig{StreamName: "spade-downstream", MaxAttemptsPerRecord: 1},
+		statter:  stats,
+		fallback: fallback,
+		putRecords: func(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+			// The second record fails, and falls back.
+			return &kinesis.PutRecordsOutput{Records: []*kinesis.PutRecordsResultEntry{
+				{},
+				{ErrorCode: aws.String("InternalFailure")},
+			}}, nil
+		},
+	}
+	kl.policies = kl.config.retryPolicies()
+
+	var written []string
+	done := make([][]func(error), len(globs))
+	for i, uuid := range []string{"i-test-1", "i-test-2"} {
+		uuid := uuid
+		done[i] = []func(error){func(err error) {
+			if err != nil {
+				t.Errorf("Unexpected error writing %s: %s", uuid, err)
+			}
+			written = append(written, uuid)
+		}}
+	}
+	records := make([]*kinesis.PutRecordsRequestEntry, len(globs))
+	for i, glob := range globs {
+		records[i] = &kinesis.PutRecordsRequestEntry{PartitionKey: aws.String("i-test-1"), Data: glob}
+	}
+	kl.Add(1)
+	kl.sendRecords(records, done)
+	if !reflect.DeepEqual(written, []string{"i-test-1", "i-test-2"}) || fallback.logged != 1 {
+		t.Errorf("Expected both events done, one through the fallback, got %v", written)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	for _, tt := range []struct {
+		err   error
+		class string
+	}{
+		{awserr.New("ProvisionedThroughputExceededException", "slow down", nil), errorThrottled},
+		{awserr.New("KMSDisabledException", "disabled", nil), errorValidation},
+		{awserr.New("RequestError", "send request failed", errors.New("connection reset")), errorNetwork},
+		{awserr.NewRequestFailure(awserr.New("SomethingNew", "", nil), 503, "id"), errorInternal},
+		{awserr.NewRequestFailure(awserr.New("SomethingNew", "", nil), 400, "id"), errorUnknown},
+		{errors.New("opaque"), errorUnknown},
+	} {
+		if class, _ := classifyError(tt.err); class != tt.class {
+			t.Errorf("Expected %v to be classified %s, got %s", tt.err, tt.class, class)
+		}
+	}
+
+	config := KinesisLoggerConfig{RetryPolicies: map[string]*KinesisRetryPolicy{"Throttled": {}}}
+	if err := validateRetryPolicies(&config); err == nil {
+		t.Error("Expected an unknown class to be rejected")
+	}
+}
@@ -0,0 +1,72 @@
+package loggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// jsonEncoder pairs a json.Encoder with the buffer it writes to so both can
+// be reused.
+type jsonEncoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+func newJSONEncoder() *jsonEncoder {
+	e := &jsonEncoder{}
+	e.enc = json.NewEncoder(&e.buf)
+	return e
+}
+
+// encode resets the buffer and encodes v into it, returning the JSON without
+// the newline json.Encoder appends. The result is only valid until the next
+// call.
+func (e *jsonEncoder) encode(v interface{}) ([]byte, error) {
+	e.buf.Reset()
+	if err := e.enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(e.buf.Bytes(), []byte("\n")), nil
+}
+
+var encoderPool = sync.Pool{
+	New: func() interface{} { return newJSONEncoder() },
+}
+
+// MarshalEvent is an EventToStringFunc that serializes events to JSON in
+// env's envelope, reusing its buffers between calls. Under SerializationV1
+// the output is the same as spade.Marshal's.
+func (env *Env) MarshalEvent(e *spade.Event) (string, error) {
+	enc := encoderPool.Get().(*jsonEncoder)
+	defer encoderPool.Put(enc)
+	b, err := enc.encode(env.envelope().wrap(e))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// SerializeEvent serializes an event to the same JSON as MarshalEvent, for
+// passing to SerializedLoggers.
+func (env *Env) SerializeEvent(e *spade.Event) ([]byte, error) {
+	enc := encoderPool.Get().(*jsonEncoder)
+	defer encoderPool.Put(enc)
+	b, err := enc.encode(env.envelope().wrap(e))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+// MarshalEvent is Env.MarshalEvent in the default envelope.
+func MarshalEvent(e *spade.Event) (string, error) {
+	return (*Env)(nil).MarshalEvent(e)
+}
+
+// SerializeEvent is Env.SerializeEvent in the default envelope.
+func SerializeEvent(e *spade.Event) ([]byte, error) {
+	return (*Env)(nil).SerializeEvent(e)
+}
@@ -0,0 +1,71 @@
+package loggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+func newTestEnv(t *testing.T, version int) *Env {
+	stats, _ := statsd.NewNoop()
+	envelope, err := NewEnvelope(EnvelopeConfig{Version: version}, "test", stats)
+	if err != nil {
+		t.Fatalf("Failed to create envelope: %s", err)
+	}
+	return &Env{Envelope: envelope}
+}
+
+func TestMarshalEventMatchesSpade(t *testing.T) {
+	env := newTestEnv(t, SerializationV1)
+
+	events := []*spade.Event{
+		spade.NewEvent(time.Unix(1500000000, 0), net.ParseIP("222.222.222.222"), "222.222.222.222",
+			"i-test-1", "eyJldmVudCI6ImEifQ==", "<script>&", spade.INTERNAL_EDGE),
+		spade.NewEvent(time.Unix(1500000001, 0), nil, "", "i-test-2", "", "", spade.EXTERNAL_EDGE),
+	}
+	for _, e := range events {
+		expected, err := spade.Marshal(e)
+		if err != nil {
+			t.Fatalf("spade.Marshal failed: %s", err)
+		}
+		// Marshal twice so the second call reuses the pooled buffer.
+		for i := 0; i < 2; i++ {
+			actual, err := env.MarshalEvent(e)
+			if err != nil {
+				t.Fatalf("MarshalEvent failed: %s", err)
+			}
+			if actual != string(expected) {
+				t.Errorf("Expected %s, got %s", expected, actual)
+			}
+		}
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	e := spade.NewEvent(time.Unix(1500000000, 0), nil, "", "i-test-1", "", "", spade.INTERNAL_EDGE)
+	serialized, err := newTestEnv(t, 0).SerializeEvent(e)
+	if err != nil {
+		t.Fatalf("SerializeEvent failed: %s", err)
+	}
+	var decoded map[string]interface{}
+	if err = json.Unmarshal(serialized, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal %s: %s", serialized, err)
+	}
+	if decoded["edgeVersion"] != "test" || decoded["serializationVersion"] != float64(LatestSerialization) ||
+		decoded["uuid"] != "i-test-1" {
+		t.Errorf("Expected the event in a versioned envelope, got %s", serialized)
+	}
+
+	if serialized, err = SerializeEvent(e); err != nil || bytes.Contains(serialized, []byte(`"edgeVersion":"test"`)) {
+		t.Errorf("Expected the default envelope to have no edge version, got %s", serialized)
+	}
+
+	if _, err = NewEnvelope(EnvelopeConfig{Version: LatestSerialization + 1}, "", nil); err == nil {
+		t.Error("Expected unknown version to be rejected")
+	}
+}
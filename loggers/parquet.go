@@ -0,0 +1,257 @@
+package loggers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+	"github.com/twitchscience/spade_edge/parquet"
+)
+
+const (
+	defaultRowGroupSize = 10000
+	parquetStatsPrefix  = "logger.parquet."
+)
+
+// ParquetLoggerConfig configures a new SpadeEdgeLogger that writes events to
+// Parquet files and uploads them to AWS S3.
+type ParquetLoggerConfig struct {
+	Bucket string
+
+	// Prefix is prepended to the keys of uploaded files, which are partitioned
+	// as <Prefix>/dt=<date>/hr=<hour>/<host>-<nanos>.parquet. It is optional.
+	Prefix string
+
+	// RowGroupSize is the number of events per row group. It defaults to 10000.
+	RowGroupSize int
+
+	// MaxRows and MaxAge rotate a file once it holds that many events or is
+	// that old.
+	MaxRows int
+	MaxAge  string
+}
+
+// Validate verifies that a ParquetLoggerConfig is valid, and fills in defaults
+func (c *ParquetLoggerConfig) Validate() error {
+	if len(c.Bucket) == 0 {
+		return errors.New("Bucket is required")
+	}
+
+	if c.RowGroupSize == 0 {
+		c.RowGroupSize = defaultRowGroupSize
+	}
+	if c.RowGroupSize < 0 {
+		return errors.New("RowGroupSize must be a positive value")
+	}
+
+	if c.MaxRows <= 0 {
+		return errors.New("MaxRows must be a positive value")
+	}
+
+	maxAge, err := time.ParseDuration(c.MaxAge)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.MaxAge, err)
+	}
+
+	if maxAge <= 0 {
+		return errors.New("MaxAge must be greater than 0")
+	}
+	return nil
+}
+
+type parquetLogger struct {
+	config   ParquetLoggerConfig
+	maxAge   time.Duration
+	dir      string
+	host     string
+	uploader s3manageriface.UploaderAPI
+	stats    statsd.Statter
+	clock    clock.Clock
+
+	sync.Mutex
+	file     *os.File
+	writer   *parquet.Writer
+	openedAt time.Time
+
+	uploads sync.WaitGroup
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewParquetLogger returns a new SpadeEdgeLogger that writes events to
+// Parquet files in loggingDir and uploads each file to S3 when it's rotated.
+// Files that fail to upload are left in loggingDir.
+func NewParquetLogger(
+	config ParquetLoggerConfig,
+	loggingDir string,
+	S3Uploader s3manageriface.UploaderAPI,
+	stats statsd.Statter,
+	env *Env,
+) (SpadeEdgeLogger, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	maxAge, _ := time.ParseDuration(config.MaxAge)
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("error getting hostname: %v", err)
+	}
+
+	pl := &parquetLogger{
+		config:   config,
+		maxAge:   maxAge,
+		dir:      loggingDir,
+		host:     host,
+		uploader: S3Uploader,
+		stats:    stats,
+		clock:    env.clock(),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	interval := maxAge / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := pl.clock.NewTicker(interval)
+	logger.Go(func() { pl.run(ticker) })
+	return pl, nil
+}
+
+// run rotates files that have reached MaxAge, checking every tick, until the
+// logger is closed.
+func (pl *parquetLogger) run(ticker clock.Ticker) {
+	defer close(pl.stopped)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pl.stop:
+			return
+		case <-ticker.C():
+			pl.Lock()
+			if pl.writer != nil && pl.clock.Now().Sub(pl.openedAt) >= pl.maxAge {
+				pl.rotate()
+			}
+			pl.Unlock()
+		}
+	}
+}
+
+func (pl *parquetLogger) Log(e *spade.Event) error {
+	pl.Lock()
+	defer pl.Unlock()
+	if pl.writer == nil {
+		if err := pl.open(); err != nil {
+			return err
+		}
+	}
+	if err := pl.writer.Write(e); err != nil {
+		return err
+	}
+	if pl.writer.Rows() >= int64(pl.config.MaxRows) {
+		pl.rotate()
+	}
+	return nil
+}
+
+// open starts a new file. It must be called with the lock held.
+func (pl *parquetLogger) open() error {
+	f, err := ioutil.TempFile(pl.dir, "events.parquet.")
+	if err != nil {
+		return fmt.Errorf("error creating parquet file: %v", err)
+	}
+	w, err := parquet.NewWriter(f, pl.config.RowGroupSize)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	pl.file, pl.writer, pl.openedAt = f, w, pl.clock.Now()
+	return nil
+}
+
+// rotate finishes the current file and uploads it in the background. It must
+// be called with the lock held.
+func (pl *parquetLogger) rotate() {
+	f, w, openedAt := pl.file, pl.writer, pl.openedAt
+	pl.file, pl.writer = nil, nil
+
+	err := w.Close()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.WithError(err).WithField("file", f.Name()).Error("Error finishing parquet file")
+		_ = pl.stats.Inc(parquetStatsPrefix+"write.failed", 1, 1.0)
+		return
+	}
+	_ = pl.stats.Inc(parquetStatsPrefix+"rows", w.Rows(), 1.0)
+
+	pl.uploads.Add(1)
+	logger.Go(func() {
+		defer pl.uploads.Done()
+		pl.upload(f.Name(), pl.key(openedAt))
+	})
+}
+
+// key returns the S3 key of a file opened at openedAt.
+func (pl *parquetLogger) key(openedAt time.Time) string {
+	t := openedAt.UTC()
+	name := fmt.Sprintf("%s-%d.parquet", pl.host, t.UnixNano())
+	return strings.TrimPrefix(path.Join(
+		strings.Trim(pl.config.Prefix, "/"),
+		"dt="+t.Format("2006-01-02"),
+		"hr="+t.Format("15"),
+		name), "/")
+}
+
+func (pl *parquetLogger) upload(filename, key string) {
+	f, err := os.Open(filename)
+	if err != nil {
+		logger.WithError(err).WithField("file", filename).Error("Error opening parquet file")
+		_ = pl.stats.Inc(parquetStatsPrefix+"upload.failed", 1, 1.0)
+		return
+	}
+	_, err = pl.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(pl.config.Bucket),
+		Key:    aws.String(key),
+		Body:   f,
+	})
+	_ = f.Close()
+	if err != nil {
+		logger.WithError(err).
+			WithField("file", filename).
+			WithField("key", key).
+			Error("Error uploading parquet file, leaving it on disk")
+		_ = pl.stats.Inc(parquetStatsPrefix+"upload.failed", 1, 1.0)
+		return
+	}
+	_ = pl.stats.Inc(parquetStatsPrefix+"upload.success", 1, 1.0)
+	if err = os.Remove(filename); err != nil {
+		logger.WithError(err).WithField("file", filename).Warn("Error removing uploaded parquet file")
+	}
+}
+
+// Close uploads the current file and waits for uploads to finish.
+func (pl *parquetLogger) Close() {
+	close(pl.stop)
+	<-pl.stopped
+	pl.Lock()
+	if pl.writer != nil {
+		pl.rotate()
+	}
+	pl.Unlock()
+	pl.uploads.Wait()
+}
@@ -0,0 +1,91 @@
+package loggers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+type fakeUploader struct {
+	sync.Mutex
+	keys []string
+	err  error
+}
+
+func (u *fakeUploader) Upload(input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	u.Lock()
+	defer u.Unlock()
+	if u.err != nil {
+		return nil, u.err
+	}
+	if _, err := ioutil.ReadAll(input.Body); err != nil {
+		return nil, err
+	}
+	u.keys = append(u.keys, aws.StringValue(input.Key))
+	return &s3manager.UploadOutput{}, nil
+}
+
+func newTestParquetLogger(t *testing.T, uploader *fakeUploader) (*parquetLogger, string) {
+	dir, err := ioutil.TempDir("", "parquet")
+	if err != nil {
+		t.Fatalf("Failed to create logging dir: %s", err)
+	}
+	stats, _ := statsd.NewNoop()
+	l, err := NewParquetLogger(ParquetLoggerConfig{
+		Bucket:  "bucket",
+		Prefix:  "events/",
+		MaxRows: 2,
+		MaxAge:  "1h",
+	}, dir, uploader, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %s", err)
+	}
+	pl := l.(*parquetLogger)
+	pl.host = "host"
+	pl.clock = clock.NewFake(time.Date(2017, 6, 1, 13, 30, 0, 0, time.UTC))
+	return pl, dir
+}
+
+func TestParquetLoggerRotatesAndUploads(t *testing.T) {
+	uploader := &fakeUploader{}
+	pl, dir := newTestParquetLogger(t, uploader)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for i := 0; i < 3; i++ {
+		if err := pl.Log(&spade.Event{Uuid: "u"}); err != nil {
+			t.Fatalf("Failed to log: %s", err)
+		}
+	}
+	pl.Close()
+
+	expected := "events/dt=2017-06-01/hr=13/host-1496323800000000000.parquet"
+	if len(uploader.keys) != 2 || uploader.keys[0] != expected {
+		t.Errorf("Expected 2 uploads to %s, got %v", expected, uploader.keys)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected uploaded files to be removed, got %d files", len(files))
+	}
+}
+
+func TestParquetLoggerKeepsFailedUploads(t *testing.T) {
+	pl, dir := newTestParquetLogger(t, &fakeUploader{err: errors.New("unavailable")})
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if err := pl.Log(&spade.Event{}); err != nil {
+		t.Fatalf("Failed to log: %s", err)
+	}
+	pl.Close()
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected the file that failed to upload to be kept, got %d files", len(files))
+	}
+}
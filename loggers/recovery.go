@@ -0,0 +1,190 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/gologging/key_name_generator"
+)
+
+const (
+	recoveryStatsPrefix = "logger.s3.recovery."
+
+	// claimedMarker is added to the names of orphaned files once they're
+	// claimed for recovery, followed by when.
+	claimedMarker = ".orphan-"
+)
+
+// A Recovery uploads the orphaned files of an S3 logger in the background.
+type Recovery struct {
+	// Claimed is the number of orphaned files found.
+	Claimed int
+
+	wg sync.WaitGroup
+}
+
+// Wait waits for the orphaned files to be uploaded, or fail to be.
+func (r *Recovery) Wait() {
+	r.wg.Wait()
+}
+
+// RecoverOrphans finds the files an S3 logger for config left in loggingDir
+// when a previous run crashed or was killed before uploading them, and
+// uploads them in the background. It must be called before any S3 logger
+// writing to loggingDir is created, since their files are named alike.
+//
+// Orphaned files are claimed by renaming them before RecoverOrphans returns,
+// so another process sharing loggingDir can't upload them too. They're
+// uploaded under keys derived from their modification time and contents,
+// so a file uploaded again after a crash mid-recovery replaces the first
+// upload rather than duplicating it. Files cut short by the crash are
+// uploaded with their complete lines. Files that fail to upload are left
+// claimed, and retried by the next run.
+func RecoverOrphans(
+	config S3LoggerConfig,
+	loggingDir string,
+	s3Uploader s3manageriface.UploaderAPI,
+	stats statsd.StatSender,
+) (*Recovery, error) {
+	infos, err := ioutil.ReadDir(loggingDir)
+	if err != nil {
+		return nil, err
+	}
+	info := key_name_generator.BuildInstanceInfo(&key_name_generator.EnvInstanceFetcher{}, config.Bucket, loggingDir)
+	prefix := config.Bucket + ".log.gz."
+	claimedAt := fmt.Sprintf("%s%d", claimedMarker, time.Now().UnixNano())
+
+	r := &Recovery{}
+	for _, fi := range infos {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		filename := filepath.Join(loggingDir, name)
+		if !strings.Contains(name, claimedMarker) {
+			claimed := filename + claimedAt
+			if err = os.Rename(filename, claimed); err != nil {
+				// Another process sharing loggingDir claimed it first.
+				continue
+			}
+			filename = claimed
+		}
+		r.Claimed++
+		r.wg.Add(1)
+		modTime := fi.ModTime()
+		logger.Go(func() {
+			defer r.wg.Done()
+			recoverOrphan(filename, modTime, config.Bucket, info, s3Uploader, stats)
+		})
+	}
+	if r.Claimed > 0 {
+		logger.WithField("bucket", config.Bucket).
+			WithField("files", r.Claimed).
+			Info("Recovering orphaned S3 logger files")
+	}
+	return r, nil
+}
+
+func recoverOrphan(filename string, modTime time.Time, bucket string,
+	info *key_name_generator.InstanceInfo, s3Uploader s3manageriface.UploaderAPI, stats statsd.StatSender) {
+	body, truncated, err := salvage(filename)
+	if err != nil {
+		logger.WithError(err).WithField("file", filename).Error("Error reading orphaned S3 logger file")
+		_ = stats.Inc(recoveryStatsPrefix+"failed", 1, 1)
+		return
+	}
+	if body == nil {
+		_ = stats.Inc(recoveryStatsPrefix+"empty", 1, 1)
+		if err = os.Remove(filename); err != nil {
+			logger.WithError(err).WithField("file", filename).Warn("Error removing empty orphaned S3 logger file")
+		}
+		return
+	}
+	if truncated {
+		_ = stats.Inc(recoveryStatsPrefix+"truncated", 1, 1)
+	}
+
+	key := orphanKey(info, modTime, body)
+	_, err = s3Uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		ACL:         aws.String("bucket-owner-full-control"),
+		ContentType: aws.String("application/x-gzip"),
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		logger.WithError(err).
+			WithField("file", filename).
+			WithField("key", key).
+			Error("Error uploading orphaned S3 logger file, leaving it for the next run")
+		_ = stats.Inc(recoveryStatsPrefix+"failed", 1, 1)
+		return
+	}
+	_ = stats.Inc(recoveryStatsPrefix+"recovered", 1, 1)
+	if err = os.Remove(filename); err != nil {
+		logger.WithError(err).WithField("file", filename).Warn("Error removing recovered S3 logger file")
+	}
+}
+
+// salvage returns the gzipped contents of the file at filename to upload, or
+// nil if it has no complete lines. Files cut short mid-write are recompressed
+// with the lines that were completely written, and reported as truncated.
+func salvage(filename string) ([]byte, bool, error) {
+	compressed, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, false, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		// The crash came before the gzip header was written.
+		return nil, false, nil
+	}
+	lines, err := ioutil.ReadAll(zr)
+	if err == nil {
+		if len(lines) == 0 {
+			return nil, false, nil
+		}
+		return compressed, false, nil
+	}
+
+	end := bytes.LastIndexByte(lines, '\n')
+	if end < 0 {
+		return nil, true, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(lines[:end+1]); err != nil {
+		return nil, true, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, true, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// orphanKey returns the key an orphaned file is uploaded under: a key like
+// the S3 logger's, dated by when the file was last written, and with a hash of
+// its contents in place of the random part.
+func orphanKey(info *key_name_generator.InstanceInfo, modTime time.Time, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf("%s/%s/%d.%s.%x.log.gz",
+		modTime.Format("20060102"),
+		info.AutoScaleGroup,
+		modTime.Unix(),
+		info.Node,
+		sum[:8],
+	)
+}
@@ -0,0 +1,117 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/gologging/key_name_generator"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte(s))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to gzip: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRecoverOrphans(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recovery")
+	if err != nil {
+		t.Fatalf("Failed to create logging dir: %s", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	complete := gzipped(t, "a\nb\n")
+	// A gzip stream cut short loses its trailer, and here part of a line.
+	truncated := gzipped(t, "c\nd\npartial")
+	truncated = truncated[:len(truncated)-8]
+	for name, contents := range map[string][]byte{
+		"bucket.log.gz.000":          complete,
+		"bucket.log.gz.001":          truncated,
+		"bucket.log.gz.002":          nil,
+		"bucket.log.gz.003.orphan-1": gzipped(t, "e\n"),
+		"other-bucket.log.gz.000":    complete,
+	} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), contents, 0660); err != nil {
+			t.Fatalf("Failed to write %s: %s", name, err)
+		}
+	}
+
+	uploader := &fakeUploader{}
+	stats, _ := statsd.NewNoop()
+	r, err := RecoverOrphans(S3LoggerConfig{Bucket: "bucket"}, dir, uploader, stats)
+	if err != nil {
+		t.Fatalf("Failed to recover orphans: %s", err)
+	}
+	r.Wait()
+	if r.Claimed != 4 {
+		t.Errorf("Expected 4 files to be claimed, got %d", r.Claimed)
+	}
+	if len(uploader.keys) != 3 {
+		t.Errorf("Expected 3 files to be uploaded, got %v", uploader.keys)
+	}
+
+	var left []string
+	files, _ := ioutil.ReadDir(dir)
+	for _, f := range files {
+		left = append(left, f.Name())
+	}
+	if !reflect.DeepEqual(left, []string{"other-bucket.log.gz.000"}) {
+		t.Errorf("Expected only the other bucket's file to be left, got %v", left)
+	}
+}
+
+func TestSalvage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recovery")
+	if err != nil {
+		t.Fatalf("Failed to create logging dir: %s", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	filename := filepath.Join(dir, "bucket.log.gz.000")
+
+	truncated := gzipped(t, "c\nd\npartial")
+	_ = ioutil.WriteFile(filename, truncated[:len(truncated)-8], 0660)
+	body, wasTruncated, err := salvage(filename)
+	if err != nil || !wasTruncated {
+		t.Fatalf("Expected the file to be salvaged as truncated, got %v, %v", wasTruncated, err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Expected a valid gzip stream: %s", err)
+	}
+	if lines, _ := ioutil.ReadAll(zr); string(lines) != "c\nd\n" {
+		t.Errorf("Expected only the complete lines, got %q", lines)
+	}
+
+	complete := gzipped(t, "a\n")
+	_ = ioutil.WriteFile(filename, complete, 0660)
+	if body, wasTruncated, _ = salvage(filename); wasTruncated || !bytes.Equal(body, complete) {
+		t.Error("Expected a complete file to be uploaded as is")
+	}
+}
+
+func TestOrphanKey(t *testing.T) {
+	info := &key_name_generator.InstanceInfo{AutoScaleGroup: "asg", Node: "node"}
+	modTime := time.Date(2017, 6, 1, 13, 30, 0, 0, time.UTC)
+	key := orphanKey(info, modTime, []byte("body"))
+	if !strings.HasPrefix(key, "20170601/asg/1496323800.node.") || !strings.HasSuffix(key, ".log.gz") {
+		t.Errorf("Unexpected key %s", key)
+	}
+	if orphanKey(info, modTime, []byte("body")) != key {
+		t.Error("Expected a file uploaded again to get the same key")
+	}
+	if orphanKey(info, modTime, []byte("other")) == key {
+		t.Error("Expected files with other contents to get other keys")
+	}
+}
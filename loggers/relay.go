@@ -0,0 +1,495 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+// RelayContentType is the Content-Type of the batches relayed to a peer edge:
+// events serialized as by SerializeEvent, one per line.
+const RelayContentType = "application/x-ndjson"
+
+// RelayHopsHeader carries the number of edges a relayed batch has been relayed
+// by, counting the one sending it, so peers can refuse batches going in
+// circles.
+const RelayHopsHeader = "X-Spade-Relay-Hops"
+
+// upstreamHopsTTL is how long the hops of a batch relayed to this edge count
+// towards those of the batches it relays on.
+const upstreamHopsTTL = time.Minute
+
+const (
+	defaultRelayBatchLength = 500
+	defaultRelayBatchSize   = 1 << 20
+	defaultRelayBatchAge    = "1s"
+	defaultRelayTimeout     = "10s"
+	defaultRelayMaxAttempts = 3
+	defaultRelayRetryDelay  = "1s"
+	defaultRelayQueueLength = 16
+
+	relayStatsPrefix = "logger.relay."
+)
+
+var errRelayClosed = errors.New("relay logger closed")
+
+// upstreamHops are the most hops of the batches relayed to an edge within
+// upstreamHopsTTL. Events don't carry their hops, so every batch the edge
+// relays on counts them.
+type upstreamHops struct {
+	sync.Mutex
+	hops  int
+	until time.Time
+}
+
+// ObserveRelayHops records that a batch relayed by hops edges was received,
+// at now, so the batches relayed on by the loggers of env over the next minute
+// count them. It does nothing on a nil Env.
+func (env *Env) ObserveRelayHops(hops int, now time.Time) {
+	if env == nil {
+		return
+	}
+	env.upstreamHops.Lock()
+	defer env.upstreamHops.Unlock()
+	if hops >= env.upstreamHops.hops || now.After(env.upstreamHops.until) {
+		env.upstreamHops.hops = hops
+		env.upstreamHops.until = now.Add(upstreamHopsTTL)
+	}
+}
+
+// relayHops returns the hops of a batch relayed by the loggers of env now.
+func (env *Env) relayHops(now time.Time) int {
+	if env == nil {
+		return 1
+	}
+	env.upstreamHops.Lock()
+	defer env.upstreamHops.Unlock()
+	if now.After(env.upstreamHops.until) {
+		return 1
+	}
+	return env.upstreamHops.hops + 1
+}
+
+// RelayConfig configures a SpadeEdgeLogger that forwards events to another
+// spade_edge over HTTPS, for edges that can reach a peer edge but not AWS.
+type RelayConfig struct {
+	// URL is the relay endpoint of the peer edge, e.g.
+	// "https://edge.example.com/relay"
+	URL string
+
+	// KeyID and Secret sign each batch, as HMACAuth checks signed requests
+	KeyID  string
+	Secret string
+
+	// CAFile, if set, is a PEM file of the certificate authorities trusted
+	// to sign the peer's certificate, instead of the system's
+	CAFile string
+
+	// BatchLength is the most events per batch. It defaults to 500.
+	BatchLength int
+
+	// BatchSize is the most bytes of events per batch, before compression.
+	// It defaults to 1MB.
+	BatchSize int
+
+	// BatchAge is the longest an event waits for its batch to fill, e.g. "1s"
+	BatchAge string
+
+	// Compression is how batches are compressed, "gzip" or "none". It
+	// defaults to "gzip".
+	Compression string
+
+	// Timeout bounds each POST, e.g. "10s"
+	Timeout string
+
+	// MaxAttempts is how many times a batch is posted before its events go to
+	// the fallback logger. It defaults to 3.
+	MaxAttempts int
+
+	// RetryDelay is the wait before the first retry, doubling with each
+	// retry after it, e.g. "1s"
+	RetryDelay string
+
+	// QueueLength is how many full batches may wait to be posted. Events
+	// that don't fit go to the fallback logger. It defaults to 16.
+	QueueLength int
+}
+
+// Validate verifies that a RelayConfig is valid and fills in defaults
+func (c *RelayConfig) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("URL %s is not a valid URL", c.URL)
+	}
+	if u.Scheme != "https" {
+		return errors.New("URL must be https")
+	}
+	if c.KeyID == "" || len(c.Secret) < 16 {
+		return errors.New("KeyID and a Secret of at least 16 bytes are required")
+	}
+	if c.BatchLength == 0 {
+		c.BatchLength = defaultRelayBatchLength
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultRelayBatchSize
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = defaultRelayMaxAttempts
+	}
+	if c.QueueLength == 0 {
+		c.QueueLength = defaultRelayQueueLength
+	}
+	if c.BatchLength < 0 || c.BatchSize < 0 || c.MaxAttempts < 0 || c.QueueLength < 0 {
+		return errors.New("BatchLength, BatchSize, MaxAttempts and QueueLength must be positive values")
+	}
+	switch c.Compression {
+	case "":
+		c.Compression = "gzip"
+	case "gzip", "none":
+	default:
+		return fmt.Errorf("unknown Compression %s: it must be gzip or none", c.Compression)
+	}
+	if c.BatchAge == "" {
+		c.BatchAge = defaultRelayBatchAge
+	}
+	if c.Timeout == "" {
+		c.Timeout = defaultRelayTimeout
+	}
+	if c.RetryDelay == "" {
+		c.RetryDelay = defaultRelayRetryDelay
+	}
+	for _, d := range []string{c.BatchAge, c.Timeout, c.RetryDelay} {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed <= 0 {
+			return errors.New("BatchAge, Timeout and RetryDelay must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// relayBatch is a batch of events and their serializations, along with the
+// callbacks of the events logged durably.
+type relayBatch struct {
+	events     []*spade.Event
+	serialized [][]byte
+	done       []func(error)
+	size       int
+}
+
+type relayLogger struct {
+	config      RelayConfig
+	client      *http.Client
+	fallback    SpadeEdgeLogger
+	statter     statsd.StatSender
+	env         *Env
+	clock       clock.Clock
+	batchAge    time.Duration
+	retryDelay  time.Duration
+	compression bool
+
+	mu     sync.Mutex
+	batch  *relayBatch
+	queued int
+	closed bool
+
+	queue chan *relayBatch
+	quit  chan struct{}
+	done  chan struct{}
+}
+
+// NewRelayLogger returns a SpadeEdgeLogger that posts events to the peer edge
+// of config in batches, signed and compressed. Events of batches that still
+// fail after MaxAttempts, or that find the queue full, are written to
+// fallback instead. Closing the logger posts the last batch and closes
+// fallback.
+func NewRelayLogger(config RelayConfig, fallback SpadeEdgeLogger, statter statsd.StatSender,
+	env *Env) (SpadeEdgeLogger, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	timeout, _ := time.ParseDuration(config.Timeout)
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: &tls.Config{}}
+	if config.CAFile != "" {
+		pem, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CAFile: %v", err)
+		}
+		transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+		if !transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CAFile)
+		}
+	}
+	rl := &relayLogger{
+		config:      config,
+		client:      &http.Client{Timeout: timeout, Transport: transport},
+		fallback:    fallback,
+		statter:     statter,
+		env:         env,
+		clock:       env.clock(),
+		compression: config.Compression == "gzip",
+		batch:       &relayBatch{},
+		queue:       make(chan *relayBatch, config.QueueLength),
+		quit:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	rl.batchAge, _ = time.ParseDuration(config.BatchAge)
+	rl.retryDelay, _ = time.ParseDuration(config.RetryDelay)
+	logger.Go(rl.run)
+	return rl, nil
+}
+
+// Buffered returns the number of events waiting to be posted.
+func (rl *relayLogger) Buffered() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return len(rl.batch.events) + rl.queued
+}
+
+func (rl *relayLogger) Log(e *spade.Event) error {
+	return rl.LogSerialized(e, nil)
+}
+
+func (rl *relayLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return rl.LogDurably(e, serialized, nil)
+}
+
+// LogDurably is LogSerialized, calling done once the event's batch is posted
+// or the fallback logger has it.
+func (rl *relayLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	if serialized == nil {
+		var err error
+		if serialized, err = rl.env.SerializeEvent(e); err != nil {
+			return err
+		}
+	}
+	rl.mu.Lock()
+	if rl.closed {
+		rl.mu.Unlock()
+		return errRelayClosed
+	}
+	var full *relayBatch
+	if len(rl.batch.events) > 0 &&
+		(len(rl.batch.events) == rl.config.BatchLength || rl.batch.size+len(serialized)+1 > rl.config.BatchSize) {
+		full = rl.enqueue()
+	}
+	rl.batch.events = append(rl.batch.events, e)
+	rl.batch.serialized = append(rl.batch.serialized, serialized)
+	rl.batch.done = append(rl.batch.done, done)
+	rl.batch.size += len(serialized) + 1
+	rl.mu.Unlock()
+
+	if full != nil {
+		rl.overflow(full)
+	}
+	return nil
+}
+
+// enqueue hands the batch to run and starts a new one, returning the batch
+// if the queue is full. rl.mu must be held.
+func (rl *relayLogger) enqueue() *relayBatch {
+	b := rl.batch
+	rl.batch = &relayBatch{}
+	if len(b.events) == 0 {
+		return nil
+	}
+	select {
+	case rl.queue <- b:
+		rl.queued += len(b.events)
+		return nil
+	default:
+		return b
+	}
+}
+
+// overflow hands a batch that found the queue full to the fallback logger.
+func (rl *relayLogger) overflow(b *relayBatch) {
+	_ = rl.statter.Inc(relayStatsPrefix+"queue_full", 1, 1)
+	rl.logToFallback(b)
+}
+
+func (rl *relayLogger) run() {
+	defer close(rl.done)
+	ticker := rl.clock.NewTicker(rl.batchAge)
+	defer ticker.Stop()
+	for {
+		select {
+		case b := <-rl.queue:
+			rl.dequeued(b)
+			rl.send(b)
+		case <-ticker.C():
+			rl.mu.Lock()
+			full := rl.enqueue()
+			rl.mu.Unlock()
+			if full != nil {
+				rl.overflow(full)
+			}
+		case <-rl.quit:
+			for {
+				select {
+				case b := <-rl.queue:
+					rl.dequeued(b)
+					rl.send(b)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (rl *relayLogger) dequeued(b *relayBatch) {
+	rl.mu.Lock()
+	rl.queued -= len(b.events)
+	rl.mu.Unlock()
+}
+
+// send posts b, retrying failures that may pass, and hands its events to the
+// fallback logger if it can't be posted.
+func (rl *relayLogger) send(b *relayBatch) {
+	body, err := rl.encode(b)
+	if err != nil {
+		logger.WithError(err).Error("Error encoding relay batch")
+		rl.logToFallback(b)
+		return
+	}
+	delay := rl.retryDelay
+	for attempt := 1; ; attempt++ {
+		t0 := time.Now()
+		retry, err := rl.post(body)
+		_ = rl.statter.TimingDuration(relayStatsPrefix+"post", time.Since(t0), 1)
+		if err == nil {
+			_ = rl.statter.Inc(relayStatsPrefix+"batches_sent", 1, 1)
+			_ = rl.statter.Inc(relayStatsPrefix+"events_sent", int64(len(b.events)), 1)
+			for _, done := range b.done {
+				callDone(done, nil)
+			}
+			return
+		}
+		_ = rl.statter.Inc(relayStatsPrefix+"post.errors", 1, 1)
+		logger.WithError(err).
+			WithField("attempt", attempt).
+			WithField("max_attempts", rl.config.MaxAttempts).
+			Warn("Relay failure")
+		if !retry || attempt >= rl.config.MaxAttempts || !rl.wait(delay) {
+			break
+		}
+		delay *= 2
+	}
+	_ = rl.statter.Inc(relayStatsPrefix+"batches_failed", 1, 1)
+	rl.logToFallback(b)
+}
+
+// wait waits for d, returning false if the logger is closed meanwhile, in
+// which case the batch isn't retried.
+func (rl *relayLogger) wait(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-rl.quit:
+		return false
+	}
+}
+
+// encode returns the body of the POST of b.
+func (rl *relayLogger) encode(b *relayBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var zw *gzip.Writer
+	if rl.compression {
+		zw = gzip.NewWriter(&buf)
+		w = zw
+	}
+	for _, s := range b.serialized {
+		if _, err := w.Write(s); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte{'\n'}); err != nil {
+			return nil, err
+		}
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// post posts body to the peer, returning whether a failure may pass if
+// retried.
+func (rl *relayLogger) post(body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", rl.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", RelayContentType)
+	if rl.compression {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	now := rl.clock.Now()
+	req.Header.Set(RelayHopsHeader, strconv.Itoa(rl.env.relayHops(now)))
+	if err = auth.SignRequest(req, rl.config.KeyID, rl.config.Secret, body, now); err != nil {
+		return false, err
+	}
+	resp, err := rl.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	// The peer may recover from throttling and its own errors, but not from
+	// rejecting the request or finding a loop.
+	retry := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500 && resp.StatusCode != http.StatusLoopDetected
+	return retry, fmt.Errorf("peer responded %s", resp.Status)
+}
+
+func (rl *relayLogger) logToFallback(b *relayBatch) {
+	for i, e := range b.events {
+		_ = rl.statter.Inc(relayStatsPrefix+"fallback.added", 1, 0.1)
+		if err := LogDurably(rl.fallback, e, b.serialized[i], b.done[i]); err != nil {
+			_ = rl.statter.Inc(relayStatsPrefix+"fallback.errors", 1, 0.1)
+			logger.WithError(err).Error("Error logging failed relay event to fallback logger")
+			callDone(b.done[i], err)
+		}
+	}
+}
+
+func (rl *relayLogger) Close() {
+	rl.mu.Lock()
+	if rl.closed {
+		rl.mu.Unlock()
+		return
+	}
+	rl.closed = true
+	full := rl.enqueue()
+	rl.mu.Unlock()
+	if full != nil {
+		rl.overflow(full)
+	}
+	close(rl.quit)
+	<-rl.done
+	rl.fallback.Close()
+}
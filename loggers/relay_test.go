@@ -0,0 +1,172 @@
+package loggers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/auth"
+)
+
+const relaySecret = "0123456789abcdef"
+
+// relayPeer is a peer edge answering each POST with the next of statuses.
+type relayPeer struct {
+	*httptest.Server
+	caFile string
+
+	mu       sync.Mutex
+	statuses []int
+	posts    int
+	events   []string
+}
+
+func newRelayPeer(t *testing.T, statuses ...int) *relayPeer {
+	verifier, err := auth.NewHMACVerifier(auth.HMACConfig{
+		Secrets:   map[string]string{"inner": relaySecret},
+		Endpoints: []string{"/relay"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %s", err)
+	}
+	p := &relayPeer{statuses: statuses}
+	p.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.posts++
+		if _, err := verifier.VerifyRequest(r); err != nil {
+			t.Errorf("Expected a signed request, got %v", err)
+		}
+		if r.Header.Get("Content-Type") != RelayContentType || r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("Expected gzipped events, got %v", r.Header)
+		}
+		status := p.statuses[0]
+		p.statuses = p.statuses[1:]
+		if status == http.StatusOK {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("Failed to gunzip: %s", err)
+			}
+			for lines := bufio.NewScanner(zr); lines.Scan(); {
+				p.events = append(p.events, lines.Text())
+			}
+		}
+		w.WriteHeader(status)
+	}))
+
+	f, err := ioutil.TempFile("", "relay-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: p.Certificate().Raw})
+	_ = f.Close()
+	p.caFile = f.Name()
+	return p
+}
+
+func (p *relayPeer) Close() {
+	p.Server.Close()
+	_ = os.Remove(p.caFile)
+}
+
+func (p *relayPeer) postCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.posts
+}
+
+func (p *relayPeer) config() RelayConfig {
+	return RelayConfig{URL: p.URL + "/relay", KeyID: "inner", Secret: relaySecret, CAFile: p.caFile,
+		BatchLength: 2, BatchAge: "1h", RetryDelay: "1ms"}
+}
+
+func TestRelayLogger(t *testing.T) {
+	peer := newRelayPeer(t, http.StatusServiceUnavailable, http.StatusOK, http.StatusOK)
+	defer peer.Close()
+	stats, _ := statsd.NewNoop()
+	fallback := &countingLogger{}
+	rl, err := NewRelayLogger(peer.config(), fallback, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create relay logger: %s", err)
+	}
+	for _, uuid := range []string{"a", "b", "c"} {
+		if err = rl.Log(&spade.Event{Uuid: uuid}); err != nil {
+			t.Fatalf("Failed to log: %s", err)
+		}
+	}
+	// The third event starts a batch of its own, posted on Close once the
+	// first has been retried, since closing stops retries.
+	for deadline := time.Now().Add(5 * time.Second); peer.postCount() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	rl.Close()
+
+	if peer.posts != 3 || len(peer.events) != 3 || fallback.logged != 0 {
+		t.Fatalf("Expected the first batch retried and all events relayed, got %d posts of %v and %d in the fallback",
+			peer.posts, peer.events, fallback.logged)
+	}
+	expected, _ := SerializeEvent(&spade.Event{Uuid: "a"})
+	if peer.events[0] != string(expected) {
+		t.Errorf("Expected events serialized as for the other sinks, got %s", peer.events[0])
+	}
+}
+
+func TestRelayLoggerRejected(t *testing.T) {
+	peer := newRelayPeer(t, http.StatusUnauthorized)
+	defer peer.Close()
+	stats, _ := statsd.NewNoop()
+	fallback := &countingLogger{}
+	rl, err := NewRelayLogger(peer.config(), fallback, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create relay logger: %s", err)
+	}
+	_ = rl.Log(&spade.Event{Uuid: "a"})
+	rl.Close()
+
+	if peer.posts != 1 || fallback.logged != 1 {
+		t.Errorf("Expected a rejected batch to go to the fallback without retrying, got %d posts and %d in the fallback",
+			peer.posts, fallback.logged)
+	}
+}
+
+func TestRelayConfig(t *testing.T) {
+	for _, c := range []RelayConfig{
+		{URL: "http://edge.example.com/relay", KeyID: "inner", Secret: relaySecret},
+		{URL: "https://edge.example.com/relay", KeyID: "inner", Secret: "short"},
+		{URL: "https://edge.example.com/relay", KeyID: "inner", Secret: relaySecret, Compression: "zstd"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
+
+func TestRelayHops(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	edge, other := &Env{}, &Env{}
+	edge.ObserveRelayHops(2, now)
+	edge.ObserveRelayHops(1, now.Add(time.Second))
+	if hops := edge.relayHops(now.Add(time.Second)); hops != 3 {
+		t.Errorf("Expected the most upstream hops counted, got %d", hops)
+	}
+	if hops := other.relayHops(now.Add(time.Second)); hops != 1 {
+		t.Errorf("Expected another edge's hops not to count, got %d", hops)
+	}
+	if hops := edge.relayHops(now.Add(upstreamHopsTTL + time.Second)); hops != 1 {
+		t.Errorf("Expected upstream hops to expire, got %d", hops)
+	}
+	var nilEnv *Env
+	nilEnv.ObserveRelayHops(2, now)
+	if hops := nilEnv.relayHops(now); hops != 1 {
+		t.Errorf("Expected a nil Env to relay as the first hop, got %d", hops)
+	}
+}
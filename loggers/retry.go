@@ -0,0 +1,42 @@
+package loggers
+
+import (
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/retry"
+)
+
+type retryLogger struct {
+	logger  SpadeEdgeLogger
+	retrier *retry.Retrier
+}
+
+// NewRetryLogger returns a SpadeEdgeLogger that retries failed writes to
+// logger as r allows.
+func NewRetryLogger(logger SpadeEdgeLogger, r *retry.Retrier) SpadeEdgeLogger {
+	return &retryLogger{
+		logger:  logger,
+		retrier: r,
+	}
+}
+
+func (rl *retryLogger) Log(e *spade.Event) error {
+	return rl.retrier.Do(func() error {
+		return rl.logger.Log(e)
+	})
+}
+
+func (rl *retryLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return rl.retrier.Do(func() error {
+		return LogSerialized(rl.logger, e, serialized)
+	})
+}
+
+func (rl *retryLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	return rl.retrier.Do(func() error {
+		return LogDurably(rl.logger, e, serialized, done)
+	})
+}
+
+func (rl *retryLogger) Close() {
+	rl.logger.Close()
+}
@@ -1,6 +1,7 @@
 package loggers
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -37,6 +38,10 @@ type EventToStringFunc func(*spade.Event) (string, error)
 type s3Logger struct {
 	uploadLogger      *gologging.UploadLogger
 	eventToStringFunc EventToStringFunc
+
+	// acceptsSerialized is set if events are written as the JSON
+	// SerializeEvent produces.
+	acceptsSerialized bool
 }
 
 // S3LoggerConfig configures a new SpadeEdgeLogger that writes
@@ -45,30 +50,101 @@ type S3LoggerConfig struct {
 	Bucket   string
 	MaxLines int
 	MaxAge   string
+
+	// FailoverBuckets are uploaded to in order while Bucket is failing
+	FailoverBuckets []FailoverBucket
+
+	// FailoverThreshold is how many uploads in a row must fail for a bucket
+	// to be failed over from. It defaults to 3.
+	FailoverThreshold int
+
+	// FailoverCooldown is how long a failing bucket is skipped, e.g. "5m"
+	FailoverCooldown string
+
+	// RotateEvery, if set, also rotates files on wall-clock boundaries, e.g.
+	// "1h" for files holding the events received in one hour. It must evenly
+	// divide a day.
+	RotateEvery string
+
+	// LateTolerance is how long after a boundary events received before it
+	// are still written to the previous interval's files, e.g. "30s". It
+	// defaults to 0.
+	LateTolerance string
+
+	// PartitionByHour, if set, writes each event to files of the hour it was
+	// received in, so files hold a single hour even when events are logged
+	// out of order, e.g. while replaying a fallback. It can't be combined
+	// with RotateEvery.
+	PartitionByHour bool
+
+	// MaxOpenHours is how many hours' files PartitionByHour keeps open at
+	// once. It defaults to 4.
+	MaxOpenHours int
+}
+
+// Validate verifies that an S3LoggerConfig is valid and fills in defaults
+func (c *S3LoggerConfig) Validate() error {
+	if len(c.Bucket) == 0 {
+		return errors.New("Bucket is required")
+	}
+
+	if c.MaxLines <= 0 {
+		return errors.New("MaxLines must be a positive value")
+	}
+
+	maxAge, err := time.ParseDuration(c.MaxAge)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.MaxAge, err)
+	}
+
+	if maxAge <= 0 {
+		return errors.New("MaxAge must be greater than 0")
+	}
+
+	if err = validateWindow(c); err != nil {
+		return err
+	}
+	return validateFailover(c)
 }
 
 // NewS3Logger returns a new SpadeEdgeLogger that events to S3 after
-// transforming the events into lines of text using the printFunc
+// transforming the events into lines of text using the printFunc. If printFunc
+// is nil the events are written as JSON in env's envelope, and events logged
+// with LogSerialized are written without serializing them again.
 func NewS3Logger(
 	config S3LoggerConfig,
 	loggingDir string,
 	printFunc EventToStringFunc,
 	sqs sqsiface.SQSAPI,
 	S3Uploader s3manageriface.UploaderAPI,
+	env *Env,
 ) (SpadeEdgeLogger, error) {
-	maxAge, err := time.ParseDuration(config.MaxAge)
+	err := config.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("error parsing %s as a time.Duration: %v", config.MaxAge, err)
+		return nil, err
 	}
-
-	rotateCoordinator := gologging.NewRotateCoordinator(config.MaxLines, maxAge)
 	loggingInfo := key_name_generator.BuildInstanceInfo(&key_name_generator.EnvInstanceFetcher{}, config.Bucket, loggingDir)
+	if config.RotateEvery != "" || config.PartitionByHour {
+		return newWindowedLogger(config, loggingInfo, printFunc, S3Uploader, env), nil
+	}
+	return startS3Logger(config, loggingInfo, &key_name_generator.EdgeKeyNameGenerator{Info: loggingInfo},
+		printFunc, S3Uploader, env)
+}
 
-	s3Uploader := uploader.NewFactory(
-		config.Bucket,
-		&key_name_generator.EdgeKeyNameGenerator{Info: loggingInfo},
-		S3Uploader,
-	)
+// startS3Logger starts an s3Logger writing files named after info.Service and
+// uploading them under the keys keys generates.
+func startS3Logger(
+	config S3LoggerConfig,
+	loggingInfo *key_name_generator.InstanceInfo,
+	keys uploader.S3KeyNameGenerator,
+	printFunc EventToStringFunc,
+	S3Uploader s3manageriface.UploaderAPI,
+	env *Env,
+) (*s3Logger, error) {
+	maxAge, _ := time.ParseDuration(config.MaxAge)
+
+	rotateCoordinator := gologging.NewRotateCoordinator(config.MaxLines, maxAge)
+	s3Uploader := uploader.NewFactory(config.Bucket, keys, S3Uploader)
 
 	uploadLogger, err := gologging.StartS3Logger(
 		rotateCoordinator,
@@ -87,6 +163,10 @@ func NewS3Logger(
 		uploadLogger:      uploadLogger,
 		eventToStringFunc: printFunc,
 	}
+	if printFunc == nil {
+		s3l.eventToStringFunc = env.MarshalEvent
+		s3l.acceptsSerialized = true
+	}
 
 	return s3l, nil
 }
@@ -100,6 +180,14 @@ func (s3l *s3Logger) Log(e *spade.Event) error {
 	return nil
 }
 
+func (s3l *s3Logger) LogSerialized(e *spade.Event, serialized []byte) error {
+	if !s3l.acceptsSerialized {
+		return s3l.Log(e)
+	}
+	s3l.uploadLogger.Log(string(serialized))
+	return nil
+}
+
 func (s3l *s3Logger) Close() {
 	s3l.uploadLogger.Close()
 }
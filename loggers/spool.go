@@ -0,0 +1,56 @@
+package loggers
+
+import (
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/wal"
+)
+
+const spoolStatsPrefix = "logger.spool."
+
+// SpoolLogger appends events to a log on local disk, as the last resort of a
+// fallback chain. Spooled events are never acked, so the log keeps them for
+// the next run to replay.
+type SpoolLogger struct {
+	log     *wal.WAL
+	statter statsd.StatSender
+}
+
+// NewSpoolLogger opens the spool in config.Dir.
+func NewSpoolLogger(config wal.Config, statter statsd.StatSender) (*SpoolLogger, error) {
+	log, err := wal.Open(config, statter, spoolStatsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &SpoolLogger{log: log, statter: statter}, nil
+}
+
+// Log appends e to the spool.
+func (sl *SpoolLogger) Log(e *spade.Event) error {
+	if _, err := sl.log.Append(e); err != nil {
+		_ = sl.statter.Inc(spoolStatsPrefix+"failed", 1, 1)
+		return err
+	}
+	_ = sl.statter.Inc(spoolStatsPrefix+"appended", 1, 1)
+	return nil
+}
+
+// Replay calls fn with each event spooled by an earlier run, deleting the
+// spool's files as they're replayed. It stops at the first error, leaving the
+// rest for the next run.
+func (sl *SpoolLogger) Replay(fn func(*spade.Event) error) error {
+	return sl.log.Replay(fn)
+}
+
+// DiskUsage returns the size, in bytes, of the spool on disk.
+func (sl *SpoolLogger) DiskUsage() (int64, error) {
+	return sl.log.DiskUsage()
+}
+
+// Close syncs the spool and closes it.
+func (sl *SpoolLogger) Close() {
+	if err := sl.log.Close(); err != nil {
+		logger.WithError(err).Error("Error closing spool")
+	}
+}
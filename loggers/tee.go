@@ -0,0 +1,96 @@
+package loggers
+
+import (
+	"sync"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+type teeLogger struct {
+	loggers []SpadeEdgeLogger
+}
+
+// NewTeeLogger returns a SpadeEdgeLogger that writes every event to each of
+// loggers. Every logger is written to even if one fails, and the first error
+// is returned.
+func NewTeeLogger(loggers ...SpadeEdgeLogger) SpadeEdgeLogger {
+	return &teeLogger{loggers: loggers}
+}
+
+func (tl *teeLogger) Log(e *spade.Event) error {
+	return tl.LogSerialized(e, nil)
+}
+
+func (tl *teeLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return tl.LogDurably(e, serialized, nil)
+}
+
+// LogDurably is LogSerialized, calling done once every logger that accepted
+// the event has written it, with the first error any of them reported.
+func (tl *teeLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	var g *doneGroup
+	if done != nil {
+		// The extra pending write keeps done from being called before every
+		// logger has been written to.
+		g = &doneGroup{pending: len(tl.loggers) + 1, done: done}
+	}
+	var firstErr error
+	for _, l := range tl.loggers {
+		var ldone func(error)
+		if g != nil {
+			ldone = g.finish
+		}
+		if err := LogDurably(l, e, serialized, ldone); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			if g != nil {
+				g.finish(nil)
+			}
+		}
+	}
+	if g != nil {
+		if firstErr != nil {
+			g.cancel()
+		}
+		g.finish(nil)
+	}
+	return firstErr
+}
+
+// doneGroup calls done once pending writes have finished, with the first
+// error.
+type doneGroup struct {
+	mu       sync.Mutex
+	pending  int
+	err      error
+	done     func(error)
+	canceled bool
+}
+
+func (g *doneGroup) finish(err error) {
+	g.mu.Lock()
+	if err != nil && g.err == nil {
+		g.err = err
+	}
+	g.pending--
+	call := g.pending == 0 && !g.canceled
+	g.mu.Unlock()
+	if call {
+		g.done(g.err)
+	}
+}
+
+// cancel keeps done from being called, for writes whose caller was told they
+// failed.
+func (g *doneGroup) cancel() {
+	g.mu.Lock()
+	g.canceled = true
+	g.mu.Unlock()
+}
+
+func (tl *teeLogger) Close() {
+	for _, l := range tl.loggers {
+		l.Close()
+	}
+}
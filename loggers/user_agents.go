@@ -0,0 +1,159 @@
+package loggers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+// UserAgentHashPrefix starts the hashes that replace User-Agents in events,
+// so consumers can tell them from User-Agents.
+const UserAgentHashPrefix = "sha256:"
+
+// userAgentHashBytes is how much of the SHA-256 of a User-Agent is kept.
+// Eight bytes are plenty for the number of distinct User-Agents there are.
+const userAgentHashBytes = 8
+
+const defaultUserAgentMaxEntries = 100000
+
+// UserAgentDictionaryConfig configures the replacement of the User-Agents of
+// events with their hashes, and the dictionary of hashes to User-Agents
+// written to S3 instead.
+type UserAgentDictionaryConfig struct {
+	// S3 configures the logger the dictionary is written to. Its Bucket
+	// must differ from those of the other S3 loggers.
+	S3 S3LoggerConfig
+
+	// MaxEntries is how many User-Agents are remembered per rotation period
+	// before they're forgotten early, and written again when next seen. It
+	// defaults to 100000.
+	MaxEntries int
+}
+
+// Validate verifies that a UserAgentDictionaryConfig is valid and fills in
+// defaults
+func (c *UserAgentDictionaryConfig) Validate() error {
+	if err := c.S3.Validate(); err != nil {
+		return fmt.Errorf("S3: %v", err)
+	}
+	if c.MaxEntries == 0 {
+		c.MaxEntries = defaultUserAgentMaxEntries
+	}
+	if c.MaxEntries < 0 {
+		return errors.New("MaxEntries must be a positive value")
+	}
+	return nil
+}
+
+// userAgentEntry is a line of the dictionary.
+type userAgentEntry struct {
+	Hash      string `json:"hash"`
+	UserAgent string `json:"userAgent"`
+}
+
+// MarshalUserAgent is the EventToStringFunc of the dictionary's S3 logger,
+// which is logged events holding a hash as their Uuid and its User-Agent.
+func MarshalUserAgent(e *spade.Event) (string, error) {
+	enc := encoderPool.Get().(*jsonEncoder)
+	defer encoderPool.Put(enc)
+	b, err := enc.encode(userAgentEntry{Hash: e.Uuid, UserAgent: e.UserAgent})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// HashUserAgent returns the hash that replaces userAgent in events.
+func HashUserAgent(userAgent string) string {
+	sum := sha256.Sum256([]byte(userAgent))
+	return UserAgentHashPrefix + hex.EncodeToString(sum[:userAgentHashBytes])
+}
+
+// UserAgentDictionary replaces User-Agents with their hashes, writing each
+// hash and its User-Agent to the dictionary the first time it's seen in a
+// rotation period of the dictionary's S3 logger, so every period's files
+// hold the User-Agents of its events. Periods are RotateEvery, an hour with
+// PartitionByHour, or else MaxAge, aligned to the wall clock.
+type UserAgentDictionary struct {
+	sink       SpadeEdgeLogger
+	statter    statsd.StatSender
+	clock      clock.Clock
+	period     time.Duration
+	maxEntries int
+
+	mu          sync.Mutex
+	periodStart time.Time
+	seen        map[string]struct{}
+}
+
+// NewUserAgentDictionary returns a UserAgentDictionary writing to sink, an
+// S3 logger for config.S3 printing events with MarshalUserAgent.
+func NewUserAgentDictionary(config UserAgentDictionaryConfig, sink SpadeEdgeLogger,
+	statter statsd.StatSender, env *Env) (*UserAgentDictionary, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	d := &UserAgentDictionary{
+		sink:       sink,
+		statter:    statter,
+		clock:      env.clock(),
+		maxEntries: config.MaxEntries,
+		seen:       make(map[string]struct{}),
+	}
+	switch {
+	case config.S3.RotateEvery != "":
+		d.period, _ = time.ParseDuration(config.S3.RotateEvery)
+	case config.S3.PartitionByHour:
+		d.period = time.Hour
+	default:
+		d.period, _ = time.ParseDuration(config.S3.MaxAge)
+	}
+	return d, nil
+}
+
+// Replace returns the hash of userAgent, writing it to the dictionary unless
+// it already was this period. An empty userAgent is returned as is.
+func (d *UserAgentDictionary) Replace(userAgent string) string {
+	if userAgent == "" {
+		return userAgent
+	}
+	hash := HashUserAgent(userAgent)
+	_ = d.statter.Inc("user_agents.bytes_saved", int64(len(userAgent)-len(hash)), 0.1)
+
+	now := d.clock.Now()
+	d.mu.Lock()
+	if start := now.Truncate(d.period); !start.Equal(d.periodStart) || len(d.seen) >= d.maxEntries {
+		d.periodStart = start
+		d.seen = make(map[string]struct{})
+	}
+	_, seen := d.seen[hash]
+	d.seen[hash] = struct{}{}
+	d.mu.Unlock()
+	if seen {
+		return hash
+	}
+
+	err := d.sink.Log(&spade.Event{ReceivedAt: now, Uuid: hash, UserAgent: userAgent})
+	if err != nil {
+		// It's written with the next event that has it instead.
+		d.mu.Lock()
+		delete(d.seen, hash)
+		d.mu.Unlock()
+		_ = d.statter.Inc("user_agents.failed", 1, 1)
+		return hash
+	}
+	_ = d.statter.Inc("user_agents.new", 1, 1)
+	return hash
+}
+
+// Close closes the dictionary's logger.
+func (d *UserAgentDictionary) Close() {
+	d.sink.Close()
+}
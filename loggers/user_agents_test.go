@@ -0,0 +1,77 @@
+package loggers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+type dictionarySink struct {
+	failingLogger
+	lines []string
+}
+
+func (d *dictionarySink) Log(e *spade.Event) error {
+	if d.err != nil {
+		return d.err
+	}
+	line, err := MarshalUserAgent(e)
+	if err != nil {
+		return err
+	}
+	d.lines = append(d.lines, line)
+	return nil
+}
+
+func TestUserAgentDictionary(t *testing.T) {
+	stats, _ := statsd.NewNoop()
+	sink := &dictionarySink{}
+	config := UserAgentDictionaryConfig{S3: S3LoggerConfig{Bucket: "ua", MaxLines: 100, MaxAge: "10m",
+		RotateEvery: "1h"}}
+	d, err := NewUserAgentDictionary(config, sink, stats, nil)
+	if err != nil {
+		t.Fatalf("Failed to create dictionary: %s", err)
+	}
+	hour := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(hour)
+	d.clock = fake
+
+	const ua = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/58.0"
+	hash := d.Replace(ua)
+	if !strings.HasPrefix(hash, UserAgentHashPrefix) || len(hash) != len(UserAgentHashPrefix)+16 {
+		t.Errorf("Expected a truncated SHA-256, got %s", hash)
+	}
+	if d.Replace(ua) != hash || d.Replace("") != "" {
+		t.Errorf("Expected the same hash for the same User-Agent and none for none")
+	}
+	expected := `{"hash":"` + hash + `","userAgent":"` + ua + `"}`
+	if len(sink.lines) != 1 || sink.lines[0] != expected {
+		t.Fatalf("Expected the User-Agent written once, got %v", sink.lines)
+	}
+
+	// Each rotation period has its own dictionary.
+	fake.Advance(time.Hour)
+	d.Replace(ua)
+	if len(sink.lines) != 2 {
+		t.Errorf("Expected the User-Agent written again the next hour, got %v", sink.lines)
+	}
+
+	// A failed write is retried with the next event.
+	sink.err = errors.New("full")
+	d.Replace("curl/7.54.0")
+	sink.err = nil
+	d.Replace("curl/7.54.0")
+	if len(sink.lines) != 3 || !strings.Contains(sink.lines[2], "curl") {
+		t.Errorf("Expected the failed User-Agent written by the next event, got %v", sink.lines)
+	}
+
+	d.Close()
+	if !sink.closed {
+		t.Error("Expected closing the dictionary to close its logger")
+	}
+}
@@ -0,0 +1,166 @@
+package loggers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/avro"
+)
+
+const verifyStatsPrefix = "logger.kinesis.verify."
+
+// VerifyConfig configures dual-write verification of a stream: a sample of
+// its globs is also encoded the way a candidate implementation would, and the
+// two are compared, so a migration of the stream's Format or serialization
+// version can be checked on live traffic before it's made.
+type VerifyConfig struct {
+	// SampleRate is the fraction of globs verified, e.g. 0.01
+	SampleRate float64
+
+	// Format is the candidate's glob format. It defaults to the stream's.
+	Format string
+
+	// EnvelopeVersion is the candidate's serialization version. It defaults
+	// to the version written.
+	EnvelopeVersion int
+
+	// ShadowStream, if set, is a stream the candidate's records are written
+	// to, so its consumers can be tried out. Failed writes aren't retried.
+	ShadowStream string
+}
+
+// Validate verifies that a VerifyConfig is valid for stream, whose Format
+// must already be filled in
+func (c *VerifyConfig) Validate(stream *KinesisLoggerConfig) error {
+	if c.SampleRate <= 0 || c.SampleRate > 1 {
+		return errors.New("SampleRate must be between 0 and 1")
+	}
+	switch c.Format {
+	case "":
+		c.Format = stream.Format
+	case formatJSON:
+	case formatAvro:
+		if stream.SchemaRegistryURL == "" {
+			return errors.New("the stream's SchemaRegistryURL is required for the avro format")
+		}
+	default:
+		return fmt.Errorf("unknown Format %s", c.Format)
+	}
+	if c.EnvelopeVersion != 0 && (c.EnvelopeVersion < SerializationV1 || c.EnvelopeVersion > LatestSerialization) {
+		return fmt.Errorf("EnvelopeVersion must be between %d and %d", SerializationV1, LatestSerialization)
+	}
+	return nil
+}
+
+// verifier compares the globs of a kinesisLogger with a candidate encoding.
+type verifier struct {
+	config  VerifyConfig
+	statter statsd.StatSender
+	// env is the kinesisLogger's, whose envelope candidates start from.
+	env *Env
+	// schemaID is the registered glob schema, for the avro format.
+	schemaID  int32
+	putRecord func(*kinesis.PutRecordInput) (*kinesis.PutRecordOutput, error)
+	random    func() float64
+}
+
+// sampled returns whether the next glob is verified.
+func (v *verifier) sampled() bool {
+	return v.random() < v.config.SampleRate
+}
+
+// encode returns events encoded by the candidate.
+func (v *verifier) encode(events []*spade.Event) ([]byte, error) {
+	if v.config.Format == formatAvro {
+		return avro.AppendGlob(avro.AppendFrame(nil, v.schemaID), events), nil
+	}
+	env := *v.env.envelope()
+	if v.config.EnvelopeVersion != 0 {
+		env.version = v.config.EnvelopeVersion
+	}
+	// Candidate serializations aren't written, so they aren't counted.
+	env.stats, _ = statsd.NewNoop()
+	enc := newJSONEncoder()
+	parts := [][]byte{[]byte("[")}
+	for i, e := range events {
+		if i > 0 {
+			parts = append(parts, []byte(","))
+		}
+		b, err := enc.encode(env.wrap(e))
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, append([]byte(nil), b...))
+	}
+	return compressGlob(append(parts, []byte("]"))...)
+}
+
+// verify compares the record written for events, in format, with the
+// candidate's, and writes the candidate's to the shadow stream.
+func (v *verifier) verify(record []byte, format string, events []*spade.Event) {
+	_ = v.statter.Inc(verifyStatsPrefix+"globs", 1, 1)
+	candidate, err := v.encode(events)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to encode candidate glob")
+		_ = v.statter.Inc(verifyStatsPrefix+"failed", 1, 1)
+		return
+	}
+	_ = v.statter.Inc(verifyStatsPrefix+"bytes.primary", int64(len(record)), 1)
+	_ = v.statter.Inc(verifyStatsPrefix+"bytes.candidate", int64(len(candidate)), 1)
+	if bytes.Equal(record, candidate) {
+		_ = v.statter.Inc(verifyStatsPrefix+"identical", 1, 1)
+	}
+
+	primaryRecords, primaryErr := globLength(record, format)
+	candidateRecords, candidateErr := globLength(candidate, v.config.Format)
+	if primaryErr != nil || candidateErr != nil || primaryRecords != len(events) || candidateRecords != len(events) {
+		logger.WithFields(map[string]interface{}{
+			"events":            len(events),
+			"primary_records":   primaryRecords,
+			"primary_error":     primaryErr,
+			"candidate_records": candidateRecords,
+			"candidate_error":   candidateErr,
+		}).Warn("Candidate glob diverged")
+		_ = v.statter.Inc(verifyStatsPrefix+"diverged", 1, 1)
+	}
+
+	if v.config.ShadowStream == "" {
+		return
+	}
+	_, err = v.putRecord(&kinesis.PutRecordInput{
+		StreamName:   aws.String(v.config.ShadowStream),
+		PartitionKey: aws.String(events[0].Uuid),
+		Data:         candidate,
+	})
+	if err != nil {
+		_ = v.statter.Inc(verifyStatsPrefix+"shadow_failed", 1, 1)
+	}
+}
+
+// globLength returns the number of events a glob in format decodes to.
+func globLength(record []byte, format string) (int, error) {
+	if format == formatAvro {
+		return avro.GlobLength(record)
+	}
+	events, err := spade.Deglob(record)
+	return len(events), err
+}
+
+func newVerifier(config VerifyConfig, schemaID int32, client *kinesis.Kinesis, statter statsd.StatSender,
+	env *Env) *verifier {
+	return &verifier{
+		config:    config,
+		statter:   statter,
+		env:       env,
+		schemaID:  schemaID,
+		putRecord: client.PutRecord,
+		random:    rand.Float64,
+	}
+}
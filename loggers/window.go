@@ -0,0 +1,317 @@
+package loggers
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/gologging/key_name_generator"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+const defaultMaxOpenHours = 4
+
+var errWindowedLoggerClosed = errors.New("S3 logger closed")
+
+func validateWindow(c *S3LoggerConfig) error {
+	if c.PartitionByHour {
+		if c.RotateEvery != "" || c.LateTolerance != "" {
+			return errors.New("PartitionByHour can't be combined with RotateEvery")
+		}
+		if c.MaxOpenHours == 0 {
+			c.MaxOpenHours = defaultMaxOpenHours
+		}
+		if c.MaxOpenHours < 0 {
+			return errors.New("MaxOpenHours must be a positive value")
+		}
+		return nil
+	}
+	if c.MaxOpenHours != 0 {
+		return errors.New("MaxOpenHours requires PartitionByHour")
+	}
+	if c.RotateEvery == "" {
+		if c.LateTolerance != "" {
+			return errors.New("LateTolerance requires RotateEvery")
+		}
+		return nil
+	}
+	every, err := time.ParseDuration(c.RotateEvery)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.RotateEvery, err)
+	}
+	if every <= 0 || (24*time.Hour)%every != 0 {
+		return errors.New("RotateEvery must evenly divide a day")
+	}
+	if c.LateTolerance == "" {
+		c.LateTolerance = "0s"
+	}
+	tolerance, err := time.ParseDuration(c.LateTolerance)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.LateTolerance, err)
+	}
+	if tolerance < 0 || tolerance >= every {
+		return errors.New("LateTolerance must be between 0 and RotateEvery")
+	}
+	return nil
+}
+
+// window is the logger of the events received in one interval.
+type window struct {
+	*s3Logger
+	expiry clock.Timer
+
+	// lastWrite is the Unix nanoseconds of the last write, tracked for
+	// partitions.
+	lastWrite int64
+}
+
+// windowedLogger writes the events received in each interval, aligned to the
+// wall clock, to their own files. With RotateEvery, the intervals are written
+// one after the other, and each is closed once it's over. With
+// PartitionByHour, events are written to their hour's files whenever they're
+// logged, and each hour is closed once it's gone MaxAge without a write or
+// more than MaxOpenHours hours are needed.
+type windowedLogger struct {
+	config      S3LoggerConfig
+	info        key_name_generator.InstanceInfo
+	s3Uploader  s3manageriface.UploaderAPI
+	printFunc   EventToStringFunc
+	every       time.Duration
+	tolerance   time.Duration
+	partitioned bool
+	maxIdle     time.Duration
+	env         *Env
+	clock       clock.Clock
+
+	// windows are keyed by the Unix time their intervals start.
+	mu      sync.RWMutex
+	windows map[int64]*window
+	closed  bool
+
+	// closing tracks the windows being closed, so Close can wait for them.
+	closing sync.WaitGroup
+}
+
+func newWindowedLogger(
+	config S3LoggerConfig,
+	info *key_name_generator.InstanceInfo,
+	printFunc EventToStringFunc,
+	s3Uploader s3manageriface.UploaderAPI,
+	env *Env,
+) *windowedLogger {
+	l := &windowedLogger{
+		config:     config,
+		info:       *info,
+		s3Uploader: s3Uploader,
+		printFunc:  printFunc,
+		env:        env,
+		clock:      env.clock(),
+		windows:    make(map[int64]*window),
+	}
+	if config.PartitionByHour {
+		l.every = time.Hour
+		l.partitioned = true
+		l.maxIdle, _ = time.ParseDuration(config.MaxAge)
+	} else {
+		l.every, _ = time.ParseDuration(config.RotateEvery)
+		l.tolerance, _ = time.ParseDuration(config.LateTolerance)
+	}
+	return l
+}
+
+// windowStart returns the start of the interval an event received at
+// receivedAt is written in: the interval it was received in, unless that's
+// over and more than LateTolerance ago, in which case it's the current one.
+func (l *windowedLogger) windowStart(receivedAt time.Time) time.Time {
+	now := l.clock.Now()
+	current := now.Truncate(l.every)
+	start := receivedAt.Truncate(l.every)
+	if l.partitioned && !receivedAt.IsZero() {
+		return start
+	}
+	if start.Equal(current) || start.Equal(current.Add(-l.every)) && now.Sub(current) < l.tolerance {
+		return start
+	}
+	return current
+}
+
+// write writes with the logger of the interval receivedAt falls in.
+func (l *windowedLogger) write(receivedAt time.Time, write func(*s3Logger) error) error {
+	start := l.windowStart(receivedAt)
+	l.mu.RLock()
+	w := l.windows[start.Unix()]
+	if w == nil {
+		l.mu.RUnlock()
+		if err := l.open(start); err != nil {
+			return err
+		}
+		l.mu.RLock()
+		w = l.windows[start.Unix()]
+	}
+	// The read lock keeps the window from being closed while it's written to.
+	defer l.mu.RUnlock()
+	if w == nil {
+		return errWindowedLoggerClosed
+	}
+	if l.partitioned {
+		atomic.StoreInt64(&w.lastWrite, l.clock.Now().UnixNano())
+	}
+	return write(w.s3Logger)
+}
+
+// open starts the logger of the interval starting at start, if it's not
+// already started, and closes it once the interval and LateTolerance pass.
+func (l *windowedLogger) open(start time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return errWindowedLoggerClosed
+	}
+	if l.windows[start.Unix()] != nil {
+		return nil
+	}
+	if l.partitioned && len(l.windows) >= l.config.MaxOpenHours {
+		l.evict()
+	}
+
+	// Each interval's files get their own names, so the writers of two
+	// intervals can't pick the same one. They still start with the names of
+	// the bucket's files, so RecoverOrphans finds them.
+	info := l.info
+	info.Service = fmt.Sprintf("%s.log.gz.w%d", l.config.Bucket, start.Unix())
+	s3l, err := startS3Logger(l.config, &info, &windowKeyNameGenerator{info: &info, start: start},
+		l.printFunc, l.s3Uploader, l.env)
+	if err != nil {
+		return err
+	}
+	w := &window{s3Logger: s3l, lastWrite: l.clock.Now().UnixNano()}
+	if l.partitioned {
+		w.expiry = l.clock.AfterFunc(l.maxIdle, func() { l.expireIdle(start.Unix()) })
+	} else {
+		w.expiry = l.clock.AfterFunc(start.Add(l.every+l.tolerance).Sub(l.clock.Now()), func() {
+			l.expire(start.Unix())
+		})
+	}
+	l.windows[start.Unix()] = w
+	return nil
+}
+
+func (l *windowedLogger) expire(start int64) {
+	l.mu.Lock()
+	w := l.windows[start]
+	if w == nil {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.windows, start)
+	l.closing.Add(1)
+	l.mu.Unlock()
+	defer l.closing.Done()
+	w.Close()
+}
+
+// expireIdle closes a partition if it's gone maxIdle without a write, or
+// checks again once it could have.
+func (l *windowedLogger) expireIdle(start int64) {
+	l.mu.Lock()
+	w := l.windows[start]
+	if w == nil {
+		l.mu.Unlock()
+		return
+	}
+	idle := l.clock.Now().Sub(time.Unix(0, atomic.LoadInt64(&w.lastWrite)))
+	if idle < l.maxIdle {
+		w.expiry.Reset(l.maxIdle - idle)
+		l.mu.Unlock()
+		return
+	}
+	delete(l.windows, start)
+	l.closing.Add(1)
+	l.mu.Unlock()
+	defer l.closing.Done()
+	w.Close()
+}
+
+// evict closes the partition written to least recently in the background. It
+// must be called with the lock held.
+func (l *windowedLogger) evict() {
+	var oldest int64
+	var w *window
+	for start, candidate := range l.windows {
+		if w == nil || atomic.LoadInt64(&candidate.lastWrite) < atomic.LoadInt64(&w.lastWrite) {
+			oldest, w = start, candidate
+		}
+	}
+	delete(l.windows, oldest)
+	w.expiry.Stop()
+	l.closing.Add(1)
+	logger.Go(func() {
+		defer l.closing.Done()
+		w.Close()
+	})
+}
+
+func (l *windowedLogger) Log(e *spade.Event) error {
+	return l.write(e.ReceivedAt, func(s3l *s3Logger) error {
+		return s3l.Log(e)
+	})
+}
+
+func (l *windowedLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return l.write(e.ReceivedAt, func(s3l *s3Logger) error {
+		return s3l.LogSerialized(e, serialized)
+	})
+}
+
+// LogDurably is LogSerialized, handing done to the logger of the event's
+// window.
+func (l *windowedLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	return l.write(e.ReceivedAt, func(s3l *s3Logger) error {
+		return LogDurably(s3l, e, serialized, done)
+	})
+}
+
+func (l *windowedLogger) Close() {
+	l.mu.Lock()
+	l.closed = true
+	windows := l.windows
+	l.windows = nil
+	l.mu.Unlock()
+
+	for _, w := range windows {
+		w.expiry.Stop()
+		l.closing.Add(1)
+		w := w
+		logger.Go(func() {
+			defer l.closing.Done()
+			w.Close()
+		})
+	}
+	l.closing.Wait()
+}
+
+// windowKeyNameGenerator names files like EdgeKeyNameGenerator, but dated by
+// the start of their interval rather than their upload, so every file of an
+// interval has the same date and timestamp however late it's uploaded.
+type windowKeyNameGenerator struct {
+	info  *key_name_generator.InstanceInfo
+	start time.Time
+}
+
+func (g *windowKeyNameGenerator) GetKeyName(string) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s/%s/%d.%s.%08x.log.gz",
+		g.start.UTC().Format("20060102"),
+		g.info.AutoScaleGroup,
+		g.start.Unix(),
+		g.info.Node,
+		b,
+	)
+}
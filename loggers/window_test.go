@@ -0,0 +1,118 @@
+package loggers
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchscience/gologging/key_name_generator"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/clock"
+)
+
+func TestWindowedLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "window")
+	if err != nil {
+		t.Fatalf("Failed to create logging dir: %s", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	config := S3LoggerConfig{Bucket: "bucket", MaxLines: 100, MaxAge: "1h", RotateEvery: "1h", LateTolerance: "1m"}
+	if err = config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %s", err)
+	}
+	uploader := &fakeUploader{}
+	info := &key_name_generator.InstanceInfo{Service: "bucket", AutoScaleGroup: "asg", Node: "node", LoggingDir: dir}
+	l := newWindowedLogger(config, info, nil, uploader, nil)
+	hour := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	l.clock = clock.NewFake(hour.Add(30 * time.Second))
+
+	for _, receivedAt := range []time.Time{
+		hour.Add(-time.Second), // late, but within LateTolerance
+		hour.Add(10 * time.Second),
+		hour.Add(-2 * time.Hour), // too late for its hour
+	} {
+		if err = l.Log(&spade.Event{ReceivedAt: receivedAt}); err != nil {
+			t.Fatalf("Failed to log: %s", err)
+		}
+	}
+	l.Close()
+
+	sort.Strings(uploader.keys)
+	if len(uploader.keys) != 2 ||
+		!strings.HasPrefix(uploader.keys[0], "20170601/asg/1496318400.node.") ||
+		!strings.HasPrefix(uploader.keys[1], "20170601/asg/1496322000.node.") {
+		t.Errorf("Expected a file for each hour, got %v", uploader.keys)
+	}
+	if err = l.Log(&spade.Event{ReceivedAt: hour}); err != errWindowedLoggerClosed {
+		t.Errorf("Expected logging after Close to fail, got %v", err)
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	for _, c := range []S3LoggerConfig{
+		{RotateEvery: "7m"},
+		{RotateEvery: "1h", LateTolerance: "1h"},
+		{LateTolerance: "1m"},
+		{RotateEvery: "1h", PartitionByHour: true},
+		{MaxOpenHours: 2},
+	} {
+		c.Bucket, c.MaxLines, c.MaxAge = "bucket", 1, "1m"
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
+
+func TestPartitionByHour(t *testing.T) {
+	dir, err := ioutil.TempDir("", "window")
+	if err != nil {
+		t.Fatalf("Failed to create logging dir: %s", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	config := S3LoggerConfig{Bucket: "bucket", MaxLines: 100, MaxAge: "1h", PartitionByHour: true, MaxOpenHours: 2}
+	if err = config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %s", err)
+	}
+	uploader := &fakeUploader{}
+	info := &key_name_generator.InstanceInfo{Service: "bucket", AutoScaleGroup: "asg", Node: "node", LoggingDir: dir}
+	l := newWindowedLogger(config, info, nil, uploader, nil)
+	hour := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(hour)
+	l.clock = fake
+
+	// Replayed events from three hours interleave; the least recently written
+	// hour is uploaded to make room for the third.
+	for i, receivedAt := range []time.Time{
+		hour.Add(-2 * time.Hour),
+		hour.Add(-time.Hour),
+		hour.Add(-2*time.Hour + time.Minute),
+		hour.Add(-time.Hour + time.Minute),
+		hour,
+	} {
+		fake.Set(hour.Add(time.Duration(i) * time.Second))
+		if err = l.Log(&spade.Event{ReceivedAt: receivedAt}); err != nil {
+			t.Fatalf("Failed to log: %s", err)
+		}
+	}
+	l.mu.RLock()
+	open := len(l.windows)
+	_, evicted := l.windows[hour.Add(-2*time.Hour).Unix()]
+	l.mu.RUnlock()
+	if open != 2 || evicted {
+		t.Errorf("Expected the 11:00 files to be closed for the 13:00 ones, got %d hours open", open)
+	}
+	l.Close()
+
+	sort.Strings(uploader.keys)
+	if len(uploader.keys) != 3 ||
+		!strings.HasPrefix(uploader.keys[0], "20170601/asg/1496314800.node.") ||
+		!strings.HasPrefix(uploader.keys[1], "20170601/asg/1496318400.node.") ||
+		!strings.HasPrefix(uploader.keys[2], "20170601/asg/1496322000.node.") {
+		t.Errorf("Expected a file for each hour, got %v", uploader.keys)
+	}
+}
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCardinalityLimit  = 100
+	defaultCardinalityWindow = "10m"
+
+	// candidatesPerValue is how many more values than the limit are counted
+	// as candidates for the next window.
+	candidatesPerValue = 10
+
+	// OtherValue replaces the values of a dimension that aren't tracked.
+	OtherValue = "other"
+)
+
+// CardinalityConfig bounds the number of distinct values of dimensional stats
+// derived from client input, like requests.hosts.<host>.
+type CardinalityConfig struct {
+	// Limit is the most values tracked per dimension
+	Limit int
+
+	// Window is how often the tracked values are recomputed from the most
+	// frequent values seen, e.g. "10m"
+	Window string
+}
+
+// Validate verifies that a CardinalityConfig is valid and fills in defaults
+func (c *CardinalityConfig) Validate() error {
+	if c.Limit == 0 {
+		c.Limit = defaultCardinalityLimit
+	}
+	if c.Limit < 0 {
+		return errors.New("Limit must be greater than 0")
+	}
+	if c.Window == "" {
+		c.Window = defaultCardinalityWindow
+	}
+	window, err := time.ParseDuration(c.Window)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Window, err)
+	}
+	if window <= 0 {
+		return errors.New("Window must be greater than 0")
+	}
+	return nil
+}
+
+// CardinalityLimiter tracks the most frequent values of each dimension and
+// replaces the rest with OtherValue.
+//
+// New values are tracked first come, first served while fewer than Limit are.
+// At the end of each window the tracked values are replaced by the top Limit
+// values counted during it, so values that flooded in early don't stay
+// tracked. Only a bounded number of untracked values are counted, so a flood
+// of distinct values can't grow memory, and tracked values are always counted
+// so they can't be displaced by one.
+type CardinalityLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu         sync.Mutex
+	dimensions map[string]*dimension
+}
+
+type dimension struct {
+	tracked   map[string]bool
+	counts    map[string]int64
+	untracked int
+	windowEnd time.Time
+}
+
+// NewCardinalityLimiter returns a CardinalityLimiter for config.
+func NewCardinalityLimiter(config CardinalityConfig) (*CardinalityLimiter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	window, _ := time.ParseDuration(config.Window)
+	return &CardinalityLimiter{
+		limit:      config.Limit,
+		window:     window,
+		now:        time.Now,
+		dimensions: make(map[string]*dimension),
+	}, nil
+}
+
+// Limit returns value if it is tracked for dimension, or OtherValue.
+func (l *CardinalityLimiter) Limit(dimensionName, value string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	d, ok := l.dimensions[dimensionName]
+	if !ok {
+		d = &dimension{
+			tracked:   make(map[string]bool),
+			counts:    make(map[string]int64),
+			windowEnd: now.Add(l.window),
+		}
+		l.dimensions[dimensionName] = d
+	}
+	if !now.Before(d.windowEnd) {
+		l.rotate(d, now)
+	}
+
+	if !d.tracked[value] && len(d.tracked) < l.limit {
+		d.tracked[value] = true
+	}
+	if d.tracked[value] {
+		d.counts[value]++
+		return value
+	}
+	if _, counted := d.counts[value]; counted || d.untracked < l.limit*candidatesPerValue {
+		if !counted {
+			d.untracked++
+		}
+		d.counts[value]++
+	}
+	return OtherValue
+}
+
+// rotate tracks the most frequent values of the window that ended and starts
+// counting anew.
+func (l *CardinalityLimiter) rotate(d *dimension, now time.Time) {
+	values := make([]string, 0, len(d.counts))
+	for v := range d.counts {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if d.counts[values[i]] != d.counts[values[j]] {
+			return d.counts[values[i]] > d.counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	if len(values) > l.limit {
+		values = values[:l.limit]
+	}
+
+	d.tracked = make(map[string]bool, len(values))
+	for _, v := range values {
+		d.tracked[v] = true
+	}
+	d.counts = make(map[string]int64, len(d.counts))
+	d.untracked = 0
+	d.windowEnd = now.Add(l.window)
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCardinalityLimiter(t *testing.T) {
+	l, err := NewCardinalityLimiter(CardinalityConfig{Limit: 2, Window: "1m"})
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %s", err)
+	}
+	now := time.Unix(1500000000, 0)
+	l.now = func() time.Time { return now }
+
+	// The first values seen fill the limit.
+	for _, host := range []string{"junk_1", "junk_2"} {
+		if v := l.Limit("hosts", host); v != host {
+			t.Errorf("Expected %s to be tracked, got %s", host, v)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if v := l.Limit("hosts", "spade_example_com"); v != OtherValue {
+			t.Errorf("Expected host over the limit to be %s, got %s", OtherValue, v)
+		}
+		_ = l.Limit("hosts", "api_example_com")
+	}
+	for i := 0; i < 100; i++ {
+		_ = l.Limit("hosts", fmt.Sprintf("flood_%d", i))
+	}
+	if v := l.Limit("other_dimension", "spade_example_com"); v != "spade_example_com" {
+		t.Errorf("Expected dimensions to be limited independently, got %s", v)
+	}
+
+	// The most frequent hosts of the last window replace the first ones.
+	now = now.Add(time.Minute)
+	for _, tt := range []struct{ host, expected string }{
+		{"spade_example_com", "spade_example_com"},
+		{"api_example_com", "api_example_com"},
+		{"junk_1", OtherValue},
+		{"flood_99", OtherValue},
+	} {
+		if v := l.Limit("hosts", tt.host); v != tt.expected {
+			t.Errorf("Expected %s to be %s, got %s", tt.host, tt.expected, v)
+		}
+	}
+
+	d := l.dimensions["hosts"]
+	if d.untracked > 2*candidatesPerValue {
+		t.Errorf("Expected at most %d untracked values, got %d", 2*candidatesPerValue, d.untracked)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Package metrics lets deployments tune the stats the edge sends without code
+changes: sample rates can be overridden per stat group, forced to 1 where
+traffic is too low for sampled stats to be useful, and stat names can be
+remapped to fit existing dashboards.
+
+A stat group is a dot-separated prefix of stat names, so "bad_request" covers
+bad_request.empty and bad_request.parse_form. When several configured groups
+match a stat, the longest wins.
+*/
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// Config configures stat sampling and naming.
+type Config struct {
+	// SampleRates overrides the sample rates of stat groups
+	SampleRates map[string]float32
+
+	// ForceFullSampling sends every stat with a rate of 1, overriding
+	// SampleRates. Stats with a rate of 0 stay disabled.
+	ForceFullSampling bool
+
+	// Rename maps stat groups to the names they are sent as, e.g.
+	// {"split_large_request": "large_request.split"}
+	Rename map[string]string
+}
+
+// Validate verifies that a Config is valid
+func (c *Config) Validate() error {
+	for group, rate := range c.SampleRates {
+		if group == "" {
+			return errors.New("SampleRates: stat group must not be empty")
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("SampleRates[%s] must be between 0 and 1", group)
+		}
+	}
+	for from, to := range c.Rename {
+		if from == "" || to == "" {
+			return fmt.Errorf("invalid rename %q to %q", from, to)
+		}
+	}
+	return nil
+}
+
+// Statter is a statsd.Statter that applies a Config to the stats it sends to
+// the wrapped Statter.
+type Statter struct {
+	statsd.Statter
+	config Config
+}
+
+// New returns a Statter sending stats to statter as configured.
+func New(config Config, statter statsd.Statter) (*Statter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Statter{Statter: statter, config: config}, nil
+}
+
+// resolve returns the name and sample rate to send stat with.
+func (s *Statter) resolve(stat string, rate float32) (string, float32) {
+	if rate > 0 {
+		if s.config.ForceFullSampling {
+			rate = 1
+		} else if group, ok := longestGroup(stat, func(g string) bool {
+			_, ok := s.config.SampleRates[g]
+			return ok
+		}); ok {
+			rate = s.config.SampleRates[group]
+		}
+	}
+	if group, ok := longestGroup(stat, func(g string) bool {
+		_, ok := s.config.Rename[g]
+		return ok
+	}); ok {
+		stat = s.config.Rename[group] + stat[len(group):]
+	}
+	return stat, rate
+}
+
+// longestGroup returns the longest dot-separated prefix of stat that is a
+// configured group.
+func longestGroup(stat string, configured func(string) bool) (string, bool) {
+	for end := len(stat); end > 0; end = strings.LastIndexByte(stat[:end], '.') {
+		if configured(stat[:end]) {
+			return stat[:end], true
+		}
+	}
+	return "", false
+}
+
+// Inc increments a counter.
+func (s *Statter) Inc(stat string, value int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Inc(stat, value, rate)
+}
+
+// Dec decrements a counter.
+func (s *Statter) Dec(stat string, value int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Dec(stat, value, rate)
+}
+
+// Gauge sets a gauge.
+func (s *Statter) Gauge(stat string, value int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Gauge(stat, value, rate)
+}
+
+// GaugeDelta changes a gauge.
+func (s *Statter) GaugeDelta(stat string, value int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.GaugeDelta(stat, value, rate)
+}
+
+// Timing records a timing.
+func (s *Statter) Timing(stat string, delta int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Timing(stat, delta, rate)
+}
+
+// TimingDuration records a timing.
+func (s *Statter) TimingDuration(stat string, delta time.Duration, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.TimingDuration(stat, delta, rate)
+}
+
+// Set records a value in a set.
+func (s *Statter) Set(stat string, value string, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Set(stat, value, rate)
+}
+
+// SetInt records a number in a set.
+func (s *Statter) SetInt(stat string, value int64, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.SetInt(stat, value, rate)
+}
+
+// Raw sends a preformatted value.
+func (s *Statter) Raw(stat string, value string, rate float32) error {
+	stat, rate = s.resolve(stat, rate)
+	return s.Statter.Raw(stat, value, rate)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func TestResolve(t *testing.T) {
+	noop, _ := statsd.NewNoop()
+	s, err := New(Config{
+		SampleRates: map[string]float32{"bad_request": 1, "bad_request.empty": 0.5},
+		Rename:      map[string]string{"split_large_request": "large_request.split"},
+	}, noop)
+	if err != nil {
+		t.Fatalf("Failed to create statter: %s", err)
+	}
+
+	for _, tt := range []struct {
+		stat, expectedStat string
+		rate, expectedRate float32
+	}{
+		{"bad_request.parse_form", "bad_request.parse_form", 0.01, 1},
+		{"bad_request.empty", "bad_request.empty", 0.01, 0.5},
+		{"bad_requests", "bad_requests", 0.01, 0.01},
+		{"bad_request.read_data", "bad_request.read_data", 0, 0},
+		{"split_large_request.event.fail", "large_request.split.event.fail", 0.1, 0.1},
+		{"split_large_request", "large_request.split", 0.1, 0.1},
+	} {
+		stat, rate := s.resolve(tt.stat, tt.rate)
+		if stat != tt.expectedStat || rate != tt.expectedRate {
+			t.Errorf("Expected %s to resolve to %s@%v, got %s@%v",
+				tt.stat, tt.expectedStat, tt.expectedRate, stat, rate)
+		}
+	}
+
+	s.config.ForceFullSampling = true
+	if _, rate := s.resolve("status_code.204", 0.001); rate != 1 {
+		t.Errorf("Expected forced rate of 1, got %v", rate)
+	}
+	if _, rate := s.resolve("event_in_URI", 0); rate != 0 {
+		t.Errorf("Expected disabled stat to stay disabled, got %v", rate)
+	}
+}
@@ -0,0 +1,327 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultStatsdFlushInterval     = "100ms"
+	defaultStatsdQueueLength       = 64
+	defaultStatsdReconnectDelay    = "100ms"
+	defaultStatsdMaxReconnectDelay = "10s"
+
+	// udpFlushBytes keeps UDP datagrams within a typical MTU; unix sockets
+	// and TCP take larger writes.
+	udpFlushBytes    = 1432
+	streamFlushBytes = 8192
+
+	statsdDialTimeout  = 5 * time.Second
+	statsdWriteTimeout = time.Second
+)
+
+// StatsdConfig configures how stats are sent to statsd.
+type StatsdConfig struct {
+	// Address is where statsd listens: host:port or udp://host:port for UDP,
+	// tcp://host:port for TCP or uds:///path for a unix datagram socket. It
+	// overrides the STATSD_HOSTPORT environment variable.
+	Address string
+
+	// FlushInterval is how often buffered stats are sent, e.g. "100ms"
+	FlushInterval string
+
+	// FlushBytes is the size buffered stats are sent at. It defaults to 1432
+	// for UDP and 8192 otherwise.
+	FlushBytes int
+
+	// QueueLength is how many buffers may wait to be written. Stats that
+	// don't fit are dropped. It defaults to 64.
+	QueueLength int
+
+	// ReconnectDelay is how long to wait before reconnecting after a failure,
+	// doubling up to MaxReconnectDelay while reconnecting fails, e.g.
+	// "100ms"
+	ReconnectDelay    string
+	MaxReconnectDelay string
+}
+
+// Validate verifies that a StatsdConfig is valid and fills in defaults
+func (c *StatsdConfig) Validate() error {
+	if c.Address != "" {
+		if _, _, err := ParseStatsdAddress(c.Address); err != nil {
+			return err
+		}
+	}
+	if c.FlushInterval == "" {
+		c.FlushInterval = defaultStatsdFlushInterval
+	}
+	if c.ReconnectDelay == "" {
+		c.ReconnectDelay = defaultStatsdReconnectDelay
+	}
+	if c.MaxReconnectDelay == "" {
+		c.MaxReconnectDelay = defaultStatsdMaxReconnectDelay
+	}
+	for _, d := range []string{c.FlushInterval, c.ReconnectDelay, c.MaxReconnectDelay} {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed <= 0 {
+			return errors.New("FlushInterval, ReconnectDelay and MaxReconnectDelay must be greater than 0")
+		}
+	}
+	if c.FlushBytes < 0 {
+		return errors.New("FlushBytes must be a positive value")
+	}
+	if c.QueueLength == 0 {
+		c.QueueLength = defaultStatsdQueueLength
+	}
+	if c.QueueLength < 0 {
+		return errors.New("QueueLength must be a positive value")
+	}
+	return nil
+}
+
+// ParseStatsdAddress returns the network and address to dial for a statsd
+// Address.
+func ParseStatsdAddress(address string) (network, addr string, err error) {
+	switch {
+	case strings.HasPrefix(address, "udp://"):
+		network, addr = "udp", strings.TrimPrefix(address, "udp://")
+	case strings.HasPrefix(address, "tcp://"):
+		network, addr = "tcp", strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "uds://"):
+		network, addr = "unixgram", strings.TrimPrefix(address, "uds://")
+	case strings.Contains(address, "://"):
+		return "", "", fmt.Errorf("unsupported statsd address %s: the schemes are udp, tcp and uds", address)
+	default:
+		network, addr = "udp", address
+	}
+	if addr == "" {
+		return "", "", fmt.Errorf("statsd address %s has no host or path", address)
+	}
+	return network, addr, nil
+}
+
+// DialStatsd connects to a statsd Address.
+func DialStatsd(address string) (net.Conn, error) {
+	network, addr, err := ParseStatsdAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return net.DialTimeout(network, addr, statsdDialTimeout)
+}
+
+// StatsdSender is a statsd.Sender that buffers stats and writes them to
+// statsd from its own goroutine, so sending a stat never waits on the
+// network. Stats are newline-separated, FlushBytes at a time or every
+// FlushInterval.
+//
+// A failed write drops the buffer and closes the connection, which is
+// reopened after ReconnectDelay; the stats sent meanwhile are dropped too, and
+// logged once the connection is back.
+type StatsdSender struct {
+	address           string
+	stream            bool
+	flushBytes        int
+	reconnectDelay    time.Duration
+	maxReconnectDelay time.Duration
+
+	mu      sync.Mutex
+	buf     *bytes.Buffer
+	lines   int64
+	closed  bool
+	dropped int64
+
+	pool  sync.Pool
+	queue chan *bytes.Buffer
+	quit  chan struct{}
+	done  chan struct{}
+
+	// Only used by the writing goroutine.
+	conn      net.Conn
+	retryAt   time.Time
+	nextDelay time.Duration
+}
+
+// NewStatsdSender returns a StatsdSender for config, which must have an
+// Address. It connects in the background, so statsd needn't be up yet.
+func NewStatsdSender(config StatsdConfig) (*StatsdSender, error) {
+	if config.Address == "" {
+		return nil, errors.New("a statsd Address is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	network, _, _ := ParseStatsdAddress(config.Address)
+	s := &StatsdSender{
+		address:    config.Address,
+		stream:     network == "tcp",
+		flushBytes: config.FlushBytes,
+		queue:      make(chan *bytes.Buffer, config.QueueLength),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	if s.flushBytes == 0 {
+		s.flushBytes = streamFlushBytes
+		if network == "udp" {
+			s.flushBytes = udpFlushBytes
+		}
+	}
+	s.pool.New = func() interface{} { return bytes.NewBuffer(make([]byte, 0, s.flushBytes)) }
+	s.buf = s.pool.Get().(*bytes.Buffer)
+	s.reconnectDelay, _ = time.ParseDuration(config.ReconnectDelay)
+	s.maxReconnectDelay, _ = time.ParseDuration(config.MaxReconnectDelay)
+	s.nextDelay = s.reconnectDelay
+	flushInterval, _ := time.ParseDuration(config.FlushInterval)
+	logger.Go(func() { s.run(flushInterval) })
+	return s, nil
+}
+
+// Send buffers a stat.
+func (s *StatsdSender) Send(data []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, errors.New("statsd sender closed")
+	}
+	if s.buf.Len() > 0 && s.buf.Len()+len(data)+1 > s.flushBytes {
+		s.enqueue()
+	}
+	s.buf.Write(data)
+	s.buf.WriteByte('\n')
+	s.lines++
+	return len(data), nil
+}
+
+// enqueue hands the buffer to the writer, or drops it if the queue is full.
+// s.mu must be held.
+func (s *StatsdSender) enqueue() {
+	if s.buf.Len() == 0 {
+		return
+	}
+	select {
+	case s.queue <- s.buf:
+	default:
+		s.dropped += s.lines
+		s.buf.Reset()
+		s.lines = 0
+		return
+	}
+	s.buf = s.pool.Get().(*bytes.Buffer)
+	s.lines = 0
+}
+
+func (s *StatsdSender) run(flushInterval time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case b := <-s.queue:
+			s.write(b)
+		case <-ticker.C:
+			s.mu.Lock()
+			s.enqueue()
+			s.mu.Unlock()
+		case <-s.quit:
+			for {
+				select {
+				case b := <-s.queue:
+					s.write(b)
+				default:
+					if s.conn != nil {
+						_ = s.conn.Close()
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes b to statsd, connecting first if need be, and recycles it.
+func (s *StatsdSender) write(b *bytes.Buffer) {
+	defer func() {
+		b.Reset()
+		s.pool.Put(b)
+	}()
+	if s.conn == nil && !s.connect() {
+		s.drop(b)
+		return
+	}
+	_ = s.conn.SetWriteDeadline(time.Now().Add(statsdWriteTimeout))
+	data := b.Bytes()
+	if !s.stream {
+		// TCP is a stream of lines, so each one is terminated, while a
+		// datagram's stats are only separated.
+		data = bytes.TrimSuffix(data, []byte{'\n'})
+	}
+	if _, err := s.conn.Write(data); err != nil {
+		logger.WithError(err).WithField("address", s.address).Warn("Error writing to statsd, reconnecting")
+		_ = s.conn.Close()
+		s.conn = nil
+		s.retryAt = time.Now().Add(s.nextDelay)
+		s.drop(b)
+	}
+}
+
+// connect dials statsd unless it's too soon after the last failure,
+// returning whether it's connected.
+func (s *StatsdSender) connect() bool {
+	now := time.Now()
+	if now.Before(s.retryAt) {
+		return false
+	}
+	conn, err := DialStatsd(s.address)
+	if err != nil {
+		logger.WithError(err).WithField("address", s.address).WithField("retry_in", s.nextDelay.String()).
+			Warn("Error connecting to statsd")
+		s.retryAt = now.Add(s.nextDelay)
+		s.nextDelay *= 2
+		if s.nextDelay > s.maxReconnectDelay {
+			s.nextDelay = s.maxReconnectDelay
+		}
+		return false
+	}
+	s.conn = conn
+	s.nextDelay = s.reconnectDelay
+	s.mu.Lock()
+	dropped := s.dropped
+	s.dropped = 0
+	s.mu.Unlock()
+	if dropped > 0 {
+		logger.WithField("address", s.address).WithField("dropped", dropped).Info("Reconnected to statsd")
+	}
+	return true
+}
+
+// drop counts the stats of b as dropped.
+func (s *StatsdSender) drop(b *bytes.Buffer) {
+	lines := int64(bytes.Count(b.Bytes(), []byte{'\n'}))
+	s.mu.Lock()
+	s.dropped += lines
+	s.mu.Unlock()
+}
+
+// Close sends the buffered stats and closes the connection.
+func (s *StatsdSender) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.enqueue()
+	s.mu.Unlock()
+	close(s.quit)
+	<-s.done
+	return nil
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func TestParseStatsdAddress(t *testing.T) {
+	for _, tt := range []struct {
+		address, network, addr string
+	}{
+		{"localhost:8125", "udp", "localhost:8125"},
+		{"udp://localhost:8125", "udp", "localhost:8125"},
+		{"tcp://localhost:8125", "tcp", "localhost:8125"},
+		{"uds:///var/run/statsd.sock", "unixgram", "/var/run/statsd.sock"},
+	} {
+		network, addr, err := ParseStatsdAddress(tt.address)
+		if err != nil || network != tt.network || addr != tt.addr {
+			t.Errorf("Expected %s to be %s %s, got %s %s, %v", tt.address, tt.network, tt.addr, network, addr, err)
+		}
+	}
+	for _, address := range []string{"http://localhost:8125", "uds://"} {
+		if _, _, err := ParseStatsdAddress(address); err == nil {
+			t.Errorf("Expected %s to be invalid", address)
+		}
+	}
+}
+
+func TestStatsdSenderUnixgram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statsd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "statsd.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	sender, err := NewStatsdSender(StatsdConfig{Address: "uds://" + path, FlushInterval: "10ms"})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %s", err)
+	}
+	client, _ := statsd.NewClientWithSender(sender, "edge")
+	_ = client.Inc("a", 1, 1)
+	_ = client.Gauge("b", 2, 1)
+	if err = client.Close(); err != nil {
+		t.Fatalf("Failed to close: %s", err)
+	}
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read: %s", err)
+	}
+	if got := string(buf[:n]); got != "edge.a:1|c\nedge.b:2|g" {
+		t.Errorf("Expected both stats in one datagram, got %q", got)
+	}
+}
+
+func TestStatsdSenderReconnects(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	defer func() { _ = l.Close() }()
+	lines := make(chan string, 100)
+	go func() {
+		for first := true; ; first = false {
+			conn, acceptErr := l.Accept()
+			if acceptErr != nil {
+				return
+			}
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+				if first {
+					// Drop the first connection after a line, as if statsd
+					// restarted.
+					break
+				}
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	sender, err := NewStatsdSender(StatsdConfig{
+		Address:        "tcp://" + l.Addr().String(),
+		FlushInterval:  "5ms",
+		ReconnectDelay: "5ms",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %s", err)
+	}
+	defer func() { _ = sender.Close() }()
+	expect := func(line string) {
+		select {
+		case got := <-lines:
+			if got != line {
+				t.Errorf("Expected %q, got %q", line, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %q", line)
+		}
+	}
+	_, _ = sender.Send([]byte("a:1|c"))
+	expect("a:1|c")
+
+	// Stats are dropped until the sender notices and reconnects.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		_, _ = sender.Send([]byte("b:1|c"))
+		time.Sleep(5 * time.Millisecond)
+		select {
+		case got := <-lines:
+			if got != "b:1|c" {
+				t.Fatalf("Unexpected line %q", got)
+			}
+			return
+		default:
+		}
+	}
+	t.Error("Expected stats to be sent after reconnecting")
+}
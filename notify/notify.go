@@ -0,0 +1,160 @@
+/*
+Package notify sends notifications about the edge to an SQS queue, an SNS
+topic or a webhook, for conditions operators want to hear about before the
+dashboards show them.
+
+A Notification is sent as JSON: the SQS message body, the SNS message and the
+webhook's POST body are all the same document, unless a webhook has a Template
+to shape it for the service it posts to.
+*/
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+const defaultTimeout = "10s"
+
+// Config configures where notifications are sent. Each of the targets set is
+// sent every notification.
+type Config struct {
+	// SQSQueueURL is the URL of an SQS queue to send notifications to
+	SQSQueueURL string
+
+	// SNSTopicARN is the ARN of an SNS topic to publish notifications to
+	SNSTopicARN string
+
+	// WebhookURL is a URL to POST notifications to
+	WebhookURL string
+
+	// Timeout bounds each send, e.g. "10s"
+	Timeout string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.SQSQueueURL == "" && c.SNSTopicARN == "" && c.WebhookURL == "" {
+		return errors.New("one of SQSQueueURL, SNSTopicARN or WebhookURL is required")
+	}
+	if c.Timeout == "" {
+		c.Timeout = defaultTimeout
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Timeout, err)
+	}
+	if timeout <= 0 {
+		return errors.New("Timeout must be greater than 0")
+	}
+	return nil
+}
+
+// Notification is something that happened to an edge.
+type Notification struct {
+	// Event is what happened, e.g. "fallback_engaged".
+	Event string `json:"event"`
+	// Subject is what it happened to, e.g. a sink, if anything in particular.
+	Subject string `json:"subject,omitempty"`
+	// Resolved is set when a condition Event reported has cleared.
+	Resolved bool `json:"resolved,omitempty"`
+	// Host is the edge it happened to.
+	Host string `json:"host"`
+	// Time is when it happened.
+	Time time.Time `json:"time"`
+	// Message describes it for people.
+	Message string `json:"message"`
+	// Counts are the numbers behind it.
+	Counts map[string]int64 `json:"counts,omitempty"`
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	Notify(n *Notification) error
+}
+
+type notifier struct {
+	config  Config
+	host    string
+	sqs     sqsiface.SQSAPI
+	sns     snsiface.SNSAPI
+	webhook *webhook
+	statter statsd.StatSender
+}
+
+// New returns a Notifier sending to the targets of config, stamping each
+// notification with host. Sends are counted under notify.sent and
+// notify.failed.
+func New(config Config, host string, sqsClient sqsiface.SQSAPI, snsClient snsiface.SNSAPI,
+	statter statsd.StatSender) (Notifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	timeout, _ := time.ParseDuration(config.Timeout)
+	return &notifier{
+		config: config,
+		host:   host,
+		sqs:    sqsClient,
+		sns:    snsClient,
+		webhook: &webhook{
+			config: WebhookConfig{URL: config.WebhookURL},
+			client: &http.Client{Timeout: timeout},
+		},
+		statter: statter,
+	}, nil
+}
+
+// Notify sends n to each target, returning the last error.
+func (nt *notifier) Notify(n *Notification) error {
+	n.Host = nt.host
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for _, send := range []struct {
+		target string
+		send   func([]byte) error
+	}{
+		{nt.config.SQSQueueURL, nt.sendSQS},
+		{nt.config.SNSTopicARN, nt.sendSNS},
+		{nt.config.WebhookURL, func([]byte) error { return nt.webhook.post(n) }},
+	} {
+		if send.target == "" {
+			continue
+		}
+		if err = send.send(body); err != nil {
+			_ = nt.statter.Inc("notify.failed", 1, 1)
+			lastErr = fmt.Errorf("error notifying %s: %v", send.target, err)
+			continue
+		}
+		_ = nt.statter.Inc("notify.sent", 1, 1)
+	}
+	return lastErr
+}
+
+func (nt *notifier) sendSQS(body []byte) error {
+	_, err := nt.sqs.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:    aws.String(nt.config.SQSQueueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
+
+func (nt *notifier) sendSNS(body []byte) error {
+	_, err := nt.sns.Publish(&sns.PublishInput{
+		TopicArn: aws.String(nt.config.SNSTopicARN),
+		Message:  aws.String(string(body)),
+	})
+	return err
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	bodies []string
+}
+
+func (f *fakeSQS) SendMessage(in *sqs.SendMessageInput) (*sqs.SendMessageOutput, error) {
+	f.bodies = append(f.bodies, *in.MessageBody)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func TestNotify(t *testing.T) {
+	var posted []Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("Failed to decode webhook body: %s", err)
+		}
+		posted = append(posted, n)
+	}))
+	defer server.Close()
+
+	stats, _ := statsd.NewNoop()
+	queue := &fakeSQS{}
+	n, err := New(Config{SQSQueueURL: "https://sqs/queue", WebhookURL: server.URL}, "i-123", queue, nil, stats)
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %s", err)
+	}
+	if err = n.Notify(&Notification{Event: "fallback_engaged", Counts: map[string]int64{"events": 3}}); err != nil {
+		t.Fatalf("Failed to notify: %s", err)
+	}
+	if len(posted) != 1 || posted[0].Host != "i-123" || posted[0].Counts["events"] != 3 {
+		t.Errorf("Expected the webhook to get the notification, got %+v", posted)
+	}
+	if len(queue.bodies) != 1 {
+		t.Errorf("Expected the queue to get the notification, got %v", queue.bodies)
+	}
+}
+
+func TestNotifyWebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	stats, _ := statsd.NewNoop()
+	n, err := New(Config{WebhookURL: server.URL}, "i-123", nil, nil, stats)
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %s", err)
+	}
+	if err = n.Notify(&Notification{Event: "fallback_engaged"}); err == nil {
+		t.Error("Expected a failed webhook to be an error")
+	}
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// WebhookConfig configures a webhook notifications are POSTed to.
+type WebhookConfig struct {
+	// URL is the URL to POST to
+	URL string
+
+	// Template, if set, is a text/template of the body, executed with the
+	// Notification. Its json function encodes a value as JSON, e.g.
+	// {"text": {{json .Message}}}. The body defaults to the Notification as
+	// JSON.
+	Template string
+
+	// Headers are added to each request, e.g. for authorization
+	Headers map[string]string
+
+	// Timeout bounds each request, e.g. "10s"
+	Timeout string
+}
+
+// Validate verifies that a WebhookConfig is valid and fills in defaults
+func (c *WebhookConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("URL is required")
+	}
+	if _, err := parseTemplate(c.Template); err != nil {
+		return fmt.Errorf("error parsing Template: %v", err)
+	}
+	if c.Timeout == "" {
+		c.Timeout = defaultTimeout
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Timeout, err)
+	}
+	if timeout <= 0 {
+		return errors.New("Timeout must be greater than 0")
+	}
+	return nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// parseTemplate parses a body template, or returns nil for the default body.
+func parseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New("body").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+type webhook struct {
+	config   WebhookConfig
+	host     string
+	template *template.Template
+	client   *http.Client
+	statter  statsd.StatSender
+}
+
+// NewWebhook returns a Notifier POSTing to the webhook of config, stamping
+// each notification with host. Requests are counted under notify.sent and
+// notify.failed.
+func NewWebhook(config WebhookConfig, host string, statter statsd.StatSender) (Notifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	timeout, _ := time.ParseDuration(config.Timeout)
+	tmpl, _ := parseTemplate(config.Template)
+	return &webhook{
+		config:   config,
+		host:     host,
+		template: tmpl,
+		client:   &http.Client{Timeout: timeout},
+		statter:  statter,
+	}, nil
+}
+
+func (wh *webhook) Notify(n *Notification) error {
+	n.Host = wh.host
+	err := wh.post(n)
+	if err != nil {
+		_ = wh.statter.Inc("notify.failed", 1, 1)
+		return fmt.Errorf("error notifying %s: %v", wh.config.URL, err)
+	}
+	_ = wh.statter.Inc("notify.sent", 1, 1)
+	return nil
+}
+
+func (wh *webhook) post(n *Notification) error {
+	var body bytes.Buffer
+	if wh.template == nil {
+		if err := json.NewEncoder(&body).Encode(n); err != nil {
+			return err
+		}
+	} else if err := wh.template.Execute(&body, n); err != nil {
+		return fmt.Errorf("error executing template: %v", err)
+	}
+	req, err := http.NewRequest("POST", wh.config.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wh.config.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := wh.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package parquet
+
+import "encoding/binary"
+
+// Parquet metadata is serialized with the Thrift compact protocol. Only the
+// parts of the protocol the metadata written here needs are implemented.
+
+// Thrift compact protocol field types.
+const (
+	typeI32    = 5
+	typeI64    = 6
+	typeBinary = 8
+	typeList   = 9
+	typeStruct = 12
+)
+
+// thriftWriter writes a Thrift compact protocol struct, tracking the last
+// field ID of each nested struct so field headers can be delta encoded.
+type thriftWriter struct {
+	buf     []byte
+	lastIDs []int16
+	lastID  int16
+}
+
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|fieldType)
+	} else {
+		w.buf = append(w.buf, fieldType)
+		w.varint(int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *thriftWriter) varint(n int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, b[:binary.PutVarint(b[:], n)]...)
+}
+
+func (w *thriftWriter) uvarint(n uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, b[:binary.PutUvarint(b[:], n)]...)
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, typeI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, typeI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(id int16, v string) {
+	w.fieldHeader(id, typeBinary)
+	w.uvarint(uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// listHeader starts a list field of n elements of elemType.
+func (w *thriftWriter) listHeader(id int16, elemType byte, n int) {
+	w.fieldHeader(id, typeList)
+	if n < 15 {
+		w.buf = append(w.buf, byte(n)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.uvarint(uint64(n))
+	}
+}
+
+// listI32 writes a list of i32s.
+func (w *thriftWriter) listI32(id int16, vs []int32) {
+	w.listHeader(id, typeI32, len(vs))
+	for _, v := range vs {
+		w.varint(int64(v))
+	}
+}
+
+// listBinary writes a list of strings.
+func (w *thriftWriter) listBinary(id int16, vs []string) {
+	w.listHeader(id, typeBinary, len(vs))
+	for _, v := range vs {
+		w.uvarint(uint64(len(v)))
+		w.buf = append(w.buf, v...)
+	}
+}
+
+// structField starts a struct field; it is ended with endStruct.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, typeStruct)
+	w.beginStruct()
+}
+
+// beginStruct starts a struct that is a list element.
+func (w *thriftWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+// endStruct writes the stop field and returns to the enclosing struct.
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	if n := len(w.lastIDs); n > 0 {
+		w.lastID = w.lastIDs[n-1]
+		w.lastIDs = w.lastIDs[:n-1]
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Package parquet writes spade events to Parquet files, so they can land in S3
+ready for analytics without a conversion job.
+
+Only what the edge needs is implemented: a fixed schema with one required
+column per spade event field, PLAIN encoding and GZIP compressed pages, one
+page per column per row group.
+*/
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// magic starts and ends every Parquet file.
+const magic = "PAR1"
+
+// Parquet physical types, converted types and enums from parquet.thrift.
+const (
+	typeInt32     = 1
+	typeInt64     = 2
+	typeByteArray = 6
+
+	convertedNone            = -1
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecGzip          = 2
+	pageTypeData       = 0
+)
+
+// column is a column of the event schema.
+type column struct {
+	name      string
+	physical  int32
+	converted int32
+	// appendValue appends the PLAIN encoding of the column's value in e.
+	appendValue func(b []byte, e *spade.Event) []byte
+}
+
+func appendInt32(b []byte, v int32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(v))
+	return append(b, buf[:]...)
+}
+
+func appendInt64(b []byte, v int64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	return append(b, buf[:]...)
+}
+
+func appendByteArray(b []byte, v string) []byte {
+	return append(appendInt32(b, int32(len(v))), v...)
+}
+
+func stringColumn(name string, value func(e *spade.Event) string) column {
+	return column{name, typeByteArray, convertedUTF8, func(b []byte, e *spade.Event) []byte {
+		return appendByteArray(b, value(e))
+	}}
+}
+
+// columns are named after the JSON fields of spade.Event.
+var columns = []column{
+	{"receivedAt", typeInt64, convertedTimestampMillis, func(b []byte, e *spade.Event) []byte {
+		return appendInt64(b, e.ReceivedAt.UnixNano()/1e6)
+	}},
+	stringColumn("clientIp", func(e *spade.Event) string {
+		if e.ClientIp == nil {
+			return ""
+		}
+		return e.ClientIp.String()
+	}),
+	stringColumn("xForwardedFor", func(e *spade.Event) string { return e.XForwardedFor }),
+	stringColumn("uuid", func(e *spade.Event) string { return e.Uuid }),
+	stringColumn("data", func(e *spade.Event) string { return e.Data }),
+	stringColumn("userAgent", func(e *spade.Event) string { return e.UserAgent }),
+	{"recordversion", typeInt32, convertedNone, func(b []byte, e *spade.Event) []byte {
+		return appendInt32(b, int32(e.Version))
+	}},
+	stringColumn("edgeType", func(e *spade.Event) string { return e.EdgeType }),
+}
+
+// chunk describes a column chunk written to the file.
+type chunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// rowGroup describes a row group written to the file.
+type rowGroup struct {
+	chunks  []chunk
+	numRows int64
+}
+
+// Writer writes events to a Parquet file. It is not safe for concurrent use.
+type Writer struct {
+	w            io.Writer
+	offset       int64
+	rowGroupSize int
+	pending      []*spade.Event
+	rowGroups    []rowGroup
+	numRows      int64
+	closed       bool
+}
+
+// NewWriter starts a Parquet file on w whose row groups hold rowGroupSize
+// events.
+func NewWriter(w io.Writer, rowGroupSize int) (*Writer, error) {
+	if rowGroupSize <= 0 {
+		return nil, errors.New("row group size must be greater than 0")
+	}
+	pw := &Writer{w: w, rowGroupSize: rowGroupSize}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+func (pw *Writer) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	return err
+}
+
+// Write adds e to the file, writing a row group once enough events are
+// pending.
+func (pw *Writer) Write(e *spade.Event) error {
+	if pw.closed {
+		return errors.New("parquet writer is closed")
+	}
+	pw.pending = append(pw.pending, e)
+	pw.numRows++
+	if len(pw.pending) >= pw.rowGroupSize {
+		return pw.flushRowGroup()
+	}
+	return nil
+}
+
+// Rows returns the number of events written, including pending ones.
+func (pw *Writer) Rows() int64 {
+	return pw.numRows
+}
+
+// Size returns the number of bytes written so far, excluding pending events.
+func (pw *Writer) Size() int64 {
+	return pw.offset
+}
+
+// flushRowGroup writes the pending events as a row group.
+func (pw *Writer) flushRowGroup() error {
+	if len(pw.pending) == 0 {
+		return nil
+	}
+	group := rowGroup{numRows: int64(len(pw.pending))}
+	var plain []byte
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	for _, c := range columns {
+		plain = plain[:0]
+		for _, e := range pw.pending {
+			plain = c.appendValue(plain, e)
+		}
+		compressed.Reset()
+		gz.Reset(&compressed)
+		if _, err := gz.Write(plain); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+
+		var header thriftWriter
+		header.i32(1, pageTypeData)
+		header.i32(2, int32(len(plain)))
+		header.i32(3, int32(compressed.Len()))
+		header.structField(5)
+		header.i32(1, int32(len(pw.pending)))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		ch := chunk{
+			offset:           pw.offset,
+			uncompressedSize: int64(len(header.buf) + len(plain)),
+			compressedSize:   int64(len(header.buf) + compressed.Len()),
+		}
+		if err := pw.write(header.buf); err != nil {
+			return err
+		}
+		if err := pw.write(compressed.Bytes()); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, ch)
+	}
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.pending = pw.pending[:0]
+	return nil
+}
+
+// Close writes the pending events and the file footer. It doesn't close the
+// underlying writer.
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return nil
+	}
+	pw.closed = true
+	if err := pw.flushRowGroup(); err != nil {
+		return err
+	}
+
+	var meta thriftWriter
+	meta.i32(1, 1)
+	meta.listHeader(2, typeStruct, len(columns)+1)
+	meta.beginStruct()
+	meta.binary(4, "spade_event")
+	meta.i32(5, int32(len(columns)))
+	meta.endStruct()
+	for _, c := range columns {
+		meta.beginStruct()
+		meta.i32(1, c.physical)
+		meta.i32(3, repetitionRequired)
+		meta.binary(4, c.name)
+		if c.converted != convertedNone {
+			meta.i32(6, c.converted)
+		}
+		meta.endStruct()
+	}
+	meta.i64(3, pw.numRows)
+	meta.listHeader(4, typeStruct, len(pw.rowGroups))
+	for _, g := range pw.rowGroups {
+		meta.beginStruct()
+		meta.listHeader(1, typeStruct, len(g.chunks))
+		var total int64
+		for i, ch := range g.chunks {
+			c := columns[i]
+			meta.beginStruct()
+			meta.i64(2, ch.offset)
+			meta.structField(3)
+			meta.i32(1, c.physical)
+			meta.listI32(2, []int32{encodingPlain, encodingRLE})
+			meta.listBinary(3, []string{c.name})
+			meta.i32(4, codecGzip)
+			meta.i64(5, g.numRows)
+			meta.i64(6, ch.uncompressedSize)
+			meta.i64(7, ch.compressedSize)
+			meta.i64(9, ch.offset)
+			meta.endStruct()
+			meta.endStruct()
+			total += ch.uncompressedSize
+		}
+		meta.i64(2, total)
+		meta.i64(3, g.numRows)
+		meta.endStruct()
+	}
+	meta.binary(6, "spade_edge")
+	meta.endStruct()
+
+	if err := pw.write(meta.buf); err != nil {
+		return err
+	}
+	if err := pw.write(appendInt32(nil, int32(len(meta.buf)))); err != nil {
+		return err
+	}
+	return pw.write([]byte(magic))
+}
@@ -0,0 +1,68 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w, err := NewWriter(&b, 2)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %s", err)
+	}
+	for i := 1; i <= 3; i++ {
+		e := spade.NewEvent(time.Unix(int64(i), 0), net.ParseIP("1.2.3.4"), "", "u", "d", "", spade.INTERNAL_EDGE)
+		if err = w.Write(e); err != nil {
+			t.Fatalf("Failed to write event: %s", err)
+		}
+	}
+	if len(w.rowGroups) != 1 || w.Rows() != 3 {
+		t.Errorf("Expected one row group and 3 rows, got %d and %d", len(w.rowGroups), w.Rows())
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %s", err)
+	}
+	if len(w.rowGroups) != 2 {
+		t.Errorf("Expected the pending row to be written on close, got %d row groups", len(w.rowGroups))
+	}
+
+	data := b.Bytes()
+	if string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		t.Fatal("Expected the file to start and end with the magic")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta := data[len(data)-8-metaLen : len(data)-8]
+	for _, c := range columns {
+		if !bytes.Contains(meta, []byte(c.name)) {
+			t.Errorf("Expected column %s in the footer", c.name)
+		}
+	}
+
+	// The first page holds the receivedAt of the first row group.
+	start := bytes.Index(data, []byte{0x1f, 0x8b})
+	r, err := gzip.NewReader(bytes.NewReader(data[start:]))
+	if err != nil {
+		t.Fatalf("Failed to read first page: %s", err)
+	}
+	r.Multistream(false)
+	page, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read first page: %s", err)
+	}
+	expected := appendInt64(appendInt64(nil, 1000), 2000)
+	if !bytes.Equal(page, expected) {
+		t.Errorf("Expected first page %v, got %v", expected, page)
+	}
+
+	if err = w.Write(&spade.Event{}); err == nil {
+		t.Error("Expected writes after close to fail")
+	}
+}
@@ -0,0 +1,205 @@
+package proxyproto
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+// readBufferSize is enough to peek at a header's signature; longer headers
+// are read through it.
+const readBufferSize = 256
+
+// Listener returns a net.Listener that accepts from inner, reading the header
+// of each connection in mode. Headers are read concurrently, so a connection
+// slow to send its own doesn't hold up the others; each connection must send
+// its first bytes within timeout.
+func Listener(inner net.Listener, mode string, timeout time.Duration, stats statsd.StatSender) net.Listener {
+	l := &listener{
+		Listener: inner,
+		mode:     mode,
+		timeout:  timeout,
+		stats:    stats,
+		conns:    make(chan net.Conn),
+		errs:     make(chan error),
+		done:     make(chan struct{}),
+	}
+	logger.Go(l.accept)
+	return l
+}
+
+type listener struct {
+	net.Listener
+	mode    string
+	timeout time.Duration
+	stats   statsd.StatSender
+
+	conns chan net.Conn
+	errs  chan error
+	// done is closed once inner fails for good, with the error in err.
+	done chan struct{}
+	err  error
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case err := <-l.errs:
+		return nil, err
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+// accept accepts from inner until it fails for good, passing temporary
+// errors on to Accept.
+func (l *listener) accept() {
+	for {
+		c, err := l.Listener.Accept()
+		if err == nil {
+			logger.Go(func() { l.handshake(c) })
+			continue
+		}
+		if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+			select {
+			case l.errs <- err:
+				continue
+			case <-l.done:
+				return
+			}
+		}
+		l.err = err
+		close(l.done)
+		return
+	}
+}
+
+// handshake reads the header of c and hands it to Accept, or closes it if
+// its header is missing, unexpected or malformed.
+func (l *listener) handshake(c net.Conn) {
+	conn, reason := l.readHeader(c)
+	if reason != "" {
+		_ = l.stats.Inc("proxyproto.rejected."+reason, 1, 1)
+		_ = c.Close()
+		return
+	}
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		_ = c.Close()
+	}
+}
+
+// readHeader reads the header of c, returning the Conn to hand out or why c
+// must be closed instead.
+func (l *listener) readHeader(c net.Conn) (*Conn, string) {
+	conn := &Conn{Conn: c, r: bufio.NewReaderSize(c, readBufferSize)}
+	if err := c.SetReadDeadline(time.Now().Add(l.timeout)); err != nil {
+		return nil, "closed"
+	}
+	h, err := readHeader(conn.r)
+	switch {
+	case err == errMalformed:
+		return nil, "malformed"
+	case err == io.EOF:
+		// Load balancers check that they can connect, and close again.
+		return nil, "closed"
+	case err != nil:
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return nil, "timeout"
+		}
+		return nil, "closed"
+	}
+	if err = c.SetReadDeadline(time.Time{}); err != nil {
+		return nil, "closed"
+	}
+
+	switch {
+	case h == nil && l.mode == ModeRequire:
+		return nil, "missing"
+	case h == nil:
+		_ = l.stats.Inc("proxyproto.none", 1, 0.1)
+	case l.mode == ModeReject:
+		return nil, "unexpected"
+	case h.source == nil:
+		_ = l.stats.Inc("proxyproto.local", 1, 0.1)
+	default:
+		conn.source = h.source
+		if h.version == 1 {
+			_ = l.stats.Inc("proxyproto.v1", 1, 0.1)
+		} else {
+			_ = l.stats.Inc("proxyproto.v2", 1, 0.1)
+		}
+	}
+	return conn, ""
+}
+
+// Conn is a connection accepted by a Listener, with its header read.
+type Conn struct {
+	net.Conn
+	r      *bufio.Reader
+	source *net.TCPAddr
+}
+
+// Read reads what follows the connection's header.
+func (c *Conn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// RemoteAddr returns the client address the connection's header gave, or
+// the address it was accepted from if there was none.
+func (c *Conn) RemoteAddr() net.Addr {
+	if c.source != nil {
+		return c.source
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// Source returns the client address the connection's header gave, or nil.
+func (c *Conn) Source() *net.TCPAddr {
+	return c.source
+}
+
+// NetConn returns the connection the Conn wraps.
+func (c *Conn) NetConn() net.Conn {
+	return c.Conn
+}
+
+type contextKey struct{}
+
+// ConnContext adds the client address the connection's header gave to the
+// context of its requests. It must be installed as the server's ConnContext.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	for {
+		switch conn := c.(type) {
+		case *Conn:
+			if conn.source == nil {
+				return ctx
+			}
+			return NewContext(ctx, conn.source)
+		case interface{ NetConn() net.Conn }:
+			c = conn.NetConn()
+		default:
+			return ctx
+		}
+	}
+}
+
+// NewContext returns a copy of ctx carrying the client address addr, as
+// ConnContext does for the address of a connection.
+func NewContext(ctx context.Context, addr *net.TCPAddr) context.Context {
+	return context.WithValue(ctx, contextKey{}, addr)
+}
+
+// FromContext returns the client address a PROXY header gave for the
+// connection a request's context belongs to, or nil if there is none.
+func FromContext(ctx context.Context) *net.TCPAddr {
+	addr, _ := ctx.Value(contextKey{}).(*net.TCPAddr)
+	return addr
+}
@@ -0,0 +1,256 @@
+/*
+Package proxyproto reads the PROXY protocol headers a load balancer like an
+NLB opens connections with, so the edge sees the address of the client rather
+than the load balancer's. Both the text format of version 1 and the binary
+format of version 2 are read.
+
+A Listener reads each connection's header before handing the connection out,
+and the connection's RemoteAddr is then the client's. The address is exposed to
+handlers through the request context:
+
+	server.ConnContext = proxyproto.ConnContext
+	err := server.Serve(proxyproto.Listener(l, proxyproto.ModeRequire, 5*time.Second, stats))
+
+	// in a handler
+	if addr := proxyproto.FromContext(r.Context()); addr != nil {
+		clientIP := addr.IP
+	}
+
+Headers are counted under proxyproto.<v1|v2|local|none>, and the connections
+closed for theirs under proxyproto.rejected.<reason>.
+*/
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The modes of a listener.
+const (
+	// ModeRequire closes connections that don't open with a header.
+	ModeRequire = "require"
+
+	// ModeOptional reads a header if a connection opens with one. Any client
+	// can then claim any address, so it's only safe where clients can't
+	// reach the listener but through the load balancer.
+	ModeOptional = "optional"
+
+	// ModeReject closes connections that open with a header.
+	ModeReject = "reject"
+)
+
+const defaultHeaderTimeout = "5s"
+
+var (
+	signatureV1 = []byte("PROXY ")
+	signatureV2 = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	errMalformed = errors.New("malformed PROXY header")
+)
+
+const (
+	// maxHeaderV1Length is the longest a version 1 header may be, CRLF
+	// included.
+	maxHeaderV1Length = 107
+	headerV2Length    = 16
+	maxHeaderV2Length = headerV2Length + 1<<12
+)
+
+// Config configures which listen addresses read PROXY protocol headers.
+type Config struct {
+	// Mode is require, optional or reject, for the listen addresses not in
+	// Listeners. It defaults to require.
+	Mode string
+
+	// Listeners sets the modes of listen addresses, e.g.
+	// {"0.0.0.0:8443": "reject"}
+	Listeners map[string]string
+
+	// HeaderTimeout is the longest a connection may take to send its header,
+	// e.g. "5s"
+	HeaderTimeout string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Mode == "" {
+		c.Mode = ModeRequire
+	}
+	if err := validateMode(c.Mode); err != nil {
+		return err
+	}
+	for addr, mode := range c.Listeners {
+		if err := validateMode(mode); err != nil {
+			return fmt.Errorf("Listeners: %s: %v", addr, err)
+		}
+	}
+	if c.HeaderTimeout == "" {
+		c.HeaderTimeout = defaultHeaderTimeout
+	}
+	timeout, err := time.ParseDuration(c.HeaderTimeout)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.HeaderTimeout, err)
+	}
+	if timeout <= 0 {
+		return errors.New("HeaderTimeout must be greater than 0")
+	}
+	return nil
+}
+
+func validateMode(mode string) error {
+	switch mode {
+	case ModeRequire, ModeOptional, ModeReject:
+		return nil
+	}
+	return fmt.Errorf("mode must be %s, %s or %s, got %q", ModeRequire, ModeOptional, ModeReject, mode)
+}
+
+// ModeFor returns the mode of the listen address addr.
+func (c *Config) ModeFor(addr string) string {
+	if mode, ok := c.Listeners[addr]; ok {
+		return mode
+	}
+	return c.Mode
+}
+
+// Timeout returns the parsed HeaderTimeout of a validated Config.
+func (c *Config) Timeout() time.Duration {
+	timeout, _ := time.ParseDuration(c.HeaderTimeout)
+	return timeout
+}
+
+// header is a parsed PROXY header.
+type header struct {
+	version int
+	// source is the client's address, or nil if the header doesn't give
+	// one, e.g. for the load balancer's own health checks.
+	source *net.TCPAddr
+}
+
+// readHeader reads the header r opens with, returning nil if it doesn't open
+// with one.
+func readHeader(r *bufio.Reader) (*header, error) {
+	isV1, err := hasPrefix(r, signatureV1)
+	if err != nil {
+		return nil, err
+	}
+	if isV1 {
+		return readHeaderV1(r)
+	}
+	isV2, err := hasPrefix(r, signatureV2)
+	if err != nil {
+		return nil, err
+	}
+	if isV2 {
+		return readHeaderV2(r)
+	}
+	return nil, nil
+}
+
+// hasPrefix reports whether r starts with prefix, reading no more than it
+// takes to tell.
+func hasPrefix(r *bufio.Reader, prefix []byte) (bool, error) {
+	for n := 1; n <= len(prefix); n++ {
+		b, err := r.Peek(n)
+		if err != nil {
+			return false, err
+		}
+		if b[n-1] != prefix[n-1] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// readHeaderV1 reads a header like "PROXY TCP4 1.2.3.4 5.6.7.8 1234 80\r\n".
+func readHeaderV1(r *bufio.Reader) (*header, error) {
+	var line []byte
+	for len(line) < maxHeaderV1Length {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errMalformed
+	}
+	fields := strings.Split(string(line[len(signatureV1):len(line)-2]), " ")
+	h := &header{version: 1}
+	switch fields[0] {
+	case "UNKNOWN":
+		return h, nil
+	case "TCP4", "TCP6":
+	default:
+		return nil, errMalformed
+	}
+	if len(fields) != 5 {
+		return nil, errMalformed
+	}
+	ip := net.ParseIP(fields[1])
+	port, err := strconv.ParseUint(fields[3], 10, 16)
+	if ip == nil || err != nil || (ip.To4() != nil) != (fields[0] == "TCP4") {
+		return nil, errMalformed
+	}
+	h.source = &net.TCPAddr{IP: ip, Port: int(port)}
+	return h, nil
+}
+
+// readHeaderV2 reads a binary header.
+func readHeaderV2(r *bufio.Reader) (*header, error) {
+	var fixed [headerV2Length]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return nil, err
+	}
+	if fixed[12]>>4 != 2 {
+		return nil, errMalformed
+	}
+	length := int(binary.BigEndian.Uint16(fixed[14:16]))
+	if headerV2Length+length > maxHeaderV2Length {
+		return nil, errMalformed
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	h := &header{version: 2}
+	switch fixed[12] & 0xf {
+	case 0:
+		// LOCAL: the load balancer's own connection, e.g. a health check.
+		return h, nil
+	case 1:
+	default:
+		return nil, errMalformed
+	}
+	// The address family is in the high nibble and the transport in the low
+	// one. Families other than IPv4 and IPv6 keep the connection's address.
+	var ipLength int
+	switch fixed[13] >> 4 {
+	case 1:
+		ipLength = net.IPv4len
+	case 2:
+		ipLength = net.IPv6len
+	default:
+		return h, nil
+	}
+	if len(body) < 2*ipLength+4 {
+		return nil, errMalformed
+	}
+	h.source = &net.TCPAddr{
+		IP:   net.IP(append([]byte(nil), body[:ipLength]...)),
+		Port: int(binary.BigEndian.Uint16(body[2*ipLength:])),
+	}
+	return h, nil
+}
@@ -0,0 +1,156 @@
+package proxyproto
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// serve serves a handler writing the client address it sees behind a Listener
+// in mode, returning its address and a function to stop it.
+func serve(t *testing.T, mode string) (string, func()) {
+	noop, _ := statsd.NewNoop()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var fromContext string
+			if addr := FromContext(r.Context()); addr != nil {
+				fromContext = addr.String()
+			}
+			_, _ = fmt.Fprintf(w, "%s %s", r.RemoteAddr, fromContext)
+		}),
+		ConnContext: ConnContext,
+	}
+	go func() { _ = server.Serve(Listener(l, mode, 100*time.Millisecond, noop)) }()
+	return l.Addr().String(), func() { _ = l.Close() }
+}
+
+// request sends header and then a request to addr, returning the response
+// body or "closed" if the connection is closed instead.
+func request(t *testing.T, addr string, header []byte) string {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = c.Close() }()
+	_, _ = c.Write(header)
+	_, _ = c.Write([]byte("GET / HTTP/1.0\r\n\r\n"))
+	resp, err := http.ReadResponse(bufio.NewReader(c), nil)
+	if err != nil {
+		return "closed"
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return string(body)
+}
+
+// headerV2 returns a version 2 header with command cmd, family fam and body.
+func headerV2(cmd, fam byte, body []byte) []byte {
+	h := append([]byte(nil), signatureV2...)
+	h = append(h, 0x20|cmd, fam, 0, 0)
+	binary.BigEndian.PutUint16(h[14:], uint16(len(body)))
+	return append(h, body...)
+}
+
+func TestListener(t *testing.T) {
+	v4 := headerV2(1, 0x11, []byte{1, 2, 3, 4, 5, 6, 7, 8, 0x30, 0x39, 0, 80})
+	v6Body := make([]byte, 36)
+	v6Body[15], v6Body[31], v6Body[33] = 1, 2, 80
+	binary.BigEndian.PutUint16(v6Body[32:], 4321)
+	v6 := headerV2(1, 0x21, v6Body)
+	local := headerV2(0, 0, nil)
+
+	for _, tt := range []struct {
+		mode   string
+		header string
+		// expected is the address the handler sees, "direct" for the
+		// connection's own, or "closed".
+		expected string
+	}{
+		{ModeRequire, "PROXY TCP4 1.2.3.4 5.6.7.8 12345 80\r\n", "1.2.3.4:12345"},
+		{ModeRequire, "PROXY TCP6 2001:db8::1 2001:db8::2 4321 80\r\n", "[2001:db8::1]:4321"},
+		{ModeRequire, "PROXY UNKNOWN\r\n", "direct"},
+		{ModeRequire, string(v4), "1.2.3.4:12345"},
+		{ModeRequire, string(v6), "[::1]:4321"},
+		{ModeRequire, string(local), "direct"},
+		{ModeRequire, "", "closed"},
+		{ModeRequire, "PROXY TCP4 1.2.3.4\r\n", "closed"},
+		{ModeRequire, "PROXY TCP4 2001:db8::1 2001:db8::2 4321 80\r\n", "closed"},
+		{ModeRequire, "PROXY TCP4 1.2.3.4 5.6.7.8 12345 80" + strings.Repeat(" ", 100) + "\r\n", "closed"},
+		{ModeRequire, string(headerV2(2, 0x11, nil)), "closed"},
+		{ModeOptional, "PROXY TCP4 1.2.3.4 5.6.7.8 12345 80\r\n", "1.2.3.4:12345"},
+		{ModeOptional, "", "direct"},
+		{ModeReject, "PROXY TCP4 1.2.3.4 5.6.7.8 12345 80\r\n", "closed"},
+		{ModeReject, "", "direct"},
+	} {
+		addr, stop := serve(t, tt.mode)
+		got := request(t, addr, []byte(tt.header))
+		stop()
+		var expected string
+		switch tt.expected {
+		case "closed":
+			expected = "closed"
+		case "direct":
+			expected = "127.0.0.1:"
+		default:
+			expected = tt.expected + " " + tt.expected
+		}
+		if !strings.HasPrefix(got, expected) || (tt.expected == "direct" && !strings.HasSuffix(got, " ")) {
+			t.Errorf("Expected %q in %s mode to be answered for %s, got %q", tt.header, tt.mode, tt.expected, got)
+		}
+	}
+}
+
+func TestHeaderTimeout(t *testing.T) {
+	addr, stop := serve(t, ModeOptional)
+	defer stop()
+
+	slow, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	defer func() { _ = slow.Close() }()
+	_, _ = slow.Write([]byte("PROXY TCP4"))
+
+	// The slow connection doesn't hold up the others.
+	if got := request(t, addr, nil); !strings.HasPrefix(got, "127.0.0.1:") {
+		t.Errorf("Expected a request without a header to be served, got %q", got)
+	}
+	_ = slow.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err = ioutil.ReadAll(slow); err != nil {
+		t.Errorf("Expected a connection slow to send its header to be closed, got %s", err)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	c := Config{Listeners: map[string]string{":8443": ModeReject}}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Expected a valid config, got %s", err)
+	}
+	if c.ModeFor(":80") != ModeRequire || c.ModeFor(":8443") != ModeReject {
+		t.Errorf("Expected require by default and reject on :8443, got %s and %s", c.ModeFor(":80"), c.ModeFor(":8443"))
+	}
+	if c.Timeout() != 5*time.Second {
+		t.Errorf("Expected a 5s timeout by default, got %s", c.Timeout())
+	}
+	for _, bad := range []Config{
+		{Mode: "always"},
+		{Listeners: map[string]string{":80": "never"}},
+		{HeaderTimeout: "-1s"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", bad)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package reputation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A provider looks up the reputation score of an IP. Unknown IPs score 0.
+type provider interface {
+	lookup(ip net.IP) (int, error)
+}
+
+type scoredNet struct {
+	net   *net.IPNet
+	score int
+}
+
+// fileProvider scores IPs from a file of "<ip or cidr> <score>" lines. Blank
+// lines and lines starting with # are skipped.
+type fileProvider struct {
+	// nets are sorted most specific first, so the narrowest match wins.
+	nets []scoredNet
+}
+
+func newFileProvider(path string) (*fileProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	p := &fileProvider{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected an IP or CIDR and a score", path, line)
+		}
+		ipNet, err := parseNet(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+		score, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid score %q", path, line, fields[1])
+		}
+		p.nets = append(p.nets, scoredNet{net: ipNet, score: score})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(p.nets, func(i, j int) bool {
+		a, _ := p.nets[i].net.Mask.Size()
+		b, _ := p.nets[j].net.Mask.Size()
+		return a > b
+	})
+	return p, nil
+}
+
+// parseNet parses a CIDR, or an IP as the network of just that IP.
+func parseNet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		return ipNet, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP %q", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+func (p *fileProvider) lookup(ip net.IP) (int, error) {
+	for _, n := range p.nets {
+		if n.net.Contains(ip) {
+			return n.score, nil
+		}
+	}
+	return 0, nil
+}
+
+// httpProvider scores IPs with an HTTP API. {ip} in the URL is replaced with
+// the IP, and the response must be a JSON object with a numeric "score".
+type httpProvider struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPProvider(url string, timeout time.Duration) *httpProvider {
+	return &httpProvider{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (p *httpProvider) lookup(ip net.IP) (int, error) {
+	resp, err := p.client.Get(strings.Replace(p.url, "{ip}", ip.String(), -1))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %d from reputation API", resp.StatusCode)
+	}
+
+	var body struct {
+		Score *int `json:"score"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+	if body.Score == nil {
+		return 0, fmt.Errorf("reputation API response has no score")
+	}
+	return *body.Score, nil
+}
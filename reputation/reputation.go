@@ -0,0 +1,307 @@
+/*
+Package reputation scores client IPs with a reputation provider, either a
+local file of IPs and networks or an HTTP API, and decides what to do with
+their requests. Scores are cached, and higher scores are worse: each score
+band configures an action for the clients whose score reaches it.
+
+Lookups that fail or time out leave the client unscored, so an outage of the
+provider never turns traffic away.
+*/
+package reputation
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// The actions a Band can take.
+const (
+	// ActionTag sets the client's score on its events.
+	ActionTag = "tag"
+
+	// ActionRateLimit limits the client to the band's RequestsPerSecond, and
+	// tags its events.
+	ActionRateLimit = "rate_limit"
+
+	// ActionDrop accepts the client's requests but logs none of their events.
+	ActionDrop = "drop"
+)
+
+const (
+	defaultTimeout        = "50ms"
+	defaultCacheTTL       = "10m"
+	defaultMaxCachedIPs   = 100000
+	defaultTagProperty    = "edge_reputation"
+	defaultRateLimitBurst = 10
+)
+
+// Band configures the action taken for clients scoring at least MinScore.
+type Band struct {
+	MinScore int
+
+	// Action is one of tag, rate_limit or drop
+	Action string
+
+	// RequestsPerSecond is the rate each client is limited to by rate_limit
+	RequestsPerSecond float64
+
+	// Burst is how many requests over the rate a client may send at once.
+	// It defaults to 10.
+	Burst int
+}
+
+// Config configures reputation lookups. Exactly one of File and URL is set.
+type Config struct {
+	// File is a local reputation database of "<ip or cidr> <score>" lines.
+	// The narrowest matching network scores an IP.
+	File string
+
+	// URL is an HTTP API to look up scores with, in which {ip} is replaced by
+	// the client IP, e.g. "http://reputation.internal/v1/ip/{ip}". It must
+	// answer with a JSON object with a numeric "score", or a 404 for unknown
+	// IPs.
+	URL string
+
+	// Timeout bounds API lookups, which requests wait for, e.g. "50ms"
+	Timeout string
+
+	// CacheTTL is how long scores are cached, e.g. "10m"
+	CacheTTL string
+
+	// MaxCachedIPs bounds the number of scores cached at once
+	MaxCachedIPs int
+
+	// TagProperty is the event property scores are tagged as. It defaults to
+	// edge_reputation.
+	TagProperty string
+
+	// Bands are the actions taken per score. The band with the highest
+	// MinScore reached applies; clients reaching none are left alone.
+	Bands []Band
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if (c.File == "") == (c.URL == "") {
+		return errors.New("exactly one of File and URL is required")
+	}
+	if c.Timeout == "" {
+		c.Timeout = defaultTimeout
+	}
+	if c.CacheTTL == "" {
+		c.CacheTTL = defaultCacheTTL
+	}
+	if c.MaxCachedIPs == 0 {
+		c.MaxCachedIPs = defaultMaxCachedIPs
+	}
+	if c.MaxCachedIPs < 0 {
+		return errors.New("MaxCachedIPs must be greater than 0")
+	}
+	if c.TagProperty == "" {
+		c.TagProperty = defaultTagProperty
+	}
+	for _, d := range []string{c.Timeout, c.CacheTTL} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+
+	seen := make(map[int]bool, len(c.Bands))
+	for i := range c.Bands {
+		b := &c.Bands[i]
+		if seen[b.MinScore] {
+			return fmt.Errorf("more than one band has MinScore %d", b.MinScore)
+		}
+		seen[b.MinScore] = true
+		switch b.Action {
+		case ActionTag, ActionDrop:
+		case ActionRateLimit:
+			if b.RequestsPerSecond <= 0 {
+				return fmt.Errorf("band %d: RequestsPerSecond must be greater than 0", b.MinScore)
+			}
+			if b.Burst == 0 {
+				b.Burst = defaultRateLimitBurst
+			}
+			if b.Burst < 0 {
+				return fmt.Errorf("band %d: Burst must be greater than 0", b.MinScore)
+			}
+		default:
+			return fmt.Errorf("band %d: unknown action %q", b.MinScore, b.Action)
+		}
+	}
+	return nil
+}
+
+// Verdict is what a Checker decided for a request.
+type Verdict struct {
+	// Action is the action of the client's band, or "" if it reached none.
+	Action string
+
+	// Score is the client's score.
+	Score int
+
+	// Limited is set if a rate_limit band's rate was exceeded.
+	Limited bool
+}
+
+type cached struct {
+	score   int
+	expires time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Checker looks up client scores and applies the bands.
+type Checker struct {
+	provider    provider
+	ttl         time.Duration
+	maxCached   int
+	tagProperty string
+	bands       []Band
+	stats       statsd.StatSender
+	now         func() time.Time
+
+	mu      sync.Mutex
+	cache   map[string]cached
+	buckets map[string]*bucket
+}
+
+// New returns a Checker for config.
+func New(config Config, stats statsd.StatSender) (*Checker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	c := &Checker{
+		maxCached:   config.MaxCachedIPs,
+		tagProperty: config.TagProperty,
+		bands:       append([]Band(nil), config.Bands...),
+		stats:       stats,
+		now:         time.Now,
+		cache:       make(map[string]cached),
+		buckets:     make(map[string]*bucket),
+	}
+	c.ttl, _ = time.ParseDuration(config.CacheTTL)
+	sort.Slice(c.bands, func(i, j int) bool { return c.bands[i].MinScore > c.bands[j].MinScore })
+
+	if config.File != "" {
+		p, err := newFileProvider(config.File)
+		if err != nil {
+			return nil, fmt.Errorf("error loading reputation file: %v", err)
+		}
+		c.provider = p
+	} else {
+		timeout, _ := time.ParseDuration(config.Timeout)
+		c.provider = newHTTPProvider(config.URL, timeout)
+	}
+	return c, nil
+}
+
+// TagProperty is the event property scores are tagged as.
+func (c *Checker) TagProperty() string {
+	return c.tagProperty
+}
+
+// Check scores ip and returns the verdict for a request from it.
+func (c *Checker) Check(ip net.IP) Verdict {
+	score, ok := c.score(ip)
+	if !ok {
+		return Verdict{}
+	}
+	for _, b := range c.bands {
+		if score < b.MinScore {
+			continue
+		}
+		v := Verdict{Action: b.Action, Score: score}
+		if b.Action == ActionRateLimit {
+			v.Limited = !c.allow(ip.String(), b)
+		}
+		_ = c.stats.Inc("reputation.action."+b.Action, 1, 0.1)
+		return v
+	}
+	return Verdict{Score: score}
+}
+
+// score returns ip's score, from the cache if it's there, and whether it
+// could be looked up.
+func (c *Checker) score(ip net.IP) (int, bool) {
+	key := ip.String()
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		_ = c.stats.Inc("reputation.cache.hit", 1, 0.01)
+		return entry.score, true
+	}
+	_ = c.stats.Inc("reputation.cache.miss", 1, 0.1)
+
+	score, err := c.provider.lookup(ip)
+	if err != nil {
+		_ = c.stats.Inc("reputation.lookup_error", 1, 0.1)
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.cache[key]; !ok && len(c.cache) >= c.maxCached {
+		c.expire(now)
+	}
+	if len(c.cache) < c.maxCached {
+		c.cache[key] = cached{score: score, expires: now.Add(c.ttl)}
+	}
+	return score, true
+}
+
+// allow takes a token from ip's bucket for band b, reporting false if it's
+// empty.
+func (c *Checker) allow(ip string, b Band) bool {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bk, ok := c.buckets[ip]
+	if !ok {
+		if len(c.buckets) >= c.maxCached {
+			c.expire(now)
+		}
+		bk = &bucket{tokens: float64(b.Burst), last: now}
+		if len(c.buckets) < c.maxCached {
+			c.buckets[ip] = bk
+		}
+	}
+	bk.tokens += now.Sub(bk.last).Seconds() * b.RequestsPerSecond
+	if bk.tokens > float64(b.Burst) {
+		bk.tokens = float64(b.Burst)
+	}
+	bk.last = now
+	if bk.tokens < 1 {
+		return false
+	}
+	bk.tokens--
+	return true
+}
+
+// expire forgets expired scores, and the buckets of the IPs whose scores
+// expired. It must be called with mu held.
+func (c *Checker) expire(now time.Time) {
+	for ip, entry := range c.cache {
+		if !now.Before(entry.expires) {
+			delete(c.cache, ip)
+		}
+	}
+	for ip := range c.buckets {
+		if _, ok := c.cache[ip]; !ok {
+			delete(c.buckets, ip)
+		}
+	}
+}
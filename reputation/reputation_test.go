@@ -0,0 +1,126 @@
+package reputation
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func TestFileProvider(t *testing.T) {
+	f, err := ioutil.TempFile("", "reputation")
+	if err != nil {
+		t.Fatalf("Failed to create reputation file: %s", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, _ = f.WriteString("# bad networks\n10.0.0.0/8 50\n\n10.1.0.0/16 90\n2001:db8::/32 70\n10.1.1.1 10\n")
+	_ = f.Close()
+
+	p, err := newFileProvider(f.Name())
+	if err != nil {
+		t.Fatalf("Failed to load reputation file: %s", err)
+	}
+	for ip, expected := range map[string]int{
+		"10.2.0.1":    50,
+		"10.1.0.1":    90,
+		"10.1.1.1":    10,
+		"2001:db8::1": 70,
+		"192.0.2.1":   0,
+	} {
+		if score, _ := p.lookup(net.ParseIP(ip)); score != expected {
+			t.Errorf("%s: expected score %d, got %d", ip, expected, score)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	var lookups int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lookups, 1)
+		switch strings.TrimPrefix(r.URL.Path, "/ip/") {
+		case "1.1.1.1":
+			_, _ = w.Write([]byte(`{"score": 60}`))
+		case "2.2.2.2":
+			_, _ = w.Write([]byte(`{"score": 85}`))
+		case "3.3.3.3":
+			_, _ = w.Write([]byte(`{"score": 99}`))
+		case "4.4.4.4":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	s, _ := statsd.NewNoop()
+	checker, err := New(Config{
+		URL: api.URL + "/ip/{ip}",
+		Bands: []Band{
+			{MinScore: 50, Action: ActionTag},
+			{MinScore: 95, Action: ActionDrop},
+			{MinScore: 80, Action: ActionRateLimit, RequestsPerSecond: 1, Burst: 2},
+		},
+	}, s)
+	if err != nil {
+		t.Fatalf("Failed to create checker: %s", err)
+	}
+	now := time.Unix(1500000000, 0)
+	checker.now = func() time.Time { return now }
+
+	for ip, expected := range map[string]Verdict{
+		"1.1.1.1": {Action: ActionTag, Score: 60},
+		"2.2.2.2": {Action: ActionRateLimit, Score: 85},
+		"3.3.3.3": {Action: ActionDrop, Score: 99},
+		"4.4.4.4": {},
+		"5.5.5.5": {},
+	} {
+		if v := checker.Check(net.ParseIP(ip)); v != expected {
+			t.Errorf("%s: expected %+v, got %+v", ip, expected, v)
+		}
+	}
+
+	if checker.Check(net.ParseIP("2.2.2.2")).Limited {
+		t.Error("Expected the burst to allow a second request")
+	}
+	if !checker.Check(net.ParseIP("2.2.2.2")).Limited {
+		t.Error("Expected a request over the burst to be limited")
+	}
+	now = now.Add(time.Second)
+	if checker.Check(net.ParseIP("2.2.2.2")).Limited {
+		t.Error("Expected the rate to refill the bucket")
+	}
+
+	before := atomic.LoadInt32(&lookups)
+	checker.Check(net.ParseIP("1.1.1.1"))
+	checker.Check(net.ParseIP("4.4.4.4"))
+	if got := atomic.LoadInt32(&lookups) - before; got != 1 {
+		t.Errorf("Expected only the failed lookup to be retried, got %d lookups", got)
+	}
+	now = now.Add(time.Hour)
+	checker.Check(net.ParseIP("1.1.1.1"))
+	if got := atomic.LoadInt32(&lookups) - before; got != 2 {
+		t.Errorf("Expected expired scores to be looked up again, got %d lookups", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{File: "f", URL: "u"},
+		{URL: "u", Bands: []Band{{MinScore: 1, Action: "block"}}},
+		{URL: "u", Bands: []Band{{MinScore: 1, Action: ActionRateLimit}}},
+		{URL: "u", Bands: []Band{{MinScore: 1, Action: ActionTag}, {MinScore: 1, Action: ActionDrop}}},
+		{URL: "u", Timeout: "soon"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
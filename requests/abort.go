@@ -0,0 +1,49 @@
+package requests
+
+import "net/http"
+
+// statusClientClosedRequest is recorded, as by nginx, for requests that were
+// given up on because their client disconnected. The client never sees it.
+const statusClientClosedRequest = 499
+
+// AbortConfig decides what happens to the events of requests whose client
+// disconnects before being answered. By default they are logged anyway.
+type AbortConfig struct {
+	// DropEvents drops the events of a request whose client disconnected
+	// while it was being read.
+	DropEvents bool
+
+	// CancelWrites stops writing the events of a split or multi-data request
+	// as soon as its client disconnects, rather than writing them all.
+	CancelWrites bool
+}
+
+// aborted reports whether r's client has disconnected.
+func aborted(r *http.Request) bool {
+	select {
+	case <-r.Context().Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// dropAborted reports whether r's events should be dropped because its client
+// disconnected while it was being read.
+func (s *SpadeHandler) dropAborted(r *http.Request) bool {
+	if !s.Aborts.DropEvents || !aborted(r) {
+		return false
+	}
+	_ = s.StatLogger.Inc("client_aborted.dropped", 1, 1)
+	return true
+}
+
+// cancelWrites reports whether the rest of r's events should be left
+// unwritten because its client disconnected.
+func (s *SpadeHandler) cancelWrites(r *http.Request) bool {
+	if !s.Aborts.CancelWrites || !aborted(r) {
+		return false
+	}
+	_ = s.StatLogger.Inc("client_aborted.cancelled_writes", 1, 1)
+	return true
+}
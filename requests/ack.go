@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"errors"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+// AckHeader is the request header server-side producers set to "sync" to be
+// answered with a 2xx only once their events are durably written, rather than
+// once they're buffered. The events are written to the S3 logger as usual,
+// and to Kinesis on their own, bypassing its buffer and the async queue.
+const AckHeader = "X-Spade-Ack"
+
+// AckSequenceHeader is the response header holding the sequence number of
+// the Kinesis record the last event of a synchronously acked request was
+// written in.
+const AckSequenceHeader = "X-Spade-Ack-Sequence"
+
+const ackSync = "sync"
+
+var (
+	errLoggersClosed = errors.New("Loggers are shutting down")
+	errNoLogger      = errors.New("Failed to store the event in any of the loggers")
+)
+
+// acker returns the Acker of tenant's events, nil if they can't be acked.
+func (e *EdgeLoggers) acker(tenant string) loggers.AckLogger {
+	if t, ok := e.Tenants[tenant]; ok {
+		return t.Acker
+	}
+	return e.Acker
+}
+
+// logAcked writes event to the S3 logger and durably with the Acker of its
+// tenant, returning where the Acker wrote it.
+func (e *EdgeLoggers) logAcked(event *spade.Event, context *RequestContext) (string, error) {
+	e.Add(1)
+	defer e.Done()
+	if e.isClosed() {
+		return "", errLoggersClosed
+	}
+	if e.Sequencer != nil {
+		e.Sequencer.stamp(event)
+	}
+	entry, err := e.appendWAL(event)
+	if err != nil {
+		return "", err
+	}
+	eventErr := e.S3EventLogger.Log(event)
+	context.RecordLoggerAttempt(eventErr, "event")
+	sequence, err := e.acker(context.Tenant).LogAcked(event)
+	context.RecordLoggerAttempt(err, "kinesis")
+	e.ackWAL(entry, eventErr, err)
+	return sequence, err
+}
+
+// logAcked logs an event of a request asking for a synchronous ack, timing
+// the write under ack.sync.duration and counting it under
+// ack.sync.<succeeded|failed>.
+func (s *SpadeHandler) logAcked(event *spade.Event, context *RequestContext) error {
+	start := time.Now()
+	sequence, err := s.EdgeLoggers.logAcked(event, context)
+	_ = s.StatLogger.TimingDuration("ack.sync.duration", time.Since(start), 0.1)
+	if err != nil {
+		_ = s.StatLogger.Inc("ack.sync.failed", 1, 0.1)
+		return err
+	}
+	_ = s.StatLogger.Inc("ack.sync.succeeded", 1, 0.1)
+	context.AckSequence = sequence
+	return nil
+}
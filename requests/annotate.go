@@ -0,0 +1,167 @@
+package requests
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/twitchscience/spade_edge/clienthello"
+	"github.com/twitchscience/spade_edge/reputation"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultAcceptLanguageProperty = "edge_accept_language"
+	defaultRefererProperty        = "edge_referer"
+	defaultMaxCaptureLength       = 256
+
+	// The properties TLS client fingerprints are set as, when the edge
+	// terminates TLS.
+	ja3Property = "edge_ja3"
+	ja4Property = "edge_ja4"
+
+	// The properties of TimeBucketsConfig.
+	hourProperty        = "received_at_hour"
+	dateProperty        = "received_at_date"
+	epochMillisProperty = "epoch_ms"
+)
+
+// HeaderCapture configures the capture of a request header as an event
+// property.
+type HeaderCapture struct {
+	// Property is the event property the header is set as.
+	Property string
+
+	// MaxLength is the most bytes of the header kept. It defaults to 256.
+	MaxLength int
+}
+
+// CaptureConfig configures which request headers are captured as event
+// properties. Headers are only captured if their HeaderCapture is set.
+type CaptureConfig struct {
+	// AcceptLanguage's Property defaults to edge_accept_language.
+	AcceptLanguage *HeaderCapture
+
+	// Referer's Property defaults to edge_referer.
+	Referer *HeaderCapture
+}
+
+// Validate verifies that a CaptureConfig is valid and fills in defaults
+func (c *CaptureConfig) Validate() error {
+	for _, capture := range []struct {
+		config   *HeaderCapture
+		property string
+	}{
+		{c.AcceptLanguage, defaultAcceptLanguageProperty},
+		{c.Referer, defaultRefererProperty},
+	} {
+		if capture.config == nil {
+			continue
+		}
+		if capture.config.Property == "" {
+			capture.config.Property = capture.property
+		}
+		if capture.config.MaxLength == 0 {
+			capture.config.MaxLength = defaultMaxCaptureLength
+		}
+		if capture.config.MaxLength < 0 {
+			return errors.New("MaxLength must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// TimeBucketsConfig configures properties derived from the time events are
+// received, so downstream jobs can partition by them without parsing it.
+type TimeBucketsConfig struct {
+	// Hour sets received_at_hour, the UTC hour, e.g. "2017-06-01T13"
+	Hour bool
+
+	// Date sets received_at_date, the UTC date, e.g. "2017-06-01"
+	Date bool
+
+	// EpochMillis sets epoch_ms, the milliseconds since the Unix epoch
+	EpochMillis bool
+}
+
+// Validate verifies that a TimeBucketsConfig is valid
+func (c *TimeBucketsConfig) Validate() error {
+	if !c.Hour && !c.Date && !c.EpochMillis {
+		return errors.New("at least one of Hour, Date and EpochMillis must be set")
+	}
+	return nil
+}
+
+// set sets the enabled buckets of receivedAt in properties.
+func (c *TimeBucketsConfig) set(properties map[string]interface{}, receivedAt time.Time) {
+	receivedAt = receivedAt.UTC()
+	if c.Hour {
+		properties[hourProperty] = receivedAt.Format("2006-01-02T15")
+	}
+	if c.Date {
+		properties[dateProperty] = receivedAt.Format("2006-01-02")
+	}
+	if c.EpochMillis {
+		properties[epochMillisProperty] = receivedAt.UnixNano() / int64(time.Millisecond)
+	}
+}
+
+// truncateUTF8 truncates s to at most max bytes without splitting a rune.
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+// annotate sets the request's fingerprints, captured headers, reputation
+// score, SDK and time buckets on the events in data, if any are enabled.
+func (s *SpadeHandler) annotate(r *http.Request, context *RequestContext, clientIP net.IP, data string) string {
+	properties := make(map[string]interface{}, 11)
+	if s.Fingerprinter != nil {
+		properties[s.Fingerprinter.property] = s.Fingerprinter.Fingerprint(r, clientIP)
+	}
+	if hello := clienthello.FromContext(r.Context()); hello != nil {
+		properties[ja3Property] = hello.JA3()
+		properties[ja4Property] = hello.JA4()
+	}
+	switch context.Reputation.Action {
+	case reputation.ActionTag, reputation.ActionRateLimit:
+		properties[s.Reputation.TagProperty()] = context.Reputation.Score
+	}
+	for _, capture := range []struct {
+		config *HeaderCapture
+		header string
+	}{
+		{s.Capture.AcceptLanguage, "Accept-Language"},
+		{s.Capture.Referer, "Referer"},
+	} {
+		if capture.config == nil {
+			continue
+		}
+		if value := r.Header.Get(capture.header); value != "" {
+			properties[capture.config.Property] = truncateUTF8(value, capture.config.MaxLength)
+		}
+	}
+	if context.SDKName != "" {
+		properties[sdkNameProperty] = context.SDKName
+		properties[sdkVersionProperty] = context.SDKVersion
+	}
+	s.TimeBuckets.set(properties, context.Now)
+	if len(properties) == 0 {
+		return data
+	}
+
+	annotated, err := transform.SetProperties(data, properties)
+	if err != nil {
+		_ = s.StatLogger.Inc("annotate.error", 1, 0.1)
+		return data
+	}
+	_ = s.StatLogger.Inc("annotate.applied", 1, 0.01)
+	return annotated
+}
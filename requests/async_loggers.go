@@ -0,0 +1,92 @@
+package requests
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+// AsyncConfig configures asynchronous sink writes.
+type AsyncConfig struct {
+	// Workers is the number of goroutines writing events to the sinks
+	Workers int
+
+	// QueueSize is the number of events that can wait for a worker. Events
+	// that don't fit are written on the request goroutine instead.
+	QueueSize int
+}
+
+// Validate verifies that an AsyncConfig is valid
+func (c *AsyncConfig) Validate() error {
+	if c.Workers <= 0 {
+		return errors.New("Workers must be greater than 0")
+	}
+	if c.QueueSize <= 0 {
+		return errors.New("QueueSize must be greater than 0")
+	}
+	return nil
+}
+
+// StartAsync makes the loggers write events from a bounded queue on a pool
+// of workers, so requests are answered without waiting for the sinks. It
+// must be called before any events are logged. Write failures can't be
+// reported to the client and are counted under loggers.async.<sink>.failed.
+func (e *EdgeLoggers) StartAsync(config AsyncConfig, stats statsd.StatSender) {
+	e.queue = make(chan walEntry, config.QueueSize)
+	e.stats = stats
+	for i := 0; i < config.Workers; i++ {
+		e.workers.Add(1)
+		logger.Go(func() {
+			defer e.workers.Done()
+			for entry := range e.queue {
+				e.writeAsync(entry)
+			}
+		})
+	}
+	logger.Go(e.reportQueueDepth)
+}
+
+func (e *EdgeLoggers) writeAsync(entry walEntry) {
+	eventErr, kinesisErr := e.write(entry)
+	if eventErr != nil && eventErr != loggers.ErrUndefined {
+		_ = e.stats.Inc("loggers.async.event.failed", 1, 0.1)
+	}
+	if kinesisErr != nil && kinesisErr != loggers.ErrUndefined {
+		_ = e.stats.Inc("loggers.async.kinesis.failed", 1, 0.1)
+	}
+	if eventErr != nil && kinesisErr != nil {
+		logger.Warn(errNoLogger.Error())
+	}
+}
+
+// enqueue hands entry to the workers, returning false if the queue is full.
+func (e *EdgeLoggers) enqueue(entry walEntry) bool {
+	select {
+	case e.queue <- entry:
+		return true
+	default:
+		_ = e.stats.Inc("loggers.async.overflow", 1, 0.1)
+		return false
+	}
+}
+
+// QueueDepth returns the number of events waiting for an async worker.
+func (e *EdgeLoggers) QueueDepth() int {
+	return len(e.queue)
+}
+
+func (e *EdgeLoggers) reportQueueDepth() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.closed:
+			return
+		case <-ticker.C:
+			_ = e.stats.Gauge("loggers.async.queue_depth", int64(e.QueueDepth()), 1)
+		}
+	}
+}
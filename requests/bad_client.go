@@ -0,0 +1,143 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// badClientWarning is the Warning header value of responses to bad clients.
+const badClientWarning = `299 spade-edge "Request body starts with data=; send the event without form-encoding it twice"`
+
+// maxClientNameLength bounds the client names bad clients are counted under.
+const maxClientNameLength = 64
+
+// BadClientConfig configures how requests whose body starts with a stray
+// "data=", from clients that form-encode it twice, are answered. They are
+// fixed up and accepted until Sunset.
+type BadClientConfig struct {
+	// Warn adds a Warning header to the responses to such requests, along
+	// with Deprecation and Sunset headers if Sunset is set
+	Warn bool
+
+	// Link, if set, is the URL of the deprecation notice, sent in a Link
+	// header with the warning
+	Link string
+
+	// Sunset, if set, is when such requests start being rejected with a
+	// 400, in RFC 3339, e.g. "2018-01-01T00:00:00Z"
+	Sunset string
+
+	// ClientHeader, if set, names the request header identifying clients in
+	// the bad_client.client stats, e.g. the header carrying an API key. Its
+	// values end up in stat names, so it must not carry secrets
+	ClientHeader string
+}
+
+// Validate verifies that a BadClientConfig is valid
+func (c *BadClientConfig) Validate() error {
+	if c.Sunset != "" {
+		if _, err := time.Parse(time.RFC3339, c.Sunset); err != nil {
+			return fmt.Errorf("error parsing Sunset %s as RFC 3339: %v", c.Sunset, err)
+		}
+	}
+	if c.Link != "" && !strings.HasPrefix(c.Link, "https://") && !strings.HasPrefix(c.Link, "http://") {
+		return errors.New("Link must be an http or https URL")
+	}
+	return nil
+}
+
+// BadClientPolicy warns bad clients and rejects them once their sunset has
+// passed.
+type BadClientPolicy struct {
+	config BadClientConfig
+	sunset time.Time
+}
+
+// NewBadClientPolicy returns a BadClientPolicy for config.
+func NewBadClientPolicy(config BadClientConfig) (*BadClientPolicy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	p := &BadClientPolicy{config: config}
+	if config.Sunset != "" {
+		p.sunset, _ = time.Parse(time.RFC3339, config.Sunset)
+	}
+	return p, nil
+}
+
+// rejects returns whether bad clients are rejected at now.
+func (p *BadClientPolicy) rejects(now time.Time) bool {
+	return !p.sunset.IsZero() && !now.Before(p.sunset)
+}
+
+// checkBadClient counts a bad client's request under
+// bad_client.client.<client>, and returns whether it is to be rejected.
+// Clients are named by the BadClient policy's ClientHeader, else by the SDK
+// they identify as, else by the first product of their User-Agent.
+func (s *SpadeHandler) checkBadClient(r *http.Request, context *RequestContext) bool {
+	var client string
+	if s.BadClient != nil && s.BadClient.config.ClientHeader != "" {
+		client = r.Header.Get(s.BadClient.config.ClientHeader)
+	}
+	if client == "" {
+		client = context.SDKName
+	}
+	if client == "" {
+		client = userAgentProduct(r.Header.Get("User-Agent"))
+	}
+	client = clientStatName(client)
+	_ = s.StatLogger.Inc("bad_client.client."+s.Dimensions.Limit("bad_client.client", client), 1, 0.1)
+	if s.BadClient == nil || !s.BadClient.rejects(context.Now) {
+		return false
+	}
+	_ = s.StatLogger.Inc("bad_client.rejected", 1, 0.1)
+	return true
+}
+
+// warnBadClient sets the headers warning a bad client, if the BadClient
+// policy warns.
+func (s *SpadeHandler) warnBadClient(w http.ResponseWriter) {
+	if s.BadClient == nil || !s.BadClient.config.Warn {
+		return
+	}
+	w.Header().Set("Warning", badClientWarning)
+	if !s.BadClient.sunset.IsZero() {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", s.BadClient.sunset.UTC().Format(http.TimeFormat))
+	}
+	if s.BadClient.config.Link != "" {
+		w.Header().Set("Link", "<"+s.BadClient.config.Link+`>; rel="deprecation"`)
+	}
+}
+
+// userAgentProduct returns the name of the first product of a User-Agent,
+// e.g. "okhttp" for "okhttp/3.8.0", or "unknown" if it has none.
+func userAgentProduct(ua string) string {
+	if i := strings.IndexAny(ua, "/ "); i >= 0 {
+		ua = ua[:i]
+	}
+	if ua == "" {
+		return "unknown"
+	}
+	return ua
+}
+
+// clientStatName returns name lowercased, with the characters that aren't
+// kept in stat names replaced, and cut to maxClientNameLength.
+func clientStatName(name string) string {
+	b := []byte(strings.ToLower(name))
+	if len(b) > maxClientNameLength {
+		b = b[:maxClientNameLength]
+	}
+	for i, c := range b {
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-':
+		default:
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
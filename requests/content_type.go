@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"mime"
+	"net/http"
+)
+
+// Kinds of request body, by Content-Type. They name the content_type.<kind>
+// stats.
+const (
+	contentTypeNone      = "none"
+	contentTypeForm      = "form"
+	contentTypeJSON      = "json"
+	contentTypeText      = "text"
+	contentTypeMultipart = "multipart"
+	contentTypeOther     = "other"
+)
+
+var contentTypeKinds = map[string]string{
+	"application/x-www-form-urlencoded": contentTypeForm,
+	"application/json":                  contentTypeJSON,
+	"text/plain":                        contentTypeText,
+	"multipart/form-data":               contentTypeMultipart,
+}
+
+// contentTypeKind returns the kind of body r has, going by its Content-Type.
+func contentTypeKind(r *http.Request) string {
+	header := r.Header.Get("Content-Type")
+	if header == "" {
+		return contentTypeNone
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return contentTypeOther
+	}
+	if kind, ok := contentTypeKinds[mediaType]; ok {
+		return kind
+	}
+	return contentTypeOther
+}
+
+// checkContentType counts the kind of body r has and returns the status code
+// to reject it with, or 0 if it's acceptable. Unsupported bodies are only
+// rejected if StrictContentTypes is set, and are otherwise read as the event
+// data on a best effort basis.
+func (s *SpadeHandler) checkContentType(r *http.Request) (kind string, statusCode int) {
+	kind = contentTypeKind(r)
+	_ = s.StatLogger.Inc("content_type."+kind, 1, 0.1)
+	if !s.StrictContentTypes {
+		return kind, 0
+	}
+	// Requests with the event in the URI don't need a body or a type.
+	if kind == contentTypeOther || kind == contentTypeNone && r.ContentLength != 0 {
+		_ = s.StatLogger.Inc("content_type.rejected", 1, 0.1)
+		return kind, http.StatusUnsupportedMediaType
+	}
+	return kind, 0
+}
@@ -1,12 +1,14 @@
 package requests
 
 import (
+	"net"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cactus/go-statsd-client/statsd"
 	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/reputation"
 )
 
 // TimerInstance returns the time since the start or last time it was stopped.
@@ -28,16 +30,71 @@ func (t *TimerInstance) StopTiming() (r time.Duration) {
 	return
 }
 
+// A Timer is one of the durations recorded for a request, used to index
+// RequestContext.Timers.
+type Timer int
+
+// The timers recorded for a request.
+const (
+	TimerIP Timer = iota
+	TimerData
+	TimerWrite
+	TimerHTTP
+	numTimers
+)
+
+var timerNames = [numTimers]string{
+	TimerIP:    "ip",
+	TimerData:  "data",
+	TimerWrite: "write",
+	TimerHTTP:  "http",
+}
+
 // RequestContext is contextual information for a request.
 type RequestContext struct {
 	Now           time.Time
 	Method        string
 	IPHeader      string
 	Endpoint      string
-	Timers        map[string]time.Duration
+	Timers        [numTimers]time.Duration
 	FailedLoggers []string
 	Status        int
 	BadClient     bool
+
+	// Subject is the authenticated producer, if the request carried a token.
+	Subject string
+
+	// Tenant is the tenant whose tracking domain the request was sent to, if
+	// it was sent to one over TLS.
+	Tenant string
+
+	// ProxiedFor is the client address the PROXY protocol header of the
+	// request's connection gave, if it had one. It's also appended to the
+	// request's IPHeader.
+	ProxiedFor net.IP
+
+	// SDKName and SDKVersion identify the SDK that sent the request, if it
+	// sent an X-Spade-Client header.
+	SDKName    string
+	SDKVersion string
+
+	// Reputation is the verdict on the client's reputation, if it was
+	// checked.
+	Reputation reputation.Verdict
+
+	// RejectReason is why the request was rejected, if it was, e.g.
+	// RejectEmptyData.
+	RejectReason string
+
+	// SyncAck is set if the request asked for a synchronous ack, and
+	// AckSequence is where its last event was written.
+	SyncAck     bool
+	AckSequence string
+}
+
+// reset clears the context for reuse, keeping its allocated FailedLoggers.
+func (r *RequestContext) reset() {
+	*r = RequestContext{FailedLoggers: r.FailedLoggers[:0]}
 }
 
 // RecordLoggerAttempt records failed logging attempts for later reporting.
@@ -54,8 +111,13 @@ func (r *RequestContext) RecordStats(statter statsd.StatSender) {
 		strings.Replace(r.Endpoint, ".", "_", -1),
 		strconv.Itoa(r.Status),
 	}, ".")
-	for stat, duration := range r.Timers {
-		_ = statter.Timing(strings.Join([]string{prefix, stat}, "."), duration.Nanoseconds(), 0.1)
+	for timer, duration := range r.Timers {
+		// Timers that weren't reached, e.g. "write" for a bad request,
+		// are left at zero.
+		if duration == 0 {
+			continue
+		}
+		_ = statter.Timing(prefix+"."+timerNames[timer], duration.Nanoseconds(), 0.1)
 	}
 	for _, logger := range r.FailedLoggers {
 		_ = statter.Inc(strings.Join([]string{prefix, logger, "failed"}, "."), 1, 0.1)
@@ -63,4 +125,7 @@ func (r *RequestContext) RecordStats(statter statsd.StatSender) {
 	if r.BadClient {
 		_ = statter.Inc("bad_client", 1, 0.1)
 	}
+	if r.Subject != "" {
+		_ = statter.Inc("auth.subject."+strings.Replace(r.Subject, ".", "_", -1), 1, 0.1)
+	}
 }
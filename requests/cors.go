@@ -0,0 +1,157 @@
+package requests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// CORSGroup configures the CORS responses to a group of origins, for SDKs
+// that send headers of their own or credentials.
+type CORSGroup struct {
+	// Origins are glob patterns of the origins in the group
+	Origins []string
+
+	// AllowHeaders are the request headers the origins may send, besides
+	// X-Spade-Client, which is always allowed
+	AllowHeaders []string
+
+	// ExposeHeaders are the response headers the origins' scripts may read,
+	// e.g. X-Spade-Reject-Reason
+	ExposeHeaders []string
+
+	// AllowCredentials lets the origins send cookies and HTTP authentication
+	// with their requests
+	AllowCredentials bool
+}
+
+// catchAllProbes are origins no group means to allow, which only patterns
+// matching any origin match, such as * or https://*.
+var catchAllProbes = []string{"https://catch-all.invalid", "http://catch-all.invalid", "null"}
+
+// CORSGroups are groups of origins with their own CORS responses. An origin
+// gets the responses of the first group it's in; origins in none of them
+// that CorsOrigins accepts get the default ones, which allow X-Spade-Client
+// and nothing else.
+type CORSGroups []*CORSGroup
+
+// Validate verifies that every group has origins and its patterns and
+// header names are well formed, and that groups allowing credentials don't
+// match every origin
+func (g CORSGroups) Validate() error {
+	for i, group := range g {
+		if group == nil || len(group.Origins) == 0 {
+			return fmt.Errorf("group %d has no Origins", i)
+		}
+		for _, origin := range group.Origins {
+			matcher, err := glob.Compile(strings.TrimSpace(origin))
+			if err != nil {
+				return fmt.Errorf("group %d: invalid pattern %s: %v", i, origin, err)
+			}
+			if !group.AllowCredentials {
+				continue
+			}
+			for _, probe := range catchAllProbes {
+				if matcher.Match(probe) {
+					// Any site could make credentialed requests.
+					return fmt.Errorf("group %d: %s matches any origin, so it can't be used with AllowCredentials",
+						i, origin)
+				}
+			}
+		}
+		for _, name := range append(append([]string(nil), group.AllowHeaders...), group.ExposeHeaders...) {
+			if name == "*" && group.AllowCredentials {
+				// Browsers take * literally for requests with credentials.
+				return fmt.Errorf("group %d: * can't be used with AllowCredentials", i)
+			}
+			if name == "" || strings.ContainsAny(name, " ,:\r\n") {
+				return fmt.Errorf("group %d: invalid header name %q", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// corsPolicy is the compiled form of a CORSGroup.
+type corsPolicy struct {
+	matchers         []glob.Glob
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+}
+
+// defaultCORSPolicy is the policy of origins accepted by CorsOrigins alone.
+var defaultCORSPolicy = &corsPolicy{allowHeaders: sdkHeader}
+
+func (g CORSGroups) compile() []*corsPolicy {
+	policies := make([]*corsPolicy, 0, len(g))
+	for _, group := range g {
+		allow := []string{sdkHeader}
+		for _, name := range group.AllowHeaders {
+			if !strings.EqualFold(name, sdkHeader) {
+				allow = append(allow, name)
+			}
+		}
+		policies = append(policies, &corsPolicy{
+			matchers:         compileOrigins(group.Origins),
+			allowHeaders:     strings.Join(allow, ", "),
+			exposeHeaders:    strings.Join(group.ExposeHeaders, ", "),
+			allowCredentials: group.AllowCredentials,
+		})
+	}
+	return policies
+}
+
+// SetCORSGroups replaces the origin groups with their own CORS responses.
+func (s *SpadeHandler) SetCORSGroups(groups CORSGroups) {
+	policies := groups.compile()
+	s.settingsMu.Lock()
+	s.corsPolicies = policies
+	s.settingsMu.Unlock()
+}
+
+// corsPolicyOf returns the CORS policy of origin, or nil if it may not make
+// CORS requests.
+func (s *SpadeHandler) corsPolicyOf(origin string) *corsPolicy {
+	s.settingsMu.RLock()
+	policies := s.corsPolicies
+	s.settingsMu.RUnlock()
+	for _, policy := range policies {
+		for _, matcher := range policy.matchers {
+			if matcher.Match(origin) {
+				return policy
+			}
+		}
+	}
+	if s.isAcceptableOrigin(origin) {
+		return defaultCORSPolicy
+	}
+	return nil
+}
+
+// writeCORSHeaders sets the CORS headers of the request's origin, if it may
+// make CORS requests, and shows the origin of actual requests to the
+// OriginLearner. Exposed headers only matter on actual responses, so
+// preflights don't get them.
+func (s *SpadeHandler) writeCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	policy := s.corsPolicyOf(origin)
+	// A preflighted request is counted once, by its actual request.
+	if s.OriginLearner != nil && origin != "" && r.Method != "OPTIONS" {
+		s.OriginLearner.observe(origin, policy != nil)
+	}
+	if policy == nil {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Methods", allowedMethodsHeader)
+	w.Header().Set("Access-Control-Allow-Headers", policy.allowHeaders)
+	if policy.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	if policy.exposeHeaders != "" && r.Method != "OPTIONS" {
+		w.Header().Set("Access-Control-Expose-Headers", policy.exposeHeaders)
+	}
+}
@@ -0,0 +1,104 @@
+package requests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+)
+
+const (
+	defaultFingerprintProperty = "edge_fingerprint"
+	defaultIPv4PrefixBits      = 24
+	defaultIPv6PrefixBits      = 48
+
+	// fingerprintBytes is how much of the HMAC is kept.
+	fingerprintBytes = 16
+)
+
+// FingerprintConfig configures the request fingerprint set on events, which
+// lets traffic from the same client network and browser be clustered without
+// logging the raw headers.
+type FingerprintConfig struct {
+	// Key keys the HMAC the fingerprint is, so it can't be recomputed
+	// downstream from guessed inputs.
+	Key string
+
+	// Property is the event property the fingerprint is set as. It defaults
+	// to edge_fingerprint.
+	Property string
+
+	// IPv4PrefixBits and IPv6PrefixBits are how much of the client IP is
+	// hashed. They default to 24 and 48.
+	IPv4PrefixBits int
+	IPv6PrefixBits int
+
+	// Headers are hashed besides User-Agent and Accept-Language.
+	Headers []string
+}
+
+// Validate verifies that a FingerprintConfig is valid and fills in defaults
+func (c *FingerprintConfig) Validate() error {
+	if c.Key == "" {
+		return errors.New("Key is required")
+	}
+	if c.Property == "" {
+		c.Property = defaultFingerprintProperty
+	}
+	if c.IPv4PrefixBits == 0 {
+		c.IPv4PrefixBits = defaultIPv4PrefixBits
+	}
+	if c.IPv6PrefixBits == 0 {
+		c.IPv6PrefixBits = defaultIPv6PrefixBits
+	}
+	if c.IPv4PrefixBits < 0 || c.IPv4PrefixBits > 32 {
+		return errors.New("IPv4PrefixBits must be between 1 and 32")
+	}
+	if c.IPv6PrefixBits < 0 || c.IPv6PrefixBits > 128 {
+		return errors.New("IPv6PrefixBits must be between 1 and 128")
+	}
+	return nil
+}
+
+// Fingerprinter computes request fingerprints.
+type Fingerprinter struct {
+	key      []byte
+	property string
+	v4Mask   net.IPMask
+	v6Mask   net.IPMask
+	headers  []string
+}
+
+// NewFingerprinter returns a Fingerprinter for config.
+func NewFingerprinter(config FingerprintConfig) (*Fingerprinter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	headers := append([]string{"User-Agent", "Accept-Language"}, config.Headers...)
+	return &Fingerprinter{
+		key:      []byte(config.Key),
+		property: config.Property,
+		v4Mask:   net.CIDRMask(config.IPv4PrefixBits, 32),
+		v6Mask:   net.CIDRMask(config.IPv6PrefixBits, 128),
+		headers:  headers,
+	}, nil
+}
+
+// Fingerprint returns the fingerprint of a request from clientIP: the hex
+// encoded HMAC of the client's network and the fingerprinted headers.
+func (f *Fingerprinter) Fingerprint(r *http.Request, clientIP net.IP) string {
+	mac := hmac.New(sha256.New, f.key)
+	if ip4 := clientIP.To4(); ip4 != nil {
+		_, _ = mac.Write(ip4.Mask(f.v4Mask))
+	} else if clientIP != nil {
+		_, _ = mac.Write(clientIP.Mask(f.v6Mask))
+	}
+	for _, header := range f.headers {
+		// Separate the values so they can't run into each other.
+		_, _ = mac.Write([]byte{0})
+		_, _ = mac.Write([]byte(r.Header.Get(header)))
+	}
+	return hex.EncodeToString(mac.Sum(nil)[:fingerprintBytes])
+}
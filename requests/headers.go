@@ -0,0 +1,95 @@
+package requests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Endpoint groups that response headers can be configured for.
+const (
+	// AllEndpoints headers are sent on every response.
+	AllEndpoints = "all"
+	// TrackingEndpoints are the tracking paths and /r.
+	TrackingEndpoints = "tracking"
+	// StaticEndpoints are /crossdomain.xml and /robots.txt.
+	StaticEndpoints = "static"
+	// HealthEndpoints are /healthcheck and /xarth.
+	HealthEndpoints = "health"
+)
+
+// ResponseHeaders maps an endpoint group to the headers sent on its
+// responses. Headers for a specific group override those for AllEndpoints.
+type ResponseHeaders map[string]map[string]string
+
+// Validate verifies that every group is known and every header is well formed
+func (h ResponseHeaders) Validate() error {
+	for group, headers := range h {
+		switch group {
+		case AllEndpoints, TrackingEndpoints, StaticEndpoints, HealthEndpoints:
+		default:
+			return fmt.Errorf("unknown endpoint group %s", group)
+		}
+		for name, value := range headers {
+			if name == "" || strings.ContainsAny(name, " :\r\n") {
+				return fmt.Errorf("invalid header name %q in group %s", name, group)
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				return fmt.Errorf("header %s in group %s contains a newline", name, group)
+			}
+		}
+	}
+	return nil
+}
+
+// compile merges the AllEndpoints headers into each group.
+func (h ResponseHeaders) compile() map[string]http.Header {
+	compiled := make(map[string]http.Header)
+	for _, group := range []string{AllEndpoints, TrackingEndpoints, StaticEndpoints, HealthEndpoints} {
+		header := http.Header{}
+		for name, value := range h[AllEndpoints] {
+			header.Set(name, value)
+		}
+		for name, value := range h[group] {
+			header.Set(name, value)
+		}
+		compiled[group] = header
+	}
+	return compiled
+}
+
+// endpointGroup returns the group of the endpoint at path, given the tracking
+// paths.
+func endpointGroup(path string, tracking *trackingMatcher) string {
+	switch path {
+	case "/r":
+		return TrackingEndpoints
+	case "/crossdomain.xml", "/robots.txt":
+		return StaticEndpoints
+	case "/healthcheck", "/xarth":
+		return HealthEndpoints
+	}
+	if tracking.matches(path) {
+		return TrackingEndpoints
+	}
+	return AllEndpoints
+}
+
+// SetResponseHeaders replaces the configured response headers.
+func (s *SpadeHandler) SetResponseHeaders(headers ResponseHeaders) {
+	compiled := headers.compile()
+	s.settingsMu.Lock()
+	s.responseHeaders = compiled
+	s.settingsMu.Unlock()
+}
+
+// writeResponseHeaders sets the configured headers for the endpoint at path.
+// Headers the handler sets itself afterwards, such as Content-Type, win.
+func (s *SpadeHandler) writeResponseHeaders(w http.ResponseWriter, path string) {
+	s.settingsMu.RLock()
+	headers := s.responseHeaders[endpointGroup(path, s.trackingPaths)]
+	s.settingsMu.RUnlock()
+	for name, values := range headers {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+}
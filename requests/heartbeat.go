@@ -0,0 +1,159 @@
+package requests
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultHeartbeatEvent    = "edge_heartbeat"
+	defaultHeartbeatInterval = "1m"
+)
+
+// HeartbeatConfig configures the heartbeat events an edge logs into its own
+// event stream, so downstream can tell a dead edge from an idle one and
+// compare what each edge accepted with what was received from it.
+type HeartbeatConfig struct {
+	// Event is the name of the heartbeat event. It defaults to edge_heartbeat.
+	Event string
+
+	// Interval is how often a heartbeat is logged, e.g. "1m"
+	Interval string
+}
+
+// Validate verifies that a HeartbeatConfig is valid and fills in defaults
+func (c *HeartbeatConfig) Validate() error {
+	if c.Event == "" {
+		c.Event = defaultHeartbeatEvent
+	}
+	if c.Interval == "" {
+		c.Interval = defaultHeartbeatInterval
+	}
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Interval, err)
+	}
+	if d <= 0 {
+		return errors.New("Interval must be greater than 0")
+	}
+	return nil
+}
+
+// Heartbeater logs a heartbeat event every Interval, carrying the instance,
+// its version and the records and bytes it accepted since the last one.
+// Heartbeats go through the loggers like any other event, so with a Sequencer
+// they're numbered too.
+type Heartbeater struct {
+	config   HeartbeatConfig
+	instance string
+	version  string
+	interval time.Duration
+	stats    statsd.StatSender
+
+	// records and bytes are accepted since the last heartbeat; the totals
+	// since the edge started.
+	records      uint64
+	bytes        uint64
+	totalRecords uint64
+	totalBytes   uint64
+
+	mu      sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// NewHeartbeater returns a Heartbeater for config, naming the instance and
+// version of the edge in its heartbeats.
+func NewHeartbeater(config HeartbeatConfig, instanceID, version string, stats statsd.StatSender) (*Heartbeater, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	h := &Heartbeater{
+		config:   config,
+		instance: instanceID,
+		version:  version,
+		stats:    stats,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	h.interval, _ = time.ParseDuration(config.Interval)
+	return h, nil
+}
+
+// Observe counts a record of data accepted.
+func (h *Heartbeater) Observe(data string) {
+	atomic.AddUint64(&h.records, 1)
+	atomic.AddUint64(&h.bytes, uint64(len(data)))
+	atomic.AddUint64(&h.totalRecords, 1)
+	atomic.AddUint64(&h.totalBytes, uint64(len(data)))
+}
+
+// beat returns the data of a heartbeat event for the records and bytes
+// accepted since the last one.
+func (h *Heartbeater) beat(records, bytes uint64) string {
+	b, _ := json.Marshal(map[string]interface{}{
+		"event": h.config.Event,
+		"properties": map[string]interface{}{
+			"instance":         h.instance,
+			"version":          h.version,
+			"interval_seconds": h.interval.Seconds(),
+			"accepted_records": records,
+			"accepted_bytes":   bytes,
+			"total_records":    atomic.LoadUint64(&h.totalRecords),
+			"total_bytes":      atomic.LoadUint64(&h.totalBytes),
+		},
+	})
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// Heartbeat logs a heartbeat event with log. If it fails, the counts are
+// carried over to the next heartbeat and the failure is counted under
+// heartbeat.failed.
+func (h *Heartbeater) Heartbeat(log func(data string) error) {
+	records := atomic.SwapUint64(&h.records, 0)
+	bytes := atomic.SwapUint64(&h.bytes, 0)
+	if err := log(h.beat(records, bytes)); err != nil {
+		atomic.AddUint64(&h.records, records)
+		atomic.AddUint64(&h.bytes, bytes)
+		_ = h.stats.Inc("heartbeat.failed", 1, 1)
+		logger.WithError(err).Warn("Failed to log heartbeat")
+	}
+}
+
+// Run logs a heartbeat every Interval with log until Close is called.
+func (h *Heartbeater) Run(log func(data string) error) {
+	h.mu.Lock()
+	h.running = true
+	h.mu.Unlock()
+	defer close(h.done)
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.quit:
+			return
+		case <-ticker.C:
+			h.Heartbeat(log)
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (h *Heartbeater) Close() {
+	close(h.quit)
+	h.mu.Lock()
+	running := h.running
+	h.mu.Unlock()
+	if running {
+		<-h.done
+	}
+}
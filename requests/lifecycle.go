@@ -0,0 +1,168 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pathStatReplacer replaces the characters of paths that aren't kept in stat
+// names.
+var pathStatReplacer = strings.NewReplacer("/", "_", ".", "_")
+
+// PathLifecycle retires a path gradually: it's served as usual until
+// Deprecated, then with headers warning clients, and from Gone on is answered
+// with a 410.
+type PathLifecycle struct {
+	// Path is an exact path, or a prefix if it ends in a slash, e.g. "/v1/"
+	Path string
+
+	// Deprecated, if set, is when responses start warning clients, in
+	// RFC 3339, e.g. "2018-01-01T00:00:00Z"
+	Deprecated string
+
+	// Gone, if set, is when requests start being answered with a 410, in
+	// RFC 3339
+	Gone string
+
+	// Link, if set, is the URL of the retirement notice, sent in a Link
+	// header with the warnings
+	Link string
+}
+
+// PathLifecycles are the paths being retired. A request follows the
+// lifecycle of the longest path it matches.
+type PathLifecycles []*PathLifecycle
+
+// Validate verifies that every lifecycle names a path and at least one date,
+// in order
+func (l PathLifecycles) Validate() error {
+	seen := make(map[string]bool, len(l))
+	for _, lifecycle := range l {
+		if lifecycle == nil || !strings.HasPrefix(lifecycle.Path, "/") {
+			return errors.New("every lifecycle needs a Path starting with /")
+		}
+		if seen[lifecycle.Path] {
+			return fmt.Errorf("path %s has more than one lifecycle", lifecycle.Path)
+		}
+		seen[lifecycle.Path] = true
+		deprecated, gone, err := lifecycle.dates()
+		if err != nil {
+			return fmt.Errorf("path %s: %v", lifecycle.Path, err)
+		}
+		if deprecated.IsZero() && gone.IsZero() {
+			return fmt.Errorf("path %s needs Deprecated or Gone", lifecycle.Path)
+		}
+		if !deprecated.IsZero() && !gone.IsZero() && !deprecated.Before(gone) {
+			return fmt.Errorf("path %s must be Deprecated before it's Gone", lifecycle.Path)
+		}
+		if lifecycle.Link != "" && !strings.HasPrefix(lifecycle.Link, "https://") &&
+			!strings.HasPrefix(lifecycle.Link, "http://") {
+			return fmt.Errorf("path %s: Link must be an http or https URL", lifecycle.Path)
+		}
+	}
+	return nil
+}
+
+func (l *PathLifecycle) dates() (deprecated, gone time.Time, err error) {
+	if l.Deprecated != "" {
+		if deprecated, err = time.Parse(time.RFC3339, l.Deprecated); err != nil {
+			return deprecated, gone, fmt.Errorf("error parsing Deprecated %s as RFC 3339: %v", l.Deprecated, err)
+		}
+	}
+	if l.Gone != "" {
+		if gone, err = time.Parse(time.RFC3339, l.Gone); err != nil {
+			return deprecated, gone, fmt.Errorf("error parsing Gone %s as RFC 3339: %v", l.Gone, err)
+		}
+	}
+	return deprecated, gone, nil
+}
+
+// pathLifecycle is the parsed form of a PathLifecycle.
+type pathLifecycle struct {
+	path       string
+	stat       string
+	deprecated time.Time
+	gone       time.Time
+	link       string
+}
+
+func (p *pathLifecycle) matches(path string) bool {
+	if strings.HasSuffix(p.path, "/") {
+		return strings.HasPrefix(path, p.path)
+	}
+	return path == p.path
+}
+
+// compile parses the lifecycles, longest path first.
+func (l PathLifecycles) compile() []*pathLifecycle {
+	compiled := make([]*pathLifecycle, 0, len(l))
+	for _, lifecycle := range l {
+		deprecated, gone, _ := lifecycle.dates()
+		stat := strings.Trim(pathStatReplacer.Replace(lifecycle.Path), "_")
+		if stat == "" {
+			stat = "root"
+		}
+		compiled = append(compiled, &pathLifecycle{
+			path:       lifecycle.Path,
+			stat:       stat,
+			deprecated: deprecated,
+			gone:       gone,
+			link:       lifecycle.Link,
+		})
+	}
+	sort.SliceStable(compiled, func(i, j int) bool { return len(compiled[i].path) > len(compiled[j].path) })
+	return compiled
+}
+
+// SetPathLifecycles replaces the paths being retired.
+func (s *SpadeHandler) SetPathLifecycles(lifecycles PathLifecycles) {
+	compiled := lifecycles.compile()
+	s.settingsMu.Lock()
+	s.pathLifecycles = compiled
+	s.settingsMu.Unlock()
+}
+
+// checkLifecycle applies the lifecycle of the request's path, if it has one
+// that has started: it counts the request under lifecycle.deprecated.<path>
+// and warns the client, or once the path is gone counts it under
+// lifecycle.gone.<path> and returns a 410. It returns 0 otherwise.
+func (s *SpadeHandler) checkLifecycle(w http.ResponseWriter, path string, context *RequestContext) int {
+	s.settingsMu.RLock()
+	lifecycles := s.pathLifecycles
+	s.settingsMu.RUnlock()
+	var lifecycle *pathLifecycle
+	for _, l := range lifecycles {
+		if l.matches(path) {
+			lifecycle = l
+			break
+		}
+	}
+	if lifecycle == nil {
+		return 0
+	}
+
+	gone := !lifecycle.gone.IsZero() && !context.Now.Before(lifecycle.gone)
+	deprecated := !lifecycle.deprecated.IsZero() && !context.Now.Before(lifecycle.deprecated)
+	if !gone && !deprecated {
+		return 0
+	}
+	w.Header().Set("Deprecation", "true")
+	if !lifecycle.gone.IsZero() {
+		w.Header().Set("Sunset", lifecycle.gone.UTC().Format(http.TimeFormat))
+	}
+	if lifecycle.link != "" {
+		w.Header().Set("Link", "<"+lifecycle.link+`>; rel="deprecation"`)
+	}
+	if gone {
+		_ = s.StatLogger.Inc("lifecycle.gone."+lifecycle.stat, 1, 0.1)
+		context.reject(RejectGone)
+		return http.StatusGone
+	}
+	_ = s.StatLogger.Inc("lifecycle.deprecated."+lifecycle.stat, 1, 0.1)
+	w.Header().Set("Warning", `299 spade-edge "`+lifecycle.path+` is deprecated"`)
+	return 0
+}
@@ -0,0 +1,114 @@
+package requests
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const (
+	defaultMaxPartSize = 1024 * 1024
+	defaultMaxParts    = 16
+)
+
+var (
+	errPartTooLarge = errors.New("multipart part too large")
+	errTooManyParts = errors.New("too many multipart parts")
+)
+
+// MultipartConfig caps the multipart/form-data bodies the edge parses.
+type MultipartConfig struct {
+	// MaxPartSize is the largest part, in bytes. It defaults to 1MB.
+	MaxPartSize int64
+
+	// MaxParts is the most parts a body may have. It defaults to 16.
+	MaxParts int
+}
+
+// Validate verifies that a MultipartConfig is valid and fills in defaults
+func (c *MultipartConfig) Validate() error {
+	if c.MaxPartSize == 0 {
+		c.MaxPartSize = defaultMaxPartSize
+	}
+	if c.MaxPartSize < 0 {
+		return errors.New("MaxPartSize must be greater than 0")
+	}
+	if c.MaxParts == 0 {
+		c.MaxParts = defaultMaxParts
+	}
+	if c.MaxParts < 0 {
+		return errors.New("MaxParts must be greater than 0")
+	}
+	return nil
+}
+
+// parseMultipartForm adds the fields of a multipart/form-data body to
+// r.PostForm and r.Form, as ParseForm does for urlencoded bodies, so they are
+// handled the same way. Parts are read in memory; file parts are treated as
+// fields, since browsers send Blobs appended to a FormData that way.
+func parseMultipartForm(r *http.Request, config MultipartConfig) error {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return err
+	}
+	postForm := make(url.Values)
+	for parts := 0; ; parts++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if parts == config.MaxParts {
+			return errTooManyParts
+		}
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+		b, err := ioutil.ReadAll(io.LimitReader(part, config.MaxPartSize+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(b)) > config.MaxPartSize {
+			return errPartTooLarge
+		}
+		postForm.Add(name, string(b))
+	}
+
+	// Body values come before query values in r.Form, as with ParseForm.
+	form := make(url.Values, len(postForm)+len(r.Form))
+	for name, values := range postForm {
+		form[name] = append([]string(nil), values...)
+	}
+	for name, values := range r.Form {
+		form[name] = append(form[name], values...)
+	}
+	r.PostForm, r.Form = postForm, form
+	return nil
+}
+
+// parseMultipart parses a multipart/form-data request and returns the status
+// code to reject it with, or 0 if it was parsed.
+func (s *SpadeHandler) parseMultipart(r *http.Request) int {
+	err := parseMultipartForm(r, s.Multipart)
+	switch {
+	case err == nil:
+		return 0
+	case err == errPartTooLarge:
+		_ = s.StatLogger.Inc("multipart.part_too_large", 1, 0.1)
+		return http.StatusRequestEntityTooLarge
+	case err == errTooManyParts:
+		_ = s.StatLogger.Inc("multipart.too_many_parts", 1, 0.1)
+		return http.StatusRequestEntityTooLarge
+	case err.Error() == largeBodyErrorString:
+		s.logLargeRequestError(r, "")
+		return http.StatusRequestEntityTooLarge
+	default:
+		_ = s.StatLogger.Inc("bad_request.multipart", 1, 0.01)
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,167 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+// The modes of a NamingPolicy.
+const (
+	// NamingEnforce rejects requests with events whose names violate the
+	// policy.
+	NamingEnforce = "enforce"
+
+	// NamingTag logs events whose names violate the policy, with the
+	// violation set as their Property.
+	NamingTag = "tag"
+)
+
+// The ways an event name can violate a NamingPolicy, counted under
+// naming.violation.<violation>.
+const (
+	violationEmpty     = "empty"
+	violationTooShort  = "too_short"
+	violationTooLong   = "too_long"
+	violationUppercase = "uppercase"
+	violationPattern   = "pattern"
+)
+
+const (
+	defaultNamingPattern   = `^[a-z][a-z0-9]*(_[a-z0-9]+)*$`
+	defaultNamingMaxLength = 64
+	defaultNamingProperty  = "edge_name_violation"
+)
+
+// NamingConfig configures the naming convention event names are checked
+// against, after EventNames normalization.
+type NamingConfig struct {
+	// Mode is enforce or tag. It defaults to tag.
+	Mode string
+
+	// Pattern is the regular expression names must match. It defaults to
+	// lowercase snake_case.
+	Pattern string
+
+	// MinLength and MaxLength bound the length of names. MaxLength defaults
+	// to 64.
+	MinLength int
+	MaxLength int
+
+	// Property is the event property violations are set as in tag mode. It
+	// defaults to edge_name_violation.
+	Property string
+}
+
+// Validate verifies that a NamingConfig is valid and fills in defaults
+func (c *NamingConfig) Validate() error {
+	switch c.Mode {
+	case "":
+		c.Mode = NamingTag
+	case NamingEnforce, NamingTag:
+	default:
+		return fmt.Errorf("Mode must be %s or %s, got %q", NamingEnforce, NamingTag, c.Mode)
+	}
+	if c.Pattern == "" {
+		c.Pattern = defaultNamingPattern
+	}
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		return fmt.Errorf("Pattern: %v", err)
+	}
+	if c.MaxLength == 0 {
+		c.MaxLength = defaultNamingMaxLength
+	}
+	if c.MinLength < 0 || c.MaxLength < c.MinLength {
+		return errors.New("MinLength must be between 0 and MaxLength")
+	}
+	if c.Property == "" {
+		c.Property = defaultNamingProperty
+	}
+	return nil
+}
+
+// NamingPolicy checks event names against a naming convention.
+type NamingPolicy struct {
+	config  NamingConfig
+	pattern *regexp.Regexp
+}
+
+// NewNamingPolicy returns a NamingPolicy for config.
+func NewNamingPolicy(config NamingConfig) (*NamingPolicy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &NamingPolicy{config: config, pattern: regexp.MustCompile(config.Pattern)}, nil
+}
+
+// Enforced reports whether requests with violating names are rejected.
+func (p *NamingPolicy) Enforced() bool {
+	return p.config.Mode == NamingEnforce
+}
+
+// violation returns how name violates the policy, or "" if it doesn't.
+func (p *NamingPolicy) violation(name string) string {
+	switch {
+	case name == "":
+		return violationEmpty
+	case len(name) < p.config.MinLength:
+		return violationTooShort
+	case len(name) > p.config.MaxLength:
+		return violationTooLong
+	case p.pattern.MatchString(name):
+		return ""
+	case strings.ToLower(name) != name && p.pattern.MatchString(strings.ToLower(name)):
+		return violationUppercase
+	default:
+		return violationPattern
+	}
+}
+
+// check returns the violations of the names of the events in the base64
+// encoded event (or array of events) in data, one per violating event. In tag
+// mode, the violating events get Property set and data is returned
+// re-encoded; events without properties can't be tagged and aren't counted.
+// data that can't be decoded has no violations.
+func (p *NamingPolicy) check(data string) (string, []string) {
+	var violations []string
+	if p.Enforced() {
+		for _, name := range transform.EventNames(data) {
+			if v := p.violation(name); v != "" {
+				violations = append(violations, v)
+			}
+		}
+		return data, violations
+	}
+	tagged, _, _ := transform.Edit(data, func(name string, properties map[string]interface{}) bool {
+		v := p.violation(name)
+		if v == "" {
+			return false
+		}
+		violations = append(violations, v)
+		properties[p.config.Property] = v
+		return true
+	})
+	return tagged, violations
+}
+
+// checkNames checks the event names in data against the handler's
+// NamingPolicy, counting violations under naming.violation.<violation>. It
+// returns data, tagged in tag mode, and false if the policy is enforced and
+// data has violations, counted under naming.rejected.
+func (s *SpadeHandler) checkNames(data string) (string, bool) {
+	if s.Naming == nil {
+		return data, true
+	}
+	data, violations := s.Naming.check(data)
+	for _, v := range violations {
+		_ = s.StatLogger.Inc("naming.violation."+v, 1, 0.1)
+	}
+	if len(violations) > 0 && s.Naming.Enforced() {
+		_ = s.StatLogger.Inc("naming.rejected", 1, 0.1)
+		return data, false
+	}
+	return data, true
+}
@@ -0,0 +1,135 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/topk"
+)
+
+const (
+	defaultLearningK       = 100
+	defaultLearningWindow  = "24h"
+	defaultLearningBuckets = 24
+
+	// maxLoggedOrigins bounds the origins logged the first time they're
+	// seen, however many clients make up.
+	maxLoggedOrigins = 1000
+)
+
+// OriginLearningConfig configures counting the origins a CORS allowlist
+// being considered would reject, before it's enforced.
+type OriginLearningConfig struct {
+	// Origins are glob patterns of the allowlist being considered. If it's
+	// empty, every origin is counted
+	Origins []string
+
+	// TopK sizes the counts kept, which default to the 100 heaviest origins
+	// of the last day
+	TopK topk.Config
+}
+
+// Validate verifies that an OriginLearningConfig is valid and fills in
+// defaults
+func (c *OriginLearningConfig) Validate() error {
+	for _, origin := range c.Origins {
+		if _, err := glob.Compile(strings.TrimSpace(origin)); err != nil {
+			return fmt.Errorf("invalid pattern %s: %v", origin, err)
+		}
+	}
+	if c.TopK.K == 0 {
+		c.TopK.K = defaultLearningK
+	}
+	if c.TopK.Window == "" {
+		c.TopK.Window = defaultLearningWindow
+	}
+	if c.TopK.Buckets == 0 {
+		c.TopK.Buckets = defaultLearningBuckets
+	}
+	if err := c.TopK.Validate(); err != nil {
+		return fmt.Errorf("TopK: %v", err)
+	}
+	return nil
+}
+
+// OriginLearner counts the origins of requests that an allowlist being
+// considered would reject, without changing how they're answered: in total,
+// and those the CORS policy in force allows, which enforcing the allowlist
+// would break.
+type OriginLearner struct {
+	config   OriginLearningConfig
+	matchers []glob.Glob
+	rejected *topk.Counter
+	broken   *topk.Counter
+
+	mu     sync.Mutex
+	logged map[string]bool
+}
+
+// NewOriginLearner returns an OriginLearner for config.
+func NewOriginLearner(config OriginLearningConfig) (*OriginLearner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	l := &OriginLearner{config: config, matchers: compileOrigins(config.Origins), logged: make(map[string]bool)}
+	var err error
+	if l.rejected, err = topk.NewCounter(config.TopK); err != nil {
+		return nil, err
+	}
+	if l.broken, err = topk.NewCounter(config.TopK); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// observe counts origin if the allowlist would reject it, logging it the
+// first time. allowed is whether the CORS policy in force allows it.
+func (l *OriginLearner) observe(origin string, allowed bool) {
+	for _, matcher := range l.matchers {
+		if matcher.Match(origin) {
+			return
+		}
+	}
+	l.rejected.Add(origin)
+	if allowed {
+		l.broken.Add(origin)
+	}
+
+	l.mu.Lock()
+	first := !l.logged[origin] && len(l.logged) < maxLoggedOrigins
+	if first {
+		l.logged[origin] = true
+	}
+	l.mu.Unlock()
+	if first {
+		logger.WithField("origin", origin).WithField("allowed", allowed).
+			Info("Origin the CORS allowlist being learned would reject")
+	}
+}
+
+// ServeHTTP serves the heaviest origins the allowlist would reject as JSON,
+// along with the window: all of them as rejected, and those allowed now as
+// broken. The k query parameter asks for fewer than K origins.
+func (l *OriginLearner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k := l.config.TopK.K
+	if requested, err := strconv.Atoi(r.URL.Query().Get("k")); err == nil && requested > 0 && requested < k {
+		k = requested
+	}
+	b, err := json.MarshalIndent(struct {
+		Window   string       `json:"window"`
+		Rejected []topk.Entry `json:"rejected"`
+		Broken   []topk.Entry `json:"broken"`
+	}{l.config.TopK.Window, l.rejected.Top(k), l.broken.Top(k)}, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
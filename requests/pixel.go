@@ -0,0 +1,92 @@
+package requests
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// defaultCacheBusterParams are the query parameters clients commonly use to
+// make pixel URLs unique.
+var defaultCacheBusterParams = []string{"cb", "_", "t", "rnd"}
+
+// PixelConfig configures how pixel requests that a CDN could have cached are
+// detected and handled.
+type PixelConfig struct {
+	// CacheBusterParams are the query parameters that make a pixel URL
+	// unique. Pixel requests without any of them are suspected cacheable.
+	CacheBusterParams []string
+
+	// RedirectUncached answers pixel GETs without a cache buster with a 302
+	// to the same URL with a unique cache buster added, instead of logging
+	// the event and writing the pixel. The event is logged when the client
+	// follows the redirect.
+	RedirectUncached bool
+}
+
+// Validate verifies that a PixelConfig is valid and fills in defaults
+func (c *PixelConfig) Validate() error {
+	if len(c.CacheBusterParams) == 0 {
+		c.CacheBusterParams = defaultCacheBusterParams
+	}
+	for _, p := range c.CacheBusterParams {
+		if p == "" {
+			return errors.New("CacheBusterParams must not contain an empty name")
+		}
+	}
+	return nil
+}
+
+// PixelPolicy detects pixel requests that bypass cache busting.
+type PixelPolicy struct {
+	config PixelConfig
+}
+
+// NewPixelPolicy returns a PixelPolicy for config.
+func NewPixelPolicy(config PixelConfig) (*PixelPolicy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &PixelPolicy{config: config}, nil
+}
+
+// hasCacheBuster returns whether values carry any of the cache buster params.
+func (p *PixelPolicy) hasCacheBuster(values url.Values) bool {
+	for _, param := range p.config.CacheBusterParams {
+		if values.Get(param) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPixel records whether a pixel request could have been cached and, if
+// it is redirected to a unique URL, writes the redirect and returns true.
+func (s *SpadeHandler) checkPixel(w http.ResponseWriter, r *http.Request, values url.Values) bool {
+	// A conditional request means some cache already holds a copy of the
+	// pixel and is only revalidating it.
+	if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		_ = s.StatLogger.Inc("pixel.conditional", 1, 0.1)
+	}
+	if s.Pixel.hasCacheBuster(values) {
+		return false
+	}
+	_ = s.StatLogger.Inc("pixel.cache_buster.missing", 1, 0.1)
+	if !s.Pixel.config.RedirectUncached || r.Method != "GET" {
+		return false
+	}
+
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return false
+	}
+	values.Set(s.Pixel.config.CacheBusterParams[0], hex.EncodeToString(nonce[:]))
+	target := *r.URL
+	target.RawQuery = values.Encode()
+	w.Header().Set("Cache-Control", "no-store, private")
+	http.Redirect(w, r, target.RequestURI(), http.StatusFound)
+	_ = s.StatLogger.Inc("pixel.cache_buster.redirected", 1, 0.1)
+	return true
+}
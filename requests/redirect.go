@@ -0,0 +1,101 @@
+package requests
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	// clickEventName is the name of the events logged for /r redirects.
+	clickEventName = "edge_click"
+
+	// redirectParam is the query parameter holding the destination URL.
+	redirectParam = "u"
+
+	maxRedirectURLBytes = 2048
+)
+
+// SetRedirectHosts replaces the glob patterns of the hosts /r may redirect to.
+// Hosts are matched case-insensitively.
+func (s *SpadeHandler) SetRedirectHosts(hosts []string) {
+	lower := make([]string, len(hosts))
+	for i, h := range hosts {
+		lower[i] = strings.ToLower(h)
+	}
+	matchers := compileOrigins(lower)
+	s.settingsMu.Lock()
+	s.redirectHostMatchers = matchers
+	s.settingsMu.Unlock()
+}
+
+// redirectDestination parses and validates the destination of a click. Only
+// absolute http(s) URLs without credentials whose host matches an allowed
+// pattern are accepted, so /r can't be used as an open redirect.
+func (s *SpadeHandler) redirectDestination(raw string) (*url.URL, bool) {
+	if raw == "" || len(raw) > maxRedirectURLBytes || strings.ContainsAny(raw, "\r\n\\") {
+		return nil, false
+	}
+	dest, err := url.Parse(raw)
+	if err != nil || (dest.Scheme != "http" && dest.Scheme != "https") || dest.User != nil || dest.Host == "" {
+		return nil, false
+	}
+
+	host := strings.ToLower(dest.Hostname())
+	s.settingsMu.RLock()
+	matchers := s.redirectHostMatchers
+	s.settingsMu.RUnlock()
+	for _, matcher := range matchers {
+		if matcher.Match(host) {
+			return dest, true
+		}
+	}
+	return nil, false
+}
+
+// handleRedirect logs a click event for the destination in the query and
+// redirects the client to it.
+func (s *SpadeHandler) handleRedirect(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
+	values := r.URL.Query()
+	dest, ok := s.redirectDestination(values.Get(redirectParam))
+	if !ok {
+		_ = s.StatLogger.Inc("redirect.rejected", 1, 1)
+		context.reject(RejectBadRedirect)
+		return http.StatusBadRequest
+	}
+	host := sanitizeHostValue(dest.Host)
+
+	properties := map[string]string{"url": dest.String()}
+	for k := range values {
+		if k != redirectParam && k != "ua" {
+			properties[k] = values.Get(k)
+		}
+	}
+	b, err := json.Marshal(map[string]interface{}{"event": clickEventName, "properties": properties})
+	if err != nil {
+		logger.WithError(err).Error("Error marshaling click event")
+		return http.StatusInternalServerError
+	}
+
+	var userAgent string
+	if values.Get("ua") == "1" && len(r.Header.Get("User-Agent")) <= maxUserAgentBytes {
+		userAgent = r.Header.Get("User-Agent")
+	}
+	xForwardedFor := r.Header.Get(context.IPHeader)
+	event := s.buildEvent(base64.StdEncoding.EncodeToString(b), context, parseLastForwarder(xForwardedFor),
+		xForwardedFor, userAgent)
+	if err = s.logEvent(r, event, context); err != nil {
+		// The click is lost, but the user still gets where they were going.
+		logger.WithError(err).Warn("Error writing click event to logger")
+		_ = s.StatLogger.Inc("redirect.log_failed", 1, 1)
+	}
+
+	_ = s.StatLogger.Inc("redirect.destination."+s.Dimensions.Limit("redirect.destination", host), 1, 0.1)
+	w.Header().Set("Cache-Control", "no-cache, max-age=0")
+	http.Redirect(w, r, dest.String(), http.StatusFound)
+	return http.StatusFound
+}
@@ -0,0 +1,71 @@
+package requests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RejectReasonHeader is the response header that says why a request was
+// rejected.
+const RejectReasonHeader = "X-Spade-Reject-Reason"
+
+// The reasons requests are rejected for. Clients may depend on them, so they
+// must not change once added.
+const (
+	RejectEmptyData      = "empty_data"
+	RejectBadBase64      = "bad_base64"
+	RejectBadJSON        = "bad_json"
+	RejectTooLarge       = "too_large"
+	RejectRateLimited    = "rate_limited"
+	RejectBadForm        = "bad_form"
+	RejectBadMultipart   = "bad_multipart"
+	RejectBadContentType = "unsupported_content_type"
+	RejectReadFailed     = "read_failed"
+	RejectUnauthorized   = "unauthorized"
+	RejectBadSignature   = "bad_signature"
+	RejectBadRedirect    = "bad_redirect"
+	RejectBadMethod      = "method_not_allowed"
+	RejectAckUnavailable = "ack_unavailable"
+	RejectBadEventName   = "bad_event_name"
+	RejectRelayLoop      = "relay_loop"
+	RejectBadClient      = "bad_client"
+	RejectURITooLong     = "uri_too_long"
+	RejectGone           = "gone"
+	RejectNonceCacheFull = "nonce_cache_full"
+)
+
+// rejection is the JSON body of a rejected request that accepts JSON.
+type rejection struct {
+	Status int    `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// reject records why the request is being rejected. The first reason given
+// sticks.
+func (r *RequestContext) reject(reason string) {
+	if r.RejectReason == "" {
+		r.RejectReason = reason
+	}
+}
+
+// writeStatus writes status as the response's status, along with the reason
+// the request was rejected for, if it was: in RejectReasonHeader, and in a
+// JSON body if the client accepts JSON. Rejections are counted under
+// reject_reason.<reason>.
+func (s *SpadeHandler) writeStatus(w http.ResponseWriter, r *http.Request, context *RequestContext, status int) {
+	if status < http.StatusBadRequest || context.RejectReason == "" {
+		w.WriteHeader(status)
+		return
+	}
+	_ = s.StatLogger.Inc("reject_reason."+context.RejectReason, 1, 0.1)
+	w.Header().Set(RejectReasonHeader, context.RejectReason)
+	if !strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.WriteHeader(status)
+		return
+	}
+	b, _ := json.Marshal(rejection{Status: status, Reason: context.RejectReason})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
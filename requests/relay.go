@@ -0,0 +1,173 @@
+package requests
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+// RelayPath is the endpoint peer edges relay events to.
+const RelayPath = "/relay"
+
+const (
+	defaultRelayMaxHops = 3
+	// maxRelayLineBytes bounds a relayed event, well above any the edge
+	// accepts from clients.
+	maxRelayLineBytes = 1 << 20
+)
+
+// RelayReceiverConfig configures the endpoint peer edges relay events to.
+type RelayReceiverConfig struct {
+	// Peers are the secrets the peer edges sign their batches with, by key id
+	Peers map[string]string
+
+	// ClockTolerance is how far the timestamp of a batch may be from the
+	// edge's clock, e.g. "5m"
+	ClockTolerance string
+
+	// MaxHops is the most edges a batch may have been relayed by. Batches
+	// relayed by more are refused as going in circles. It defaults to 3.
+	MaxHops int
+}
+
+// Validate verifies that a RelayReceiverConfig is valid and fills in defaults
+func (c *RelayReceiverConfig) Validate() error {
+	if err := c.hmacConfig().Validate(); err != nil {
+		return err
+	}
+	if c.MaxHops == 0 {
+		c.MaxHops = defaultRelayMaxHops
+	}
+	if c.MaxHops < 0 {
+		return errors.New("MaxHops must be a positive value")
+	}
+	return nil
+}
+
+func (c *RelayReceiverConfig) hmacConfig() *auth.HMACConfig {
+	return &auth.HMACConfig{Secrets: c.Peers, ClockTolerance: c.ClockTolerance, Endpoints: []string{RelayPath}}
+}
+
+// RelayReceiver accepts the batches of events peer edges relay, see
+// loggers.NewRelayLogger.
+type RelayReceiver struct {
+	verifier *auth.HMACVerifier
+	maxHops  int
+}
+
+// NewRelayReceiver returns a RelayReceiver for config.
+func NewRelayReceiver(config RelayReceiverConfig) (*RelayReceiver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	verifier, err := auth.NewHMACVerifier(*config.hmacConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &RelayReceiver{verifier: verifier, maxHops: config.MaxHops}, nil
+}
+
+// handleRelay logs the events of a batch relayed by a peer edge as they were
+// received by the first edge, with its timestamp, uuid and client IP. The
+// batch is answered with a 503 if any event can't be logged, so the peer
+// retries it; the events that were logged are logged again.
+func (s *SpadeHandler) handleRelay(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
+	status := s.relay(r, context)
+	s.writeStatus(w, r, context, status)
+	return status
+}
+
+func (s *SpadeHandler) relay(r *http.Request, context *RequestContext) int {
+	if s.Relay == nil {
+		return http.StatusNotFound
+	}
+	if r.Method != "POST" {
+		context.reject(RejectBadMethod)
+		return http.StatusMethodNotAllowed
+	}
+	if _, err := s.Relay.verifier.VerifyRequest(r); err != nil {
+		_ = s.StatLogger.Inc("relay.rejected", 1, 1)
+		if err == auth.ErrNonceCacheFull {
+			_ = s.StatLogger.Inc("auth.hmac.nonce_cache_full", 1, 1)
+			context.reject(RejectNonceCacheFull)
+			return http.StatusServiceUnavailable
+		}
+		if err.Error() == largeBodyErrorString {
+			context.reject(RejectTooLarge)
+			return http.StatusRequestEntityTooLarge
+		}
+		context.reject(RejectBadSignature)
+		return http.StatusUnauthorized
+	}
+	hops, err := strconv.Atoi(r.Header.Get(loggers.RelayHopsHeader))
+	if err != nil || hops < 1 {
+		context.reject(RejectBadForm)
+		return http.StatusBadRequest
+	}
+	if hops > s.Relay.maxHops {
+		_ = s.StatLogger.Inc("relay.loop_detected", 1, 1)
+		logger.WithField("hops", hops).Warn("Refused relayed batch going in circles")
+		context.reject(RejectRelayLoop)
+		return http.StatusLoopDetected
+	}
+	if r.Header.Get("Content-Type") != loggers.RelayContentType {
+		context.reject(RejectBadContentType)
+		return http.StatusUnsupportedMediaType
+	}
+	var body io.Reader = r.Body
+	switch r.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip":
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			context.reject(RejectReadFailed)
+			return http.StatusBadRequest
+		}
+		defer func() { _ = zr.Close() }()
+		body = zr
+	default:
+		context.reject(RejectBadContentType)
+		return http.StatusUnsupportedMediaType
+	}
+	s.EdgeLoggers.Env.ObserveRelayHops(hops, context.Now)
+
+	var received, failed int64
+	lines := bufio.NewScanner(body)
+	lines.Buffer(nil, maxRelayLineBytes)
+	for lines.Scan() {
+		if len(lines.Bytes()) == 0 {
+			continue
+		}
+		var event spade.Event
+		if err = json.Unmarshal(lines.Bytes(), &event); err != nil {
+			context.reject(RejectBadJSON)
+			return http.StatusBadRequest
+		}
+		received++
+		if err = s.logEvent(r, &event, context); err != nil {
+			failed++
+		}
+	}
+	_ = s.StatLogger.Inc("relay.batches_received", 1, 1)
+	_ = s.StatLogger.Inc("relay.events_received", received, 1)
+	if err = lines.Err(); err != nil {
+		logger.WithError(err).Warn("Error reading relayed batch")
+		context.reject(RejectReadFailed)
+		return http.StatusBadRequest
+	}
+	if failed > 0 {
+		_ = s.StatLogger.Inc("relay.events_failed", failed, 1)
+		logger.WithField("failed", failed).WithField("events", received).Warn("Error logging relayed batch")
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusNoContent
+}
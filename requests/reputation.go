@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/spade_edge/reputation"
+)
+
+// checkReputation checks the client's reputation, answering the request if
+// its client is dropped or rate limited. It returns the status the request
+// was answered with and whether it was.
+func (s *SpadeHandler) checkReputation(w http.ResponseWriter, r *http.Request, values url.Values,
+	context *RequestContext) (int, bool) {
+	clientIP := parseLastForwarder(r.Header.Get(context.IPHeader))
+	if clientIP == nil {
+		return 0, false
+	}
+	context.Reputation = s.Reputation.Check(clientIP)
+	switch {
+	case context.Reputation.Action == reputation.ActionDrop:
+		// Answer as if the events were logged, so the client doesn't retry.
+		if shouldWritePixel(values) {
+			if err := writePixel(w); err != nil {
+				logger.WithError(err).Error("Error writing transparent pixel response")
+				return http.StatusInternalServerError, true
+			}
+			return http.StatusOK, true
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return http.StatusNoContent, true
+	case context.Reputation.Limited:
+		_ = s.StatLogger.Inc("reputation.rate_limited", 1, 0.1)
+		context.reject(RejectRateLimited)
+		s.writeStatus(w, r, context, http.StatusTooManyRequests)
+		return http.StatusTooManyRequests, true
+	}
+	return 0, false
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,7 +21,25 @@ import (
 	"github.com/gobwas/glob"
 	"github.com/twitchscience/aws_utils/logger"
 	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/abuse"
+	"github.com/twitchscience/spade_edge/accounting"
+	"github.com/twitchscience/spade_edge/anomaly"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/clienthello"
+	"github.com/twitchscience/spade_edge/clockguard"
+	"github.com/twitchscience/spade_edge/enrich"
+	"github.com/twitchscience/spade_edge/eventtap"
+	"github.com/twitchscience/spade_edge/features"
+	"github.com/twitchscience/spade_edge/killswitch"
 	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/metrics"
+	"github.com/twitchscience/spade_edge/proxyproto"
+	"github.com/twitchscience/spade_edge/reputation"
+	"github.com/twitchscience/spade_edge/rollup"
+	"github.com/twitchscience/spade_edge/runrate"
+	"github.com/twitchscience/spade_edge/topk"
+	"github.com/twitchscience/spade_edge/transform"
+	"github.com/twitchscience/spade_edge/wal"
 )
 
 var (
@@ -45,6 +64,59 @@ type EdgeLoggers struct {
 	closed             chan struct{}
 	S3EventLogger      loggers.SpadeEdgeLogger
 	KinesisEventLogger loggers.SpadeEdgeLogger
+
+	// Acker, if set, writes the events of requests asking for a synchronous
+	// ack, see AckHeader.
+	Acker loggers.AckLogger
+
+	// Env is the sinks' Env. Events are serialized once in its envelope for
+	// the sinks that take them serialized.
+	Env *loggers.Env
+
+	// WAL, if set, is appended every event before it's written to the sinks,
+	// and acked once they have it. The loggers close it.
+	WAL *wal.WAL
+
+	// Sequencer, if set, numbers the events before they're appended to the
+	// WAL and written.
+	Sequencer *Sequencer
+
+	// Tenants are the sinks of the tenants with their own, by tenant name.
+	// The loggers close them.
+	Tenants map[string]*TenantLoggers
+
+	// UserAgents, if set, replaces the User-Agents of events with their
+	// hashes, writing the User-Agents to its dictionary instead. The loggers
+	// close it.
+	UserAgents *loggers.UserAgentDictionary
+
+	// MaxReplayAge, if set, drops events received longer ago than that
+	// instead of replaying them from the WAL or the spool, as the Kinesis
+	// logger does with stale events on their way to its fallback.
+	MaxReplayAge time.Duration
+
+	// queue and workers are set up by StartAsync.
+	queue   chan walEntry
+	workers sync.WaitGroup
+	stats   statsd.StatSender
+}
+
+// TenantLoggers are the sinks of a tenant's events that replace the edge's
+// own.
+type TenantLoggers struct {
+	KinesisEventLogger loggers.SpadeEdgeLogger
+
+	// Acker, if set, writes the events of the tenant's requests asking for a
+	// synchronous ack.
+	Acker loggers.AckLogger
+}
+
+// now is the time by the sinks' clock, which MaxReplayAge is measured on.
+func (e *EdgeLoggers) now() time.Time {
+	if e.Env == nil || e.Env.Clock == nil {
+		return time.Now()
+	}
+	return e.Env.Clock.Now()
 }
 
 // NewEdgeLoggers returns a new instance of an EdgeLoggers struct pre-filled
@@ -61,53 +133,254 @@ func (e *EdgeLoggers) log(event *spade.Event, context *RequestContext) error {
 	e.Add(1)
 	defer e.Done()
 
-	// If reading from the `closed` channel succeeds, the logger is closed.
-	select {
-	case <-e.closed:
-		return errors.New("Loggers are shutting down")
-	default: // Make this a non-blocking select
+	if e.isClosed() {
+		return errLoggersClosed
 	}
 
-	eventErr := e.S3EventLogger.Log(event)
-	kinesisErr := e.KinesisEventLogger.Log(event)
+	if e.Sequencer != nil {
+		e.Sequencer.stamp(event)
+	}
+	entry, err := e.appendWAL(event)
+	if err != nil {
+		return err
+	}
+	entry.tenant = context.Tenant
+
+	if e.queue != nil && e.enqueue(entry) {
+		return nil
+	}
+
+	eventErr, kinesisErr := e.write(entry)
 
 	context.RecordLoggerAttempt(eventErr, "event")
 	context.RecordLoggerAttempt(kinesisErr, "kinesis")
 
 	if eventErr != nil && kinesisErr != nil {
-		return errors.New("Failed to store the event in any of the loggers")
+		return errNoLogger
 	}
 
 	return nil
 }
 
+// isClosed reports whether the loggers are closed.
+func (e *EdgeLoggers) isClosed() bool {
+	// If reading from the `closed` channel succeeds, the logger is closed.
+	select {
+	case <-e.closed:
+		return true
+	default: // Make this a non-blocking select
+		return false
+	}
+}
+
+// write writes entry's event to both loggers, or its tenant's Kinesis logger
+// if it has its own, and acks it in the write-ahead log once both have written
+// it, which for a buffering Kinesis logger is once it's put in a record. The
+// event is serialized once up front if any logger can reuse the
+// serialization, rather than once per logger.
+func (e *EdgeLoggers) write(entry walEntry) (eventErr, kinesisErr error) {
+	event := entry.event
+	kinesisLogger := e.KinesisEventLogger
+	if t, ok := e.Tenants[entry.tenant]; ok {
+		kinesisLogger = t.KinesisEventLogger
+	}
+	var serialized []byte
+	_, s3Serialized := e.S3EventLogger.(loggers.SerializedLogger)
+	_, kinesisSerialized := kinesisLogger.(loggers.SerializedLogger)
+	if s3Serialized || kinesisSerialized {
+		var err error
+		if serialized, err = e.Env.SerializeEvent(event); err != nil {
+			// Leave it to the loggers to serialize the event and report why
+			// they couldn't.
+			serialized = nil
+		}
+	}
+	eventErr = loggers.LogSerialized(e.S3EventLogger, event, serialized)
+	if e.WAL == nil {
+		kinesisErr = loggers.LogSerialized(kinesisLogger, event, serialized)
+		return
+	}
+	kinesisErr = loggers.LogDurably(kinesisLogger, event, serialized, func(err error) {
+		e.ackWAL(entry, eventErr, err)
+	})
+	if kinesisErr != nil {
+		// The event may still be acked if Kinesis isn't configured.
+		e.ackWAL(entry, eventErr, kinesisErr)
+	}
+	return
+}
+
 // Close closes the loggers
 func (e *EdgeLoggers) Close() {
 	close(e.closed)
 	e.Wait()
+	if e.queue != nil {
+		close(e.queue)
+		e.workers.Wait()
+	}
 
+	// The tenants' Kinesis loggers may share the fallback logger of the
+	// edge's own, which closes it.
+	for _, t := range e.Tenants {
+		t.KinesisEventLogger.Close()
+	}
 	e.KinesisEventLogger.Close()
 	e.S3EventLogger.Close()
+	if e.UserAgents != nil {
+		e.UserAgents.Close()
+	}
+	if e.WAL != nil {
+		if err := e.WAL.Close(); err != nil {
+			logger.WithError(err).Error("Error closing write-ahead log")
+		}
+	}
 }
 
 // SpadeHandler handles http requests and forwards them to the EdgeLoggers
 type SpadeHandler struct {
-	StatLogger         statsd.StatSender
-	EdgeLoggers        *EdgeLoggers
-	Time               func() time.Time // Defaults to time.Now
-	EdgeType           string
-	corsOriginMatchers []glob.Glob
-	crossDomainPolicy  []byte
-	instanceID         string
+	StatLogger  statsd.StatSender
+	EdgeLoggers *EdgeLoggers
+	Time        func() time.Time // Defaults to time.Now
+	EdgeType    string
+	instanceID  string
 
 	// eventCount counts the number of event requests handled. It is used in
 	// uuid generation. eventCount is read and written from multiple go routines
 	// so any access to it should go through sync/atomic
-	eventCount             uint64
+	eventCount uint64
+
+	// settingsMu guards the settings that can be changed while serving.
+	settingsMu             sync.RWMutex
+	corsOriginMatchers     []glob.Glob
+	corsPolicies           []*corsPolicy
 	eventInURISamplingRate float32
+	responseHeaders        map[string]http.Header
+	crossDomainPolicy      *staticContent
+	robotsTxt              *staticContent
+	redirectHostMatchers   []glob.Glob
+	trackingPaths          *trackingMatcher
+	pathLifecycles         []*pathLifecycle
+	routes                 []route
 
-	// Whether to split and process large events or throw them away.
+	// Whether to split and process large events or throw them away, unless
+	// overridden by the handle_large_events feature flag.
 	handleLargeEvents bool
+
+	// Features decides per request whether flagged features are enabled.
+	Features *features.Set
+
+	// Authenticator, if set, requires a valid bearer token on the endpoints
+	// it protects.
+	Authenticator *auth.JWTVerifier
+
+	// SignatureVerifier, if set, requires a fresh HMAC signature on the
+	// endpoints it protects.
+	SignatureVerifier *auth.HMACVerifier
+
+	// Relay, if set, accepts the events peer edges relay to RelayPath.
+	Relay *RelayReceiver
+
+	// RunRate, if set, keeps daily counts of bad client behaviors.
+	RunRate *runrate.Store
+
+	// BadClient, if set, warns clients that form-encode their bodies twice,
+	// and rejects them after its sunset.
+	BadClient *BadClientPolicy
+
+	// Abuse, if set, scores clients and turns away those on its deny list.
+	Abuse *abuse.Tracker
+
+	// Reputation, if set, scores clients on the tracking paths, and tags,
+	// rate limits or drops them by score.
+	Reputation *reputation.Checker
+
+	// Transformer rewrites event payloads before they are logged.
+	Transformer *transform.Transformer
+
+	// Enricher, if set, joins lookup table rows into event payloads after
+	// they're transformed.
+	Enricher *enrich.Enricher
+
+	// Fingerprinter, if set, sets a fingerprint of the request on its events.
+	Fingerprinter *Fingerprinter
+
+	// Capture selects the request headers set on its events.
+	Capture CaptureConfig
+
+	// TimeBuckets selects the buckets of the time events are received set
+	// on them.
+	TimeBuckets TimeBucketsConfig
+
+	// Dimensions bounds the distinct values of stats named after client
+	// input, like requests.hosts.<host>.
+	Dimensions *metrics.CardinalityLimiter
+
+	// Clock, if set, fails the healthcheck while the clock is skewed.
+	Clock *clockguard.Guard
+
+	// TenantHosts maps the lowercased TLS server names of tenants' tracking
+	// domains to the tenants, whose events are written to their own sinks.
+	TenantHosts map[string]string
+
+	// Naming, if set, checks event names against a naming convention.
+	Naming *NamingPolicy
+
+	// KillSwitch, if set, drops blocked events, answering them with success.
+	KillSwitch *killswitch.Switch
+
+	// Rollup, if set, counts counter-style events instead of logging each
+	// one; LogSummary logs the summaries it emits.
+	Rollup *rollup.Rollup
+
+	// Volume, if set, watches the volume of accepted events for anomalies.
+	Volume *anomaly.Detector
+
+	// TopK, if set, tracks the heaviest event names, origins and client IP
+	// prefixes of accepted events.
+	TopK *topk.Tracker
+
+	// OriginLearner, if set, counts the origins a CORS allowlist being
+	// considered would reject.
+	OriginLearner *OriginLearner
+
+	// Accounting, if set, counts the events and bytes each tenant sends of
+	// each event name, for chargeback.
+	Accounting *accounting.Accountant
+
+	// Heartbeat, if set, counts accepted events for the heartbeats it logs.
+	Heartbeat *Heartbeater
+
+	// EventTap, if set, keeps accepted events for QA to read back.
+	EventTap *eventtap.Tap
+
+	// Pixel, if set, detects pixel requests that a CDN could have cached.
+	Pixel *PixelPolicy
+
+	// Splits accounts for the outcomes of split large requests.
+	Splits *SplitRecorder
+
+	// Multipart caps the multipart/form-data bodies that are parsed.
+	Multipart MultipartConfig
+
+	// Aborts decides what happens to the events of requests whose client
+	// disconnects.
+	Aborts AbortConfig
+
+	// StrictContentTypes rejects POSTs whose Content-Type isn't supported
+	// with a 415, instead of reading their body as the event data.
+	StrictContentTypes bool
+
+	// MaxRequestBytes, if set, caps the size of the bodies of requests to the
+	// tracking paths.
+	MaxRequestBytes int64
+
+	// MaxURIBytes, if set, rejects requests to the tracking paths with longer
+	// URIs with a 414.
+	MaxURIBytes int
+
+	// MaxDataValues is the most data values of a request that are logged,
+	// each as its own event. If it's 1 or less, only the first is logged.
+	MaxDataValues int
 }
 
 // NewSpadeHandler returns a new instance of SpadeHandler
@@ -120,19 +393,46 @@ func NewSpadeHandler(stats statsd.StatSender, loggers *EdgeLoggers, instanceID s
 		Time:                   time.Now,
 		EdgeType:               edgeType,
 		instanceID:             instanceID,
-		corsOriginMatchers:     []glob.Glob{},
-		crossDomainPolicy:      []byte(crossDomainPolicy),
+		corsOriginMatchers:     compileOrigins(CORSOrigins),
+		crossDomainPolicy:      newStaticContent([]byte(crossDomainPolicy), time.Now()),
+		robotsTxt:              newStaticContent(DefaultRobotsTxt, time.Now()),
 		eventInURISamplingRate: eventInURISamplingRate,
+		responseHeaders:        ResponseHeaders(nil).compile(),
+		trackingPaths:          newTrackingMatcher(DefaultTrackingPaths),
 		handleLargeEvents:      handleLargeEvents,
+		Features:               features.NewSet(nil),
+		Transformer:            &transform.Transformer{},
+		Splits:                 NewSplitRecorder(stats),
 	}
+	_ = h.Multipart.Validate() // fills in the default caps
+	h.Dimensions, _ = metrics.NewCardinalityLimiter(metrics.CardinalityConfig{})
+	return h
+}
 
+func compileOrigins(CORSOrigins []string) []glob.Glob {
+	matchers := []glob.Glob{}
 	for _, origin := range CORSOrigins {
 		trimmedOrigin := strings.TrimSpace(origin)
 		if trimmedOrigin != "" {
-			h.corsOriginMatchers = append(h.corsOriginMatchers, glob.MustCompile(trimmedOrigin))
+			matchers = append(matchers, glob.MustCompile(trimmedOrigin))
 		}
 	}
-	return h
+	return matchers
+}
+
+// SetCORSOrigins replaces the origin patterns accepted for CORS requests.
+func (s *SpadeHandler) SetCORSOrigins(CORSOrigins []string) {
+	matchers := compileOrigins(CORSOrigins)
+	s.settingsMu.Lock()
+	s.corsOriginMatchers = matchers
+	s.settingsMu.Unlock()
+}
+
+// SetEventInURISamplingRate replaces the sample rate of the event_in_URI stat.
+func (s *SpadeHandler) SetEventInURISamplingRate(rate float32) {
+	s.settingsMu.Lock()
+	s.eventInURISamplingRate = rate
+	s.settingsMu.Unlock()
 }
 
 func parseLastForwarder(header string) net.IP {
@@ -150,7 +450,6 @@ func parseLastForwarder(header string) net.IP {
 const (
 	ipForwardHeader      = "X-Forwarded-For"
 	badEndpoint          = "FourOhFour"
-	nTimers              = 5
 	maxBytesPerRequest   = 500 * 1024
 	largeBodyErrorString = "http: request body too large" // Magic error string from the http pkg
 	maxUserAgentBytes    = 1024
@@ -158,11 +457,25 @@ const (
 
 var allowedMethods = map[string]bool{
 	"GET":     true,
+	"HEAD":    true,
 	"POST":    true,
 	"OPTIONS": true,
 }
 var allowedMethodsHeader string // Comma-separated version of allowedMethods
 
+// varyOrigin is shared by every response to save allocating it per request.
+var varyOrigin = []string{"Origin"}
+
+// statusStats holds the status_code stat names of the statuses the handler
+// returns, so they aren't formatted for every request.
+var statusStats = map[int]string{}
+
+var contextPool = sync.Pool{
+	New: func() interface{} {
+		return &RequestContext{}
+	},
+}
+
 func (s *SpadeHandler) logLargeRequestError(r *http.Request, data string) {
 	_ = s.StatLogger.Inc("large_request", 1, 0.1)
 	head := truncate(data, 100)
@@ -175,6 +488,7 @@ func (s *SpadeHandler) logLargeRequestError(r *http.Request, data string) {
 
 func (s *SpadeHandler) logLargeUserAgentError(r *http.Request, data string) {
 	_ = s.StatLogger.Inc("large_user_agent", 1, 0.1)
+	s.observeRunRate(runrate.TruncatedUserAgent)
 	head := truncate(data, 100)
 	userAgent := truncate(r.Header.Get("User-Agent"), 100)
 	logger.WithField("user_agent", userAgent).
@@ -182,6 +496,13 @@ func (s *SpadeHandler) logLargeUserAgentError(r *http.Request, data string) {
 		Warn(fmt.Sprintf("User agent larger than %d bytes, dropping.", maxUserAgentBytes))
 }
 
+// observeRunRate counts behavior in the RunRate store, if there is one.
+func (s *SpadeHandler) observeRunRate(behavior string) {
+	if s.RunRate != nil {
+		s.RunRate.Observe(behavior)
+	}
+}
+
 func truncate(s string, max int) string {
 	if len(s) > max {
 		return s[:max]
@@ -194,7 +515,10 @@ func sanitizeHostValue(host string) string {
 	if host == "" {
 		return ""
 	}
-	hostWithoutPort := strings.Split(strings.ToLower(strings.TrimSpace(host)), ":")[0]
+	hostWithoutPort := strings.ToLower(strings.TrimSpace(host))
+	if i := strings.IndexByte(hostWithoutPort, ':'); i >= 0 {
+		hostWithoutPort = hostWithoutPort[:i]
+	}
 	return strings.Replace(hostWithoutPort, ".", "_", -1)
 }
 
@@ -203,64 +527,74 @@ func (s *SpadeHandler) ExtractEvent(r *http.Request, values url.Values, context
 	xForwardedFor := r.Header.Get(context.IPHeader)
 	clientIP := parseLastForwarder(xForwardedFor)
 
-	context.Timers["ip"] = statTimer.StopTiming()
+	context.Timers[TimerIP] = statTimer.StopTiming()
+
+	var kind string
+	if r.Method == "POST" {
+		var statusCode int
+		if kind, statusCode = s.checkContentType(r); statusCode != 0 {
+			context.reject(RejectBadContentType)
+			return nil, statusCode
+		}
+	}
 
 	err := r.ParseForm()
 	if err != nil {
+		if aborted(r) {
+			return nil, statusClientClosedRequest
+		}
 		if err.Error() == largeBodyErrorString {
 			s.logLargeRequestError(r, "")
+			context.reject(RejectTooLarge)
 			return nil, http.StatusRequestEntityTooLarge
 		}
 		_ = s.StatLogger.Inc("bad_request.parse_form", 1, 0.01)
+		context.reject(RejectBadForm)
 		return nil, http.StatusBadRequest
 	}
+	if kind == contentTypeMultipart {
+		if statusCode := s.parseMultipart(r); statusCode != 0 {
+			if statusCode == http.StatusRequestEntityTooLarge {
+				context.reject(RejectTooLarge)
+			} else {
+				context.reject(RejectBadMultipart)
+			}
+			return nil, statusCode
+		}
+	}
 
 	if _, ok := values["data"]; ok {
-		_ = s.StatLogger.Inc("event_in_URI", 1, s.eventInURISamplingRate)
+		s.settingsMu.RLock()
+		rate := s.eventInURISamplingRate
+		s.settingsMu.RUnlock()
+		_ = s.StatLogger.Inc("event_in_URI", 1, rate)
+		s.observeRunRate(runrate.EventInURI)
 	}
 
-	if len(r.RequestURI) > 8192 {
-		_ = s.StatLogger.Inc("large_URI", 1, 1)
+	if host := sanitizeHostValue(r.Host); len(host) > 0 {
+		_ = s.StatLogger.Inc("requests.hosts."+s.Dimensions.Limit("requests.hosts", host), 1, hostSamplingRate)
 	}
+	s.identifySDK(r, context)
 
-	if host := sanitizeHostValue(r.Host); len(host) > 0 {
-		_ = s.StatLogger.Inc(fmt.Sprintf("requests.hosts.%s", host), 1, hostSamplingRate)
+	if statusCode := s.checkURILength(r, context); statusCode != 0 {
+		return nil, statusCode
 	}
 
 	data := r.Form.Get("data")
 	if data == "" && r.Method == "POST" {
-		// if we're here then our clients have POSTed us something weird,
-		// for example, something that maybe
-		// application/x-www-form-urlencoded but with the Content-Type
-		// header set incorrectly... best effort here on out
-
-		var b []byte
-		b, err = ioutil.ReadAll(r.Body)
-		if err != nil {
-			if err.Error() == largeBodyErrorString {
-				s.logLargeRequestError(r, string(b))
-				return nil, http.StatusRequestEntityTooLarge
-			}
-			if strings.HasSuffix(err.Error(), "i/o timeout") {
-				_ = s.StatLogger.Inc("bad_request.read_timeout", 1, 0.01)
-				// Temporary hack to mimic old 502 behavior on timeouts.
-				// We really should return StatusRequestTimeout
-				return nil, http.StatusBadGateway
-			}
-			_ = s.StatLogger.Inc("bad_request.read_data", 1, 0.01)
-			return nil, http.StatusBadRequest
-		}
-		if bytes.Equal(b[:5], dataFlag) {
-			context.BadClient = true
-			b = b[5:]
+		var statusCode int
+		if data, statusCode = s.readBody(r, kind, context); statusCode != 0 {
+			return nil, statusCode
 		}
-		data = string(b)
-
 	}
 	if data == "" {
 		_ = s.StatLogger.Inc("bad_request.empty", 1, 0.01)
+		context.reject(RejectEmptyData)
 		return nil, http.StatusBadRequest
 	}
+	if s.dropAborted(r) {
+		return nil, statusClientClosedRequest
+	}
 
 	var userAgent string
 	if values.Get("ua") == "1" {
@@ -272,85 +606,306 @@ func (s *SpadeHandler) ExtractEvent(r *http.Request, values url.Values, context
 		}
 	}
 
-	context.Timers["data"] = statTimer.StopTiming()
-	bData := []byte(data)
-	if len(bData) > maxBytesPerRequest {
-		if !s.handleLargeEvents {
-			return nil, http.StatusRequestEntityTooLarge
+	if dataValues := r.Form["data"]; len(dataValues) > 1 {
+		if s.MaxDataValues > 1 {
+			context.Timers[TimerData] = statTimer.StopTiming()
+			return nil, s.logDataValues(r, values, dataValues, context, clientIP, xForwardedFor, userAgent, statTimer)
 		}
-		_ = s.StatLogger.Inc("split_large_request.request.total", 1, 0.1)
-		var n int
-		encoding := spade.DetermineBase64Encoding(bData)
-		// We dont have to allocate a new byte array here because the len(dst) < len(src)
-		n, err = encoding.Decode(bData, bData)
-		if err != nil {
-			if cie, ok := err.(base64.CorruptInputError); ok && int(cie) < len(bData) {
-				err = fmt.Errorf("%s: %d", err.Error(), bData[cie])
-			}
-			logger.WithError(err).Warn("Error base64-decoding large request")
-			s.logLargeRequestError(r, data)
-			_ = s.StatLogger.Inc("split_large_request.request.fail.base64", 1, 0.1)
-			return nil, http.StatusRequestEntityTooLarge
+		_ = s.StatLogger.Inc("multi_data.ignored", 1, 0.1)
+	}
+
+	data = s.annotate(r, context, clientIP, s.transform(data))
+	if s.KillSwitch != nil {
+		data = s.KillSwitch.Filter(data)
+	}
+	var ok bool
+	if data, ok = s.checkNames(data); !ok {
+		context.reject(RejectBadEventName)
+		return nil, http.StatusBadRequest
+	}
+	// Rolled up events are only logged later, so they can't be acked.
+	if data != "" && s.Rollup != nil && !context.SyncAck {
+		data = s.Rollup.Absorb(data)
+	}
+	if data == "" {
+		context.Timers[TimerData] = statTimer.StopTiming()
+		if shouldWritePixel(values) {
+			return nil, http.StatusOK
 		}
+		return nil, http.StatusNoContent
+	}
 
-		if n < 1 || !bytes.Equal(bData[:2], []byte("[{")) {
-			logger.Warn("Unexpectd bytes in large event")
-			s.logLargeRequestError(r, data)
-			_ = s.StatLogger.Inc("split_large_request.request.fail.json", 1, 0.1)
+	context.Timers[TimerData] = statTimer.StopTiming()
+	if len(data) > maxBytesPerRequest {
+		if !s.featureEnabled(features.HandleLargeEvents, clientIP, s.handleLargeEvents) {
+			context.reject(RejectTooLarge)
 			return nil, http.StatusRequestEntityTooLarge
 		}
-		var events []json.RawMessage
-		err = json.Unmarshal(bData[:n], &events)
-		if err != nil {
-			logger.WithError(err).Warn("Error unmarshaling large request into JSON")
-			_ = s.StatLogger.Inc("split_large_request.request.fail.json", 1, 0.1)
-			s.logLargeRequestError(r, data)
-			return nil, http.StatusRequestEntityTooLarge
+		return nil, s.splitLargeRequest(r, data, context, clientIP, xForwardedFor, userAgent, statTimer)
+	}
+	event := s.buildEvent(data, context, clientIP, xForwardedFor, userAgent)
+	if shouldWritePixel(values) {
+		return event, http.StatusOK
+	}
+	return event, http.StatusNoContent
+
+}
+
+// readBody returns the data in the body of a POST without a data form value,
+// or the status code to respond with if it can't be read.
+func (s *SpadeHandler) readBody(r *http.Request, kind string, context *RequestContext) (string, int) {
+	// if we're here then our clients have POSTed us something weird,
+	// for example, something that maybe
+	// application/x-www-form-urlencoded but with the Content-Type
+	// header set incorrectly... best effort here on out
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		if aborted(r) {
+			return "", statusClientClosedRequest
 		}
-		defer func() {
-			context.Timers["write"] = statTimer.StopTiming()
-		}()
-		statusCode := http.StatusNoContent
-		var successCount, failCount int64
-		for _, event := range events {
-			encEvent := base64.StdEncoding.EncodeToString(event)
-			bEvent := []byte(encEvent)
-			if len(bEvent) > maxBytesPerRequest {
-				s.logLargeRequestError(r, encEvent)
-				statusCode = http.StatusRequestEntityTooLarge
-			}
-			event := s.buildEvent(encEvent, context, clientIP, xForwardedFor, userAgent)
-			err = s.EdgeLoggers.log(event, context)
-			if err != nil {
-				logger.WithError(err).Warn("Error writing to logger")
-				failCount++
-			} else {
-				successCount++
-			}
+		if err.Error() == largeBodyErrorString {
+			s.logLargeRequestError(r, string(b))
+			context.reject(RejectTooLarge)
+			return "", http.StatusRequestEntityTooLarge
+		}
+		if strings.HasSuffix(err.Error(), "i/o timeout") {
+			_ = s.StatLogger.Inc("bad_request.read_timeout", 1, 0.01)
+			// Temporary hack to mimic old 502 behavior on timeouts.
+			// We really should return StatusRequestTimeout
+			return "", http.StatusBadGateway
 		}
-		if failCount != 0 {
-			_ = s.StatLogger.Inc("split_large_request.event.fail", failCount, 0.1)
-			_ = s.StatLogger.Inc("split_large_request.request.fail.partial", 1, 0.1)
-		} else if failCount == 0 {
-			_ = s.StatLogger.Inc("split_large_request.request.success", 1, 0.1)
+		_ = s.StatLogger.Inc("bad_request.read_data", 1, 0.01)
+		context.reject(RejectReadFailed)
+		return "", http.StatusBadRequest
+	}
+	if bytes.Equal(b[:5], dataFlag) {
+		context.BadClient = true
+		s.observeRunRate(runrate.BadClient)
+		if s.checkBadClient(r, context) {
+			context.reject(RejectBadClient)
+			return "", http.StatusBadRequest
 		}
-		_ = s.StatLogger.Inc("split_large_request.request.success", 1, 0.1)
-		_ = s.StatLogger.Inc("split_large_request.event.total", int64(len(events)), 0.1)
-		_ = s.StatLogger.Inc("split_large_request.event.success", successCount, 0.1)
+		b = b[5:]
+	}
+	// A JSON body is the event itself rather than its base64 encoding,
+	// which can't start with either character.
+	if kind == contentTypeJSON && len(b) > 0 && (b[0] == '{' || b[0] == '[') {
+		return base64.StdEncoding.EncodeToString(b), 0
+	}
+	return string(b), 0
+}
 
-		// If we only failed to write some, indicate success so we don't duplicate.
-		if successCount == 0 {
-			_ = s.StatLogger.Inc("split_large_request.request.fail.write", 1, 0.1)
-			return nil, http.StatusInternalServerError
+// logDataValues logs each of a request's data values as its own event, and
+// returns the status code to respond with. Empty values are skipped, values
+// past MaxDataValues are dropped, values too large to be an event are
+// rejected rather than split, and so are values with event names the naming
+// policy rejects.
+func (s *SpadeHandler) logDataValues(r *http.Request, values url.Values, dataValues []string,
+	context *RequestContext, clientIP net.IP, xForwardedFor, userAgent string, statTimer *TimerInstance) int {
+	defer func() {
+		context.Timers[TimerWrite] = statTimer.StopTiming()
+	}()
+	_ = s.StatLogger.Inc("multi_data.request", 1, 0.1)
+
+	var total, dropped, handled, tooLarge, badName, failed int64
+	var cancelled bool
+	for _, data := range dataValues {
+		if data == "" {
+			continue
 		}
-		return nil, statusCode
+		if total == int64(s.MaxDataValues) {
+			dropped++
+			continue
+		}
+		if s.cancelWrites(r) {
+			cancelled = true
+			break
+		}
+		total++
+		data = s.annotate(r, context, clientIP, s.transform(data))
+		if s.KillSwitch != nil {
+			data = s.KillSwitch.Filter(data)
+		}
+		var ok bool
+		if data, ok = s.checkNames(data); !ok {
+			badName++
+			continue
+		}
+		if data != "" && s.Rollup != nil {
+			data = s.Rollup.Absorb(data)
+		}
+		if data == "" {
+			handled++
+			continue
+		}
+		if len(data) > maxBytesPerRequest {
+			s.logLargeRequestError(r, data)
+			tooLarge++
+			continue
+		}
+		event := s.buildEvent(data, context, clientIP, xForwardedFor, userAgent)
+		if err := s.logEvent(r, event, context); err != nil {
+			logger.WithError(err).Warn("Error writing to logger")
+			failed++
+			continue
+		}
+		handled++
+	}
+	_ = s.StatLogger.Inc("multi_data.value.total", total, 0.1)
+	_ = s.StatLogger.Inc("multi_data.value.dropped", dropped, 0.1)
+	_ = s.StatLogger.Inc("multi_data.value.success", handled, 0.1)
+	_ = s.StatLogger.Inc("multi_data.value.too_large", tooLarge, 0.1)
+	_ = s.StatLogger.Inc("multi_data.value.bad_name", badName, 0.1)
+	_ = s.StatLogger.Inc("multi_data.value.fail", failed, 0.1)
+	if cancelled {
+		return statusClientClosedRequest
+	}
+
+	// As with split requests, succeed if any value was logged so the client
+	// doesn't resend the ones that were.
+	switch {
+	case handled > 0:
+	case failed > 0:
+		return http.StatusInternalServerError
+	case tooLarge > 0:
+		context.reject(RejectTooLarge)
+		return http.StatusRequestEntityTooLarge
+	case badName > 0:
+		context.reject(RejectBadEventName)
+		return http.StatusBadRequest
+	default:
+		_ = s.StatLogger.Inc("bad_request.empty", 1, 0.01)
+		context.reject(RejectEmptyData)
+		return http.StatusBadRequest
 	}
-	event := s.buildEvent(data, context, clientIP, xForwardedFor, userAgent)
 	if shouldWritePixel(values) {
-		return event, http.StatusOK
+		return http.StatusOK
 	}
-	return event, http.StatusNoContent
+	return http.StatusNoContent
+}
 
+// errNotObjects is returned when a large request isn't an array of objects.
+var errNotObjects = errors.New("large request isn't an array of objects")
+
+// base64Encoding is spade.DetermineBase64Encoding for a string, so data
+// doesn't have to be copied to pick its encoding.
+func base64Encoding(data string) *base64.Encoding {
+	index := strings.IndexAny(data, "-_ ")
+	if index == -1 {
+		return base64.StdEncoding
+	}
+	if data[index] == ' ' {
+		return spade.SpaceEncoding
+	}
+	return base64.URLEncoding
+}
+
+// splitLargeRequest writes out each event of a request too large to be
+// written as one event, and returns the status code to respond with. The
+// batch is decoded as a stream, one event at a time, so only the event being
+// written is held in memory besides the request itself. Events before a
+// point where the batch turns out to be corrupt have already been written.
+func (s *SpadeHandler) splitLargeRequest(r *http.Request, data string, context *RequestContext,
+	clientIP net.IP, xForwardedFor, userAgent string, statTimer *TimerInstance) int {
+	var outcome SplitOutcome
+	defer func() {
+		s.Splits.Record(outcome)
+		context.Timers[TimerWrite] = statTimer.StopTiming()
+	}()
+
+	decoder := json.NewDecoder(base64.NewDecoder(base64Encoding(data), strings.NewReader(data)))
+	statusCode := http.StatusNoContent
+	tok, err := decoder.Token()
+	if err == nil && (tok != json.Delim('[') || !decoder.More()) {
+		err = errNotObjects
+	}
+	var cancelled bool
+	for err == nil && decoder.More() {
+		if cancelled = s.cancelWrites(r); cancelled {
+			break
+		}
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			break
+		}
+		if outcome.Events == 0 && raw[0] != '{' {
+			err = errNotObjects
+			break
+		}
+		outcome.Events++
+
+		encEvent := base64.StdEncoding.EncodeToString(raw)
+		if len(encEvent) > maxBytesPerRequest {
+			s.logLargeRequestError(r, encEvent)
+			context.reject(RejectTooLarge)
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		event := s.buildEvent(encEvent, context, clientIP, xForwardedFor, userAgent)
+		if logErr := s.logEvent(r, event, context); logErr != nil {
+			logger.WithError(logErr).Warn("Error writing to logger")
+			outcome.Failed++
+		} else {
+			outcome.Written++
+		}
+	}
+	if err == nil && !cancelled {
+		_, err = decoder.Token()
+	}
+
+	switch {
+	case cancelled:
+		outcome.Result = SplitCancelled
+		return statusClientClosedRequest
+	case err != nil:
+		if _, ok := err.(base64.CorruptInputError); ok {
+			logger.WithError(err).Warn("Error base64-decoding large request")
+			outcome.Result = SplitFailBase64
+			context.reject(RejectBadBase64)
+		} else {
+			logger.WithError(err).Warn("Error unmarshaling large request into JSON")
+			outcome.Result = SplitFailJSON
+			context.reject(RejectBadJSON)
+		}
+		s.logLargeRequestError(r, data)
+		return http.StatusRequestEntityTooLarge
+	case outcome.Written == 0:
+		outcome.Result = SplitFailWrite
+		return http.StatusInternalServerError
+	case outcome.Failed > 0:
+		// If we only failed to write some, indicate success so we don't duplicate.
+		outcome.Result = SplitPartial
+	default:
+		outcome.Result = SplitSuccess
+	}
+	return statusCode
+}
+
+// transform applies the handler's Transformer, then its Enricher, to data,
+// returning data as is if it can't be transformed.
+func (s *SpadeHandler) transform(data string) string {
+	transformed, result, err := s.Transformer.Apply(data)
+	switch {
+	case err != nil:
+		_ = s.StatLogger.Inc("transform.error", 1, 0.1)
+	case result.Changed:
+		_ = s.StatLogger.Inc("transform.applied", 1, 0.1)
+	}
+	for _, name := range result.Renamed {
+		name = s.Dimensions.Limit("transform.renamed", strings.Replace(name, ".", "_", -1))
+		_ = s.StatLogger.Inc("transform.renamed."+name, 1, 0.1)
+	}
+	if s.Enricher != nil {
+		transformed = s.Enricher.Apply(transformed)
+	}
+	return transformed
+}
+
+// featureEnabled reports whether the named feature is enabled for the client,
+// using def if the feature isn't flagged.
+func (s *SpadeHandler) featureEnabled(name string, clientIP net.IP, def bool) bool {
+	enabled := s.Features.Enabled(name, clientIP, def)
+	_ = s.StatLogger.Inc("features."+name+"."+strconv.FormatBool(enabled), 1, 0.1)
+	return enabled
 }
 
 func (s *SpadeHandler) handleSpadeRequests(r *http.Request, values url.Values, context *RequestContext) int {
@@ -359,9 +914,9 @@ func (s *SpadeHandler) handleSpadeRequests(r *http.Request, values url.Values, c
 
 	if event != nil {
 		defer func() {
-			context.Timers["write"] = statTimer.StopTiming()
+			context.Timers[TimerWrite] = statTimer.StopTiming()
 		}()
-		err := s.EdgeLoggers.log(event, context)
+		err := s.logEvent(r, event, context)
 		if err != nil {
 			logger.WithError(err).Warn("Error writing to logger")
 			return http.StatusInternalServerError
@@ -370,24 +925,94 @@ func (s *SpadeHandler) handleSpadeRequests(r *http.Request, values url.Values, c
 	return statusCode
 }
 
+// logEvent logs an event received from a client in r and counts it as
+// accepted.
+func (s *SpadeHandler) logEvent(r *http.Request, event *spade.Event, context *RequestContext) error {
+	var err error
+	if context.SyncAck {
+		err = s.logAcked(event, context)
+	} else {
+		err = s.EdgeLoggers.log(event, context)
+	}
+	if err != nil {
+		return err
+	}
+	if s.Volume != nil {
+		s.Volume.Observe(event.Data)
+	}
+	if s.TopK != nil {
+		s.TopK.Observe(event.Data, r.Header.Get("Origin"), event.ClientIp)
+	}
+	if s.Accounting != nil {
+		tenant := context.Subject
+		if header := s.Accounting.TenantHeader(); tenant == "" && header != "" {
+			tenant = r.Header.Get(header)
+		}
+		s.Accounting.Observe(tenant, event.Data)
+	}
+	if s.Heartbeat != nil {
+		s.Heartbeat.Observe(event.Data)
+	}
+	if s.EventTap != nil {
+		s.EventTap.Observe(event)
+	}
+	return nil
+}
+
+// LogSummary logs an event the edge generated itself, like a rollup summary,
+// with no client IP or User-Agent.
+func (s *SpadeHandler) LogSummary(data string) error {
+	context := &RequestContext{Now: s.Time()}
+	return s.EdgeLoggers.log(s.buildEvent(data, context, nil, "", ""), context)
+}
+
 func (s *SpadeHandler) buildEvent(data string, context *RequestContext, clientIP net.IP,
 	xForwardedFor string, userAgent string) *spade.Event {
 	count := atomic.AddUint64(&s.eventCount, 1)
-	uuid := fmt.Sprintf("%s-%08x-%08x", s.instanceID, context.Now.Unix(), count)
 
+	var buf [64]byte
+	uuid := s.appendUUID(buf[:0], context.Now, count)
+	if s.EdgeLoggers.UserAgents != nil {
+		userAgent = s.EdgeLoggers.UserAgents.Replace(userAgent)
+	}
+
+	// The Event itself isn't pooled: the Kinesis logger holds on to events
+	// until its batch is flushed, long after Log returns.
 	return spade.NewEvent(
 		context.Now,
 		clientIP,
 		xForwardedFor,
-		uuid,
+		string(uuid),
 		data,
 		userAgent,
 		s.EdgeType,
 	)
 }
 
+// appendUUID appends the uuid of the count-th event received at now,
+// "<instanceID>-%08x-%08x" of the time and count. It's built without fmt
+// since it runs for every event.
+func (s *SpadeHandler) appendUUID(b []byte, now time.Time, count uint64) []byte {
+	b = append(b, s.instanceID...)
+	b = appendHex8(append(b, '-'), uint64(now.Unix()))
+	return appendHex8(append(b, '-'), count)
+}
+
+// appendHex8 appends n in hex, zero-padded to at least 8 digits.
+func appendHex8(b []byte, n uint64) []byte {
+	var digits [16]byte
+	hex := strconv.AppendUint(digits[:0], n, 16)
+	for i := len(hex); i < 8; i++ {
+		b = append(b, '0')
+	}
+	return append(b, hex...)
+}
+
 func (s *SpadeHandler) isAcceptableOrigin(origin string) bool {
-	for _, matcher := range s.corsOriginMatchers {
+	s.settingsMu.RLock()
+	matchers := s.corsOriginMatchers
+	s.settingsMu.RUnlock()
+	for _, matcher := range matchers {
 		if matcher.Match(origin) {
 			return true
 		}
@@ -401,13 +1026,10 @@ func (s *SpadeHandler) RequestSetup(w http.ResponseWriter, r *http.Request) *Req
 		w.WriteHeader(http.StatusBadRequest)
 		return nil
 	}
-	w.Header().Set("Vary", "Origin")
+	s.writeResponseHeaders(w, r.URL.Path)
+	w.Header()["Vary"] = varyOrigin
 
-	origin := r.Header.Get("Origin")
-	if s.isAcceptableOrigin(origin) {
-		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", allowedMethodsHeader)
-	}
+	s.writeCORSHeaders(w, r)
 
 	if r.Method == "OPTIONS" {
 		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
@@ -415,14 +1037,26 @@ func (s *SpadeHandler) RequestSetup(w http.ResponseWriter, r *http.Request) *Req
 		return nil
 	}
 
-	return &RequestContext{
-		Now:       s.Time(),
-		Method:    r.Method,
-		Endpoint:  r.URL.Path,
-		IPHeader:  ipForwardHeader,
-		Timers:    make(map[string]time.Duration, nTimers),
-		BadClient: false,
+	context := contextPool.Get().(*RequestContext)
+	context.reset()
+	context.Now = s.Time()
+	context.Method = r.Method
+	context.Endpoint = r.URL.Path
+	context.IPHeader = ipForwardHeader
+	if r.TLS != nil && s.TenantHosts != nil {
+		context.Tenant = s.TenantHosts[strings.ToLower(r.TLS.ServerName)]
 	}
+	// Behind a load balancer speaking the PROXY protocol, the edge is the hop
+	// that forwards for the client.
+	if addr := proxyproto.FromContext(r.Context()); addr != nil {
+		context.ProxiedFor = addr.IP
+		forwarders := r.Header.Get(ipForwardHeader)
+		if forwarders != "" {
+			forwarders += ", "
+		}
+		r.Header.Set(ipForwardHeader, forwarders+addr.IP.String())
+	}
+	return context
 }
 
 // ServeHTTP services an HTTP request.
@@ -433,48 +1067,80 @@ func (s *SpadeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	timer := NewTimerInstance()
 	status := s.serve(w, r, context)
-	_ = s.StatLogger.Inc(fmt.Sprintf("status_code.%d", status), 1, 0.001)
+	if aborted(r) {
+		_ = s.StatLogger.Inc("client_aborted", 1, 0.1)
+	}
+	statusStat, ok := statusStats[status]
+	if !ok {
+		statusStat = "status_code." + strconv.Itoa(status)
+	}
+	_ = s.StatLogger.Inc(statusStat, 1, 0.001)
 	context.Status = status
-	context.Timers["http"] = timer.StopTiming()
+	context.Timers[TimerHTTP] = timer.StopTiming()
 
 	context.RecordStats(s.StatLogger)
+	contextPool.Put(context)
 }
 
 // WriteCrossDomainPolicy writes the handler's cross-domain policy to the writer.
-func (s *SpadeHandler) WriteCrossDomainPolicy(w http.ResponseWriter) int {
-	w.Header().Add("Content-Type", xmlApplicationType)
-	_, err := w.Write(s.crossDomainPolicy)
-	if err != nil {
-		logger.WithError(err).Error("Unable to write crossdomain.xml contents")
-		return http.StatusInternalServerError
-	}
-	return http.StatusOK
+func (s *SpadeHandler) WriteCrossDomainPolicy(w http.ResponseWriter, r *http.Request) int {
+	s.settingsMu.RLock()
+	content := s.crossDomainPolicy
+	s.settingsMu.RUnlock()
+	return writeStatic(w, r, content, xmlApplicationType)
 }
 
 // WriteRobotsTxt writes the handler's robot policy to the writer.
-func (s *SpadeHandler) WriteRobotsTxt(w http.ResponseWriter) int {
-	w.Header().Add("Content-Type", "text/plain")
-	_, err := w.Write([]byte("User-agent: *\nDisallow: /"))
-	if err != nil {
-		logger.WithError(err).Error("Unable to write robots.txt contents")
-		return http.StatusInternalServerError
-	}
-	return http.StatusOK
+func (s *SpadeHandler) WriteRobotsTxt(w http.ResponseWriter, r *http.Request) int {
+	s.settingsMu.RLock()
+	content := s.robotsTxt
+	s.settingsMu.RUnlock()
+	return writeStatic(w, r, content, "text/plain")
 }
 
 func (s *SpadeHandler) serve(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
 	var status int
 	path := r.URL.Path
-	if strings.HasPrefix(path, "/v1/") {
-		path = "/track"
+	if s.Abuse != nil {
+		if status := s.screenClient(r, context); status != http.StatusOK {
+			s.writeStatus(w, r, context, status)
+			return status
+		}
 	}
-	switch path {
+	if status := s.checkLifecycle(w, path, context); status != 0 {
+		s.writeStatus(w, r, context, status)
+		return status
+	}
+	if s.SignatureVerifier != nil && s.SignatureVerifier.Protects(path) {
+		if status := s.verifySignature(r, context); status != http.StatusOK {
+			s.writeStatus(w, r, context, status)
+			return status
+		}
+	}
+	if s.Authenticator != nil && s.Authenticator.Protects(path) {
+		claims, err := s.Authenticator.VerifyRequest(r)
+		if err != nil {
+			_ = s.StatLogger.Inc("auth.jwt.rejected", 1, 1)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			context.reject(RejectUnauthorized)
+			s.writeStatus(w, r, context, http.StatusUnauthorized)
+			return http.StatusUnauthorized
+		}
+		context.Subject = claims.Subject
+	}
+	if fn := s.registeredRoute(path); fn != nil {
+		return fn(w, r, context)
+	}
+	switch s.builtinRoute(path) {
 	case "/crossdomain.xml":
-		return s.WriteCrossDomainPolicy(w)
+		return s.WriteCrossDomainPolicy(w, r)
 	case "/robots.txt":
-		return s.WriteRobotsTxt(w)
+		return s.WriteRobotsTxt(w, r)
 	case "/healthcheck":
 		status = http.StatusOK
+		if s.Clock != nil && !s.Clock.Ready() {
+			status = http.StatusServiceUnavailable
+		}
 	case "/xarth":
 		_, err := w.Write(xarth)
 		if err != nil {
@@ -482,12 +1148,63 @@ func (s *SpadeHandler) serve(w http.ResponseWriter, r *http.Request, context *Re
 			return http.StatusInternalServerError
 		}
 		return http.StatusOK
-	// Accepted tracking endpoints.
-	case "/", "/track", "/track/":
+	case RelayPath:
+		return s.handleRelay(w, r, context)
+	case "/r":
+		if r.Method == "HEAD" {
+			// Following a click redirect logs an event, so only GET may.
+			w.Header().Set("Allow", "GET")
+			context.reject(RejectBadMethod)
+			status = http.StatusMethodNotAllowed
+			break
+		}
+		status = s.handleRedirect(w, r, context)
+		if status == http.StatusFound {
+			return status
+		}
+	// Accepted tracking endpoints, see SetTrackingPaths.
+	case trackingRoute:
+		if s.MaxRequestBytes > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, s.MaxRequestBytes)
+		}
 		values := r.URL.Query()
+		if r.Method == "HEAD" {
+			// Monitoring and CDNs probe with HEAD; answer with the headers
+			// of a successful request without logging an event.
+			if shouldWritePixel(values) {
+				writePixelHeaders(w)
+				status = http.StatusOK
+			} else {
+				status = http.StatusNoContent
+			}
+			break
+		}
+		if s.Reputation != nil {
+			if status, answered := s.checkReputation(w, r, values, context); answered {
+				return status
+			}
+		}
+		if s.Pixel != nil && shouldWritePixel(values) && s.checkPixel(w, r, values) {
+			return http.StatusFound
+		}
+		if r.Header.Get(AckHeader) == ackSync {
+			if s.EdgeLoggers.acker(context.Tenant) == nil {
+				context.reject(RejectAckUnavailable)
+				status = http.StatusNotImplemented
+				break
+			}
+			context.SyncAck = true
+		}
 		status = s.handleSpadeRequests(r, values, context)
+		if context.AckSequence != "" {
+			w.Header().Set(AckSequenceHeader, context.AckSequence)
+		}
+		if context.BadClient {
+			s.warnBadClient(w)
+		}
 
-		if shouldWritePixel(values) {
+		// Synchronously acked requests must not look like they succeeded.
+		if shouldWritePixel(values) && !(context.SyncAck && status >= http.StatusBadRequest) {
 			if err := writePixel(w); err != nil {
 				logger.WithError(err).Error("Error writing transparent pixel response")
 				status = http.StatusInternalServerError
@@ -501,25 +1218,98 @@ func (s *SpadeHandler) serve(w http.ResponseWriter, r *http.Request, context *Re
 		context.Endpoint = badEndpoint
 		status = http.StatusNotFound
 	}
-	w.WriteHeader(status)
+	s.writeStatus(w, r, context, status)
 	return status
 }
 
+// screenClient scores the request for abuse, returning the status to answer
+// it with if it comes from a denied client or hits a honeypot, or
+// http.StatusOK. Honeypot hits are never logged as events.
+func (s *SpadeHandler) screenClient(r *http.Request, context *RequestContext) int {
+	ip := parseLastForwarder(r.Header.Get(context.IPHeader))
+	if ip == nil {
+		return http.StatusOK
+	}
+	clientIP := ip.String()
+	if s.Abuse.Denied(clientIP) {
+		_ = s.StatLogger.Inc("abuse.denied", 1, 0.1)
+		context.reject(RejectRateLimited)
+		return http.StatusTooManyRequests
+	}
+	var tlsFingerprints []string
+	if hello := clienthello.FromContext(r.Context()); hello != nil {
+		tlsFingerprints = []string{hello.JA3(), hello.JA4()}
+	}
+	if s.Abuse.Observe(clientIP, r.URL.Path, r.Header.Get("User-Agent"), tlsFingerprints...) {
+		_ = s.StatLogger.Inc("abuse.deny_list.added", 1, 1)
+		logger.WithField("client_ip", clientIP).
+			WithField("user_agent", truncate(r.Header.Get("User-Agent"), 256)).
+			Warn("Client added to abuse deny list")
+	}
+	if s.Abuse.IsHoneypot(r.URL.Path) {
+		_ = s.StatLogger.Inc("abuse.honeypot.hit", 1, 1)
+		context.Endpoint = badEndpoint
+		return http.StatusNotFound
+	}
+	return http.StatusOK
+}
+
+// verifySignature checks the request's HMAC signature, returning the status to
+// reject it with or http.StatusOK.
+func (s *SpadeHandler) verifySignature(r *http.Request, context *RequestContext) int {
+	keyID, err := s.SignatureVerifier.VerifyRequest(r)
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case err == auth.ErrReplayedRequest:
+		_ = s.StatLogger.Inc("auth.hmac.replay_detected", 1, 1)
+		logger.WithField("key_id", keyID).Warn("Replayed signed request")
+	case err == auth.ErrStaleRequest:
+		_ = s.StatLogger.Inc("auth.hmac.stale", 1, 1)
+	case err == auth.ErrNonceCacheFull:
+		_ = s.StatLogger.Inc("auth.hmac.nonce_cache_full", 1, 1)
+		logger.WithField("key_id", keyID).Warn("Nonce cache full, rejecting signed request")
+		context.reject(RejectNonceCacheFull)
+		return http.StatusServiceUnavailable
+	case err.Error() == largeBodyErrorString:
+		s.logLargeRequestError(r, "")
+		context.reject(RejectTooLarge)
+		return http.StatusRequestEntityTooLarge
+	default:
+		_ = s.StatLogger.Inc("auth.hmac.rejected", 1, 1)
+	}
+	context.reject(RejectBadSignature)
+	return http.StatusUnauthorized
+}
+
 func shouldWritePixel(values url.Values) bool {
 	return values.Get("img") == "1"
 }
 
 func writePixel(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "image/gif")
-	w.Header().Set("Cache-Control", "no-cache, max-age=0")
+	writePixelHeaders(w)
 	_, err := w.Write(transparentPixel)
 	return err
 }
 
+func writePixelHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "image/gif")
+	w.Header().Set("Content-Length", strconv.Itoa(len(transparentPixel)))
+	if w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", "no-cache, max-age=0")
+	}
+}
+
 func init() {
 	var allowedMethodsList []string
 	for k := range allowedMethods {
 		allowedMethodsList = append(allowedMethodsList, k)
 	}
 	allowedMethodsHeader = strings.Join(allowedMethodsList, ", ")
+
+	for status := 100; status < 600; status++ {
+		if http.StatusText(status) != "" {
+			statusStats[status] = "status_code." + strconv.Itoa(status)
+		}
+	}
 }
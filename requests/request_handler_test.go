@@ -1,19 +1,46 @@
 package requests
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/cactus/go-statsd-client/statsd"
 	"github.com/cactus/go-statsd-client/statsd/statsdtest"
 	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/abuse"
+	"github.com/twitchscience/spade_edge/auth"
+	"github.com/twitchscience/spade_edge/clienthello"
+	"github.com/twitchscience/spade_edge/clock"
+	"github.com/twitchscience/spade_edge/features"
+	"github.com/twitchscience/spade_edge/killswitch"
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/metrics"
+	"github.com/twitchscience/spade_edge/proxyproto"
+	"github.com/twitchscience/spade_edge/reputation"
+	"github.com/twitchscience/spade_edge/rollup"
+	"github.com/twitchscience/spade_edge/topk"
+	"github.com/twitchscience/spade_edge/wal"
 )
 
 const (
@@ -171,6 +198,485 @@ func TestTooBigRequestSplittableHighCharsBadEncoding(t *testing.T) {
 	}
 }
 
+func TestTooBigRequestSplitCorruptTail(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	for _, tt := range []struct {
+		data   string
+		code   int
+		events int
+	}{
+		{longJSONSplittable, http.StatusNoContent, 70001},
+		// The events before the corruption are written as the batch is decoded.
+		{longJSONSplittable[:len(longJSONSplittable)-8] + "!!!!!!!!", http.StatusRequestEntityTooLarge, 70000},
+		{base64.StdEncoding.EncodeToString([]byte(`[` + strings.Repeat(`"BigData",`, 70000) + `"X"]`)),
+			http.StatusRequestEntityTooLarge, 0},
+	} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		testrecorder := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", "http://spade.example.com/", strings.NewReader("data="+tt.data))
+		if err != nil {
+			t.Fatalf("Failed to build request: %s", err)
+		}
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+
+		if testrecorder.Code != tt.code || len(logger.events) != tt.events {
+			t.Errorf("Expected %d with %d events, got %d with %d", tt.code, tt.events, testrecorder.Code, len(logger.events))
+		}
+	}
+}
+
+// flakyEdgeLogger fails to log every other event.
+type flakyEdgeLogger struct {
+	calls int
+}
+
+func (f *flakyEdgeLogger) Log(e *spade.Event) error {
+	f.calls++
+	if f.calls%2 == 0 {
+		return errors.New("flaky")
+	}
+	return nil
+}
+
+func (f *flakyEdgeLogger) Close() {}
+
+func TestSplitOutcomes(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	for _, tt := range []struct {
+		data     string
+		logger   loggers.SpadeEdgeLogger
+		code     int
+		expected SplitOutcome
+		stats    map[string]string
+	}{
+		{longJSONSplittable, &testEdgeLogger{}, http.StatusNoContent,
+			SplitOutcome{SplitSuccess, 70001, 70001, 0},
+			map[string]string{"request.success": "1", "event.success": "70001"}},
+		{longJSONSplittable, &flakyEdgeLogger{}, http.StatusNoContent,
+			SplitOutcome{SplitPartial, 70001, 35001, 35000},
+			map[string]string{"request.fail.partial": "1", "event.fail": "35000"}},
+		{longJSONSplittable, loggers.UndefinedLogger{}, http.StatusInternalServerError,
+			SplitOutcome{SplitFailWrite, 70001, 0, 70001},
+			map[string]string{"request.fail.write": "1", "event.total": "70001"}},
+		{longJSONUnsplittable, &testEdgeLogger{}, http.StatusRequestEntityTooLarge,
+			SplitOutcome{Result: SplitFailJSON},
+			map[string]string{"request.fail.json": "1"}},
+		{"!" + longJSONSplittable[1:], &testEdgeLogger{}, http.StatusRequestEntityTooLarge,
+			SplitOutcome{Result: SplitFailBase64},
+			map[string]string{"request.fail.base64": "1"}},
+	} {
+		rs.ClearSent()
+		spadeHandler.Splits = NewSplitRecorder(statter)
+		spadeHandler.EdgeLoggers.S3EventLogger = tt.logger
+		testrecorder := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", "http://spade.example.com/", strings.NewReader("data="+tt.data))
+		if err != nil {
+			t.Fatalf("Failed to build request: %s", err)
+		}
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+
+		if testrecorder.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d", tt.expected.Result, tt.code, testrecorder.Code)
+		}
+		totals := spadeHandler.Splits.Totals()
+		got := SplitOutcome{"", totals.Events, totals.EventsWritten, totals.EventsFailed}
+		for result, n := range totals.Requests {
+			if n == 1 {
+				got.Result = result
+			}
+		}
+		if len(totals.Requests) != 1 || got != tt.expected {
+			t.Errorf("Expected outcome %+v, got %+v", tt.expected, totals)
+		}
+
+		sent := make(map[string]string)
+		for _, stat := range rs.GetSent() {
+			if strings.HasPrefix(stat.Stat, "split_large_request.") {
+				sent[strings.TrimPrefix(stat.Stat, "split_large_request.")] = stat.Value
+			}
+		}
+		tt.stats["request.total"] = "1"
+		for stat, value := range tt.stats {
+			if sent[stat] != value {
+				t.Errorf("%s: expected %s to be %s, got %q", tt.expected.Result, stat, value, sent[stat])
+			}
+		}
+		if tt.expected.Result != SplitSuccess && sent["request.success"] != "" {
+			t.Errorf("%s: expected no request.success stat", tt.expected.Result)
+		}
+	}
+}
+
+func TestTooBigRequestSplittableFeatureDisabled(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Features = features.NewSet(map[string]*features.Flag{
+		features.HandleLargeEvents: {Percent: 0},
+	})
+	testrecorder := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		"POST",
+		"http://spade.example.com/",
+		strings.NewReader(fmt.Sprintf("data=%s", longJSONSplittable)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to build request: %s error: %s\n", "/", err)
+	}
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(testrecorder, req)
+
+	if testrecorder.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("%s expected code %d not %d\n", "/", http.StatusRequestEntityTooLarge, testrecorder.Code)
+	}
+}
+
+func TestAuthenticatedEndpointRejectsMissingToken(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	var err error
+	spadeHandler.Authenticator, err = auth.NewJWTVerifier(auth.JWTConfig{
+		Issuer:    "https://issuer.example.com",
+		Audience:  "spade",
+		JWKSURL:   "http://127.0.0.1:0/jwks",
+		Endpoints: []string{"/track"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %s", err)
+	}
+
+	for path, expected := range map[string]int{"/track": http.StatusUnauthorized, "/": http.StatusNoContent} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.example.com"+path, strings.NewReader("data=blah"))
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != expected {
+			t.Errorf("%s expected code %d not %d", path, expected, testrecorder.Code)
+		}
+	}
+}
+
+func TestHoneypotDeniesClient(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	var err error
+	spadeHandler.Abuse, err = abuse.NewTracker(abuse.Config{HoneypotPaths: []string{"/admin"}, ScoreThreshold: 20})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %s", err)
+	}
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	for i, tc := range []struct {
+		path     string
+		expected int
+	}{
+		{"/admin", http.StatusNotFound},
+		{"/", http.StatusNoContent},
+		{"/admin", http.StatusNotFound},
+		{"/", http.StatusTooManyRequests},
+	} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.example.com"+tc.path, strings.NewReader("data=blah"))
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != tc.expected {
+			t.Errorf("request %d to %s expected code %d not %d", i, tc.path, tc.expected, testrecorder.Code)
+		}
+	}
+	if len(logger.events) != 1 {
+		t.Errorf("Expected only the first tracking request to be logged, got %d events", len(logger.events))
+	}
+}
+
+func TestReputation(t *testing.T) {
+	f, err := ioutil.TempFile("", "reputation")
+	if err != nil {
+		t.Fatalf("Failed to create reputation file: %s", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, _ = f.WriteString("111.111.111.111 60\n222.222.222.222 99\n")
+	_ = f.Close()
+
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Reputation, err = reputation.New(reputation.Config{
+		File: f.Name(),
+		Bands: []reputation.Band{
+			{MinScore: 50, Action: reputation.ActionTag},
+			{MinScore: 90, Action: reputation.ActionDrop},
+		},
+	}, s)
+	if err != nil {
+		t.Fatalf("Failed to create reputation checker: %s", err)
+	}
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{}}`))
+
+	for _, ip := range []string{"111.111.111.111", "222.222.222.222", "123.123.123.123"} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req.Header.Add("X-Forwarded-For", ip)
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != http.StatusNoContent {
+			t.Errorf("%s: expected code %d, got %d", ip, http.StatusNoContent, testrecorder.Code)
+		}
+	}
+	if len(logger.events) != 2 {
+		t.Fatalf("Expected the dropped client's event not to be logged, got %d events", len(logger.events))
+	}
+
+	var scores []interface{}
+	for _, b := range logger.events {
+		var e spade.Event
+		if err := spade.Unmarshal(b, &e); err != nil {
+			t.Fatalf("Failed to unmarshal event: %s", err)
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+		var event struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		_ = json.Unmarshal(decoded, &event)
+		scores = append(scores, event.Properties["edge_reputation"])
+	}
+	if scores[0] != 60.0 || scores[1] != nil {
+		t.Errorf("Expected only the tagged client's event to carry its score, got %v", scores)
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.SetResponseHeaders(ResponseHeaders{
+		AllEndpoints:      {"Server": "spade", "Strict-Transport-Security": "max-age=31536000"},
+		TrackingEndpoints: {"Timing-Allow-Origin": "*", "Server": "spade-edge"},
+	})
+
+	for path, expected := range map[string]map[string]string{
+		"/track?img=1&data=blah": {"Server": "spade-edge", "Timing-Allow-Origin": "*",
+			"Strict-Transport-Security": "max-age=31536000", "Content-Type": "image/gif"},
+		"/robots.txt": {"Server": "spade", "Timing-Allow-Origin": "", "Content-Type": "text/plain"},
+	} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.example.com"+path, nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		for name, value := range expected {
+			if actual := testrecorder.Header().Get(name); actual != value {
+				t.Errorf("%s expected header %s to be %q, got %q", path, name, value, actual)
+			}
+		}
+	}
+
+	if err := (ResponseHeaders{"unknown": {"Server": "spade"}}).Validate(); err == nil {
+		t.Error("Expected unknown endpoint group to be rejected")
+	}
+}
+
+func TestStaticContentCaching(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+
+	get := func(path string, header, value string) *httptest.ResponseRecorder {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.example.com"+path, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		spadeHandler.ServeHTTP(testrecorder, req)
+		return testrecorder
+	}
+
+	first := get("/crossdomain.xml", "", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") == "" {
+		t.Fatalf("Expected 200 with validators, got %d %v", first.Code, first.Header())
+	}
+	if r := get("/crossdomain.xml", "If-None-Match", etag); r.Code != http.StatusNotModified || r.Body.Len() != 0 {
+		t.Errorf("Expected 304 for matching ETag, got %d", r.Code)
+	}
+	if r := get("/crossdomain.xml", "If-Modified-Since", first.Header().Get("Last-Modified")); r.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for If-Modified-Since, got %d", r.Code)
+	}
+
+	spadeHandler.SetCrossDomainPolicy([]byte("newPolicy"))
+	r := get("/crossdomain.xml", "If-None-Match", etag)
+	if r.Code != http.StatusOK || r.Body.String() != "newPolicy" {
+		t.Errorf("Expected new policy after replacing it, got %d %q", r.Code, r.Body.String())
+	}
+
+	spadeHandler.SetRobotsTxt([]byte("User-agent: *\nAllow: /"))
+	if r := get("/robots.txt", "", ""); r.Body.String() != "User-agent: *\nAllow: /" {
+		t.Errorf("Expected new robots.txt, got %q", r.Body.String())
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.SetRedirectHosts([]string{"www.twitch.tv", "*.Twitch.tv"})
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	for dest, expected := range map[string]int{
+		"https://www.twitch.tv/directory":      http.StatusFound,
+		"https://blog.TWITCH.tv/":              http.StatusFound,
+		"https://evil.example.com/":            http.StatusBadRequest,
+		"https://www.twitch.tv.evil.com/":      http.StatusBadRequest,
+		"//www.twitch.tv/":                     http.StatusBadRequest,
+		"javascript:alert(1)":                  http.StatusBadRequest,
+		"https://user:pw@www.twitch.tv/":       http.StatusBadRequest,
+		"https://evil.example.com\\@twitch.tv": http.StatusBadRequest,
+	} {
+		testrecorder := httptest.NewRecorder()
+		target := "http://spade.example.com/r?utm_campaign=spring&u=" + url.QueryEscape(dest)
+		req, _ := http.NewRequest("GET", target, nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != expected {
+			t.Errorf("%s expected code %d not %d", dest, expected, testrecorder.Code)
+		}
+		if expected == http.StatusFound && testrecorder.Header().Get("Location") != dest {
+			t.Errorf("%s expected redirect, got Location %q", dest, testrecorder.Header().Get("Location"))
+		}
+	}
+
+	if len(logger.events) != 2 {
+		t.Fatalf("Expected 2 click events, got %d", len(logger.events))
+	}
+	var event spade.Event
+	if err := spade.Unmarshal(logger.events[0], &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %s", err)
+	}
+	data, _ := base64.StdEncoding.DecodeString(event.Data)
+	if !strings.Contains(string(data), `"utm_campaign":"spring"`) || !strings.Contains(string(data), `"edge_click"`) {
+		t.Errorf("Expected click event with campaign params, got %s", data)
+	}
+}
+
+type blockingEdgeLogger struct {
+	testEdgeLogger
+	block chan struct{}
+}
+
+func (b *blockingEdgeLogger) Log(e *spade.Event) error {
+	<-b.block
+	return b.testEdgeLogger.Log(e)
+}
+
+func TestAsyncLoggers(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	sink := &blockingEdgeLogger{block: make(chan struct{})}
+	spadeHandler.EdgeLoggers.S3EventLogger = sink
+	spadeHandler.EdgeLoggers.StartAsync(AsyncConfig{Workers: 1, QueueSize: 1}, s)
+
+	// The first event is picked up by the worker and the second waits in the
+	// queue; both requests return without waiting for the sink.
+	for i := 0; i < 2; i++ {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.example.com/track", strings.NewReader("data=blah"))
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != http.StatusNoContent {
+			t.Errorf("Expected 204, got %d", testrecorder.Code)
+		}
+		for i == 0 && len(spadeHandler.EdgeLoggers.queue) != 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	close(sink.block)
+	spadeHandler.EdgeLoggers.Close()
+	if len(sink.events) != 2 {
+		t.Errorf("Expected Close to drain the queue, got %d events", len(sink.events))
+	}
+}
+
+type serializedEdgeLogger struct {
+	serialized [][]byte
+}
+
+func (l *serializedEdgeLogger) Log(e *spade.Event) error {
+	return errors.New("expected a serialized event")
+}
+
+func (l *serializedEdgeLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	l.serialized = append(l.serialized, serialized)
+	return nil
+}
+
+func (l *serializedEdgeLogger) Close() {}
+
+func TestLoggersShareSerialization(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	s3, kinesis := &serializedEdgeLogger{}, &serializedEdgeLogger{}
+	spadeHandler.EdgeLoggers.S3EventLogger = s3
+	spadeHandler.EdgeLoggers.KinesisEventLogger = kinesis
+
+	testrecorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "http://spade.example.com/track", strings.NewReader("data=blah"))
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(testrecorder, req)
+	if testrecorder.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", testrecorder.Code)
+	}
+	if len(s3.serialized) != 1 || len(kinesis.serialized) != 1 {
+		t.Fatalf("Expected one serialized event per logger, got %d and %d",
+			len(s3.serialized), len(kinesis.serialized))
+	}
+	if &s3.serialized[0][0] != &kinesis.serialized[0][0] {
+		t.Error("Expected the loggers to share one serialization")
+	}
+	var event spade.Event
+	if err := spade.Unmarshal(s3.serialized[0], &event); err != nil || event.Data != "blah" {
+		t.Errorf("Expected a serialized event with data blah, got %s (%v)", s3.serialized[0], err)
+	}
+}
+
+type discardEdgeLogger struct{}
+
+func (discardEdgeLogger) Log(e *spade.Event) error {
+	_, err := loggers.MarshalEvent(e)
+	return err
+}
+
+func (discardEdgeLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	return nil
+}
+
+func (discardEdgeLogger) Close() {}
+
+func BenchmarkServeHTTP(b *testing.B) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.EdgeLoggers.S3EventLogger = discardEdgeLogger{}
+	body := "data=" + base64.StdEncoding.EncodeToString([]byte(`{"event":"video-play","properties":{"channel":"a"}}`))
+
+	// The request and recorder are reused so only the handler's allocations
+	// are measured.
+	reader := strings.NewReader(body)
+	req, _ := http.NewRequest("POST", "http://spade.example.com/track", reader)
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	testrecorder := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Reset(body)
+		req.Form, req.PostForm = nil, nil
+		for k := range testrecorder.HeaderMap {
+			delete(testrecorder.HeaderMap, k)
+		}
+		spadeHandler.ServeHTTP(testrecorder, req)
+	}
+}
+
 func TestParseLastForwarder(t *testing.T) {
 	var testHeaders = []struct {
 		input    string
@@ -362,7 +868,6 @@ func TestHandle(t *testing.T) {
 			Method:   req.Method,
 			Endpoint: req.URL.Path,
 			IPHeader: ipForwardHeader,
-			Timers:   make(map[string]time.Duration, nTimers),
 		}
 		status := spadeHandler.serve(testrecorder, req, context)
 
@@ -447,13 +952,817 @@ func TestHostCounting(t *testing.T) {
 	if hasHostCountStat(rs) {
 		t.Errorf("Expected no statsd metrics sent for an empty host")
 	}
-
-	req.Host = "spade.twitch.tv:80"
-	spadeHandler.ServeHTTP(testRecorder, req)
-	if !hasHostCountStat(rs) {
-		t.Errorf("Expected statsd metrics sent when a host is provided")
+
+	req.Host = "spade.twitch.tv:80"
+	spadeHandler.ServeHTTP(testRecorder, req)
+	if !hasHostCountStat(rs) {
+		t.Errorf("Expected statsd metrics sent when a host is provided")
+	}
+
+}
+
+func TestHostCardinality(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	spadeHandler.Dimensions, _ = metrics.NewCardinalityLimiter(metrics.CardinalityConfig{Limit: 1})
+
+	hostSamplingRate = float32(1.0)
+	for _, host := range []string{"spade.twitch.tv", "attacker-1.example.com", "attacker-2.example.com"} {
+		req, _ := http.NewRequest("POST", "http://"+host+"/", strings.NewReader("data=blah"))
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	counts := map[string]int{}
+	for _, stat := range rs.GetSent() {
+		if strings.HasPrefix(stat.Stat, hostStatPrefix) {
+			counts[stat.Stat]++
+		}
+	}
+	expected := map[string]int{hostStatPrefix + "spade_twitch_tv": 1, hostStatPrefix + "other": 2}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected host stats %v, got %v", expected, counts)
+	}
+}
+
+func TestRollup(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Rollup, _ = rollup.New(rollup.Config{Events: []string{"ping"}}, s)
+
+	ping := base64.StdEncoding.EncodeToString([]byte(`{"event":"ping","properties":{}}`))
+	for i := 0; i < 3; i++ {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+ping, nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != http.StatusNoContent {
+			t.Fatalf("Expected rolled up request to succeed, got %d", testrecorder.Code)
+		}
+	}
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+	if len(logger.events) != 0 {
+		t.Fatalf("Expected rolled up events not to be logged, got %d", len(logger.events))
+	}
+
+	spadeHandler.Rollup.Flush(spadeHandler.LogSummary)
+	if len(logger.events) != 1 {
+		t.Fatalf("Expected one summary event, got %d", len(logger.events))
+	}
+	var ev spade.Event
+	if err := spade.Unmarshal(logger.events[0], &ev); err != nil {
+		t.Fatalf("Failed to unmarshal summary: %s", err)
+	}
+	data, _ := base64.StdEncoding.DecodeString(ev.Data)
+	if !strings.Contains(string(data), `"edge_rollup_count":3`) {
+		t.Errorf("Expected summary to count 3 events, got %s", data)
+	}
+}
+
+func TestPixelCacheBusting(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Pixel, _ = NewPixelPolicy(PixelConfig{RedirectUncached: true})
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	testrecorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data=blah&img=1", nil)
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(testrecorder, req)
+	if testrecorder.Code != http.StatusFound {
+		t.Fatalf("Expected pixel without cache buster to be redirected, got %d", testrecorder.Code)
+	}
+	if len(logger.events) != 0 {
+		t.Fatal("Expected redirected request not to be logged")
+	}
+	location, err := url.Parse(testrecorder.Header().Get("Location"))
+	if err != nil || location.Query().Get("cb") == "" || location.Query().Get("data") != "blah" {
+		t.Fatalf("Expected redirect to the same event with a cache buster, got %q", testrecorder.Header().Get("Location"))
+	}
+
+	testrecorder = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "http://spade.twitch.tv"+location.RequestURI(), nil)
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(testrecorder, req)
+	if testrecorder.Code != http.StatusOK || len(logger.events) != 1 {
+		t.Fatalf("Expected redirected pixel to be logged and served, got %d with %d events",
+			testrecorder.Code, len(logger.events))
+	}
+}
+
+func TestBadClient(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	spadeHandler.BadClient, _ = NewBadClientPolicy(BadClientConfig{Warn: true, Sunset: "2014-06-01T00:00:00Z",
+		Link: "https://docs.example.com/data-prefix", ClientHeader: "X-Api-Key"})
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	post := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv/track", strings.NewReader("data=blah"))
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		req.Header.Set("User-Agent", "okhttp/3.8.0")
+		spadeHandler.ServeHTTP(w, req)
+		return w
+	}
+	w := post()
+	if w.Code != http.StatusNoContent || len(logger.events) != 1 {
+		t.Fatalf("Expected a bad client fixed up before its sunset, got %d with %d events", w.Code, len(logger.events))
+	}
+	if w.Header().Get("Warning") == "" || w.Header().Get("Sunset") != "Sun, 01 Jun 2014 00:00:00 GMT" ||
+		w.Header().Get("Link") != `<https://docs.example.com/data-prefix>; rel="deprecation"` {
+		t.Errorf("Expected deprecation headers, got %v", w.Header())
+	}
+	var counted bool
+	for _, stat := range rs.GetSent() {
+		counted = counted || stat.Stat == "bad_client.client.okhttp"
+	}
+	if !counted {
+		t.Error("Expected the bad client counted by its User-Agent")
+	}
+
+	spadeHandler.Time = func() time.Time { return time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC) }
+	w = post()
+	if w.Code != http.StatusBadRequest || w.Header().Get(RejectReasonHeader) != RejectBadClient ||
+		len(logger.events) != 1 {
+		t.Errorf("Expected a bad client rejected after its sunset, got %d with %d events", w.Code, len(logger.events))
+	}
+}
+
+func TestMaxURIBytes(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	spadeHandler.MaxURIBytes = 100
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	get := func(data string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "http://spade.twitch.tv/track?data="+data, nil)
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		req.Header.Set("Origin", "https://www.twitch.tv")
+		req.Header.Set(sdkHeader, "spade-js/2.3.1")
+		spadeHandler.ServeHTTP(w, req)
+		return w
+	}
+	if w := get("blah"); w.Code != http.StatusNoContent || len(logger.events) != 1 {
+		t.Fatalf("Expected a short URI to be logged, got %d", w.Code)
+	}
+	w := get(strings.Repeat("a", 100))
+	if w.Code != http.StatusRequestURITooLong || w.Header().Get(RejectReasonHeader) != RejectURITooLong ||
+		len(logger.events) != 1 {
+		t.Fatalf("Expected a long URI to be rejected, got %d", w.Code)
+	}
+	sent := make(map[string]bool)
+	for _, stat := range rs.GetSent() {
+		sent[stat.Stat] = true
+	}
+	for _, stat := range []string{"large_URI.rejected", "large_URI.origin.www_twitch_tv", "large_URI.sdk.spade-js.2_3_1"} {
+		if !sent[stat] {
+			t.Errorf("Expected %s to be counted", stat)
+		}
+	}
+	if sent["large_URI"] {
+		t.Error("Expected only URIs over 8KB counted as large")
+	}
+}
+
+func TestPathLifecycles(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	lifecycles := PathLifecycles{
+		{Path: "/v1/", Deprecated: "2014-05-01T00:00:00Z", Gone: "2014-06-01T00:00:00Z",
+			Link: "https://docs.example.com/v1"},
+		// The longest path wins.
+		{Path: "/v1/legacy/", Gone: "2014-05-01T00:00:00Z"},
+		{Path: "/track", Deprecated: "2014-06-01T00:00:00Z"},
+	}
+	if err := lifecycles.Validate(); err != nil {
+		t.Fatalf("Expected valid lifecycles, got %v", err)
+	}
+	spadeHandler.SetPathLifecycles(lifecycles)
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	for _, tt := range []struct {
+		path   string
+		status int
+		sunset string
+	}{
+		{"/", http.StatusNoContent, ""},
+		{"/track", http.StatusNoContent, ""},
+		{"/v1/events", http.StatusNoContent, "Sun, 01 Jun 2014 00:00:00 GMT"},
+		{"/v1/legacy/events", http.StatusGone, "Thu, 01 May 2014 00:00:00 GMT"},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "http://spade.twitch.tv"+tt.path, strings.NewReader("data=blah"))
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(w, req)
+		deprecated := w.Header().Get("Deprecation") == "true"
+		if w.Code != tt.status || deprecated != (tt.sunset != "") || w.Header().Get("Sunset") != tt.sunset {
+			t.Errorf("%s: expected %d with Sunset %q, got %d with %v", tt.path, tt.status, tt.sunset, w.Code,
+				w.Header())
+		}
+	}
+	if len(logger.events) != 3 {
+		t.Errorf("Expected all but the gone path logged, got %d events", len(logger.events))
+	}
+
+	spadeHandler.Time = func() time.Time { return time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC) }
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "http://spade.twitch.tv/v1/events?data=blah", nil)
+	spadeHandler.ServeHTTP(w, req)
+	if w.Code != http.StatusGone || w.Header().Get(RejectReasonHeader) != RejectGone ||
+		w.Header().Get("Link") != `<https://docs.example.com/v1>; rel="deprecation"` {
+		t.Errorf("Expected /v1/ gone after its date, got %d with %v", w.Code, w.Header())
+	}
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "http://spade.twitch.tv/track?data=blah", nil)
+	spadeHandler.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || w.Header().Get("Warning") == "" || w.Header().Get("Sunset") != "" {
+		t.Errorf("Expected /track deprecated without a sunset, got %d with %v", w.Code, w.Header())
+	}
+
+	for _, invalid := range []PathLifecycles{
+		{{Path: "v1/", Gone: "2014-06-01T00:00:00Z"}},
+		{{Path: "/v1/"}},
+		{{Path: "/v1/", Deprecated: "2014-06-01T00:00:00Z", Gone: "2014-05-01T00:00:00Z"}},
+		{{Path: "/v1/", Gone: "June"}},
+		{{Path: "/v1/", Gone: "2014-06-01T00:00:00Z"}, {Path: "/v1/", Deprecated: "2014-05-01T00:00:00Z"}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid[0])
+		}
+	}
+}
+
+func TestClientAborts(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	spadeHandler.MaxDataValues = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tt := range []struct {
+		name   string
+		aborts AbortConfig
+		body   string
+		logged int
+		split  string
+	}{
+		{"default", AbortConfig{}, "data=blah", 1, ""},
+		{"drop", AbortConfig{DropEvents: true}, "data=blah", 0, ""},
+		{"multi data", AbortConfig{}, "data=a&data=b", 2, ""},
+		{"multi data cancelled", AbortConfig{CancelWrites: true}, "data=a&data=b", 0, ""},
+		{"split", AbortConfig{}, "data=" + longJSONSplittable, 70001, SplitSuccess},
+		{"split cancelled", AbortConfig{CancelWrites: true}, "data=" + longJSONSplittable, 0, SplitCancelled},
+	} {
+		rs.ClearSent()
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		spadeHandler.Splits = NewSplitRecorder(statter)
+		spadeHandler.Aborts = tt.aborts
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv/", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+		if len(logger.events) != tt.logged {
+			t.Errorf("%s: expected %d events to be logged, got %d", tt.name, tt.logged, len(logger.events))
+		}
+		if tt.split != "" && spadeHandler.Splits.Totals().Requests[tt.split] != 1 {
+			t.Errorf("%s: expected a %s split, got %v", tt.name, tt.split, spadeHandler.Splits.Totals())
+		}
+		var counted bool
+		for _, stat := range rs.GetSent() {
+			counted = counted || stat.Stat == "client_aborted"
+		}
+		if !counted {
+			t.Errorf("%s: expected the request to be counted as aborted", tt.name)
+		}
+	}
+}
+
+func TestMultipleDataValues(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	for _, tt := range []struct {
+		maxDataValues int
+		events        []string
+	}{
+		{0, []string{"a"}},
+		{2, []string{"a", "b"}},
+		{5, []string{"a", "b", "c"}},
+	} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		spadeHandler.MaxDataValues = tt.maxDataValues
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv/", strings.NewReader("data=a&data=&data=b&data=c"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+
+		if testrecorder.Code != http.StatusNoContent {
+			t.Errorf("Expected 204 with MaxDataValues %d, got %d", tt.maxDataValues, testrecorder.Code)
+		}
+		var data []string
+		uuids := make(map[string]bool)
+		for _, line := range logger.events {
+			var e spade.Event
+			if err := spade.Unmarshal(line, &e); err != nil {
+				t.Fatalf("Failed to unmarshal event: %s", err)
+			}
+			data = append(data, e.Data)
+			uuids[e.Uuid] = true
+		}
+		if !reflect.DeepEqual(data, tt.events) || len(uuids) != len(tt.events) {
+			t.Errorf("Expected events %v with their own uuids for MaxDataValues %d, got %v",
+				tt.events, tt.maxDataValues, data)
+		}
+	}
+}
+
+func TestContentTypes(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+	for _, tt := range []struct {
+		strict      bool
+		path        string
+		contentType string
+		body        string
+		code        int
+		data        string
+		stat        string
+	}{
+		{true, "/", "application/x-www-form-urlencoded", "data=blah", http.StatusNoContent, "blah", "form"},
+		{true, "/", "text/plain;charset=UTF-8", "blah", http.StatusNoContent, "blah", "text"},
+		{true, "/", "application/json", `{"event":"x"}`, http.StatusNoContent,
+			base64.StdEncoding.EncodeToString([]byte(`{"event":"x"}`)), "json"},
+		{true, "/", "application/json", "eyJldmVudCI6IngifQ==", http.StatusNoContent, "eyJldmVudCI6IngifQ==", "json"},
+		{true, "/", "application/x-randomfoofoo", "blah", http.StatusUnsupportedMediaType, "", "other"},
+		{true, "/", "", "blah", http.StatusUnsupportedMediaType, "", "none"},
+		{true, "/track?data=blah", "", "", http.StatusNoContent, "blah", "none"},
+		{false, "/", "application/x-randomfoofoo", "blah", http.StatusNoContent, "blah", "other"},
+	} {
+		rs.ClearSent()
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		spadeHandler.StrictContentTypes = tt.strict
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv"+tt.path, strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+
+		if testrecorder.Code != tt.code {
+			t.Errorf("%q (strict: %t): expected %d, got %d", tt.contentType, tt.strict, tt.code, testrecorder.Code)
+		}
+		if tt.data != "" {
+			var e spade.Event
+			if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil || e.Data != tt.data {
+				t.Errorf("%q: expected data %q to be logged", tt.contentType, tt.data)
+			}
+		}
+		counted := false
+		for _, stat := range rs.GetSent() {
+			counted = counted || stat.Stat == "content_type."+tt.stat
+		}
+		if !counted {
+			t.Errorf("%q: expected content_type.%s to be counted", tt.contentType, tt.stat)
+		}
+	}
+}
+
+func multipartBody(t *testing.T, fields ...string) (string, *bytes.Buffer) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for i := 0; i < len(fields); i += 2 {
+		if fields[i] == "blob" {
+			part, _ := w.CreateFormFile("data", "blob")
+			_, _ = part.Write([]byte(fields[i+1]))
+			continue
+		}
+		if err := w.WriteField(fields[i], fields[i+1]); err != nil {
+			t.Fatalf("Failed to write field: %s", err)
+		}
+	}
+	_ = w.Close()
+	return w.FormDataContentType(), &body
+}
+
+func TestMultipartForms(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Multipart = MultipartConfig{MaxPartSize: 16, MaxParts: 2}
+	for _, tt := range []struct {
+		name   string
+		fields []string
+		code   int
+		data   string
+	}{
+		{"field", []string{"data", "blah"}, http.StatusNoContent, "blah"},
+		{"file", []string{"blob", "blah"}, http.StatusNoContent, "blah"},
+		{"empty", []string{"other", "blah"}, http.StatusBadRequest, ""},
+		{"part too large", []string{"data", strings.Repeat("x", 17)}, http.StatusRequestEntityTooLarge, ""},
+		{"too many parts", []string{"a", "1", "b", "2", "data", "blah"}, http.StatusRequestEntityTooLarge, ""},
+	} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		contentType, body := multipartBody(t, tt.fields...)
+		testrecorder := httptest.NewRecorder()
+		// Query parameters still apply, as they do to urlencoded forms.
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv/track?ua=1", body)
+		req.Header.Set("User-Agent", "webview")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+
+		if testrecorder.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.code, testrecorder.Code)
+		}
+		var e spade.Event
+		if tt.data != "" && (len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil ||
+			e.Data != tt.data || e.UserAgent != "webview") {
+			t.Errorf("%s: expected %q to be logged with the user agent", tt.name, tt.data)
+		}
+	}
+}
+
+func TestMaxRequestBytes(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.MaxRequestBytes = 20
+	for body, code := range map[string]int{
+		"data=blah":                       http.StatusNoContent,
+		"data=" + strings.Repeat("x", 20): http.StatusRequestEntityTooLarge,
+	} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://spade.twitch.tv/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != code {
+			t.Errorf("%d byte body: expected %d, got %d", len(body), code, testrecorder.Code)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Fingerprinter, _ = NewFingerprinter(FingerprintConfig{Key: "secret"})
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{"a":1}}`))
+	fingerprint := func(ip, userAgent string) string {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req.Header.Add("X-Forwarded-For", ip)
+		req.Header.Set("User-Agent", userAgent)
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var e spade.Event
+		if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+			t.Fatalf("Expected an event to be logged")
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+		var event struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		if err := json.Unmarshal(decoded, &event); err != nil || event.Properties["a"] != 1.0 {
+			t.Fatalf("Expected the event's properties to be kept, got %s", decoded)
+		}
+		fingerprint, _ := event.Properties["edge_fingerprint"].(string)
+		if len(fingerprint) != 32 {
+			t.Errorf("Expected a 32 character fingerprint, got %q", fingerprint)
+		}
+		return fingerprint
+	}
+
+	first := fingerprint("222.222.222.1", "browser")
+	if fingerprint("222.222.222.2", "browser") != first {
+		t.Error("Expected clients on the same /24 to share a fingerprint")
+	}
+	if fingerprint("222.222.223.1", "browser") == first || fingerprint("222.222.222.1", "other") == first {
+		t.Error("Expected other networks and user agents to have other fingerprints")
+	}
+}
+
+func TestCapture(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Capture = CaptureConfig{AcceptLanguage: &HeaderCapture{}, Referer: &HeaderCapture{MaxLength: 20}}
+	if err := spadeHandler.Capture.Validate(); err != nil {
+		t.Fatalf("Expected the capture config to be valid, got %v", err)
+	}
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{"a":1}}`))
+	capture := func(headers map[string]string) map[string]interface{} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var e spade.Event
+		if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+			t.Fatalf("Expected an event to be logged")
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+		var event struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		if err := json.Unmarshal(decoded, &event); err != nil || event.Properties["a"] != 1.0 {
+			t.Fatalf("Expected the event's properties to be kept, got %s", decoded)
+		}
+		return event.Properties
+	}
+
+	properties := capture(map[string]string{
+		"Accept-Language": "en-US,en;q=0.9",
+		"Referer":         "https://www.twitch.tv/directory/ünïcode",
+	})
+	if properties["edge_accept_language"] != "en-US,en;q=0.9" {
+		t.Errorf("Expected Accept-Language to be captured, got %v", properties["edge_accept_language"])
+	}
+	if properties["edge_referer"] != "https://www.twitch.t" {
+		t.Errorf("Expected Referer to be truncated, got %q", properties["edge_referer"])
+	}
+
+	properties = capture(map[string]string{})
+	if _, ok := properties["edge_accept_language"]; ok {
+		t.Error("Expected absent headers not to be captured")
+	}
+
+	if got := truncateUTF8("aü", 2); got != "a" {
+		t.Errorf("Expected truncation at a character boundary, got %q", got)
+	}
+}
+
+func TestTimeBuckets(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+	receivedAt := time.Date(2017, 6, 1, 13, 45, 30, 123e6, time.FixedZone("PDT", -7*3600))
+	spadeHandler.Time = func() time.Time { return receivedAt }
+	spadeHandler.TimeBuckets = TimeBucketsConfig{Hour: true, Date: true, EpochMillis: true}
+
+	data := base64.StdEncoding.EncodeToString([]byte(`[{"event":"a","properties":{}},{"event":"b","properties":{}}]`))
+	req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var e spade.Event
+	if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+		t.Fatalf("Expected an event to be logged")
+	}
+	decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+	var events []struct {
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(decoded, &events); err != nil || len(events) != 2 {
+		t.Fatalf("Expected both events, got %s", decoded)
+	}
+	for _, event := range events {
+		if event.Properties["received_at_hour"] != "2017-06-01T20" || event.Properties["received_at_date"] != "2017-06-01" ||
+			event.Properties["epoch_ms"] != float64(receivedAt.UnixNano()/1e6) {
+			t.Errorf("Expected the UTC buckets of the time received, got %v", event.Properties)
+		}
+	}
+	if !e.ReceivedAt.Equal(receivedAt) {
+		t.Errorf("Expected the buckets of the event's receivedAt %s", e.ReceivedAt)
+	}
+
+	if err := (&TimeBucketsConfig{}).Validate(); err == nil {
+		t.Error("Expected a config without buckets to be rejected")
+	}
+}
+
+func TestSDKHeader(t *testing.T) {
+	sender := statsdtest.NewRecordingSender()
+	stats, _ := statsd.NewClientWithSender(sender, "")
+	stats.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(stats, spade.INTERNAL_EDGE)
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{}}`))
+	for _, tt := range []struct {
+		header, name, version, stat string
+	}{
+		{"spade-js/2.3.1", "spade-js", "2.3.1", "requests.sdk.spade-js.2_3_1"},
+		{"Spade.Android/4.0.0+build7", "Spade.Android", "4.0.0+build7", "requests.sdk.spade_android.4_0_0_build7"},
+		{"spade-js", "", "", "requests.sdk.malformed"},
+		{"spade js/1.0", "", "", "requests.sdk.malformed"},
+		{"", "", "", ""},
+	} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		sender.ClearSent()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		if tt.header != "" {
+			req.Header.Set("X-Spade-Client", tt.header)
+		}
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var e spade.Event
+		if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+			t.Fatalf("%q: expected an event to be logged", tt.header)
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+		var event struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		_ = json.Unmarshal(decoded, &event)
+		if tt.name == "" {
+			if _, ok := event.Properties["sdk_name"]; ok {
+				t.Errorf("%q: expected no SDK to be set, got %v", tt.header, event.Properties)
+			}
+		} else if event.Properties["sdk_name"] != tt.name || event.Properties["sdk_version"] != tt.version {
+			t.Errorf("%q: expected SDK %s %s, got %v", tt.header, tt.name, tt.version, event.Properties)
+		}
+		if tt.stat != "" && len(sender.GetSent().CollectNamed(tt.stat)) != 1 {
+			t.Errorf("%q: expected the request counted under %s, got %v", tt.header, tt.stat, sender.GetSent())
+		}
+	}
+}
+
+func TestTLSFingerprints(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+	hello := &clienthello.Hello{Version: 0x0303, CipherSuites: []uint16{0xc02f}}
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{}}`))
+	req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+	req = req.WithContext(clienthello.NewContext(req.Context(), hello))
+	req.Header.Add("X-Forwarded-For", "222.222.222.222")
+	spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var e spade.Event
+	if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+		t.Fatalf("Expected an event to be logged")
+	}
+	decoded, _ := base64.StdEncoding.DecodeString(e.Data)
+	var event struct {
+		Properties map[string]interface{} `json:"properties"`
+	}
+	_ = json.Unmarshal(decoded, &event)
+	if event.Properties["edge_ja3"] != hello.JA3() || event.Properties["edge_ja4"] != hello.JA4() {
+		t.Errorf("Expected the TLS fingerprints to be set, got %v", event.Properties)
+	}
+}
+
+func TestProxyProtocolClientIP(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+	for _, forwarded := range []string{"", "1.1.1.1"} {
+		logger.events = nil
+		data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{}}`))
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req = req.WithContext(proxyproto.NewContext(req.Context(), &net.TCPAddr{IP: net.ParseIP("222.222.222.222")}))
+		if forwarded != "" {
+			// Clients can send their own X-Forwarded-For.
+			req.Header.Add("X-Forwarded-For", forwarded)
+		}
+		spadeHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var e spade.Event
+		if len(logger.events) != 1 || spade.Unmarshal(logger.events[0], &e) != nil {
+			t.Fatalf("Expected an event to be logged")
+		}
+		if e.ClientIp.String() != "222.222.222.222" {
+			t.Errorf("Expected the PROXY header's client IP with X-Forwarded-For %q, got %s", forwarded, e.ClientIp)
+		}
+	}
+}
+
+func TestTenants(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	edgeStream, tenantStream := &testEdgeLogger{}, &testEdgeLogger{}
+	spadeHandler.EdgeLoggers.KinesisEventLogger = edgeStream
+	spadeHandler.EdgeLoggers.Tenants = map[string]*TenantLoggers{"acme": {KinesisEventLogger: tenantStream}}
+	spadeHandler.TenantHosts = map[string]string{"t.acme.com": "acme"}
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"e","properties":{}}`))
+	for _, tt := range []struct {
+		serverName string
+		ack        bool
+		status     int
+		stream     *testEdgeLogger
+	}{
+		{"T.acme.com", false, http.StatusNoContent, tenantStream},
+		{"spade.twitch.tv", false, http.StatusNoContent, edgeStream},
+		{"", false, http.StatusNoContent, edgeStream},
+		// The tenant's stream can't ack.
+		{"t.acme.com", true, http.StatusNotImplemented, nil},
+	} {
+		edgeStream.events, tenantStream.events = nil, nil
+		req, _ := http.NewRequest("GET", "https://spade.twitch.tv/track?data="+url.QueryEscape(data), nil)
+		req.TLS = &tls.ConnectionState{ServerName: tt.serverName}
+		if tt.ack {
+			req.Header.Set(AckHeader, ackSync)
+		}
+		recorder := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(recorder, req)
+		if recorder.Code != tt.status {
+			t.Errorf("Expected %d for server name %q, got %d", tt.status, tt.serverName, recorder.Code)
+		}
+		if (len(edgeStream.events) == 1) != (tt.stream == edgeStream) ||
+			(len(tenantStream.events) == 1) != (tt.stream == tenantStream) {
+			t.Errorf("Expected server name %q to be written to its stream, got %d events in the edge's and %d in the tenant's",
+				tt.serverName, len(edgeStream.events), len(tenantStream.events))
+		}
+	}
+}
+
+func TestTrackingPaths(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.SetTrackingPaths(TrackingPaths{Exact: []string{"/collect"}, Prefixes: []string{"/e/", "/"}})
+	spadeHandler.Handle("/custom", func(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
+		w.WriteHeader(http.StatusTeapot)
+		return http.StatusTeapot
+	})
+	spadeHandler.Handle("/e/custom/", func(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
+		w.WriteHeader(http.StatusAccepted)
+		return http.StatusAccepted
+	})
+	for _, tt := range []struct {
+		path   string
+		code   int
+		logged bool
+	}{
+		{"/collect", http.StatusNoContent, true},
+		{"/e/anything", http.StatusNoContent, true},
+		{"/track", http.StatusNoContent, true},
+		{"/healthcheck", http.StatusOK, false},
+		{"/custom", http.StatusTeapot, false},
+		{"/e/custom/x", http.StatusAccepted, false},
+	} {
+		logger := &testEdgeLogger{}
+		spadeHandler.EdgeLoggers.S3EventLogger = logger
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv"+tt.path+"?data=blah", nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d", tt.path, tt.code, testrecorder.Code)
+		}
+		if logged := len(logger.events) == 1; logged != tt.logged {
+			t.Errorf("%s: expected logged to be %v", tt.path, tt.logged)
+		}
+	}
+
+	// Only the configured paths are accepted, even the default ones.
+	spadeHandler.SetTrackingPaths(TrackingPaths{Exact: []string{"/collect"}})
+	for _, path := range []string{"/track", "/v1/x", "/"} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://spade.twitch.tv"+path+"?data=blah", nil)
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, testrecorder.Code)
+		}
+	}
+}
+
+func TestHeadRequests(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	for _, tt := range []struct {
+		path string
+		code int
+	}{
+		{"/", http.StatusNoContent},
+		{"/track?data=blah", http.StatusNoContent},
+		{"/track?data=blah&img=1", http.StatusOK},
+		{"/healthcheck", http.StatusOK},
+		{"/crossdomain.xml", http.StatusOK},
+		{"/robots.txt", http.StatusOK},
+		{"/r?u=https%3A%2F%2Fwww.twitch.tv%2F", http.StatusMethodNotAllowed},
+	} {
+		testrecorder := httptest.NewRecorder()
+		req, _ := http.NewRequest("HEAD", "http://spade.twitch.tv"+tt.path, nil)
+		req.Header.Add("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(testrecorder, req)
+		if testrecorder.Code != tt.code {
+			t.Errorf("HEAD %s: expected %d, got %d", tt.path, tt.code, testrecorder.Code)
+		}
+		if testrecorder.Body.Len() != 0 {
+			t.Errorf("HEAD %s: expected no body, got %q", tt.path, testrecorder.Body.String())
+		}
+	}
+	if n := len(spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger).events); n != 0 {
+		t.Errorf("Expected HEAD requests not to log events, got %d", n)
 	}
-
 }
 
 func TestCorsOriginAcceptance(t *testing.T) {
@@ -497,6 +1806,133 @@ func TestCorsOriginAcceptance(t *testing.T) {
 	}
 }
 
+func TestCORSGroups(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	groups := CORSGroups{
+		{Origins: []string{"https://player.twitch.tv"}, AllowHeaders: []string{"X-Device-Id", "x-spade-client"},
+			ExposeHeaders: []string{RejectReasonHeader}, AllowCredentials: true},
+		// Groups come before CorsOrigins, and the first match wins.
+		{Origins: []string{"https://www.twitch.tv", "https://player.twitch.tv"}, AllowHeaders: []string{"X-Trace"}},
+	}
+	if err := groups.Validate(); err != nil {
+		t.Fatalf("Expected valid groups, got %v", err)
+	}
+	if err := (CORSGroups{{Origins: []string{"https://*"}}}).Validate(); err != nil {
+		t.Errorf("Expected a catch-all group without credentials to be valid, got %v", err)
+	}
+	spadeHandler.SetCORSGroups(groups)
+
+	for _, tt := range []struct {
+		origin, method string
+		allowHeaders   string
+		credentials    string
+		exposeHeaders  string
+	}{
+		{"https://player.twitch.tv", "OPTIONS", "X-Spade-Client, X-Device-Id", "true", ""},
+		{"https://player.twitch.tv", "POST", "X-Spade-Client, X-Device-Id", "true", RejectReasonHeader},
+		{"https://www.twitch.tv", "OPTIONS", "X-Spade-Client, X-Trace", "", ""},
+		{"https://www.twitch.tv", "POST", "X-Spade-Client, X-Trace", "", ""},
+		{"http://www.twitch.tv", "OPTIONS", "X-Spade-Client", "", ""},
+		{"http://www.twitch.tv", "POST", "X-Spade-Client", "", ""},
+		{"https://evil.example.com", "OPTIONS", "", "", ""},
+		{"https://evil.example.com", "POST", "", "", ""},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, "http://spade.twitch.tv/track", strings.NewReader("data=blah"))
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(w, req)
+		allowOrigin := w.Header().Get("Access-Control-Allow-Origin")
+		if (tt.allowHeaders != "") != (allowOrigin == tt.origin) ||
+			w.Header().Get("Access-Control-Allow-Headers") != tt.allowHeaders ||
+			w.Header().Get("Access-Control-Allow-Credentials") != tt.credentials ||
+			w.Header().Get("Access-Control-Expose-Headers") != tt.exposeHeaders {
+			t.Errorf("%s from %s: unexpected CORS headers %v", tt.method, tt.origin, w.Header())
+		}
+	}
+
+	for _, invalid := range []CORSGroups{
+		{{}},
+		{{Origins: []string{"https://[www"}}},
+		{{Origins: []string{"https://www.twitch.tv"}, AllowHeaders: []string{"X-A, X-B"}}},
+		{{Origins: []string{"https://www.twitch.tv"}, AllowHeaders: []string{"*"}, AllowCredentials: true}},
+		{{Origins: []string{"*"}, AllowCredentials: true}},
+		{{Origins: []string{"https://www.twitch.tv", "https://*"}, AllowCredentials: true}},
+		{{Origins: []string{"http*://*"}, AllowCredentials: true}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid[0])
+		}
+	}
+}
+
+func TestOriginLearning(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	learner, err := NewOriginLearner(OriginLearningConfig{Origins: []string{"https://*.twitch.tv"}})
+	if err != nil {
+		t.Fatalf("Failed to create origin learner: %s", err)
+	}
+	spadeHandler.OriginLearner = learner
+
+	for _, tt := range []struct{ method, origin string }{
+		{"POST", "https://www.twitch.tv"},
+		{"POST", "http://www.twitch.tv"},
+		// A preflight and its actual request count once.
+		{"OPTIONS", "http://www.twitch.tv"},
+		{"POST", "http://www.twitch.tv"},
+		{"OPTIONS", "https://evil.example.com"},
+		{"POST", "https://evil.example.com"},
+		{"POST", ""},
+	} {
+		origin := tt.origin
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, "http://spade.twitch.tv/track", strings.NewReader("data=blah"))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		spadeHandler.ServeHTTP(w, req)
+		if allowed := w.Header().Get("Access-Control-Allow-Origin"); origin == "http://www.twitch.tv" && allowed != origin {
+			t.Errorf("Expected learning not to change responses, got %v", w.Header())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	learner.ServeHTTP(w, httptest.NewRequest("GET", "/debug/origins", nil))
+	var body struct {
+		Window   string
+		Rejected []topk.Entry
+		Broken   []topk.Entry
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %s", err)
+	}
+	if body.Window != "24h" || len(body.Rejected) != 2 ||
+		body.Rejected[0] != (topk.Entry{Key: "http://www.twitch.tv", Count: 2}) ||
+		body.Rejected[1] != (topk.Entry{Key: "https://evil.example.com", Count: 1}) ||
+		len(body.Broken) != 1 || body.Broken[0] != (topk.Entry{Key: "http://www.twitch.tv", Count: 2}) {
+		t.Errorf("Expected the origins outside the allowlist, got %s", w.Body.String())
+	}
+}
+
+func TestSetCORSOrigins(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	if !spadeHandler.isAcceptableOrigin("http://www.twitch.tv") {
+		t.Fatal("Expected configured origin to be accepted")
+	}
+
+	spadeHandler.SetCORSOrigins([]string{"https://*.example.com", " "})
+	if spadeHandler.isAcceptableOrigin("http://www.twitch.tv") {
+		t.Error("Expected replaced origin to be rejected")
+	}
+	if !spadeHandler.isAcceptableOrigin("https://www.example.com") {
+		t.Error("Expected new origin to be accepted")
+	}
+}
+
 func BenchmarkRequests(b *testing.B) {
 	s, _ := statsd.NewNoop()
 	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
@@ -792,3 +2228,630 @@ var (
 		},
 	}
 )
+
+func TestServeValidate(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	spadeHandler.Handle("/validate", spadeHandler.ServeValidate)
+	logger := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger)
+
+	validate := func(method, target, contentType, body string) *Diagnosis {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-Forwarded-For", "222.222.222.222")
+		req.Header.Set("User-Agent", "sdk/1.0")
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200 validating %s, got %d", target, rec.Code)
+		}
+		var d Diagnosis
+		if err := json.Unmarshal(rec.Body.Bytes(), &d); err != nil {
+			t.Fatalf("Failed to decode diagnosis: %s", err)
+		}
+		return &d
+	}
+
+	d := validate("GET", "/validate?ua=1&data="+base64.URLEncoding.EncodeToString([]byte(`{"event":"play"}`)), "", "")
+	if !d.Valid || len(d.Payloads) != 1 || d.Payloads[0].Encoding != "base64" ||
+		len(d.Payloads[0].Events) != 1 || d.Payloads[0].Events[0]["event"] != "play" {
+		t.Errorf("Unexpected diagnosis of a valid event: %+v", d)
+	}
+	if d.ClientIP != "222.222.222.222" || d.UserAgent != "sdk/1.0" || !strings.HasPrefix(d.UUID, instanceID+"-") {
+		t.Errorf("Unexpected assignments: %+v", d)
+	}
+
+	d = validate("POST", "/validate", "application/json", `[{"event":"play"},{"properties":{}}]`)
+	if d.Valid || d.Payloads[0].Encoding != "json" || len(d.Payloads[0].Events) != 2 ||
+		len(d.Payloads[0].Errors) != 1 || d.Payloads[0].Errors[0] != "event 1 has no event name" {
+		t.Errorf("Unexpected diagnosis of an unnamed event: %+v", d)
+	}
+
+	d = validate("POST", "/validate", "", "data=not base64!")
+	if d.Valid || !d.BadClient || len(d.Payloads[0].Errors) != 1 {
+		t.Errorf("Unexpected diagnosis of a corrupt event: %+v", d)
+	}
+
+	if len(logger.events) != 0 {
+		t.Errorf("Expected nothing to be logged, got %d events", len(logger.events))
+	}
+}
+
+func TestRejectReason(t *testing.T) {
+	rs := statsdtest.NewRecordingSender()
+	statter, _ := statsd.NewClientWithSender(rs, "")
+	statter.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+	spadeHandler := makeSpadeHandler(statter, spade.INTERNAL_EDGE)
+
+	for _, tt := range []struct {
+		body, accept, reason string
+		code                 int
+	}{
+		{"", "", RejectEmptyData, http.StatusBadRequest},
+		{"", "application/json", RejectEmptyData, http.StatusBadRequest},
+		{"data=" + strings.Repeat("!", maxBytesPerRequest+4), "", RejectBadBase64, http.StatusRequestEntityTooLarge},
+		{"data=" + base64.StdEncoding.EncodeToString([]byte(`[{"event":"a"},`+strings.Repeat(" ", maxBytesPerRequest)+`}`)),
+			"", RejectBadJSON, http.StatusRequestEntityTooLarge},
+	} {
+		req := httptest.NewRequest("POST", "/track", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, req)
+		if rec.Code != tt.code || rec.Header().Get(RejectReasonHeader) != tt.reason {
+			t.Errorf("Expected %d %s, got %d %q", tt.code, tt.reason, rec.Code, rec.Header().Get(RejectReasonHeader))
+		}
+		if tt.accept == "" {
+			if rec.Body.Len() != 0 {
+				t.Errorf("Expected no body, got %q", rec.Body.String())
+			}
+			continue
+		}
+		var body rejection
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Reason != tt.reason || body.Status != tt.code {
+			t.Errorf("Expected JSON rejection, got %q (%v)", rec.Body.String(), err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data=eyJldmVudCI6ImhlbGxvIn0", nil))
+	if reason := rec.Header().Get(RejectReasonHeader); rec.Code != http.StatusNoContent || reason != "" {
+		t.Errorf("Expected accepted request without a reason, got %d %q", rec.Code, reason)
+	}
+
+	var counted int
+	for _, stat := range rs.GetSent() {
+		if strings.HasPrefix(stat.Stat, "reject_reason.") {
+			counted++
+		}
+	}
+	if counted != 4 {
+		t.Errorf("Expected 4 rejections counted, got %d", counted)
+	}
+}
+
+// testAcker acks events with their UUIDs, or fails with err.
+type testAcker struct {
+	acked []*spade.Event
+	err   error
+}
+
+func (a *testAcker) LogAcked(e *spade.Event) (string, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+	a.acked = append(a.acked, e)
+	return "seq-" + e.Uuid, nil
+}
+
+func TestSyncAck(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	kinesisLogger := &testEdgeLogger{}
+	spadeHandler.EdgeLoggers.KinesisEventLogger = kinesisLogger
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		req.Header.Set(AckHeader, "sync")
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/track?data=eyJldmVudCI6ImhlbGxvIn0")
+	if rec.Code != http.StatusNotImplemented || rec.Header().Get(RejectReasonHeader) != RejectAckUnavailable {
+		t.Errorf("Expected 501 without an acker, got %d", rec.Code)
+	}
+
+	acker := &testAcker{}
+	spadeHandler.EdgeLoggers.Acker = acker
+	rec = serve("/track?data=eyJldmVudCI6ImhlbGxvIn0")
+	if rec.Code != http.StatusNoContent || len(acker.acked) != 1 ||
+		rec.Header().Get(AckSequenceHeader) != "seq-"+acker.acked[0].Uuid {
+		t.Errorf("Expected acked event, got %d %q", rec.Code, rec.Header().Get(AckSequenceHeader))
+	}
+	if len(kinesisLogger.events) != 0 {
+		t.Errorf("Expected the buffered Kinesis logger to be bypassed, got %d events", len(kinesisLogger.events))
+	}
+
+	// A failed ack fails the request, even a pixel one.
+	acker.err = errors.New("throttled")
+	if rec = serve("/track?data=eyJldmVudCI6ImhlbGxvIn0&img=1"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 for a failed ack, got %d", rec.Code)
+	}
+}
+
+func TestWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	edgeLoggers := spadeHandler.EdgeLoggers
+	if edgeLoggers.WAL, err = wal.Open(wal.Config{Dir: dir}, s, "wal."); err != nil {
+		t.Fatalf("Failed to open write-ahead log: %s", err)
+	}
+	// The first event fails to reach the Kinesis logger, so it isn't acked.
+	edgeLoggers.KinesisEventLogger = &flakyEdgeLogger{calls: 1}
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data=eyJldmVudCI6ImhlbGxvIn0", nil))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d", rec.Code)
+		}
+	}
+	edgeLoggers.Close()
+
+	restarted := NewEdgeLoggers()
+	if restarted.WAL, err = wal.Open(wal.Config{Dir: dir}, s, "wal."); err != nil {
+		t.Fatalf("Failed to reopen write-ahead log: %s", err)
+	}
+	defer restarted.Close()
+	kinesisLogger := &testEdgeLogger{}
+	restarted.KinesisEventLogger = kinesisLogger
+	// Its segment is replayed whole, acked event and all.
+	if replayed, _, err := restarted.ReplayWAL(); err != nil || replayed != 2 || len(kinesisLogger.events) != 2 {
+		t.Errorf("Expected the unacked event's segment replayed, got %d, %v", replayed, err)
+	}
+}
+
+// bufferingEdgeLogger holds events like the Kinesis logger's buffer, calling
+// their done callbacks only when flushed.
+type bufferingEdgeLogger struct {
+	mu   sync.Mutex
+	done []func(error)
+}
+
+func (b *bufferingEdgeLogger) Log(e *spade.Event) error {
+	return b.LogDurably(e, nil, nil)
+}
+
+func (b *bufferingEdgeLogger) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done = append(b.done, done)
+	return nil
+}
+
+func (b *bufferingEdgeLogger) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, done := range b.done {
+		done(nil)
+	}
+	b.done = nil
+}
+
+func (b *bufferingEdgeLogger) Close() {}
+
+func TestWALAckedOnFlush(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	edgeLoggers := spadeHandler.EdgeLoggers
+	if edgeLoggers.WAL, err = wal.Open(wal.Config{Dir: dir}, s, "wal."); err != nil {
+		t.Fatalf("Failed to open write-ahead log: %s", err)
+	}
+	// The edge dies before the Kinesis logger flushes the event, so it's
+	// never acked.
+	edgeLoggers.KinesisEventLogger = &bufferingEdgeLogger{}
+	rec := httptest.NewRecorder()
+	spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data=eyJldmVudCI6ImhlbGxvIn0", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", rec.Code)
+	}
+	edgeLoggers.Close()
+
+	restarted := NewEdgeLoggers()
+	if restarted.WAL, err = wal.Open(wal.Config{Dir: dir}, s, "wal."); err != nil {
+		t.Fatalf("Failed to reopen write-ahead log: %s", err)
+	}
+	kinesisLogger := &bufferingEdgeLogger{}
+	restarted.KinesisEventLogger = kinesisLogger
+	if replayed, _, err := restarted.ReplayWAL(); err != nil || replayed != 1 {
+		t.Fatalf("Expected the unflushed event replayed, got %d, %v", replayed, err)
+	}
+	// Once the replayed event is flushed, it's acked and isn't replayed
+	// again.
+	kinesisLogger.flush()
+	restarted.Close()
+
+	again := NewEdgeLoggers()
+	if again.WAL, err = wal.Open(wal.Config{Dir: dir}, s, "wal."); err != nil {
+		t.Fatalf("Failed to reopen write-ahead log: %s", err)
+	}
+	defer again.Close()
+	again.KinesisEventLogger = &bufferingEdgeLogger{}
+	if replayed, _, err := again.ReplayWAL(); err != nil || replayed != 0 {
+		t.Errorf("Expected nothing left to replay, got %d, %v", replayed, err)
+	}
+}
+
+func TestWALAckedOnRelayPost(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	var posts int32
+	peer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer peer.Close()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: peer.Certificate().Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	walConfig := wal.Config{Dir: filepath.Join(dir, "wal")}
+
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	edgeLoggers := spadeHandler.EdgeLoggers
+	if edgeLoggers.WAL, err = wal.Open(walConfig, s, "wal."); err != nil {
+		t.Fatalf("Failed to open write-ahead log: %s", err)
+	}
+	relay, err := loggers.NewRelayLogger(loggers.RelayConfig{URL: peer.URL + "/relay", KeyID: "inner",
+		Secret: "0123456789abcdef", CAFile: caFile, BatchAge: "1h"}, loggers.UndefinedLogger{}, s, nil)
+	if err != nil {
+		t.Fatalf("Failed to create relay logger: %s", err)
+	}
+	edgeLoggers.KinesisEventLogger = relay
+	rec := httptest.NewRecorder()
+	spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data=eyJldmVudCI6ImhlbGxvIn0", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", rec.Code)
+	}
+	// The edge dies before the relay logger posts the event's batch, so it's
+	// never acked.
+	if err = edgeLoggers.WAL.Close(); err != nil {
+		t.Fatalf("Failed to close write-ahead log: %s", err)
+	}
+
+	restarted := NewEdgeLoggers()
+	if restarted.WAL, err = wal.Open(walConfig, s, "wal."); err != nil {
+		t.Fatalf("Failed to reopen write-ahead log: %s", err)
+	}
+	restarted.KinesisEventLogger = &bufferingEdgeLogger{}
+	if replayed, _, err := restarted.ReplayWAL(); err != nil || replayed != 1 {
+		t.Errorf("Expected the unposted event replayed, got %d, %v", replayed, err)
+	}
+	restarted.Close()
+
+	relay.Close()
+	if atomic.LoadInt32(&posts) != 1 {
+		t.Errorf("Expected the batch posted on close, got %d posts", posts)
+	}
+}
+
+func TestReplayExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	walConfig := wal.Config{Dir: filepath.Join(dir, "wal")}
+	spoolConfig := wal.Config{Dir: filepath.Join(dir, "spool")}
+
+	s, _ := statsd.NewNoop()
+	log, err := wal.Open(walConfig, s, "wal.")
+	if err != nil {
+		t.Fatalf("Failed to open write-ahead log: %s", err)
+	}
+	spool, err := loggers.NewSpoolLogger(spoolConfig, s)
+	if err != nil {
+		t.Fatalf("Failed to open spool: %s", err)
+	}
+	// The first event will be older than MaxReplayAge, the second won't.
+	for _, receivedAt := range []time.Time{fixedTime, fixedTime.Add(150 * time.Minute)} {
+		event := &spade.Event{ReceivedAt: receivedAt, Uuid: "uuid", Version: spade.PROTOCOL_VERSION}
+		if _, err = log.Append(event); err != nil {
+			t.Fatalf("Failed to append to write-ahead log: %s", err)
+		}
+		if err = spool.Log(event); err != nil {
+			t.Fatalf("Failed to spool: %s", err)
+		}
+	}
+	_ = log.Close()
+	spool.Close()
+
+	edgeLoggers := NewEdgeLoggers()
+	if edgeLoggers.WAL, err = wal.Open(walConfig, s, "wal."); err != nil {
+		t.Fatalf("Failed to reopen write-ahead log: %s", err)
+	}
+	defer edgeLoggers.Close()
+	if spool, err = loggers.NewSpoolLogger(spoolConfig, s); err != nil {
+		t.Fatalf("Failed to reopen spool: %s", err)
+	}
+	defer spool.Close()
+	kinesisLogger := &testEdgeLogger{}
+	edgeLoggers.KinesisEventLogger = kinesisLogger
+	edgeLoggers.MaxReplayAge = time.Hour
+	edgeLoggers.Env = &loggers.Env{Clock: clock.NewFake(fixedTime.Add(3 * time.Hour))}
+
+	if replayed, expired, err := edgeLoggers.ReplayWAL(); err != nil || replayed != 1 || expired != 1 {
+		t.Errorf("Expected 1 event replayed from the WAL and 1 expired, got %d, %d, %v", replayed, expired, err)
+	}
+	if replayed, expired, err := edgeLoggers.ReplaySpool(spool); err != nil || replayed != 1 || expired != 1 {
+		t.Errorf("Expected 1 event replayed from the spool and 1 expired, got %d, %d, %v", replayed, expired, err)
+	}
+	if len(kinesisLogger.events) != 2 {
+		t.Errorf("Expected only the recent events written, got %d", len(kinesisLogger.events))
+	}
+}
+
+func TestSequence(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := &testEdgeLogger{}
+	spadeHandler.EdgeLoggers.KinesisEventLogger = logger
+	sequencer, err := NewSequencer(SequenceConfig{}, instanceID, s)
+	if err != nil {
+		t.Fatalf("Failed to create sequencer: %s", err)
+	}
+	spadeHandler.EdgeLoggers.Sequencer = sequencer
+	heartbeater, err := NewHeartbeater(HeartbeatConfig{}, instanceID, "v1", s)
+	if err != nil {
+		t.Fatalf("Failed to create heartbeater: %s", err)
+	}
+
+	// An array of events is one record, with one number, and a record that
+	// can't be decoded is logged without one.
+	payload := base64.StdEncoding.EncodeToString([]byte(`[{"event":"a","properties":{}},{"event":"b","properties":{}}]`))
+	for _, data := range []string{payload, "!!!!"} {
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data="+data, nil))
+	}
+	heartbeater.Heartbeat(spadeHandler.LogSummary)
+
+	var sequences []string
+	for _, line := range logger.events {
+		var event spade.Event
+		if err = spade.Unmarshal(line, &event); err != nil {
+			t.Fatal(err)
+		}
+		var decoded []struct {
+			Event      string
+			Properties map[string]interface{}
+		}
+		b, err := base64.StdEncoding.DecodeString(event.Data)
+		if err != nil {
+			sequences = append(sequences, event.Data)
+			continue
+		}
+		if b[0] != '[' {
+			b = append(append([]byte{'['}, b...), ']')
+		}
+		if err = json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("Failed to decode %s: %s", b, err)
+		}
+		for _, e := range decoded {
+			if e.Properties["edge_run"] != sequencer.run {
+				t.Errorf("Expected run %s, got %v", sequencer.run, e.Properties["edge_run"])
+			}
+			sequences = append(sequences, fmt.Sprintf("%s:%v", e.Event, e.Properties["edge_sequence"]))
+		}
+	}
+	if fmt.Sprint(sequences) != "[a:1 b:1 !!!! edge_heartbeat:2]" || sequencer.Last() != 2 {
+		t.Errorf("Unexpected sequence numbers %v", sequences)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	heartbeater, err := NewHeartbeater(HeartbeatConfig{Interval: "30s"}, instanceID, "v1", s)
+	if err != nil {
+		t.Fatalf("Failed to create heartbeater: %s", err)
+	}
+	spadeHandler.Heartbeat = heartbeater
+	for _, data := range []string{"YQ==", "YWJj"} {
+		rec := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data="+data, nil))
+	}
+
+	var beats []string
+	var fail bool
+	log := func(data string) error {
+		if fail {
+			return errors.New("unavailable")
+		}
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			t.Fatalf("Failed to decode heartbeat %q: %s", data, err)
+		}
+		beats = append(beats, string(b))
+		return nil
+	}
+	heartbeater.Heartbeat(log)
+	// A heartbeat that fails to be logged leaves its counts to the next one.
+	spadeHandler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/track?data=YQ==", nil))
+	fail = true
+	heartbeater.Heartbeat(log)
+	fail = false
+	heartbeater.Heartbeat(log)
+
+	expected := []string{
+		`{"event":"edge_heartbeat","properties":{"accepted_bytes":8,"accepted_records":2,` +
+			`"instance":"i-test","interval_seconds":30,"total_bytes":8,"total_records":2,"version":"v1"}}`,
+		`{"event":"edge_heartbeat","properties":{"accepted_bytes":4,"accepted_records":1,` +
+			`"instance":"i-test","interval_seconds":30,"total_bytes":12,"total_records":3,"version":"v1"}}`,
+	}
+	if !reflect.DeepEqual(beats, expected) {
+		t.Errorf("Expected heartbeats %v, got %v", expected, beats)
+	}
+}
+
+func TestKillSwitch(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := &testEdgeLogger{}
+	spadeHandler.EdgeLoggers.KinesisEventLogger = logger
+	var err error
+	spadeHandler.KillSwitch, err = killswitch.New(killswitch.Config{Events: []string{"video-play"}}, nil, s)
+	if err != nil {
+		t.Fatalf("Failed to create kill switch: %s", err)
+	}
+	for _, tt := range []struct {
+		payload string
+		logged  int
+	}{
+		{`{"event":"video-play","properties":{}}`, 0},
+		{`[{"event":"video-play","properties":{}},{"event":"follow","properties":{}}]`, 1},
+	} {
+		logger.events = nil
+		rec := httptest.NewRecorder()
+		data := base64.StdEncoding.EncodeToString([]byte(tt.payload))
+		spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data="+data, nil))
+		if rec.Code != http.StatusNoContent || len(logger.events) != tt.logged {
+			t.Errorf("Expected %s to succeed with %d logged, got %d with %d", tt.payload, tt.logged,
+				rec.Code, len(logger.events))
+		}
+	}
+}
+
+func TestNaming(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	logger := &testEdgeLogger{}
+	spadeHandler.EdgeLoggers.KinesisEventLogger = logger
+	for _, tt := range []struct {
+		mode, payload string
+		code          int
+		logged        string
+	}{
+		{NamingTag, `{"event":"video_play","properties":{}}`, http.StatusNoContent,
+			`{"event":"video_play","properties":{}}`},
+		{NamingTag, `[{"event":"Video_Play","properties":{}},{"event":"video-play","properties":{}}]`,
+			http.StatusNoContent, `[{"event":"Video_Play","properties":{"edge_name_violation":"uppercase"}},` +
+				`{"event":"video-play","properties":{"edge_name_violation":"pattern"}}]`},
+		{NamingTag, `{"event":"vp","properties":{}}`, http.StatusNoContent,
+			`{"event":"vp","properties":{"edge_name_violation":"too_short"}}`},
+		{NamingEnforce, `{"event":"video_play","properties":{}}`, http.StatusNoContent,
+			`{"event":"video_play","properties":{}}`},
+		{NamingEnforce, `[{"event":"video_play"},{"event":"videoPlay"}]`, http.StatusBadRequest, ""},
+	} {
+		var err error
+		if spadeHandler.Naming, err = NewNamingPolicy(NamingConfig{Mode: tt.mode, MinLength: 3}); err != nil {
+			t.Fatalf("Failed to create naming policy: %s", err)
+		}
+		logger.events = nil
+		rec := httptest.NewRecorder()
+		data := base64.StdEncoding.EncodeToString([]byte(tt.payload))
+		spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data="+data, nil))
+		if rec.Code != tt.code {
+			t.Errorf("Expected %s in %s mode to return %d, got %d", tt.payload, tt.mode, tt.code, rec.Code)
+		}
+		var logged string
+		if len(logger.events) > 0 {
+			var event spade.Event
+			if err = spade.Unmarshal(logger.events[0], &event); err != nil {
+				t.Fatal(err)
+			}
+			b, _ := base64.StdEncoding.DecodeString(event.Data)
+			logged = string(b)
+		}
+		if logged != tt.logged {
+			t.Errorf("Expected %s in %s mode to be logged as %q, got %q", tt.payload, tt.mode, tt.logged, logged)
+		}
+	}
+	rec := httptest.NewRecorder()
+	data := base64.StdEncoding.EncodeToString([]byte(`{"event":"videoPlay"}`))
+	spadeHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/track?data="+data, nil))
+	if reason := rec.Header().Get(RejectReasonHeader); reason != RejectBadEventName {
+		t.Errorf("Expected the %s reject reason, got %q", RejectBadEventName, reason)
+	}
+}
+
+func relayedBatch(t *testing.T, hops int, events ...*spade.Event) *http.Request {
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	for _, e := range events {
+		serialized, err := loggers.SerializeEvent(e)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %s", err)
+		}
+		_, _ = zw.Write(append(serialized, '\n'))
+	}
+	_ = zw.Close()
+	req, _ := http.NewRequest("POST", "http://spade.example.com"+RelayPath, bytes.NewReader(body.Bytes()))
+	req.Header.Set("Content-Type", loggers.RelayContentType)
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set(loggers.RelayHopsHeader, fmt.Sprint(hops))
+	if err := auth.SignRequest(req, "outer", "0123456789abcdef", body.Bytes(), time.Now()); err != nil {
+		t.Fatalf("Failed to sign: %s", err)
+	}
+	return req
+}
+
+func TestRelay(t *testing.T) {
+	s, _ := statsd.NewNoop()
+	spadeHandler := makeSpadeHandler(s, spade.INTERNAL_EDGE)
+	var err error
+	spadeHandler.Relay, err = NewRelayReceiver(RelayReceiverConfig{Peers: map[string]string{"outer": "0123456789abcdef"}})
+	if err != nil {
+		t.Fatalf("Failed to create relay receiver: %s", err)
+	}
+	received := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	first := spade.NewEvent(received, net.ParseIP("10.0.0.1"), "10.0.0.1", "uuid-1", "data", "", spade.EXTERNAL_EDGE)
+
+	for _, tt := range []struct {
+		req      *http.Request
+		expected int
+	}{
+		{relayedBatch(t, 1, first, first), http.StatusNoContent},
+		{relayedBatch(t, 4, first), http.StatusLoopDetected},
+		{httptest.NewRequest("POST", RelayPath, strings.NewReader("")), http.StatusUnauthorized},
+	} {
+		recorder := httptest.NewRecorder()
+		spadeHandler.ServeHTTP(recorder, tt.req)
+		if recorder.Code != tt.expected {
+			t.Errorf("Expected %d, got %d", tt.expected, recorder.Code)
+		}
+	}
+
+	logged := spadeHandler.EdgeLoggers.S3EventLogger.(*testEdgeLogger).events
+	if len(logged) != 2 {
+		t.Fatalf("Expected the relayed events logged, got %d", len(logged))
+	}
+	var e spade.Event
+	if err = json.Unmarshal(logged[0], &e); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+	if !e.ReceivedAt.Equal(received) || e.Uuid != "uuid-1" || !e.ClientIp.Equal(first.ClientIp) {
+		t.Errorf("Expected the event as the first edge received it, got %+v", e)
+	}
+}
@@ -0,0 +1,156 @@
+package requests
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// TrackingPaths are the paths events are accepted on.
+type TrackingPaths struct {
+	// Exact paths accept events only on that path, e.g. "/track".
+	Exact []string
+
+	// Prefixes accept events on every path starting with them, e.g. "/v1/".
+	Prefixes []string
+}
+
+// DefaultTrackingPaths are the paths events are accepted on unless configured
+// otherwise.
+var DefaultTrackingPaths = TrackingPaths{
+	Exact:    []string{"/", "/track", "/track/"},
+	Prefixes: []string{"/v1/"},
+}
+
+// Validate verifies that every tracking path is absolute and fills in the
+// defaults if none are given.
+func (p *TrackingPaths) Validate() error {
+	if len(p.Exact) == 0 && len(p.Prefixes) == 0 {
+		*p = DefaultTrackingPaths
+	}
+	for _, path := range append(append([]string{}, p.Exact...), p.Prefixes...) {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("path %q must start with /", path)
+		}
+	}
+	return nil
+}
+
+// trackingMatcher matches request paths against TrackingPaths.
+type trackingMatcher struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+func newTrackingMatcher(paths TrackingPaths) *trackingMatcher {
+	m := &trackingMatcher{
+		exact:    make(map[string]bool, len(paths.Exact)),
+		prefixes: append([]string(nil), paths.Prefixes...),
+	}
+	for _, path := range paths.Exact {
+		m.exact[path] = true
+	}
+	return m
+}
+
+func (m *trackingMatcher) matches(path string) bool {
+	if m.exact[path] {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTrackingPaths replaces the paths events are accepted on.
+func (s *SpadeHandler) SetTrackingPaths(paths TrackingPaths) {
+	matcher := newTrackingMatcher(paths)
+	s.settingsMu.Lock()
+	s.trackingPaths = matcher
+	s.settingsMu.Unlock()
+}
+
+// builtinEndpoints are the endpoints served besides the tracking paths. They
+// can't be shadowed by a tracking path.
+var builtinEndpoints = map[string]bool{
+	"/crossdomain.xml": true,
+	"/robots.txt":      true,
+	"/healthcheck":     true,
+	"/xarth":           true,
+	"/r":               true,
+	RelayPath:          true,
+}
+
+// trackingRoute is what builtinRoute returns for the tracking paths. It isn't
+// a valid path, so it can't be requested directly.
+const trackingRoute = "track"
+
+// builtinRoute returns the built-in endpoint that serves path: path itself
+// for one of the builtinEndpoints, trackingRoute for a tracking path, or ""
+// if there is none.
+func (s *SpadeHandler) builtinRoute(path string) string {
+	if builtinEndpoints[path] {
+		return path
+	}
+	s.settingsMu.RLock()
+	matcher := s.trackingPaths
+	s.settingsMu.RUnlock()
+	if matcher.matches(path) {
+		return trackingRoute
+	}
+	return ""
+}
+
+// A RouteFunc serves a route registered with Handle. It writes the whole
+// response and returns its status code, which is recorded like that of any
+// other request.
+type RouteFunc func(w http.ResponseWriter, r *http.Request, context *RequestContext) int
+
+type route struct {
+	pattern string
+	fn      RouteFunc
+}
+
+// byPatternLength sorts routes longest pattern first, so the most specific
+// prefix wins.
+type byPatternLength []route
+
+func (b byPatternLength) Len() int           { return len(b) }
+func (b byPatternLength) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byPatternLength) Less(i, j int) bool { return len(b[i].pattern) > len(b[j].pattern) }
+
+// Handle registers fn to serve pattern, which like an http.ServeMux pattern
+// is an exact path, or a prefix if it ends in a slash. Registered routes take
+// precedence over the built-in endpoints and tracking paths, but requests to
+// them are still screened, authenticated and counted like any other.
+// Registering a pattern again replaces its RouteFunc.
+func (s *SpadeHandler) Handle(pattern string, fn RouteFunc) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	routes := make([]route, 0, len(s.routes)+1)
+	for _, r := range s.routes {
+		if r.pattern != pattern {
+			routes = append(routes, r)
+		}
+	}
+	routes = append(routes, route{pattern: pattern, fn: fn})
+	sort.Stable(byPatternLength(routes))
+	s.routes = routes
+}
+
+// registeredRoute returns the RouteFunc registered for path, or nil.
+func (s *SpadeHandler) registeredRoute(path string) RouteFunc {
+	s.settingsMu.RLock()
+	routes := s.routes
+	s.settingsMu.RUnlock()
+	for _, r := range routes {
+		if r.pattern == path || strings.HasSuffix(r.pattern, "/") && strings.HasPrefix(path, r.pattern) {
+			return r.fn
+		}
+	}
+	return nil
+}
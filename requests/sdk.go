@@ -0,0 +1,70 @@
+package requests
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	// sdkHeader is the header SDKs identify themselves with, as
+	// "<name>/<version>", e.g. "spade-js/2.3.1".
+	sdkHeader = "X-Spade-Client"
+
+	sdkNameProperty    = "sdk_name"
+	sdkVersionProperty = "sdk_version"
+
+	// maxSDKFieldLength bounds the SDK names and versions set on events.
+	maxSDKFieldLength = 64
+)
+
+// sdkStatReplacer replaces the characters of SDK names and versions that
+// aren't kept in stat names, like the dots separating their parts.
+var sdkStatReplacer = strings.NewReplacer(".", "_", "+", "_")
+
+// parseSDK returns the SDK name and version of an X-Spade-Client header
+// value. ok is false for values that aren't a name and a version of letters,
+// digits and "-_.+" separated by a slash.
+func parseSDK(value string) (name, version string, ok bool) {
+	i := strings.IndexByte(value, '/')
+	if i < 0 {
+		return "", "", false
+	}
+	name, version = value[:i], value[i+1:]
+	if !isSDKField(name) || !isSDKField(version) {
+		return "", "", false
+	}
+	return name, version, true
+}
+
+func isSDKField(s string) bool {
+	if s == "" || len(s) > maxSDKFieldLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == '+':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// identifySDK records the SDK the request's X-Spade-Client header names on
+// the context, and counts the request under requests.sdk.<name>.<version>.
+func (s *SpadeHandler) identifySDK(r *http.Request, context *RequestContext) {
+	value := r.Header.Get(sdkHeader)
+	if value == "" {
+		return
+	}
+	name, version, ok := parseSDK(value)
+	if !ok {
+		_ = s.StatLogger.Inc("requests.sdk.malformed", 1, 0.1)
+		return
+	}
+	context.SDKName, context.SDKVersion = name, version
+	dimension := sdkStatReplacer.Replace(strings.ToLower(name)) + "." + sdkStatReplacer.Replace(version)
+	_ = s.StatLogger.Inc("requests.sdk."+s.Dimensions.Limit("requests.sdk", dimension), 1, 0.1)
+}
@@ -0,0 +1,95 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultSequenceProperty = "edge_sequence"
+	defaultRunProperty      = "edge_run"
+)
+
+// SequenceConfig configures the sequence numbers stamped on logged events, so
+// downstream can tell an edge that had no traffic from one whose events were
+// lost.
+type SequenceConfig struct {
+	// Property is the event property the sequence number is set as. It
+	// defaults to edge_sequence.
+	Property string
+
+	// RunProperty is the event property identifying the run of the edge the
+	// sequence belongs to, since it restarts from 1. It defaults to edge_run.
+	RunProperty string
+}
+
+// Validate verifies that a SequenceConfig is valid and fills in defaults
+func (c *SequenceConfig) Validate() error {
+	if c.Property == "" {
+		c.Property = defaultSequenceProperty
+	}
+	if c.RunProperty == "" {
+		c.RunProperty = defaultRunProperty
+	}
+	if c.Property == c.RunProperty {
+		return errors.New("Property and RunProperty must differ")
+	}
+	return nil
+}
+
+// Sequencer numbers the records the loggers write. Every event of a record
+// gets the record's number, and numbers are only taken by records that can be
+// decoded, so a gap downstream means records the edge meant to write were
+// lost. Records that fail to be written also leave a gap, but their clients
+// were answered with an error. Heartbeats keep the numbers going without
+// traffic.
+type Sequencer struct {
+	config SequenceConfig
+	run    string
+	stats  statsd.StatSender
+	last   uint64
+}
+
+// NewSequencer returns a Sequencer for config, numbering from 1 in a run
+// named after instanceID and the time.
+func NewSequencer(config SequenceConfig, instanceID string, stats statsd.StatSender) (*Sequencer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Sequencer{
+		config: config,
+		run:    fmt.Sprintf("%s-%x", instanceID, time.Now().UnixNano()),
+		stats:  stats,
+	}, nil
+}
+
+// Last returns the last sequence number taken.
+func (q *Sequencer) Last() uint64 {
+	return atomic.LoadUint64(&q.last)
+}
+
+// stamp sets the next sequence number and the run on the events of event.
+// Events that can't be decoded are left alone and counted under
+// sequence.error.
+func (q *Sequencer) stamp(event *spade.Event) {
+	var sequence uint64
+	data, _, err := transform.Edit(event.Data, func(_ string, properties map[string]interface{}) bool {
+		if sequence == 0 {
+			sequence = atomic.AddUint64(&q.last, 1)
+		}
+		properties[q.config.Property] = sequence
+		properties[q.config.RunProperty] = q.run
+		return true
+	})
+	if err != nil {
+		_ = q.stats.Inc("sequence.error", 1, 0.1)
+		return
+	}
+	event.Data = data
+}
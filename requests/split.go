@@ -0,0 +1,93 @@
+package requests
+
+import (
+	"sync"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+// Split request outcomes. A request fails if none of its events could be
+// written, or if it was rejected by decoding, possibly after some events were
+// written. It is cancelled if its client disconnected before all of its
+// events were written, and AbortConfig.CancelWrites is set.
+const (
+	SplitSuccess    = "success"
+	SplitPartial    = "partial"
+	SplitFailBase64 = "fail.base64"
+	SplitFailJSON   = "fail.json"
+	SplitFailWrite  = "fail.write"
+	SplitCancelled  = "cancelled"
+)
+
+// SplitOutcome is how splitting a large request turned out.
+type SplitOutcome struct {
+	Result string
+
+	// Events is the number of events decoded from the request, of which
+	// Written were written and Failed couldn't be.
+	Events, Written, Failed int64
+}
+
+// SplitTotals are the outcomes of the split requests since startup.
+type SplitTotals struct {
+	Requests      map[string]int64 `json:"requests"`
+	Events        int64            `json:"events"`
+	EventsWritten int64            `json:"events_written"`
+	EventsFailed  int64            `json:"events_failed"`
+}
+
+// SplitRecorder counts split request outcomes, both as stats and as totals
+// that can be reported by the edge.
+type SplitRecorder struct {
+	stats statsd.StatSender
+
+	mu     sync.Mutex
+	totals SplitTotals
+}
+
+// NewSplitRecorder returns a SplitRecorder sending stats to stats.
+func NewSplitRecorder(stats statsd.StatSender) *SplitRecorder {
+	return &SplitRecorder{
+		stats:  stats,
+		totals: SplitTotals{Requests: make(map[string]int64)},
+	}
+}
+
+// Record counts the outcome of a split request. Every request is counted
+// under split_large_request.request.total and exactly one of
+// split_large_request.request.<result>, with partial results counted as
+// split_large_request.request.fail.partial.
+func (r *SplitRecorder) Record(o SplitOutcome) {
+	r.mu.Lock()
+	r.totals.Requests[o.Result]++
+	r.totals.Events += o.Events
+	r.totals.EventsWritten += o.Written
+	r.totals.EventsFailed += o.Failed
+	r.mu.Unlock()
+
+	stat := o.Result
+	if stat == SplitPartial {
+		stat = "fail.partial"
+	}
+	_ = r.stats.Inc("split_large_request.request.total", 1, 0.1)
+	_ = r.stats.Inc("split_large_request.request."+stat, 1, 0.1)
+	if o.Events > 0 {
+		_ = r.stats.Inc("split_large_request.event.total", o.Events, 0.1)
+		_ = r.stats.Inc("split_large_request.event.success", o.Written, 0.1)
+	}
+	if o.Failed > 0 {
+		_ = r.stats.Inc("split_large_request.event.fail", o.Failed, 0.1)
+	}
+}
+
+// Totals returns a copy of the totals.
+func (r *SplitRecorder) Totals() SplitTotals {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	totals := r.totals
+	totals.Requests = make(map[string]int64, len(r.totals.Requests))
+	for result, n := range r.totals.Requests {
+		totals.Requests[result] = n
+	}
+	return totals
+}
@@ -0,0 +1,97 @@
+package requests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+// DefaultRobotsTxt is served at /robots.txt unless replaced.
+var DefaultRobotsTxt = []byte("User-agent: *\nDisallow: /")
+
+// staticContent is a cacheable response body with its validators.
+type staticContent struct {
+	body     []byte
+	etag     string
+	modified time.Time
+}
+
+func newStaticContent(body []byte, modified time.Time) *staticContent {
+	sum := sha256.Sum256(body)
+	return &staticContent{
+		body:     body,
+		etag:     `"` + hex.EncodeToString(sum[:8]) + `"`,
+		modified: modified.UTC().Truncate(time.Second),
+	}
+}
+
+// replaceStatic returns the content to store for body, keeping current (and
+// its Last-Modified time) if the body hasn't changed.
+func (s *SpadeHandler) replaceStatic(current *staticContent, body []byte) *staticContent {
+	if current != nil && bytes.Equal(current.body, body) {
+		return current
+	}
+	return newStaticContent(body, s.Time())
+}
+
+// SetCrossDomainPolicy replaces the content served at /crossdomain.xml.
+func (s *SpadeHandler) SetCrossDomainPolicy(policy []byte) {
+	s.settingsMu.Lock()
+	s.crossDomainPolicy = s.replaceStatic(s.crossDomainPolicy, policy)
+	s.settingsMu.Unlock()
+}
+
+// SetRobotsTxt replaces the content served at /robots.txt.
+func (s *SpadeHandler) SetRobotsTxt(robots []byte) {
+	s.settingsMu.Lock()
+	s.robotsTxt = s.replaceStatic(s.robotsTxt, robots)
+	s.settingsMu.Unlock()
+}
+
+// writeStatic writes content with ETag and Last-Modified headers, answering
+// with a 304 if the request's If-None-Match or If-Modified-Since shows the
+// client already has it.
+func writeStatic(w http.ResponseWriter, r *http.Request, content *staticContent, contentType string) int {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", content.etag)
+	w.Header().Set("Last-Modified", content.modified.Format(http.TimeFormat))
+
+	if notModified(r, content) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return http.StatusNotModified
+	}
+	if r.Method == "HEAD" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content.body)))
+		w.WriteHeader(http.StatusOK)
+		return http.StatusOK
+	}
+	_, err := w.Write(content.body)
+	if err != nil {
+		logger.WithError(err).WithField("path", r.URL.Path).Error("Unable to write static contents")
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
+func notModified(r *http.Request, content *staticContent) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, etag := range strings.Split(inm, ",") {
+			etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+			if etag == content.etag || etag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		return !content.modified.After(ims)
+	}
+	return false
+}
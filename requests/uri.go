@@ -0,0 +1,46 @@
+package requests
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/twitchscience/spade_edge/runrate"
+)
+
+// largeURIBytes is the length past which request URIs are counted as large,
+// since some proxies and browsers start truncating them there.
+const largeURIBytes = 8192
+
+// checkURILength counts requests with large URIs, and the origins and SDK
+// versions sending them, under large_URI.origin.<host> and
+// large_URI.sdk.<name>.<version>, so their owners can be asked to POST
+// instead. It returns a 414 for URIs longer than MaxURIBytes, or 0.
+func (s *SpadeHandler) checkURILength(r *http.Request, context *RequestContext) int {
+	tooLong := s.MaxURIBytes > 0 && len(r.RequestURI) > s.MaxURIBytes
+	if len(r.RequestURI) > largeURIBytes {
+		_ = s.StatLogger.Inc("large_URI", 1, 1)
+		s.observeRunRate(runrate.LargeURI)
+	} else if !tooLong {
+		return 0
+	}
+
+	origin := "none"
+	if u, err := url.Parse(r.Header.Get("Origin")); err == nil && u.Host != "" {
+		origin = sanitizeHostValue(u.Host)
+	}
+	_ = s.StatLogger.Inc("large_URI.origin."+s.Dimensions.Limit("large_URI.origin", origin), 1, 1)
+	sdk := "none"
+	if context.SDKName != "" {
+		sdk = sdkStatReplacer.Replace(strings.ToLower(context.SDKName)) + "." +
+			sdkStatReplacer.Replace(context.SDKVersion)
+	}
+	_ = s.StatLogger.Inc("large_URI.sdk."+s.Dimensions.Limit("large_URI.sdk", sdk), 1, 1)
+
+	if !tooLong {
+		return 0
+	}
+	_ = s.StatLogger.Inc("large_URI.rejected", 1, 1)
+	context.reject(RejectURITooLong)
+	return http.StatusRequestURITooLong
+}
@@ -0,0 +1,265 @@
+package requests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/features"
+)
+
+const defaultValidatePath = "/validate"
+
+// ValidateConfig configures the endpoint client SDK developers send payloads
+// to, to see what the edge makes of them. Nothing sent to it is logged; to
+// require a token, add its Path to the JWTAuth Endpoints.
+type ValidateConfig struct {
+	// Path is the path the endpoint is served on. It defaults to /validate.
+	Path string
+}
+
+// Validate verifies that a ValidateConfig is valid and fills in defaults
+func (c *ValidateConfig) Validate() error {
+	if c.Path == "" {
+		c.Path = defaultValidatePath
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("Path %q must start with /", c.Path)
+	}
+	return nil
+}
+
+// Diagnosis is what the edge makes of a request to the validate endpoint.
+type Diagnosis struct {
+	// Valid is set if every payload decodes to named events.
+	Valid    bool               `json:"valid"`
+	Payloads []PayloadDiagnosis `json:"payloads"`
+
+	// The UUID, client IP and User-Agent the events would have been logged
+	// with. The User-Agent is only kept when the ua=1 parameter is set.
+	UUID      string `json:"uuid"`
+	ClientIP  string `json:"clientIp,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// BadClient is set if the body starts with a stray "data=".
+	BadClient bool `json:"badClient,omitempty"`
+
+	// Errors are the problems with the request as a whole.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// PayloadDiagnosis is what the edge makes of a data value.
+type PayloadDiagnosis struct {
+	// Encoding is how the events are encoded: base64, base64url, base64space
+	// or json for a JSON body.
+	Encoding string `json:"encoding"`
+
+	// Bytes is the size of the data as the edge logs it, base64 encoded.
+	Bytes int `json:"bytes"`
+
+	// Events are the events as they would be logged, after transforms and
+	// annotations.
+	Events []map[string]interface{} `json:"events"`
+	Errors []string                 `json:"errors,omitempty"`
+}
+
+// ServeValidate is a RouteFunc that accepts the same requests as the tracking
+// paths, but answers with a JSON Diagnosis instead of logging their events.
+func (s *SpadeHandler) ServeValidate(w http.ResponseWriter, r *http.Request, context *RequestContext) int {
+	if s.MaxRequestBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.MaxRequestBytes)
+	}
+	diagnosis, status := s.diagnose(r, context)
+	b, err := json.MarshalIndent(diagnosis, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+	return status
+}
+
+// diagnose decodes r like ExtractEvent does, and returns the diagnosis and the
+// status code to respond with.
+func (s *SpadeHandler) diagnose(r *http.Request, context *RequestContext) (*Diagnosis, int) {
+	d := &Diagnosis{Payloads: []PayloadDiagnosis{}}
+	values := r.URL.Query()
+	xForwardedFor := r.Header.Get(context.IPHeader)
+	clientIP := parseLastForwarder(xForwardedFor)
+	if clientIP != nil {
+		d.ClientIP = clientIP.String()
+	}
+	var buf [64]byte
+	d.UUID = string(s.appendUUID(buf[:0], context.Now, atomic.LoadUint64(&s.eventCount)+1))
+	fail := func(status int, format string, args ...interface{}) (*Diagnosis, int) {
+		d.Errors = append(d.Errors, fmt.Sprintf(format, args...))
+		return d, status
+	}
+
+	var kind string
+	if r.Method == "POST" {
+		var statusCode int
+		if kind, statusCode = s.checkContentType(r); statusCode != 0 {
+			return fail(statusCode, "unsupported Content-Type %q", r.Header.Get("Content-Type"))
+		}
+	}
+	if err := r.ParseForm(); err != nil {
+		return fail(http.StatusBadRequest, "error parsing form: %v", err)
+	}
+	if kind == contentTypeMultipart {
+		if statusCode := s.parseMultipart(r); statusCode != 0 {
+			return fail(statusCode, "error parsing multipart body")
+		}
+	}
+
+	dataValues := r.Form["data"]
+	encoding := ""
+	if len(dataValues) == 0 || dataValues[0] == "" {
+		if r.Method != "POST" {
+			return fail(http.StatusBadRequest, "no data parameter")
+		}
+		// A JSON body is re-encoded as base64 when it's read, so peek at
+		// what it starts with to report it.
+		body := bufio.NewReader(r.Body)
+		if first, err := body.Peek(1); err == nil && kind == contentTypeJSON &&
+			(first[0] == '{' || first[0] == '[') {
+			encoding = "json"
+		}
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{body, r.Body}
+		data, statusCode := s.readBody(r, kind, context)
+		if statusCode != 0 {
+			return fail(statusCode, "error reading body: %s", http.StatusText(statusCode))
+		}
+		d.BadClient = context.BadClient
+		dataValues = []string{data}
+	}
+	if len(dataValues) > 1 && s.MaxDataValues <= 1 {
+		d.Errors = append(d.Errors, fmt.Sprintf("only the first of %d data values would be logged", len(dataValues)))
+		dataValues = dataValues[:1]
+	} else if s.MaxDataValues > 1 && len(dataValues) > s.MaxDataValues {
+		d.Errors = append(d.Errors, fmt.Sprintf("only the first %d of %d data values would be logged",
+			s.MaxDataValues, len(dataValues)))
+		dataValues = dataValues[:s.MaxDataValues]
+	}
+
+	if values.Get("ua") == "1" {
+		if ua := r.Header.Get("User-Agent"); len(ua) > maxUserAgentBytes {
+			d.Errors = append(d.Errors, fmt.Sprintf("User-Agent over %d bytes would be dropped", maxUserAgentBytes))
+		} else {
+			d.UserAgent = ua
+		}
+	}
+
+	d.Valid = len(d.Errors) == 0
+	for _, data := range dataValues {
+		p := s.diagnosePayload(r, context, data, encoding)
+		if len(p.Errors) > 0 {
+			d.Valid = false
+		}
+		d.Payloads = append(d.Payloads, p)
+	}
+	return d, http.StatusOK
+}
+
+// diagnosePayload decodes a data value, after the transforms and annotations
+// it would be logged with.
+func (s *SpadeHandler) diagnosePayload(r *http.Request, context *RequestContext, data, encoding string) PayloadDiagnosis {
+	p := PayloadDiagnosis{Encoding: encoding, Events: []map[string]interface{}{}}
+	if data == "" {
+		p.Errors = append(p.Errors, "empty data")
+		return p
+	}
+	if p.Encoding == "" {
+		p.Encoding = encodingName(base64Encoding(data))
+	}
+	clientIP := parseLastForwarder(r.Header.Get(context.IPHeader))
+	data = s.annotate(r, context, clientIP, s.transform(data))
+	if s.Naming != nil {
+		var violations []string
+		data, violations = s.Naming.check(data)
+		if len(violations) > 0 && s.Naming.Enforced() {
+			p.Errors = append(p.Errors, fmt.Sprintf("event names violate the naming policy (%s): "+
+				"the request would be rejected", strings.Join(violations, ", ")))
+		}
+	}
+	p.Bytes = len(data)
+	// Large requests are split and their events logged one by one, unless
+	// that's turned off.
+	if p.Bytes > maxBytesPerRequest && !s.featureEnabled(features.HandleLargeEvents, clientIP, s.handleLargeEvents) {
+		p.Errors = append(p.Errors, fmt.Sprintf("over %d bytes: the request would be rejected", maxBytesPerRequest))
+	}
+
+	decoded, err := base64Encoding(data).DecodeString(data)
+	if err != nil {
+		p.Errors = append(p.Errors, fmt.Sprintf("error base64-decoding data: %v", err))
+		return p
+	}
+	decoded = bytes.TrimSpace(decoded)
+	dec := json.NewDecoder(bytes.NewReader(decoded))
+	dec.UseNumber()
+	if len(decoded) > 0 && decoded[0] == '[' {
+		var events []interface{}
+		if err = dec.Decode(&events); err != nil {
+			p.Errors = append(p.Errors, fmt.Sprintf("error unmarshaling events: %v", err))
+			return p
+		}
+		if len(events) == 0 {
+			p.Errors = append(p.Errors, "empty array of events")
+		}
+		for i, e := range events {
+			p.addEvent(fmt.Sprintf("event %d", i), e)
+		}
+		return p
+	}
+	var event interface{}
+	if err = dec.Decode(&event); err != nil {
+		p.Errors = append(p.Errors, fmt.Sprintf("error unmarshaling event: %v", err))
+		return p
+	}
+	p.addEvent("event", event)
+	return p
+}
+
+// addEvent adds a decoded event, checking it has a name and its properties
+// are an object.
+func (p *PayloadDiagnosis) addEvent(label string, e interface{}) {
+	event, ok := e.(map[string]interface{})
+	if !ok {
+		p.Errors = append(p.Errors, label+" isn't an object")
+		return
+	}
+	p.Events = append(p.Events, event)
+	if name, ok := event["event"].(string); !ok || name == "" {
+		p.Errors = append(p.Errors, label+" has no event name")
+	}
+	if properties, ok := event["properties"]; ok {
+		if _, ok = properties.(map[string]interface{}); !ok {
+			p.Errors = append(p.Errors, label+"'s properties aren't an object")
+		}
+	}
+}
+
+// encodingName names a base64 encoding base64Encoding returns.
+func encodingName(encoding *base64.Encoding) string {
+	switch encoding {
+	case base64.URLEncoding:
+		return "base64url"
+	case spade.SpaceEncoding:
+		return "base64space"
+	default:
+		return "base64"
+	}
+}
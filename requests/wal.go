@@ -0,0 +1,103 @@
+package requests
+
+import (
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+	"github.com/twitchscience/spade_edge/wal"
+)
+
+// walEntry is an event on its way to the sinks, with the ticket to ack it in
+// the write-ahead log with and the tenant it was sent to.
+type walEntry struct {
+	event  *spade.Event
+	ticket wal.Ticket
+	tenant string
+}
+
+// appendWAL appends event to the write-ahead log, if there is one.
+func (e *EdgeLoggers) appendWAL(event *spade.Event) (walEntry, error) {
+	entry := walEntry{event: event}
+	if e.WAL == nil {
+		return entry, nil
+	}
+	var err error
+	entry.ticket, err = e.WAL.Append(event)
+	return entry, err
+}
+
+// ackWAL acks entry in the write-ahead log if every configured sink wrote its
+// event. Otherwise the event is left to be replayed when the edge restarts.
+func (e *EdgeLoggers) ackWAL(entry walEntry, errs ...error) {
+	if e.WAL == nil {
+		return
+	}
+	for _, err := range errs {
+		if err != nil && err != loggers.ErrUndefined {
+			return
+		}
+	}
+	e.WAL.Ack(entry.ticket)
+}
+
+// expired returns whether event was received longer ago than MaxReplayAge.
+func (e *EdgeLoggers) expired(event *spade.Event) bool {
+	return e.MaxReplayAge > 0 && e.now().Sub(event.ReceivedAt) > e.MaxReplayAge
+}
+
+// ReplayWAL writes the events a previous run left unacked in the write-ahead
+// log to the sinks, returning how many it wrote and how many it dropped for
+// being older than MaxReplayAge. It stops at the first event no sink accepts,
+// or when the loggers are closed, leaving it and the rest for the next run.
+// Each event is appended to the log again, to be acked once the sinks have
+// it, since a replayed segment is deleted as soon as it's been read. The log
+// doesn't keep the tenants of events, so they're all written to the edge's
+// own sinks.
+func (e *EdgeLoggers) ReplayWAL() (replayed, expired int, err error) {
+	err = e.WAL.Replay(func(event *spade.Event) error {
+		e.Add(1)
+		defer e.Done()
+		if e.isClosed() {
+			return errLoggersClosed
+		}
+		if e.expired(event) {
+			expired++
+			return nil
+		}
+		entry, err := e.appendWAL(event)
+		if err != nil {
+			return err
+		}
+		eventErr, kinesisErr := e.write(entry)
+		if eventErr != nil && kinesisErr != nil {
+			return errNoLogger
+		}
+		replayed++
+		return nil
+	})
+	return replayed, expired, err
+}
+
+// ReplaySpool writes the events a previous run left in the spool at the end
+// of the fallback chain to the Kinesis logger, returning how many it wrote and
+// how many it dropped for being older than MaxReplayAge. They were written to
+// the S3 event logger when they were received. Like ReplayWAL, it stops at the
+// first event it can't write, or when the loggers are closed.
+func (e *EdgeLoggers) ReplaySpool(spool *loggers.SpoolLogger) (replayed, expired int, err error) {
+	err = spool.Replay(func(event *spade.Event) error {
+		e.Add(1)
+		defer e.Done()
+		if e.isClosed() {
+			return errLoggersClosed
+		}
+		if e.expired(event) {
+			expired++
+			return nil
+		}
+		if err := e.KinesisEventLogger.Log(event); err != nil {
+			return err
+		}
+		replayed++
+		return nil
+	})
+	return replayed, expired, err
+}
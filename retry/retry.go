@@ -0,0 +1,191 @@
+/*
+Package retry retries failed sink writes with exponential backoff and jitter.
+
+Errors are retried unless they are classified as permanent: errors wrapped with
+Permanent, JSON marshalling errors, which fail the same way every time, and AWS
+errors whose code is listed in NonRetryableCodes.
+*/
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = "50ms"
+	defaultMaxBackoff     = "1s"
+	defaultJitter         = 0.2
+)
+
+// defaultNonRetryableCodes are AWS error codes that retrying won't fix.
+var defaultNonRetryableCodes = []string{
+	"AccessDenied",
+	"InvalidArgumentException",
+	"NoSuchBucket",
+	"ResourceNotFoundException",
+	"ValidationException",
+}
+
+// Config configures how a sink's writes are retried. Zero values are replaced
+// with defaults.
+type Config struct {
+	// MaxAttempts is the most times a write is attempted, including the first
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, e.g. "50ms". Each
+	// further retry doubles it.
+	InitialBackoff string
+
+	// MaxBackoff caps the delay between attempts, e.g. "1s"
+	MaxBackoff string
+
+	// Jitter is the fraction of each delay that is randomized, between 0 and 1
+	Jitter float64
+
+	// NonRetryableCodes are the AWS error codes that aren't retried
+	NonRetryableCodes []string
+}
+
+func (c *Config) applyDefaults() {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.InitialBackoff == "" {
+		c.InitialBackoff = defaultInitialBackoff
+	}
+	if c.MaxBackoff == "" {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.Jitter == 0 {
+		c.Jitter = defaultJitter
+	}
+	if c.NonRetryableCodes == nil {
+		c.NonRetryableCodes = defaultNonRetryableCodes
+	}
+}
+
+// Validate fills in defaults and verifies that a Config is valid.
+func (c *Config) Validate() error {
+	c.applyDefaults()
+	if c.MaxAttempts < 1 {
+		return errors.New("MaxAttempts must be at least 1")
+	}
+	for _, d := range []string{c.InitialBackoff, c.MaxBackoff} {
+		if parsed, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		} else if parsed <= 0 {
+			return fmt.Errorf("duration %s must be greater than 0", d)
+		}
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return errors.New("Jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// permanentError marks an error as not worth retrying.
+type permanentError struct {
+	error
+}
+
+// Permanent wraps err so that it isn't retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+// Retrier retries the writes of a sink.
+type Retrier struct {
+	name           string
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         float64
+	nonRetryable   map[string]bool
+	stats          statsd.StatSender
+	sleep          func(time.Duration)
+}
+
+// New returns a Retrier for the named sink.
+func New(name string, config Config, stats statsd.StatSender) (*Retrier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	initialBackoff, _ := time.ParseDuration(config.InitialBackoff)
+	maxBackoff, _ := time.ParseDuration(config.MaxBackoff)
+	nonRetryable := make(map[string]bool, len(config.NonRetryableCodes))
+	for _, code := range config.NonRetryableCodes {
+		nonRetryable[code] = true
+	}
+	return &Retrier{
+		name:           name,
+		maxAttempts:    config.MaxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		jitter:         config.Jitter,
+		nonRetryable:   nonRetryable,
+		stats:          stats,
+		sleep:          time.Sleep,
+	}, nil
+}
+
+// Do runs fn until it succeeds, fails with an error that isn't retryable, or
+// has been attempted MaxAttempts times, and returns its last error.
+func (r *Retrier) Do(fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				_ = r.stats.Inc("retry."+r.name+".recovered", 1, 0.1)
+			}
+			return nil
+		}
+		if !r.retryable(err) {
+			if p, ok := err.(permanentError); ok {
+				return p.error
+			}
+			return err
+		}
+		if attempt >= r.maxAttempts {
+			_ = r.stats.Inc("retry."+r.name+".exhausted", 1, 0.1)
+			return err
+		}
+		_ = r.stats.Inc("retry."+r.name+".attempt", 1, 0.1)
+		r.sleep(r.backoff(attempt))
+	}
+}
+
+// retryable classifies err.
+func (r *Retrier) retryable(err error) bool {
+	switch e := err.(type) {
+	case permanentError, *json.MarshalerError, *json.UnsupportedTypeError, *json.UnsupportedValueError:
+		return false
+	case awserr.Error:
+		return !r.nonRetryable[e.Code()]
+	}
+	return true
+}
+
+// backoff returns the delay after the given attempt: the initial backoff
+// doubled for each earlier retry, capped at the max backoff, with up to the
+// jitter fraction of it taken off at random.
+func (r *Retrier) backoff(attempt int) time.Duration {
+	d := r.initialBackoff
+	for i := 1; i < attempt && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d - time.Duration(r.jitter*rand.Float64()*float64(d))
+}
@@ -0,0 +1,95 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func newTestRetrier(t *testing.T, config Config, slept *[]time.Duration) *Retrier {
+	s, _ := statsd.NewNoop()
+	r, err := New("test", config, s)
+	if err != nil {
+		t.Fatalf("Failed to create retrier: %s", err)
+	}
+	r.sleep = func(d time.Duration) { *slept = append(*slept, d) }
+	return r
+}
+
+func failing(n int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestRetriesWithBackoff(t *testing.T) {
+	var slept []time.Duration
+	r := newTestRetrier(t, Config{
+		MaxAttempts:    5,
+		InitialBackoff: "10ms",
+		MaxBackoff:     "25ms",
+		Jitter:         0.5,
+	}, &slept)
+
+	fn, calls := failing(4, errors.New("unavailable"))
+	if err := r.Do(fn); err != nil {
+		t.Fatalf("Expected success on the last attempt, got %s", err)
+	}
+	if *calls != 5 {
+		t.Errorf("Expected 5 attempts, got %d", *calls)
+	}
+	max := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond, 25 * time.Millisecond}
+	if len(slept) != len(max) {
+		t.Fatalf("Expected %d backoffs, got %v", len(max), slept)
+	}
+	for i, d := range slept {
+		if d > max[i] || d < max[i]/2 {
+			t.Errorf("Backoff %d: expected between %s and %s, got %s", i, max[i]/2, max[i], d)
+		}
+	}
+}
+
+func TestGivesUpAfterMaxAttempts(t *testing.T) {
+	var slept []time.Duration
+	r := newTestRetrier(t, Config{MaxAttempts: 2}, &slept)
+	errSink := errors.New("unavailable")
+	fn, calls := failing(10, errSink)
+	if err := r.Do(fn); err != errSink {
+		t.Errorf("Expected the last error, got %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", *calls)
+	}
+}
+
+func TestPermanentErrorsAreNotRetried(t *testing.T) {
+	var slept []time.Duration
+	r := newTestRetrier(t, Config{}, &slept)
+	errSink := errors.New("bad event")
+	for _, err := range []error{
+		Permanent(errSink),
+		awserr.New("ValidationException", "bad", nil),
+	} {
+		fn, calls := failing(10, err)
+		_ = r.Do(fn)
+		if *calls != 1 {
+			t.Errorf("Expected %v not to be retried, got %d attempts", err, *calls)
+		}
+	}
+	fn, _ := failing(10, Permanent(errSink))
+	if err := r.Do(fn); err != errSink {
+		t.Errorf("Expected the unwrapped error, got %v", err)
+	}
+	fn, calls := failing(1, awserr.New("ProvisionedThroughputExceededException", "slow down", nil))
+	if err := r.Do(fn); err != nil || *calls != 2 {
+		t.Errorf("Expected throttling to be retried, got %v after %d attempts", err, *calls)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Package rollup counts counter-style events at the edge instead of forwarding
+each one. Events with a configured name are taken out of their request and
+counted by name and properties; at the end of each window one summary event
+per distinct name and properties is emitted, carrying the count.
+
+Summary events have the properties of the events they count plus:
+
+	edge_rollup_count         the number of events counted
+	edge_rollup_window_start  the start of the window, in Unix seconds
+	edge_rollup_window        the length of the window, in seconds
+
+The client IP and User-Agent of counted events aren't kept.
+*/
+package rollup
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+const (
+	defaultWindow  = "1m"
+	defaultMaxKeys = 10000
+
+	// Property names added to summary events.
+	countProperty       = "edge_rollup_count"
+	windowStartProperty = "edge_rollup_window_start"
+	windowProperty      = "edge_rollup_window"
+)
+
+// Config configures which events are counted at the edge.
+type Config struct {
+	// Events are the names of the events to count
+	Events []string
+
+	// Window is how often summary events are emitted, e.g. "1m"
+	Window string
+
+	// MaxKeys is the most distinct names and properties counted per window.
+	// Events beyond it are forwarded as is.
+	MaxKeys int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if len(c.Events) == 0 {
+		return errors.New("Events must not be empty")
+	}
+	if c.Window == "" {
+		c.Window = defaultWindow
+	}
+	window, err := time.ParseDuration(c.Window)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Window, err)
+	}
+	if window < time.Second {
+		return errors.New("Window must be at least 1s")
+	}
+	if c.MaxKeys == 0 {
+		c.MaxKeys = defaultMaxKeys
+	}
+	if c.MaxKeys < 0 {
+		return errors.New("MaxKeys must be greater than 0")
+	}
+	return nil
+}
+
+// Rollup counts configured events and emits summaries of them.
+type Rollup struct {
+	events  map[string]bool
+	window  time.Duration
+	maxKeys int
+	stats   statsd.StatSender
+	now     func() time.Time
+
+	mu          sync.Mutex
+	counts      map[string]*count
+	windowStart time.Time
+
+	quit    chan struct{}
+	done    chan struct{}
+	running bool
+}
+
+// count is the number of events with the same name and properties.
+type count struct {
+	name       string
+	properties map[string]interface{}
+	n          int64
+}
+
+// New returns a Rollup for config.
+func New(config Config, stats statsd.StatSender) (*Rollup, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	window, _ := time.ParseDuration(config.Window)
+	r := &Rollup{
+		events:  make(map[string]bool, len(config.Events)),
+		window:  window,
+		maxKeys: config.MaxKeys,
+		stats:   stats,
+		now:     time.Now,
+		counts:  make(map[string]*count),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	for _, e := range config.Events {
+		r.events[e] = true
+	}
+	r.windowStart = r.now()
+	return r, nil
+}
+
+// Absorb counts the configured events in the base64 encoded event (or array
+// of events) in data and returns the rest of data re-encoded, or "" if every
+// event was counted. data is returned as is if it has no configured events
+// or can't be decoded.
+func (r *Rollup) Absorb(data string) string {
+	decoded, err := spade.DetermineBase64Encoding([]byte(data)).DecodeString(data)
+	if err != nil {
+		return data
+	}
+	var payload interface{}
+	dec := json.NewDecoder(bytes.NewReader(decoded))
+	dec.UseNumber()
+	if err = dec.Decode(&payload); err != nil {
+		return data
+	}
+
+	var events []interface{}
+	batch := false
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		events = []interface{}{p}
+	case []interface{}:
+		events = p
+		batch = true
+	default:
+		return data
+	}
+
+	var remaining []interface{}
+	r.mu.Lock()
+	for _, e := range events {
+		if !r.add(e) {
+			remaining = append(remaining, e)
+		}
+	}
+	r.mu.Unlock()
+
+	absorbed := len(events) - len(remaining)
+	if absorbed == 0 {
+		return data
+	}
+	_ = r.stats.Inc("rollup.absorbed", int64(absorbed), 0.1)
+	if len(remaining) == 0 {
+		return ""
+	}
+	var rest interface{} = remaining
+	if !batch {
+		rest = remaining[0]
+	}
+	encoded, err := json.Marshal(rest)
+	if err != nil {
+		return data
+	}
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// add counts e if it is a configured event and reports whether it was
+// counted. r.mu must be held.
+func (r *Rollup) add(e interface{}) bool {
+	event, ok := e.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	name, _ := event["event"].(string)
+	if !r.events[name] {
+		return false
+	}
+	properties, _ := event["properties"].(map[string]interface{})
+	// Maps are marshaled with sorted keys, so equal properties share a key.
+	key, err := json.Marshal([]interface{}{name, properties})
+	if err != nil {
+		return false
+	}
+	c, ok := r.counts[string(key)]
+	if !ok {
+		if len(r.counts) >= r.maxKeys {
+			_ = r.stats.Inc("rollup.overflow", 1, 0.1)
+			return false
+		}
+		c = &count{name: name, properties: properties}
+		r.counts[string(key)] = c
+	}
+	c.n++
+	return true
+}
+
+// Flush emits a summary event for each count of the window and starts a new
+// window.
+func (r *Rollup) Flush(emit func(data string) error) {
+	r.mu.Lock()
+	counts, start := r.counts, r.windowStart
+	r.counts = make(map[string]*count, len(counts))
+	r.windowStart = r.now()
+	r.mu.Unlock()
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		c := counts[k]
+		properties := make(map[string]interface{}, len(c.properties)+3)
+		for p, v := range c.properties {
+			properties[p] = v
+		}
+		properties[countProperty] = c.n
+		properties[windowStartProperty] = start.Unix()
+		properties[windowProperty] = int64(r.window / time.Second)
+		encoded, err := json.Marshal(map[string]interface{}{
+			"event":      c.name,
+			"properties": properties,
+		})
+		if err == nil {
+			err = emit(base64.StdEncoding.EncodeToString(encoded))
+		}
+		if err != nil {
+			logger.WithError(err).WithField("event", c.name).Error("Error emitting rollup summary")
+			_ = r.stats.Inc("rollup.summary.failed", 1, 1)
+			continue
+		}
+		_ = r.stats.Inc("rollup.summary.emitted", 1, 1)
+	}
+}
+
+// Run emits summaries with emit every window until Close is called, then
+// emits the last window's.
+func (r *Rollup) Run(emit func(data string) error) {
+	r.mu.Lock()
+	r.running = true
+	r.mu.Unlock()
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.quit:
+			r.Flush(emit)
+			return
+		case <-ticker.C:
+			r.Flush(emit)
+		}
+	}
+}
+
+// Close stops Run, waiting for it to emit the last window's summaries.
+func (r *Rollup) Close() {
+	close(r.quit)
+	r.mu.Lock()
+	running := r.running
+	r.mu.Unlock()
+	if running {
+		<-r.done
+	}
+}
@@ -0,0 +1,88 @@
+package rollup
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+func encode(payload string) string {
+	return base64.StdEncoding.EncodeToString([]byte(payload))
+}
+
+func decode(t *testing.T, data string) interface{} {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("Failed to decode %q: %s", data, err)
+	}
+	var v interface{}
+	if err = json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("Failed to unmarshal %s: %s", b, err)
+	}
+	return v
+}
+
+func newTestRollup(t *testing.T, maxKeys int) *Rollup {
+	stats, _ := statsd.NewNoop()
+	r, err := New(Config{Events: []string{"ping"}, MaxKeys: maxKeys}, stats)
+	if err != nil {
+		t.Fatalf("Failed to create rollup: %s", err)
+	}
+	r.windowStart = time.Unix(1500000000, 0)
+	return r
+}
+
+func TestAbsorb(t *testing.T) {
+	r := newTestRollup(t, 0)
+
+	other := encode(`{"event":"play","properties":{"channel":"a"}}`)
+	if got := r.Absorb(other); got != other {
+		t.Errorf("Expected unconfigured event to pass through, got %q", got)
+	}
+	if got := r.Absorb(encode(`{"event":"ping","properties":{"channel":"a"}}`)); got != "" {
+		t.Errorf("Expected single ping to be absorbed, got %q", got)
+	}
+	got := r.Absorb(encode(`[{"event":"ping","properties":{"channel":"a"}},` +
+		`{"event":"play","properties":{"channel":"a"}},{"event":"ping","properties":{"channel":"b"}}]`))
+	expected := []interface{}{map[string]interface{}{
+		"event": "play", "properties": map[string]interface{}{"channel": "a"},
+	}}
+	if v := decode(t, got); !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected %v to remain, got %v", expected, v)
+	}
+
+	var summaries []interface{}
+	r.Flush(func(data string) error {
+		summaries = append(summaries, decode(t, data))
+		return nil
+	})
+	expectedSummaries := []interface{}{
+		map[string]interface{}{"event": "ping", "properties": map[string]interface{}{
+			"channel": "a", countProperty: 2.0, windowStartProperty: 1500000000.0, windowProperty: 60.0,
+		}},
+		map[string]interface{}{"event": "ping", "properties": map[string]interface{}{
+			"channel": "b", countProperty: 1.0, windowStartProperty: 1500000000.0, windowProperty: 60.0,
+		}},
+	}
+	if !reflect.DeepEqual(summaries, expectedSummaries) {
+		t.Errorf("Expected summaries %v, got %v", expectedSummaries, summaries)
+	}
+
+	r.Flush(func(data string) error {
+		t.Errorf("Expected no summaries for an empty window, got %s", data)
+		return nil
+	})
+}
+
+func TestAbsorbOverflow(t *testing.T) {
+	r := newTestRollup(t, 1)
+	r.Absorb(encode(`{"event":"ping","properties":{"channel":"a"}}`))
+	overflow := encode(`{"event":"ping","properties":{"channel":"b"}}`)
+	if got := r.Absorb(overflow); got != overflow {
+		t.Errorf("Expected events beyond MaxKeys to pass through, got %q", got)
+	}
+}
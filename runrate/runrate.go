@@ -0,0 +1,287 @@
+/*
+Package runrate keeps daily counts of bad client behaviors in a file on local
+disk, so they can be tracked over months and across restarts rather than for
+as long as statsd keeps them.
+
+The behaviors counted are
+
+	event_in_uri          events sent in the query string rather than the body
+	large_uri             request URIs over 8KB
+	bad_client            bodies starting with "data=", from clients that
+	                      form-encode them twice
+	truncated_user_agent  User-Agents too long to record, which are dropped
+
+Counts are kept per UTC day in memory, and written to the file every
+FlushInterval and on Close, replacing it atomically. Days older than
+RetentionDays are dropped.
+*/
+package runrate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+// The behaviors counted.
+const (
+	EventInURI         = "event_in_uri"
+	LargeURI           = "large_uri"
+	BadClient          = "bad_client"
+	TruncatedUserAgent = "truncated_user_agent"
+)
+
+var behaviors = []string{EventInURI, LargeURI, BadClient, TruncatedUserAgent}
+
+const (
+	defaultFlushInterval = "1m"
+	defaultRetentionDays = 400
+
+	dayFormat = "2006-01-02"
+)
+
+// Config configures the run-rate store.
+type Config struct {
+	// Path is the file the counts are kept in
+	Path string
+
+	// FlushInterval is how often the counts are written to Path, e.g. "1m"
+	FlushInterval string
+
+	// RetentionDays is how many days of counts are kept. It defaults to 400.
+	RetentionDays int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return errors.New("Path is required")
+	}
+	if c.FlushInterval == "" {
+		c.FlushInterval = defaultFlushInterval
+	}
+	interval, err := time.ParseDuration(c.FlushInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.FlushInterval, err)
+	}
+	if interval <= 0 {
+		return errors.New("FlushInterval must be greater than 0")
+	}
+	if c.RetentionDays == 0 {
+		c.RetentionDays = defaultRetentionDays
+	}
+	if c.RetentionDays < 0 {
+		return errors.New("RetentionDays must be a positive value")
+	}
+	return nil
+}
+
+// Day is the counts of a UTC day, by behavior.
+type Day struct {
+	Date   string           `json:"date"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Store counts behaviors per day and keeps the counts in a file.
+type Store struct {
+	path          string
+	flushInterval time.Duration
+	retentionDays int
+	now           func() time.Time
+
+	mu   sync.Mutex
+	days map[string]map[string]int64
+	// dirty is set when there are counts that haven't been written.
+	dirty bool
+
+	quit    chan struct{}
+	done    chan struct{}
+	running bool
+}
+
+// Open returns a Store for config, with the counts already in its file.
+func Open(config Config) (*Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		path:          config.Path,
+		retentionDays: config.RetentionDays,
+		now:           time.Now,
+		days:          make(map[string]map[string]int64),
+		quit:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	s.flushInterval, _ = time.ParseDuration(config.FlushInterval)
+	b, err := ioutil.ReadFile(config.Path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var days []Day
+	if err = json.Unmarshal(b, &days); err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", config.Path, err)
+	}
+	for _, d := range days {
+		s.days[d.Date] = d.Counts
+	}
+	return s, nil
+}
+
+// Observe counts an occurrence of behavior today.
+func (s *Store) Observe(behavior string) {
+	date := s.now().UTC().Format(dayFormat)
+	s.mu.Lock()
+	counts, ok := s.days[date]
+	if !ok {
+		counts = make(map[string]int64, len(behaviors))
+		s.days[date] = counts
+	}
+	counts[behavior]++
+	s.dirty = true
+	s.mu.Unlock()
+}
+
+// Days returns the counts of the last days days, or all of them if days is
+// 0, oldest first.
+func (s *Store) Days(days int) []Day {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Day, 0, len(s.days))
+	for date, counts := range s.days {
+		d := Day{Date: date, Counts: make(map[string]int64, len(counts))}
+		for behavior, n := range counts {
+			d.Counts[behavior] = n
+		}
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date < out[j].Date })
+	if days > 0 && len(out) > days {
+		out = out[len(out)-days:]
+	}
+	return out
+}
+
+// Flush drops the days past retention and writes the counts to the file, if
+// they changed.
+func (s *Store) Flush() error {
+	cutoff := s.now().UTC().AddDate(0, 0, -s.retentionDays).Format(dayFormat)
+	s.mu.Lock()
+	for date := range s.days {
+		if date <= cutoff {
+			delete(s.days, date)
+			s.dirty = true
+		}
+	}
+	dirty := s.dirty
+	s.dirty = false
+	s.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	b, err := json.Marshal(s.Days(0))
+	if err == nil {
+		err = writeFile(s.path, b)
+	}
+	if err != nil {
+		// Try again next time.
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// writeFile replaces path with a file holding b.
+func writeFile(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(b); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Run writes the counts every FlushInterval until Close is called.
+func (s *Store) Run() {
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				logger.WithError(err).WithField("path", s.path).Error("Failed to write run-rate counts")
+			}
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return, and writes the counts.
+func (s *Store) Close() error {
+	close(s.quit)
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+	if running {
+		<-s.done
+	}
+	return s.Flush()
+}
+
+// ServeHTTP exports the counts as JSON, or as CSV with a column per behavior
+// if the format query parameter is "csv". The days query parameter asks for
+// only the last days.
+func (s *Store) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	counts := s.Days(days)
+	if r.URL.Query().Get("format") != "csv" {
+		b, err := json.MarshalIndent(counts, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	_ = cw.Write(append([]string{"date"}, behaviors...))
+	for _, d := range counts {
+		row := []string{d.Date}
+		for _, behavior := range behaviors {
+			row = append(row, strconv.FormatInt(d.Counts[behavior], 10))
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+}
@@ -0,0 +1,83 @@
+package runrate
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	config := Config{Path: filepath.Join(dir, "runrate.json"), RetentionDays: 2}
+
+	s, err := Open(config)
+	if err != nil {
+		t.Fatalf("Failed to open store: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 23, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	s.Observe(EventInURI)
+	s.Observe(EventInURI)
+	now = now.Add(2 * time.Hour)
+	s.Observe(LargeURI)
+	if err = s.Close(); err != nil {
+		t.Fatalf("Failed to close store: %s", err)
+	}
+
+	// The counts survive a restart.
+	s, err = Open(config)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %s", err)
+	}
+	s.now = func() time.Time { return now }
+	s.Observe(BadClient)
+	days := s.Days(0)
+	if len(days) != 2 || days[0].Date != "2017-06-01" || days[0].Counts[EventInURI] != 2 ||
+		days[1].Counts[LargeURI] != 1 || days[1].Counts[BadClient] != 1 {
+		t.Fatalf("Expected counts for two days, got %v", days)
+	}
+	if days = s.Days(1); len(days) != 1 || days[0].Date != "2017-06-02" {
+		t.Errorf("Expected only the last day, got %v", days)
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/debug/runrate?format=csv", nil))
+	expected := "date,event_in_uri,large_uri,bad_client,truncated_user_agent\n" +
+		"2017-06-01,2,0,0,0\n2017-06-02,0,1,1,0\n"
+	if w.Body.String() != expected {
+		t.Errorf("Expected a row per day, got %q", w.Body.String())
+	}
+
+	// Days past retention are dropped.
+	now = now.AddDate(0, 0, 1)
+	if err = s.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %s", err)
+	}
+	if days = s.Days(0); len(days) != 1 || days[0].Date != "2017-06-02" {
+		t.Errorf("Expected the first day dropped, got %v", days)
+	}
+	b, _ := ioutil.ReadFile(config.Path)
+	if strings.Contains(string(b), "2017-06-01") {
+		t.Errorf("Expected the first day dropped from the file, got %s", b)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{Path: "runrate.json", FlushInterval: "soon"},
+		{Path: "runrate.json", RetentionDays: -1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
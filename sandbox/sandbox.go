@@ -0,0 +1,100 @@
+/*
+Package sandbox reduces what the edge process can do once it has bound its
+ports. The edge starts as root so it can listen on port 80; after binding it
+can switch to an unprivileged user and group and, on Linux kernels with
+Landlock, restrict filesystem writes to the directories it logs to.
+
+The process is not chrooted: the AWS SDK and the resolver need to keep reading
+files such as /etc/resolv.conf and the system CA bundle, so the sandbox limits
+writes rather than hiding the rest of the filesystem.
+*/
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+)
+
+// Config configures the restrictions applied after binding ports.
+type Config struct {
+	// User is the name or numeric id of the user to switch to
+	User string
+
+	// Group is the name or numeric id of the group to switch to. Defaults to
+	// the primary group of User.
+	Group string
+
+	// RestrictWrites limits filesystem writes to the logging directory
+	RestrictWrites bool
+}
+
+// Validate verifies that a Config is valid and that its user and group exist
+func (c *Config) Validate() error {
+	if c.Group != "" && c.User == "" {
+		return errors.New("User is required when Group is set")
+	}
+	if c.User == "" {
+		return nil
+	}
+	_, _, err := c.ids()
+	return err
+}
+
+// ids resolves User and Group to numeric ids.
+func (c *Config) ids() (uid, gid int, err error) {
+	u, err := user.Lookup(c.User)
+	if err != nil {
+		u, err = user.LookupId(c.User)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("unknown user %s", c.User)
+	}
+	uid, err = strconv.Atoi(u.Uid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("user %s has non-numeric uid %s", c.User, u.Uid)
+	}
+
+	groupID := u.Gid
+	if c.Group != "" {
+		g, lerr := user.LookupGroup(c.Group)
+		if lerr != nil {
+			g, lerr = user.LookupGroupId(c.Group)
+		}
+		if lerr != nil {
+			return 0, 0, fmt.Errorf("unknown group %s", c.Group)
+		}
+		groupID = g.Gid
+	}
+	gid, err = strconv.Atoi(groupID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("group %s has non-numeric gid %s", c.Group, groupID)
+	}
+	return uid, gid, nil
+}
+
+// Apply drops privileges and restricts writes to writableDirs as configured,
+// returning a description of each restriction applied. Privileges are dropped
+// for the whole process, so Apply must be called after every privileged port
+// has been bound.
+func Apply(c Config, writableDirs []string) ([]string, error) {
+	var applied []string
+	if c.User != "" {
+		uid, gid, err := c.ids()
+		if err != nil {
+			return applied, err
+		}
+		if err = setIDs(uid, gid); err != nil {
+			return applied, fmt.Errorf("error switching to uid %d gid %d: %v", uid, gid, err)
+		}
+		applied = append(applied, fmt.Sprintf("running as uid %d gid %d", uid, gid))
+	}
+	if c.RestrictWrites {
+		if err := restrictWrites(writableDirs); err != nil {
+			return applied, fmt.Errorf("error restricting writes: %v", err)
+		}
+		applied = append(applied, fmt.Sprintf("writes restricted to %v", writableDirs))
+	}
+	return applied, nil
+}
@@ -0,0 +1,93 @@
+package sandbox
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Landlock syscalls and ABI v1 constants, see linux/landlock.h.
+const (
+	sysLandlockCreateRuleset = 444
+	sysLandlockAddRule       = 445
+	sysLandlockRestrictSelf  = 446
+
+	landlockRulePathBeneath = 1
+	prSetNoNewPrivs         = 38
+
+	accessFSWriteFile  = 1 << 1
+	accessFSRemoveDir  = 1 << 4
+	accessFSRemoveFile = 1 << 5
+	accessFSMakeChar   = 1 << 6
+	accessFSMakeDir    = 1 << 7
+	accessFSMakeReg    = 1 << 8
+	accessFSMakeSock   = 1 << 9
+	accessFSMakeFifo   = 1 << 10
+	accessFSMakeBlock  = 1 << 11
+	accessFSMakeSym    = 1 << 12
+
+	// accessFSWrite is every write access in ABI v1. Reads and executes are
+	// left unhandled so they stay unrestricted.
+	accessFSWrite = accessFSWriteFile | accessFSRemoveDir | accessFSRemoveFile |
+		accessFSMakeChar | accessFSMakeDir | accessFSMakeReg | accessFSMakeSock |
+		accessFSMakeFifo | accessFSMakeBlock | accessFSMakeSym
+)
+
+type landlockRulesetAttr struct {
+	handledAccessFS uint64
+}
+
+// landlockPathBeneathAttr is packed in the kernel; the first 12 bytes of this
+// struct have the same layout.
+type landlockPathBeneathAttr struct {
+	allowedAccess uint64
+	parentFd      int32
+}
+
+// setIDs switches every thread of the process to gid and uid, clearing
+// supplementary groups.
+func setIDs(uid, gid int) error {
+	if err := syscall.Setgroups(nil); err != nil {
+		return err
+	}
+	if err := syscall.Setgid(gid); err != nil {
+		return err
+	}
+	return syscall.Setuid(uid)
+}
+
+// restrictWrites enforces a Landlock ruleset on every thread of the process
+// that only allows writes beneath dirs. Applying it to all threads needs
+// AllThreadsSyscall, which is unavailable in binaries built with cgo.
+func restrictWrites(dirs []string) error {
+	attr := landlockRulesetAttr{handledAccessFS: accessFSWrite}
+	r, _, errno := syscall.Syscall(sysLandlockCreateRuleset,
+		uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("landlock is not available: %v", errno)
+	}
+	ruleset := int(r)
+	defer func() { _ = syscall.Close(ruleset) }()
+
+	for _, dir := range dirs {
+		fd, err := syscall.Open(dir, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("error opening %s: %v", dir, err)
+		}
+		rule := landlockPathBeneathAttr{allowedAccess: accessFSWrite, parentFd: int32(fd)}
+		_, _, errno = syscall.Syscall6(sysLandlockAddRule, uintptr(ruleset), landlockRulePathBeneath,
+			uintptr(unsafe.Pointer(&rule)), 0, 0, 0)
+		_ = syscall.Close(fd)
+		if errno != 0 {
+			return fmt.Errorf("error allowing writes to %s: %v", dir, errno)
+		}
+	}
+
+	if _, _, errno = syscall.AllThreadsSyscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+		return fmt.Errorf("error setting no_new_privs: %v", errno)
+	}
+	if _, _, errno = syscall.AllThreadsSyscall(sysLandlockRestrictSelf, uintptr(ruleset), 0, 0); errno != 0 {
+		return fmt.Errorf("error enforcing ruleset: %v", errno)
+	}
+	return nil
+}
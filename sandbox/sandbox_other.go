@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package sandbox
+
+import "errors"
+
+var errUnsupported = errors.New("not supported on this platform")
+
+func setIDs(uid, gid int) error {
+	return errUnsupported
+}
+
+func restrictWrites(dirs []string) error {
+	return errUnsupported
+}
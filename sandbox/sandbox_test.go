@@ -0,0 +1,32 @@
+package sandbox
+
+import (
+	"os/user"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("Can't look up current user: %s", err)
+	}
+
+	for _, c := range []Config{{}, {User: current.Username}, {User: current.Uid, Group: current.Gid}} {
+		if err = c.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %s", c, err)
+		}
+	}
+	for _, c := range []Config{{Group: current.Gid}, {User: "no-such-spade-user"},
+		{User: current.Uid, Group: "no-such-spade-group"}} {
+		if err = c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+}
+
+func TestApplyNothing(t *testing.T) {
+	applied, err := Apply(Config{}, nil)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("Expected an empty config to apply nothing, got %v, %v", applied, err)
+	}
+}
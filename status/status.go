@@ -0,0 +1,341 @@
+/*
+Package status builds a machine-readable report of an edge's health: its
+build, uptime and sanitized config, how each sink is doing, how many events
+are waiting in memory and the last errors the sinks returned. The report is
+served as JSON for fleet dashboards, so they don't need to scrape logs.
+
+Sinks are wrapped with Wrap to be reported on. A sink is healthy unless its
+last write failed.
+*/
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+const (
+	defaultErrorSamples = 20
+
+	// maxErrorLength bounds the error messages kept in samples.
+	maxErrorLength = 512
+
+	// meterBuckets and meterBucketWidth make up the window sink throughput is
+	// averaged over: a minute of full buckets, and the current one.
+	meterBuckets     = 7
+	meterBucketWidth = 10 * time.Second
+)
+
+// Config configures the status report.
+type Config struct {
+	// ErrorSamples is how many of the last sink errors are reported. It
+	// defaults to 20.
+	ErrorSamples int
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.ErrorSamples == 0 {
+		c.ErrorSamples = defaultErrorSamples
+	}
+	if c.ErrorSamples < 0 {
+		return errors.New("ErrorSamples must be a positive value")
+	}
+	return nil
+}
+
+// BuildInfo identifies the running edge.
+type BuildInfo struct {
+	Version    string `json:"version"`
+	GoVersion  string `json:"goVersion"`
+	EdgeType   string `json:"edgeType"`
+	InstanceID string `json:"instanceId"`
+}
+
+// Report is the status of an edge. RecentErrors are newest first.
+type Report struct {
+	Build         BuildInfo      `json:"build"`
+	StartedAt     time.Time      `json:"startedAt"`
+	UptimeSeconds float64        `json:"uptimeSeconds"`
+	Config        interface{}    `json:"config"`
+	Sinks         []SinkStatus   `json:"sinks"`
+	Depths        map[string]int `json:"depths"`
+	RecentErrors  []ErrorSample  `json:"recentErrors"`
+
+	// Totals are the running totals added with AddTotals, by name.
+	Totals map[string]interface{} `json:"totals,omitempty"`
+}
+
+// SinkStatus is the status of a sink.
+type SinkStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Logged  int64  `json:"logged"`
+	Failed  int64  `json:"failed"`
+
+	// EventsPerSecond is the rate of successful writes over the last minute.
+	EventsPerSecond float64 `json:"eventsPerSecond"`
+
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	LastFailure *time.Time `json:"lastFailure,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+// ErrorSample is an error a sink returned.
+type ErrorSample struct {
+	Time  time.Time `json:"time"`
+	Sink  string    `json:"sink"`
+	Error string    `json:"error"`
+}
+
+// Reporter keeps track of an edge's status and serves it as a JSON Report.
+type Reporter struct {
+	build     BuildInfo
+	startedAt time.Time
+	samples   int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	config  interface{}
+	sinks   []*sink
+	depths  map[string]func() int
+	totals  map[string]func() interface{}
+	errors  []ErrorSample
+	nextErr int
+}
+
+// New returns a Reporter for the edge build identifies, started now. The Go
+// version is filled in.
+func New(config Config, build BuildInfo) (*Reporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	build.GoVersion = runtime.Version()
+	return &Reporter{
+		build:     build,
+		startedAt: time.Now(),
+		samples:   config.ErrorSamples,
+		now:       time.Now,
+		depths:    make(map[string]func() int),
+		totals:    make(map[string]func() interface{}),
+	}, nil
+}
+
+// SetConfig sets the config summary reported. It must not hold secrets.
+func (r *Reporter) SetConfig(summary interface{}) {
+	r.mu.Lock()
+	r.config = summary
+	r.mu.Unlock()
+}
+
+// AddDepth reports the number of events depth returns under name, e.g. the
+// events waiting in a queue.
+func (r *Reporter) AddDepth(name string, depth func() int) {
+	r.mu.Lock()
+	r.depths[name] = depth
+	r.mu.Unlock()
+}
+
+// AddTotals reports what totals returns under name. It must marshal to JSON,
+// e.g. the outcomes of split requests since startup.
+func (r *Reporter) AddTotals(name string, totals func() interface{}) {
+	r.mu.Lock()
+	r.totals[name] = totals
+	r.mu.Unlock()
+}
+
+// Wrap returns a logger that passes events on to l and reports how it does
+// under name.
+func (r *Reporter) Wrap(name string, l loggers.SpadeEdgeLogger) loggers.SpadeEdgeLogger {
+	s := &sink{name: name, logger: l, reporter: r}
+	r.mu.Lock()
+	r.sinks = append(r.sinks, s)
+	r.mu.Unlock()
+	return s
+}
+
+// Report returns the current status.
+func (r *Reporter) Report() *Report {
+	now := r.now()
+	r.mu.Lock()
+	report := &Report{
+		Build:         r.build,
+		StartedAt:     r.startedAt,
+		UptimeSeconds: now.Sub(r.startedAt).Seconds(),
+		Config:        r.config,
+		Sinks:         make([]SinkStatus, 0, len(r.sinks)),
+		Depths:        make(map[string]int, len(r.depths)),
+		RecentErrors:  make([]ErrorSample, 0, len(r.errors)),
+	}
+	sinks := r.sinks
+	depths := make(map[string]func() int, len(r.depths))
+	for name, depth := range r.depths {
+		depths[name] = depth
+	}
+	totals := make(map[string]func() interface{}, len(r.totals))
+	for name, total := range r.totals {
+		totals[name] = total
+	}
+	// errors is a ring once full, and nextErr the oldest sample in it.
+	for i := range r.errors {
+		j := (r.nextErr - 1 - i + 2*len(r.errors)) % len(r.errors)
+		report.RecentErrors = append(report.RecentErrors, r.errors[j])
+	}
+	r.mu.Unlock()
+
+	for _, s := range sinks {
+		report.Sinks = append(report.Sinks, s.status(now))
+	}
+	for name, depth := range depths {
+		report.Depths[name] = depth()
+	}
+	if len(totals) > 0 {
+		report.Totals = make(map[string]interface{}, len(totals))
+		for name, total := range totals {
+			report.Totals[name] = total()
+		}
+	}
+	return report
+}
+
+// ServeHTTP serves the Report as JSON.
+func (r *Reporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	b, err := json.MarshalIndent(r.Report(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(b)
+}
+
+func (r *Reporter) sampleError(name string, at time.Time, err error) {
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	sample := ErrorSample{Time: at, Sink: name, Error: msg}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errors) < r.samples {
+		r.errors = append(r.errors, sample)
+		return
+	}
+	r.errors[r.nextErr] = sample
+	r.nextErr = (r.nextErr + 1) % r.samples
+}
+
+// sink reports on the writes to a sink.
+type sink struct {
+	name     string
+	logger   loggers.SpadeEdgeLogger
+	reporter *Reporter
+
+	mu          sync.Mutex
+	logged      int64
+	failed      int64
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	lastFailed  bool
+	meter       meter
+}
+
+func (s *sink) Log(e *spade.Event) error {
+	err := s.logger.Log(e)
+	s.observe(err)
+	return err
+}
+
+func (s *sink) LogSerialized(e *spade.Event, serialized []byte) error {
+	err := loggers.LogSerialized(s.logger, e, serialized)
+	s.observe(err)
+	return err
+}
+
+func (s *sink) LogDurably(e *spade.Event, serialized []byte, done func(error)) error {
+	err := loggers.LogDurably(s.logger, e, serialized, done)
+	s.observe(err)
+	return err
+}
+
+func (s *sink) Close() {
+	s.logger.Close()
+}
+
+func (s *sink) observe(err error) {
+	now := s.reporter.now()
+	s.mu.Lock()
+	s.lastFailed = err != nil
+	if err == nil {
+		s.logged++
+		s.lastSuccess = now
+		s.meter.add(now)
+	} else {
+		s.failed++
+		s.lastFailure = now
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.reporter.sampleError(s.name, now, err)
+	}
+}
+
+func (s *sink) status(now time.Time) SinkStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := SinkStatus{
+		Name:            s.name,
+		Healthy:         !s.lastFailed,
+		Logged:          s.logged,
+		Failed:          s.failed,
+		EventsPerSecond: s.meter.rate(now),
+		LastError:       s.lastError,
+	}
+	if !s.lastSuccess.IsZero() {
+		t := s.lastSuccess
+		status.LastSuccess = &t
+	}
+	if !s.lastFailure.IsZero() {
+		t := s.lastFailure
+		status.LastFailure = &t
+	}
+	return status
+}
+
+// meter counts events in buckets of meterBucketWidth.
+type meter struct {
+	starts [meterBuckets]int64
+	counts [meterBuckets]int64
+}
+
+func (m *meter) add(now time.Time) {
+	start := now.Truncate(meterBucketWidth).Unix()
+	i := int(start/int64(meterBucketWidth/time.Second)) % meterBuckets
+	if m.starts[i] != start {
+		m.starts[i], m.counts[i] = start, 0
+	}
+	m.counts[i]++
+}
+
+// rate returns the events per second over the full buckets of the window.
+func (m *meter) rate(now time.Time) float64 {
+	current := now.Truncate(meterBucketWidth).Unix()
+	oldest := current - int64((meterBuckets-1)*meterBucketWidth/time.Second)
+	var total int64
+	for i, start := range m.starts {
+		if start >= oldest && start < current {
+			total += m.counts[i]
+		}
+	}
+	return float64(total) / ((meterBuckets - 1) * meterBucketWidth).Seconds()
+}
@@ -0,0 +1,90 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+type fakeLogger struct {
+	err     error
+	written int
+}
+
+func (l *fakeLogger) Log(*spade.Event) error {
+	l.written++
+	return l.err
+}
+
+func (l *fakeLogger) Close() {}
+
+func TestReport(t *testing.T) {
+	r, err := New(Config{ErrorSamples: 2}, BuildInfo{Version: "1.2.3"})
+	if err != nil {
+		t.Fatalf("Failed to create reporter: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	r.startedAt = now
+	r.SetConfig(map[string]string{"Port": ":80"})
+	r.AddDepth("queue", func() int { return 7 })
+	r.AddTotals("splitRequests", func() interface{} { return map[string]int{"success": 3} })
+
+	event := &fakeLogger{}
+	kinesis := &fakeLogger{}
+	eventSink, kinesisSink := r.Wrap("event", event), r.Wrap("kinesis", kinesis)
+	for i := 0; i < 60; i++ {
+		_ = eventSink.Log(&spade.Event{})
+		now = now.Add(time.Second)
+	}
+	_ = kinesisSink.Log(&spade.Event{})
+	for i := 0; i < 3; i++ {
+		kinesis.err = fmt.Errorf("failure %d", i)
+		_ = kinesisSink.Log(&spade.Event{})
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/status.json", nil))
+	var report Report
+	if err = json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %s", err)
+	}
+	if report.Build.Version != "1.2.3" || report.Build.GoVersion == "" || report.UptimeSeconds != 60 {
+		t.Errorf("Unexpected build info or uptime: %+v, %v", report.Build, report.UptimeSeconds)
+	}
+	if len(report.Sinks) != 2 {
+		t.Fatalf("Expected both sinks, got %+v", report.Sinks)
+	}
+	if s := report.Sinks[0]; !s.Healthy || s.Logged != 60 || s.EventsPerSecond != 1 {
+		t.Errorf("Unexpected event sink status %+v", s)
+	}
+	if s := report.Sinks[1]; s.Healthy || s.Logged != 1 || s.Failed != 3 || s.LastError != "failure 2" {
+		t.Errorf("Unexpected kinesis sink status %+v", s)
+	}
+	if report.Depths["queue"] != 7 {
+		t.Errorf("Expected the queue depth, got %v", report.Depths)
+	}
+	if split, ok := report.Totals["splitRequests"].(map[string]interface{}); !ok || split["success"] != 3.0 {
+		t.Errorf("Expected the split request totals, got %v", report.Totals)
+	}
+	if len(report.RecentErrors) != 2 || report.RecentErrors[0].Error != "failure 2" ||
+		report.RecentErrors[1].Error != "failure 1" {
+		t.Errorf("Expected the last 2 errors, newest first, got %+v", report.RecentErrors)
+	}
+
+	kinesis.err = nil
+	_ = kinesisSink.Log(&spade.Event{})
+	if !r.Report().Sinks[1].Healthy {
+		t.Error("Expected a sink to be healthy again once a write succeeds")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (&Config{ErrorSamples: -1}).Validate(); err == nil {
+		t.Error("Expected a negative ErrorSamples to be invalid")
+	}
+}
@@ -0,0 +1,38 @@
+package testkit
+
+import (
+	"testing"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// Golden describes an expected event. Fields left empty aren't checked, so
+// values that change from run to run, like the UUID, can be left out.
+type Golden struct {
+	Data      string
+	UserAgent string
+	ClientIP  string
+	EdgeType  string
+	Uuid      string
+}
+
+// AssertEvents fails t unless got matches want, in order.
+func AssertEvents(t testing.TB, got []spade.Event, want []Golden) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(got), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		check := func(field, want, got string) {
+			if want != "" && want != got {
+				t.Errorf("event %d: expected %s %q, got %q", i, field, want, got)
+			}
+		}
+		check("Data", w.Data, g.Data)
+		check("UserAgent", w.UserAgent, g.UserAgent)
+		check("ClientIp", w.ClientIP, g.ClientIp.String())
+		check("EdgeType", w.EdgeType, g.EdgeType)
+		check("Uuid", w.Uuid, g.Uuid)
+	}
+}
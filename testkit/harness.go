@@ -0,0 +1,58 @@
+package testkit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/twitchscience/spade_edge/clock"
+	"github.com/twitchscience/spade_edge/requests"
+)
+
+const (
+	// InstanceID is the instance ID of Harness handlers, which starts the
+	// UUIDs of the events they log.
+	InstanceID = "i-testkit"
+
+	// CORSOrigin is the origin Harness handlers accept.
+	CORSOrigin = "https://www.example.com"
+)
+
+// Now is the time Harness clocks start at.
+var Now = time.Date(2014, 5, 2, 19, 34, 1, 0, time.UTC)
+
+// Harness is a SpadeHandler logging synchronously to in-memory sinks.
+type Harness struct {
+	Handler *requests.SpadeHandler
+	S3      *MemoryLogger
+	Kinesis *MemoryLogger
+	Stats   *Stats
+
+	// Clock is what the handler receives events by. It only moves when
+	// advanced.
+	Clock *clock.Fake
+}
+
+// NewHarness returns a Harness whose handler runs as edgeType.
+func NewHarness(edgeType string) *Harness {
+	h := &Harness{
+		S3:      &MemoryLogger{},
+		Kinesis: &MemoryLogger{},
+		Stats:   NewStats(),
+		Clock:   clock.NewFake(Now),
+	}
+	edgeLoggers := requests.NewEdgeLoggers()
+	edgeLoggers.S3EventLogger = h.S3
+	edgeLoggers.KinesisEventLogger = h.Kinesis
+	h.Handler = requests.NewSpadeHandler(h.Stats, edgeLoggers, InstanceID,
+		[]string{CORSOrigin}, 1, "", edgeType, true)
+	h.Handler.Time = h.Clock.Now
+	return h
+}
+
+// Serve sends r to the handler and returns the response.
+func (h *Harness) Serve(r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.Handler.ServeHTTP(rec, r)
+	return rec
+}
@@ -0,0 +1,59 @@
+package testkit
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ClientIP is the address requests built here are forwarded for.
+	ClientIP = "222.222.222.222"
+
+	// Host is the host requests built here are sent to.
+	Host = "spade.example.com"
+)
+
+// EncodeEvents returns the data parameter for events given as JSON objects:
+// a single event is sent as an object, several as a batch array.
+func EncodeEvents(events ...string) string {
+	payload := events[0]
+	if len(events) > 1 {
+		payload = "[" + strings.Join(events, ",") + "]"
+	}
+	return base64.StdEncoding.EncodeToString([]byte(payload))
+}
+
+// Get returns a GET request to endpoint with data in the query string.
+func Get(endpoint, data string) *http.Request {
+	return newRequest("GET", endpoint+"?"+url.Values{"data": {data}}.Encode(), nil)
+}
+
+// PostForm returns a form POST to endpoint carrying data.
+func PostForm(endpoint, data string) *http.Request {
+	r := newRequest("POST", endpoint, strings.NewReader(url.Values{"data": {data}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+// PostBody returns a POST to endpoint with data as the raw body, as sent by
+// clients that don't use forms.
+func PostBody(endpoint, data string) *http.Request {
+	return newRequest("POST", endpoint, strings.NewReader(data))
+}
+
+func newRequest(method, target string, body *strings.Reader) *http.Request {
+	var r *http.Request
+	var err error
+	if body == nil {
+		r, err = http.NewRequest(method, "http://"+Host+target, nil)
+	} else {
+		r, err = http.NewRequest(method, "http://"+Host+target, body)
+	}
+	if err != nil {
+		panic(err)
+	}
+	r.Header.Set("X-Forwarded-For", ClientIP)
+	return r
+}
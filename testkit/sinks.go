@@ -0,0 +1,100 @@
+/*
+Package testkit helps test code built on the edge without AWS: it provides
+in-memory sinks, a recording statsd client, builders for the request shapes
+clients send, and assertions against golden events.
+
+A Harness wires these to a SpadeHandler:
+
+	h := testkit.NewHarness(spade.INTERNAL_EDGE)
+	h.Serve(testkit.Get("/track", testkit.EncodeEvents(`{"event":"play"}`)))
+	testkit.AssertEvents(t, h.S3.Events(), []testkit.Golden{{Data: ...}})
+
+Teams building custom sinks can run them under a Harness in place of the
+MemoryLoggers.
+*/
+package testkit
+
+import (
+	"sync"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+	"github.com/twitchscience/spade_edge/loggers"
+)
+
+// MemoryLogger is a loggers.SpadeEdgeLogger that keeps the events it is
+// given in memory. It is safe for concurrent use.
+type MemoryLogger struct {
+	mu         sync.Mutex
+	events     []spade.Event
+	serialized [][]byte
+	err        error
+	closed     bool
+}
+
+// Log records a copy of e, or returns the error set with FailWith.
+func (m *MemoryLogger) Log(e *spade.Event) error {
+	return m.LogSerialized(e, nil)
+}
+
+// LogSerialized records a copy of e and its serialization, or returns the
+// error set with FailWith.
+func (m *MemoryLogger) LogSerialized(e *spade.Event, serialized []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, *e)
+	if serialized != nil {
+		serialized = append([]byte(nil), serialized...)
+	}
+	m.serialized = append(m.serialized, serialized)
+	return nil
+}
+
+// Close marks the logger as closed.
+func (m *MemoryLogger) Close() {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+}
+
+// FailWith makes the logger reject events with err, or accept them again if
+// err is nil.
+func (m *MemoryLogger) FailWith(err error) {
+	m.mu.Lock()
+	m.err = err
+	m.mu.Unlock()
+}
+
+// Events returns the events logged so far.
+func (m *MemoryLogger) Events() []spade.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]spade.Event(nil), m.events...)
+}
+
+// Serialized returns the serializations passed with the events logged so
+// far, nil where an event was logged with Log.
+func (m *MemoryLogger) Serialized() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([][]byte(nil), m.serialized...)
+}
+
+// Closed returns whether Close was called.
+func (m *MemoryLogger) Closed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.closed
+}
+
+// Reset forgets the events logged so far.
+func (m *MemoryLogger) Reset() {
+	m.mu.Lock()
+	m.events = nil
+	m.serialized = nil
+	m.mu.Unlock()
+}
+
+var _ loggers.SerializedLogger = &MemoryLogger{}
@@ -0,0 +1,48 @@
+package testkit
+
+import (
+	"strconv"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+)
+
+// Stats is a statsd client that records every stat sent, ignoring sample
+// rates.
+type Stats struct {
+	statsd.Statter
+	sender *statsdtest.RecordingSender
+}
+
+// NewStats returns a Stats with nothing recorded.
+func NewStats() *Stats {
+	sender := statsdtest.NewRecordingSender()
+	client, _ := statsd.NewClientWithSender(sender, "")
+	client.(*statsd.Client).SetSamplerFunc(func(float32) bool { return true })
+	return &Stats{Statter: client, sender: sender}
+}
+
+// Sent returns the stats sent so far.
+func (s *Stats) Sent() statsdtest.Stats {
+	return s.sender.GetSent()
+}
+
+// Count returns the sum of the values sent for the counter stat.
+func (s *Stats) Count(stat string) int64 {
+	var total int64
+	for _, sent := range s.sender.GetSent().CollectNamed(stat) {
+		if sent.Tag != "c" {
+			continue
+		}
+		v, err := strconv.ParseInt(sent.Value, 10, 64)
+		if err == nil {
+			total += v
+		}
+	}
+	return total
+}
+
+// Reset forgets the stats sent so far.
+func (s *Stats) Reset() {
+	s.sender.ClearSent()
+}
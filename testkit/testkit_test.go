@@ -0,0 +1,53 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+func TestHarness(t *testing.T) {
+	event := `{"event":"play"}`
+	batch := EncodeEvents(event, `{"event":"pause"}`)
+	for _, tt := range []struct {
+		name string
+		req  *http.Request
+		data string
+	}{
+		{"get", Get("/track", EncodeEvents(event)), EncodeEvents(event)},
+		{"post form", PostForm("/track", batch), batch},
+		{"post body", PostBody("/track", batch), batch},
+	} {
+		h := NewHarness(spade.INTERNAL_EDGE)
+		rec := h.Serve(tt.req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("%s: expected 204, got %d", tt.name, rec.Code)
+		}
+		golden := Golden{
+			Data:     tt.data,
+			ClientIP: ClientIP,
+			EdgeType: spade.INTERNAL_EDGE,
+			Uuid:     fmt.Sprintf("%s-%08x-%08x", InstanceID, Now.Unix(), 1),
+		}
+		AssertEvents(t, h.S3.Events(), []Golden{golden})
+		AssertEvents(t, h.Kinesis.Events(), []Golden{golden})
+		if n := h.Stats.Count("status_code.204"); n != 1 {
+			t.Errorf("%s: expected status_code.204 1, got %d", tt.name, n)
+		}
+	}
+}
+
+func TestMemoryLoggerFailure(t *testing.T) {
+	h := NewHarness(spade.INTERNAL_EDGE)
+	h.S3.FailWith(errors.New("unavailable"))
+	h.Kinesis.FailWith(errors.New("unavailable"))
+	if rec := h.Serve(Get("/track", EncodeEvents(`{"event":"play"}`))); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when every logger fails, got %d", rec.Code)
+	}
+	if len(h.S3.Events())+len(h.Kinesis.Events()) != 0 {
+		t.Fatal("expected no events to be recorded while failing")
+	}
+}
@@ -0,0 +1,429 @@
+/*
+Package topk answers "what's hammering us right now" by tracking the heaviest
+event names, origins and client IP prefixes of the last few minutes in bounded
+memory.
+
+Each dimension counts its keys in a count-min sketch, which never
+undercounts but can overcount keys that collide with heavy ones, and keeps the
+keys with the highest counts in a heap. The window rolls over in buckets: the
+oldest bucket's sketch and heap are dropped as a new one starts, so reported
+counts cover between Window minus a bucket and Window.
+*/
+package topk
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twitchscience/spade_edge/transform"
+)
+
+const (
+	defaultK       = 20
+	defaultWindow  = "5m"
+	defaultBuckets = 5
+	defaultWidth   = 2048
+	defaultDepth   = 4
+
+	// The dimensions tracked.
+	dimensionEvent    = "event"
+	dimensionOrigin   = "origin"
+	dimensionIPPrefix = "ip_prefix"
+)
+
+// Config configures top-K tracking.
+type Config struct {
+	// K is how many of the heaviest keys are kept per dimension. It defaults
+	// to 20.
+	K int
+
+	// Window is how far back counts go, e.g. "5m"
+	Window string
+
+	// Buckets is how many parts the window rolls over in. It defaults to 5.
+	Buckets int
+
+	// Width and Depth size the count-min sketch of each dimension and bucket:
+	// wider sketches overcount less, deeper ones are less likely to. They
+	// default to 2048 and 4.
+	Width int
+	Depth int
+
+	// SampleRate is the fraction of requests counted. It defaults to 1.
+	SampleRate float64
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.K == 0 {
+		c.K = defaultK
+	}
+	if c.Window == "" {
+		c.Window = defaultWindow
+	}
+	if c.Buckets == 0 {
+		c.Buckets = defaultBuckets
+	}
+	if c.Width == 0 {
+		c.Width = defaultWidth
+	}
+	if c.Depth == 0 {
+		c.Depth = defaultDepth
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = 1
+	}
+	if c.K < 0 || c.Buckets < 0 || c.Width < 0 || c.Depth < 0 {
+		return errors.New("K, Buckets, Width and Depth must be positive values")
+	}
+	window, err := time.ParseDuration(c.Window)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.Window, err)
+	}
+	if window < time.Duration(c.Buckets)*time.Second {
+		return errors.New("Window must be at least a second per bucket")
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return errors.New("SampleRate must be between 0 and 1")
+	}
+	return nil
+}
+
+// Entry is a key and its estimated count.
+type Entry struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// Tracker tracks the heaviest keys of each dimension.
+type Tracker struct {
+	config     Config
+	dimensions map[string]*dimension
+	now        func() time.Time
+}
+
+// New returns a Tracker for config.
+func New(config Config) (*Tracker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	t := &Tracker{config: config, now: time.Now, dimensions: make(map[string]*dimension, 3)}
+	window, _ := time.ParseDuration(config.Window)
+	for _, name := range []string{dimensionEvent, dimensionOrigin, dimensionIPPrefix} {
+		t.dimensions[name] = newDimension(config, window/time.Duration(config.Buckets))
+	}
+	return t, nil
+}
+
+// Observe counts an accepted request: the events in data, its Origin header
+// and the prefix of the client's IP.
+func (t *Tracker) Observe(data, origin string, clientIP net.IP) {
+	if t.config.SampleRate < 1 && rand.Float64() >= t.config.SampleRate {
+		return
+	}
+	now := t.now()
+	for _, name := range transform.EventNames(data) {
+		if name != "" {
+			t.dimensions[dimensionEvent].add(name, now)
+		}
+	}
+	if origin != "" {
+		t.dimensions[dimensionOrigin].add(origin, now)
+	}
+	if prefix := ipPrefix(clientIP); prefix != "" {
+		t.dimensions[dimensionIPPrefix].add(prefix, now)
+	}
+}
+
+// ipPrefix returns the /24 of IPv4 addresses and the /48 of IPv6 ones.
+func ipPrefix(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	if len(ip) == net.IPv6len {
+		return (&net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+	}
+	return ""
+}
+
+// Top returns the k heaviest keys of each dimension, heaviest first, keyed by
+// dimension: event, origin and ip_prefix. Counts are scaled up by the sample
+// rate.
+func (t *Tracker) Top(k int) map[string][]Entry {
+	now := t.now()
+	top := make(map[string][]Entry, len(t.dimensions))
+	for name, d := range t.dimensions {
+		entries := d.top(k, now)
+		for i := range entries {
+			entries[i].Count = int64(float64(entries[i].Count) / t.config.SampleRate)
+		}
+		top[name] = entries
+	}
+	return top
+}
+
+// ServeHTTP serves Top as JSON, along with the window. The k query parameter
+// asks for fewer than K keys.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k := t.config.K
+	if requested, err := strconv.Atoi(r.URL.Query().Get("k")); err == nil && requested > 0 && requested < k {
+		k = requested
+	}
+	b, err := json.MarshalIndent(struct {
+		Window string             `json:"window"`
+		Top    map[string][]Entry `json:"top"`
+	}{t.config.Window, t.Top(k)}, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// Counter tracks the heaviest keys of a single dimension of the caller's
+// choosing, in the same bounded memory as each of a Tracker's.
+type Counter struct {
+	config    Config
+	dimension *dimension
+	now       func() time.Time
+}
+
+// NewCounter returns a Counter for config.
+func NewCounter(config Config) (*Counter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	window, _ := time.ParseDuration(config.Window)
+	return &Counter{
+		config:    config,
+		dimension: newDimension(config, window/time.Duration(config.Buckets)),
+		now:       time.Now,
+	}, nil
+}
+
+// Add counts key.
+func (c *Counter) Add(key string) {
+	if c.config.SampleRate < 1 && rand.Float64() >= c.config.SampleRate {
+		return
+	}
+	c.dimension.add(key, c.now())
+}
+
+// Top returns the k heaviest keys, heaviest first, with their counts scaled
+// up by the sample rate.
+func (c *Counter) Top(k int) []Entry {
+	entries := c.dimension.top(k, c.now())
+	for i := range entries {
+		entries[i].Count = int64(float64(entries[i].Count) / c.config.SampleRate)
+	}
+	return entries
+}
+
+// dimension counts the keys of a dimension in a ring of buckets.
+type dimension struct {
+	bucketWidth time.Duration
+
+	// start is when the current bucket started, aligned to the wall clock.
+	mu      sync.Mutex
+	buckets []*bucket
+	current int
+	start   time.Time
+}
+
+func newDimension(config Config, bucketWidth time.Duration) *dimension {
+	d := &dimension{bucketWidth: bucketWidth}
+	for i := 0; i < config.Buckets; i++ {
+		d.buckets = append(d.buckets, newBucket(config))
+	}
+	return d
+}
+
+// advance rolls the buckets over to now. d.mu must be held.
+func (d *dimension) advance(now time.Time) {
+	if d.start.IsZero() {
+		d.start = now.Truncate(d.bucketWidth)
+		return
+	}
+	elapsed := int(now.Sub(d.start) / d.bucketWidth)
+	if elapsed <= 0 {
+		return
+	}
+	for i := 0; i < elapsed && i < len(d.buckets); i++ {
+		d.current = (d.current + 1) % len(d.buckets)
+		d.buckets[d.current].reset()
+	}
+	d.start = d.start.Add(time.Duration(elapsed) * d.bucketWidth)
+}
+
+func (d *dimension) add(key string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.advance(now)
+	d.buckets[d.current].add(key)
+}
+
+func (d *dimension) top(k int, now time.Time) []Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.advance(now)
+	candidates := make(map[string]bool)
+	for _, b := range d.buckets {
+		for _, e := range b.heap.entries {
+			candidates[e.Key] = true
+		}
+	}
+	entries := make([]Entry, 0, len(candidates))
+	for key := range candidates {
+		var count int64
+		for _, b := range d.buckets {
+			count += b.sketch.estimate(key)
+		}
+		entries = append(entries, Entry{Key: key, Count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	if len(entries) > k {
+		entries = entries[:k]
+	}
+	return entries
+}
+
+// bucket counts keys over part of the window, keeping the heaviest k.
+type bucket struct {
+	k      int
+	sketch *sketch
+	heap   *minHeap
+}
+
+func newBucket(config Config) *bucket {
+	return &bucket{
+		k:      config.K,
+		sketch: newSketch(config.Width, config.Depth),
+		heap:   &minHeap{index: make(map[string]int, config.K)},
+	}
+}
+
+func (b *bucket) reset() {
+	b.sketch.reset()
+	b.heap.entries = b.heap.entries[:0]
+	b.heap.index = make(map[string]int, b.k)
+}
+
+func (b *bucket) add(key string) {
+	count := b.sketch.add(key)
+	if i, ok := b.heap.index[key]; ok {
+		b.heap.entries[i].Count = count
+		heap.Fix(b.heap, i)
+		return
+	}
+	if len(b.heap.entries) < b.k {
+		heap.Push(b.heap, Entry{Key: key, Count: count})
+		return
+	}
+	if count > b.heap.entries[0].Count {
+		delete(b.heap.index, b.heap.entries[0].Key)
+		b.heap.entries[0] = Entry{Key: key, Count: count}
+		b.heap.index[key] = 0
+		heap.Fix(b.heap, 0)
+	}
+}
+
+// sketch is a count-min sketch.
+type sketch struct {
+	width  uint64
+	counts [][]int64
+}
+
+func newSketch(width, depth int) *sketch {
+	s := &sketch{width: uint64(width), counts: make([][]int64, depth)}
+	for i := range s.counts {
+		s.counts[i] = make([]int64, width)
+	}
+	return s
+}
+
+// cells calls fn with the cell of key in each row.
+func (s *sketch) cells(key string, fn func(row []int64, i uint64)) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	sum := h.Sum64()
+	// Rows are indexed by combining two halves of one hash, which is as good
+	// as independent hashes for a count-min sketch.
+	h1, h2 := sum&0xffffffff, sum>>32
+	for i, row := range s.counts {
+		fn(row, (h1+uint64(i)*h2)%s.width)
+	}
+}
+
+// add counts key and returns its estimated count.
+func (s *sketch) add(key string) int64 {
+	var min int64 = -1
+	s.cells(key, func(row []int64, i uint64) {
+		row[i]++
+		if min < 0 || row[i] < min {
+			min = row[i]
+		}
+	})
+	return min
+}
+
+func (s *sketch) estimate(key string) int64 {
+	var min int64 = -1
+	s.cells(key, func(row []int64, i uint64) {
+		if min < 0 || row[i] < min {
+			min = row[i]
+		}
+	})
+	return min
+}
+
+func (s *sketch) reset() {
+	for _, row := range s.counts {
+		for i := range row {
+			row[i] = 0
+		}
+	}
+}
+
+// minHeap is a heap of entries, lightest first, indexed by key.
+type minHeap struct {
+	entries []Entry
+	index   map[string]int
+}
+
+func (h *minHeap) Len() int           { return len(h.entries) }
+func (h *minHeap) Less(i, j int) bool { return h.entries[i].Count < h.entries[j].Count }
+
+func (h *minHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.index[h.entries[i].Key] = i
+	h.index[h.entries[j].Key] = j
+}
+
+func (h *minHeap) Push(x interface{}) {
+	e := x.(Entry)
+	h.index[e.Key] = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *minHeap) Pop() interface{} {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	delete(h.index, e.Key)
+	return e
+}
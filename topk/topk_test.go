@@ -0,0 +1,99 @@
+package topk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func encode(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+func TestTracker(t *testing.T) {
+	tracker, err := New(Config{K: 3, Window: "5m", Buckets: 5})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	for i := 0; i < 50; i++ {
+		tracker.Observe(encode(`[{"event":"play"},{"event":"pause"}]`), "https://www.twitch.tv",
+			net.ParseIP("10.1.2.3"))
+	}
+	for i := 0; i < 20; i++ {
+		tracker.Observe(encode(`{"event":"pause"}`), "", net.ParseIP("2001:db8:1:2::1"))
+	}
+	// Lots of light keys mustn't push out the heavy ones.
+	for i := 0; i < 1000; i++ {
+		tracker.Observe(encode(fmt.Sprintf(`{"event":"rare%d"}`, i)), fmt.Sprintf("https://%d.example", i), nil)
+	}
+
+	top := tracker.Top(2)
+	if got := top["event"]; len(got) != 2 || got[0] != (Entry{"pause", 70}) || got[1] != (Entry{"play", 50}) {
+		t.Errorf("Expected pause and play events, got %v", got)
+	}
+	if got := top["origin"]; len(got) != 2 || got[0] != (Entry{"https://www.twitch.tv", 50}) {
+		t.Errorf("Expected twitch.tv origin first, got %v", got)
+	}
+	if got := top["ip_prefix"]; len(got) != 2 || got[0] != (Entry{"10.1.2.0/24", 50}) ||
+		got[1] != (Entry{"2001:db8:1::/48", 20}) {
+		t.Errorf("Expected IP prefixes, got %v", got)
+	}
+
+	// Counts older than the window are dropped.
+	now = now.Add(3 * time.Minute)
+	tracker.Observe(encode(`{"event":"play"}`), "", nil)
+	if got := tracker.Top(1)["event"]; len(got) != 1 || got[0] != (Entry{"pause", 70}) {
+		t.Errorf("Expected pause still in window, got %v", got)
+	}
+	now = now.Add(3 * time.Minute)
+	if got := tracker.Top(1)["event"]; len(got) != 1 || got[0] != (Entry{"play", 1}) {
+		t.Errorf("Expected only the recent play, got %v", got)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	tracker, err := New(Config{})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %s", err)
+	}
+	tracker.Observe(encode(`{"event":"play"}`), "https://www.twitch.tv", net.ParseIP("10.1.2.3"))
+
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/topk?k=1", nil))
+	var body struct {
+		Window string
+		Top    map[string][]Entry
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %s", err)
+	}
+	if body.Window != "5m" || len(body.Top["event"]) != 1 || body.Top["event"][0] != (Entry{"play", 1}) {
+		t.Errorf("Unexpected response %s", rec.Body.String())
+	}
+}
+
+func TestCounter(t *testing.T) {
+	counter, err := NewCounter(Config{K: 2, Window: "1h", Buckets: 2})
+	if err != nil {
+		t.Fatalf("Failed to create counter: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+	for _, key := range []string{"a", "b", "b", "c", "c", "c"} {
+		counter.Add(key)
+	}
+	if got := counter.Top(2); len(got) != 2 || got[0] != (Entry{"c", 3}) || got[1] != (Entry{"b", 2}) {
+		t.Errorf("Expected c and b, got %v", got)
+	}
+	now = now.Add(time.Hour)
+	if got := counter.Top(2); len(got) != 0 {
+		t.Errorf("Expected the window to have rolled over, got %v", got)
+	}
+}
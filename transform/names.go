@@ -0,0 +1,105 @@
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NameConfig configures event name normalization. Names are lowercased
+// first if Lowercase is set, then replaced by their alias, or else rewritten
+// by the first matching pattern.
+type NameConfig struct {
+	// Lowercase lowercases every event name
+	Lowercase bool
+
+	// Aliases maps exact event names to their replacement
+	Aliases map[string]string
+
+	// Patterns are regular expression rewrites tried in order
+	Patterns []NamePattern
+}
+
+// NamePattern rewrites event names matching Match to Replace, which may
+// reference capture groups as in regexp.Regexp.ReplaceAllString.
+type NamePattern struct {
+	Match   string
+	Replace string
+}
+
+// Validate verifies that a NameConfig is valid
+func (c *NameConfig) Validate() error {
+	for i, p := range c.Patterns {
+		if _, err := regexp.Compile(p.Match); err != nil {
+			return fmt.Errorf("Patterns[%d]: %v", i, err)
+		}
+	}
+	for from, to := range c.Aliases {
+		if to == "" {
+			return fmt.Errorf("alias for %s must not be empty", from)
+		}
+	}
+	return nil
+}
+
+type namePattern struct {
+	match   *regexp.Regexp
+	replace string
+}
+
+type nameNormalizer struct {
+	lowercase bool
+	aliases   map[string]string
+	patterns  []namePattern
+}
+
+// UpdateNames replaces the name normalization of the Transformer. A nil
+// config turns normalization off.
+func (t *Transformer) UpdateNames(c *NameConfig) error {
+	var n *nameNormalizer
+	if c != nil {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		n = &nameNormalizer{lowercase: c.Lowercase, aliases: c.Aliases}
+		for _, p := range c.Patterns {
+			n.patterns = append(n.patterns, namePattern{regexp.MustCompile(p.Match), p.Replace})
+		}
+	}
+	t.mu.Lock()
+	t.names = n
+	t.mu.Unlock()
+	return nil
+}
+
+// normalize rewrites the name of event, returning the new name and whether
+// it changed.
+func (n *nameNormalizer) normalize(event map[string]interface{}) (string, bool) {
+	if n == nil {
+		return "", false
+	}
+	original, ok := event["event"].(string)
+	if !ok {
+		return "", false
+	}
+
+	name := original
+	if n.lowercase {
+		name = strings.ToLower(name)
+	}
+	if alias, ok := n.aliases[name]; ok {
+		name = alias
+	} else {
+		for _, p := range n.patterns {
+			if p.match.MatchString(name) {
+				name = p.match.ReplaceAllString(name, p.replace)
+				break
+			}
+		}
+	}
+	if name == original {
+		return "", false
+	}
+	event["event"] = name
+	return name, true
+}
@@ -0,0 +1,332 @@
+/*
+Package transform rewrites event payloads at the edge so deployments can make
+small changes, like renaming a property or adding a constant, without a fork,
+and normalizes inconsistent event names before they reach downstream schemas.
+
+Transformations are declarative rules rather than scripts: each rule can only
+rename, set or delete properties of the events it matches, so a rule can't
+loop, call out or take more than a pass over the payload, and rules can be
+reloaded safely while serving.
+*/
+package transform
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// Rule transforms the properties of matching events. Renames are applied
+// first, then deletes, then sets.
+type Rule struct {
+	// Events are the event names the rule applies to; empty matches all events
+	Events []string
+
+	// Rename maps old property names to new ones
+	Rename map[string]string
+
+	// Delete lists properties to remove
+	Delete []string
+
+	// Set maps property names to constant values
+	Set map[string]interface{}
+}
+
+// Validate verifies that a Rule is valid
+func (r *Rule) Validate() error {
+	if len(r.Rename) == 0 && len(r.Delete) == 0 && len(r.Set) == 0 {
+		return errors.New("rule must rename, delete or set a property")
+	}
+	for from, to := range r.Rename {
+		if from == "" || to == "" {
+			return fmt.Errorf("invalid rename %q to %q", from, to)
+		}
+	}
+	return nil
+}
+
+type compiledRule struct {
+	Rule
+	events map[string]bool
+}
+
+func (r *compiledRule) matches(name string) bool {
+	return len(r.events) == 0 || r.events[name]
+}
+
+// Transformer normalizes event names and applies a set of rules, both of
+// which can be replaced while in use.
+type Transformer struct {
+	mu    sync.RWMutex
+	rules []compiledRule
+	names *nameNormalizer
+}
+
+// Result describes the changes Apply made to a payload.
+type Result struct {
+	// Changed is true if the payload was rewritten
+	Changed bool
+
+	// Renamed holds the new name of each event whose name was normalized
+	Renamed []string
+}
+
+// New returns a Transformer applying rules.
+func New(rules []Rule) (*Transformer, error) {
+	t := &Transformer{}
+	if err := t.Update(rules); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update replaces the rules of the Transformer. The rules are left unchanged
+// if any of the new ones are invalid.
+func (t *Transformer) Update(rules []Rule) error {
+	compiled := make([]compiledRule, 0, len(rules))
+	for i, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %v", i, err)
+		}
+		c := compiledRule{Rule: r, events: make(map[string]bool, len(r.Events))}
+		for _, e := range r.Events {
+			c.events[e] = true
+		}
+		compiled = append(compiled, c)
+	}
+	t.mu.Lock()
+	t.rules = compiled
+	t.mu.Unlock()
+	return nil
+}
+
+// Apply normalizes the names of and applies the rules to the base64 encoded
+// event (or array of events) in data, returning it re-encoded. data is
+// returned as is if there is nothing to do or an error occurs.
+func (t *Transformer) Apply(data string) (string, Result, error) {
+	var result Result
+	t.mu.RLock()
+	rules, names := t.rules, t.names
+	t.mu.RUnlock()
+	if len(rules) == 0 && names == nil {
+		return data, result, nil
+	}
+
+	payload, events, err := decodePayload(data)
+	if err != nil {
+		return data, result, err
+	}
+	for _, event := range events {
+		if renamed, ok := names.normalize(event); ok {
+			result.Renamed = append(result.Renamed, renamed)
+			result.Changed = true
+		}
+		result.Changed = applyRules(rules, event) || result.Changed
+	}
+	if !result.Changed {
+		return data, result, nil
+	}
+
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return data, Result{}, err
+	}
+	return encoded, result, nil
+}
+
+// SetProperties sets properties on the base64 encoded event (or array of
+// events) in data, returning it re-encoded. data is returned as is if an error
+// occurs.
+func SetProperties(data string, set map[string]interface{}) (string, error) {
+	payload, events, err := decodePayload(data)
+	if err != nil {
+		return data, err
+	}
+	for _, event := range events {
+		if properties, ok := event["properties"].(map[string]interface{}); ok {
+			for name, value := range set {
+				properties[name] = value
+			}
+		}
+	}
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return data, err
+	}
+	return encoded, nil
+}
+
+// Edit calls edit with the name and properties of each event in the base64
+// encoded event (or array of events) in data, which reports whether it changed
+// them, and returns data re-encoded if any were. data is returned as is if an
+// error occurs.
+func Edit(data string, edit func(name string, properties map[string]interface{}) bool) (string, bool, error) {
+	payload, events, err := decodePayload(data)
+	if err != nil {
+		return data, false, err
+	}
+	changed := false
+	for _, event := range events {
+		name, _ := event["event"].(string)
+		if properties, ok := event["properties"].(map[string]interface{}); ok {
+			changed = edit(name, properties) || changed
+		}
+	}
+	if !changed {
+		return data, false, nil
+	}
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return data, false, err
+	}
+	return encoded, true, nil
+}
+
+// Remove removes the events whose names drop reports true for from the
+// base64 encoded event (or array of events) in data, returning the rest
+// re-encoded, or "" if every event was removed, and how many were. data is
+// returned as is if no event is removed or an error occurs.
+func Remove(data string, drop func(name string) bool) (string, int, error) {
+	payload, _, err := decodePayload(data)
+	if err != nil {
+		return data, 0, err
+	}
+	elements, batch := payload.([]interface{})
+	if !batch {
+		elements = []interface{}{payload}
+	}
+	var remaining []interface{}
+	for _, e := range elements {
+		if event, ok := e.(map[string]interface{}); ok {
+			if name, _ := event["event"].(string); drop(name) {
+				continue
+			}
+		}
+		remaining = append(remaining, e)
+	}
+	removed := len(elements) - len(remaining)
+	switch {
+	case removed == 0:
+		return data, 0, nil
+	case len(remaining) == 0:
+		return "", removed, nil
+	}
+	var rest interface{} = remaining
+	if !batch {
+		rest = remaining[0]
+	}
+	encoded, err := encodePayload(rest)
+	if err != nil {
+		return data, 0, err
+	}
+	return encoded, removed, nil
+}
+
+// EventNames returns the names of the base64 encoded event (or array of
+// events) in data, or nil if it can't be decoded. It's cheaper than decoding
+// the whole events.
+func EventNames(data string) []string {
+	decoded, err := spade.DetermineBase64Encoding([]byte(data)).DecodeString(data)
+	if err != nil {
+		return nil
+	}
+	type named struct {
+		Event string `json:"event"`
+	}
+	decoded = bytes.TrimSpace(decoded)
+	if len(decoded) > 0 && decoded[0] == '[' {
+		var events []named
+		if json.Unmarshal(decoded, &events) != nil {
+			return nil
+		}
+		names := make([]string, len(events))
+		for i, e := range events {
+			names[i] = e.Event
+		}
+		return names
+	}
+	var event named
+	if json.Unmarshal(decoded, &event) != nil {
+		return nil
+	}
+	return []string{event.Event}
+}
+
+// decodePayload decodes the base64 encoded event (or array of events) in data,
+// returning the payload and the events in it.
+func decodePayload(data string) (interface{}, []map[string]interface{}, error) {
+	decoded, err := spade.DetermineBase64Encoding([]byte(data)).DecodeString(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error base64-decoding event: %v", err)
+	}
+	// Numbers are kept as json.Number so large ids survive the round trip.
+	var payload interface{}
+	dec := json.NewDecoder(bytes.NewReader(decoded))
+	dec.UseNumber()
+	if err = dec.Decode(&payload); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshaling event: %v", err)
+	}
+
+	var elements []interface{}
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		elements = []interface{}{p}
+	case []interface{}:
+		elements = p
+	}
+	events := make([]map[string]interface{}, 0, len(elements))
+	for _, e := range elements {
+		if event, ok := e.(map[string]interface{}); ok {
+			events = append(events, event)
+		}
+	}
+	return payload, events, nil
+}
+
+// encodePayload re-encodes a payload returned by decodePayload.
+func encodePayload(payload interface{}) (string, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling event: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+// applyRules applies rules to an event and reports whether it was changed.
+func applyRules(rules []compiledRule, event map[string]interface{}) bool {
+	name, _ := event["event"].(string)
+	properties, ok := event["properties"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	changed := false
+	for _, r := range rules {
+		if !r.matches(name) {
+			continue
+		}
+		for from, to := range r.Rename {
+			if v, ok := properties[from]; ok {
+				delete(properties, from)
+				properties[to] = v
+				changed = true
+			}
+		}
+		for _, p := range r.Delete {
+			if _, ok := properties[p]; ok {
+				delete(properties, p)
+				changed = true
+			}
+		}
+		for p, v := range r.Set {
+			properties[p] = v
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,113 @@
+package transform
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func encode(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal %v: %s", v, err)
+	}
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func decode(t *testing.T, data string) interface{} {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("Failed to decode %s: %s", data, err)
+	}
+	var v interface{}
+	if err = json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("Failed to unmarshal %s: %s", b, err)
+	}
+	return v
+}
+
+func TestApply(t *testing.T) {
+	transformer, err := New([]Rule{
+		{Events: []string{"video-play"}, Rename: map[string]string{"chan": "channel"}},
+		{Delete: []string{"debug"}, Set: map[string]interface{}{"platform": "web"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create transformer: %s", err)
+	}
+
+	data := encode(t, []interface{}{
+		map[string]interface{}{"event": "video-play", "properties": map[string]interface{}{"chan": "a", "debug": true}},
+		map[string]interface{}{"event": "follow", "properties": map[string]interface{}{"chan": "b"}},
+	})
+	out, result, err := transformer.Apply(data)
+	if err != nil || !result.Changed {
+		t.Fatalf("Expected events to be transformed, got %+v, %v", result, err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"event": "video-play", "properties": map[string]interface{}{"channel": "a", "platform": "web"}},
+		map[string]interface{}{"event": "follow", "properties": map[string]interface{}{"chan": "b", "platform": "web"}},
+	}
+	if actual := decode(t, out); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+
+	if out, result, err = transformer.Apply("not base64!"); err == nil || result.Changed || out != "not base64!" {
+		t.Errorf("Expected bad data to be returned unchanged with an error, got %q, %+v, %v", out, result, err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	transformer, err := New(nil)
+	if err != nil {
+		t.Fatalf("Failed to create transformer: %s", err)
+	}
+	data := encode(t, map[string]interface{}{"event": "e", "properties": map[string]interface{}{}})
+	if _, result, _ := transformer.Apply(data); result.Changed {
+		t.Error("Expected no change without rules")
+	}
+	if err = transformer.Update([]Rule{{Events: []string{"e"}}}); err == nil {
+		t.Error("Expected a rule without actions to be rejected")
+	}
+	if err = transformer.Update([]Rule{{Set: map[string]interface{}{"x": 1.0}}}); err != nil {
+		t.Fatalf("Failed to update rules: %s", err)
+	}
+	if _, result, _ := transformer.Apply(data); !result.Changed {
+		t.Error("Expected update to take effect")
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	transformer, _ := New([]Rule{{Events: []string{"video-play"}, Set: map[string]interface{}{"normalized": true}}})
+	err := transformer.UpdateNames(&NameConfig{
+		Lowercase: true,
+		Aliases:   map[string]string{"vidoe-play": "video-play"},
+		Patterns:  []NamePattern{{Match: `^video_(\w+)$`, Replace: "video-$1"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update names: %s", err)
+	}
+
+	var events []interface{}
+	for _, name := range []string{"Video-Play", "vidoe-play", "video_play", "follow"} {
+		events = append(events, map[string]interface{}{"event": name, "properties": map[string]interface{}{}})
+	}
+	out, result, err := transformer.Apply(encode(t, events))
+	if err != nil {
+		t.Fatalf("Failed to apply: %s", err)
+	}
+	if !reflect.DeepEqual(result.Renamed, []string{"video-play", "video-play", "video-play"}) {
+		t.Errorf("Unexpected renames %v", result.Renamed)
+	}
+	for i, e := range decode(t, out).([]interface{}) {
+		event := e.(map[string]interface{})
+		normalized := event["properties"].(map[string]interface{})["normalized"] == true
+		if (i < 3) != normalized {
+			t.Errorf("Expected rules to match normalized names, got %v", event)
+		}
+	}
+
+	if err = transformer.UpdateNames(&NameConfig{Patterns: []NamePattern{{Match: "("}}}); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+}
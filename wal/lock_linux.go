@@ -0,0 +1,41 @@
+package wal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+// lockDir takes an exclusive lock on dir, held until the returned file is
+// closed or the process exits, and records the holder in the lock file.
+func lockDir(dir string) (*os.File, error) {
+	path := filepath.Join(dir, lockFile)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		if err == syscall.EWOULDBLOCK {
+			holder, _ := ioutil.ReadFile(path)
+			logger.WithField("dir", dir).WithField("holder", strings.TrimSpace(string(holder))).
+				Error("Write-ahead log directory is locked by another process")
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	if err = f.Truncate(0); err == nil {
+		_, err = f.WriteAt([]byte(fmt.Sprintf("%s %d\n", host, os.Getpid())), 0)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
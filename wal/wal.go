@@ -0,0 +1,732 @@
+/*
+Package wal is a local write-ahead log of accepted events, for the strongest
+durability tier: every event is appended to the log before it's written to
+the sinks, and acked once they have it, so events accepted but not yet written
+when the edge dies are replayed to the sinks when it restarts.
+
+The log is a directory of numbered segment files. Each record is the length
+and CRC-32 of an event serialized with spade.Marshal, followed by the event.
+A segment is deleted once it's been rotated out and every event in it acked.
+Replay doesn't know which events of a segment were acked, so replayed events
+may be duplicates: delivery is at least once. On Linux the directory is locked
+while the log is open, so two edges sharing it, e.g. on a volume handed from
+one instance to its replacement, can't both replay and ack its segments.
+
+Appends are synced to disk according to Sync:
+
+	commit    each append waits for the next group commit, an fsync of every
+	          append since the last one, every SyncInterval (the default)
+	interval  appends return at once, and are fsynced every SyncInterval
+	never     appends are handed to the OS every SyncInterval, never fsynced
+
+With MaxBytes or MaxAge set, segments are also deleted, acked or not, once
+the log outgrows MaxBytes or they're older than MaxAge. With a Key, events
+are encrypted with AES-256-GCM before they're written; the high bit of a
+record's length marks it as encrypted, so a log can hold records written
+before and after encryption was turned on.
+*/
+package wal
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+// The Sync policies.
+const (
+	SyncCommit   = "commit"
+	SyncInterval = "interval"
+	SyncNever    = "never"
+)
+
+// The Retention policies.
+const (
+	RetentionDropOldest = "drop-oldest"
+	RetentionReject     = "reject"
+)
+
+const (
+	defaultSyncInterval = "10ms"
+	defaultSegmentSize  = 64 * 1024 * 1024
+
+	segmentSuffix = ".wal"
+	headerSize    = 8
+
+	// lockFile is the file in the directory that's locked while the log is
+	// open.
+	lockFile = "LOCK"
+
+	// maxRecordSize bounds the length of records, so replay can tell
+	// corrupt lengths.
+	maxRecordSize = 16 * 1024 * 1024
+
+	// encryptedFlag is set in the length of encrypted records.
+	encryptedFlag = 1 << 31
+
+	keySize = 32
+
+	// housekeepingInterval is how often segments past MaxAge are deleted
+	// and the size of the log is gauged.
+	housekeepingInterval = time.Second
+)
+
+var errClosed = errors.New("write-ahead log closed")
+
+// ErrLocked is returned by Open when another process has the log open.
+var ErrLocked = errors.New("write-ahead log directory is locked")
+
+// ErrFull is returned by Append when the log has reached MaxBytes and its
+// Retention policy is reject.
+var ErrFull = errors.New("write-ahead log full")
+
+// Config configures a write-ahead log.
+type Config struct {
+	// Dir is the directory the log is kept in. It's created if it doesn't
+	// exist, and locked while the log is open.
+	Dir string
+
+	// Sync is when appends are synced to disk: commit, interval or never. It
+	// defaults to commit.
+	Sync string
+
+	// SyncInterval is how often appends are synced, e.g. "10ms"
+	SyncInterval string
+
+	// SegmentSize is the size, in bytes, segments are rotated at. It defaults
+	// to 64MB.
+	SegmentSize int64
+
+	// MaxBytes, if set, bounds the size of the log on disk, in bytes. It must
+	// be at least twice SegmentSize.
+	MaxBytes int64
+
+	// MaxAge, if set, is how long segments are kept once they're rotated out,
+	// e.g. "72h", acked or not
+	MaxAge string
+
+	// Retention is what appends do once the log has reached MaxBytes:
+	// drop-oldest deletes its oldest segments to make room, acked or not,
+	// and reject fails them with ErrFull until there's room. It defaults to
+	// drop-oldest.
+	Retention string
+
+	// Key, if set, is the base64 encoded 32-byte key events are encrypted
+	// with
+	Key string
+
+	// KeyLocation, if set instead of Key, is where the edge fetches the key
+	// from, like the config, e.g. an ssm: SecureString parameter, which is
+	// decrypted with KMS
+	KeyLocation string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.Dir == "" {
+		return errors.New("Dir is required")
+	}
+	if c.Sync == "" {
+		c.Sync = SyncCommit
+	}
+	switch c.Sync {
+	case SyncCommit, SyncInterval, SyncNever:
+	default:
+		return fmt.Errorf("Sync must be %s, %s or %s", SyncCommit, SyncInterval, SyncNever)
+	}
+	if c.SyncInterval == "" {
+		c.SyncInterval = defaultSyncInterval
+	}
+	d, err := time.ParseDuration(c.SyncInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing %s as a time.Duration: %v", c.SyncInterval, err)
+	}
+	if d <= 0 {
+		return errors.New("SyncInterval must be greater than 0")
+	}
+	if c.SegmentSize == 0 {
+		c.SegmentSize = defaultSegmentSize
+	}
+	if c.SegmentSize < 0 {
+		return errors.New("SegmentSize must be greater than 0")
+	}
+	if c.MaxBytes < 0 {
+		return errors.New("MaxBytes must be greater than 0")
+	}
+	if c.MaxBytes > 0 && c.MaxBytes < 2*c.SegmentSize {
+		return fmt.Errorf("MaxBytes must be at least twice SegmentSize, %d", 2*c.SegmentSize)
+	}
+	if c.MaxAge != "" {
+		d, err := time.ParseDuration(c.MaxAge)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", c.MaxAge, err)
+		}
+		if d <= 0 {
+			return errors.New("MaxAge must be greater than 0")
+		}
+	}
+	if c.Retention == "" {
+		c.Retention = RetentionDropOldest
+	}
+	switch c.Retention {
+	case RetentionDropOldest, RetentionReject:
+	default:
+		return fmt.Errorf("Retention must be %s or %s", RetentionDropOldest, RetentionReject)
+	}
+	if c.Key != "" && c.KeyLocation != "" {
+		return errors.New("only one of Key and KeyLocation can be set")
+	}
+	if c.Key != "" {
+		if key, err := base64.StdEncoding.DecodeString(c.Key); err != nil || len(key) != keySize {
+			return fmt.Errorf("Key must be %d base64 encoded bytes", keySize)
+		}
+	}
+	return nil
+}
+
+// Ticket identifies an appended event to Ack.
+type Ticket uint64
+
+// segmentInfo is the size of a segment that's been rotated out, and when it
+// was last written.
+type segmentInfo struct {
+	size     int64
+	modified time.Time
+}
+
+// WAL is a write-ahead log of events.
+type WAL struct {
+	config       Config
+	syncInterval time.Duration
+	maxAge       time.Duration
+	aead         cipher.AEAD
+	stats        statsd.StatSender
+	prefix       string
+	now          func() time.Time
+
+	// lock holds the lock on the directory.
+	lock *os.File
+
+	mu          sync.Mutex
+	synced      *sync.Cond
+	file        *os.File
+	w           *bufio.Writer
+	segment     uint64
+	size        int64
+	outstanding map[uint64]int
+	closed      bool
+
+	// rotated are the segments on disk other than the current one, left by
+	// an earlier run or rotated out, and rotatedSize their total size.
+	rotated     map[uint64]segmentInfo
+	rotatedSize int64
+
+	// appended and syncedTo count the appends written and the ones synced,
+	// and syncErr is the error of the last sync, if it failed.
+	appended uint64
+	syncedTo uint64
+	syncErr  error
+
+	// pending are the segments left by an earlier run, to replay.
+	pending []uint64
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// Open locks config.Dir and opens the write-ahead log in it, starting a new
+// segment, and starts syncing it. It returns ErrLocked if another process has
+// the log open. The segments deleted to keep within MaxBytes and MaxAge
+// are counted under <prefix>evicted.<full|expired>, and their size under
+// <prefix>evicted_bytes; appends rejected for lack of room are counted under
+// <prefix>rejected, and the size of the log is gauged under <prefix>bytes.
+func Open(config Config, stats statsd.StatSender, prefix string) (*WAL, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.KeyLocation != "" {
+		return nil, errors.New("KeyLocation must be fetched into Key")
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+	lock, err := lockDir(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	w, err := open(config, stats, prefix)
+	if err != nil {
+		if lock != nil {
+			_ = lock.Close()
+		}
+		return nil, err
+	}
+	w.lock = lock
+	logger.Go(w.syncLoop)
+	return w, nil
+}
+
+// open opens the log in the locked config.Dir.
+func open(config Config, stats statsd.StatSender, prefix string) (*WAL, error) {
+	w := &WAL{
+		config:      config,
+		stats:       stats,
+		prefix:      prefix,
+		now:         time.Now,
+		outstanding: make(map[uint64]int),
+		rotated:     make(map[uint64]segmentInfo),
+		quit:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	w.synced = sync.NewCond(&w.mu)
+	w.syncInterval, _ = time.ParseDuration(config.SyncInterval)
+	if config.MaxAge != "" {
+		w.maxAge, _ = time.ParseDuration(config.MaxAge)
+	}
+	if config.Key != "" {
+		key, _ := base64.StdEncoding.DecodeString(config.Key)
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		if w.aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if w.pending, err = segments(config.Dir); err != nil {
+		return nil, err
+	}
+	for _, segment := range w.pending {
+		info, err := os.Stat(w.path(segment))
+		if err != nil {
+			return nil, err
+		}
+		w.rotated[segment] = segmentInfo{size: info.Size(), modified: info.ModTime()}
+		w.rotatedSize += info.Size()
+	}
+	if len(w.pending) > 0 {
+		w.segment = w.pending[len(w.pending)-1]
+	}
+	if err = w.rotate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// segments returns the numbers of the segments in dir, in order.
+func segments(dir string) ([]uint64, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var numbers []uint64
+	for _, info := range infos {
+		name := info.Name()
+		if !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 16, 64)
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, n)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers, nil
+}
+
+func (w *WAL) path(segment uint64) string {
+	return filepath.Join(w.config.Dir, fmt.Sprintf("%016x%s", segment, segmentSuffix))
+}
+
+// rotate closes the current segment, if any, and starts the next one. w.mu
+// must be held, or w not shared yet.
+func (w *WAL) rotate() error {
+	if w.file != nil {
+		if err := w.sync(true); err != nil {
+			return err
+		}
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		if w.outstanding[w.segment] == 0 {
+			w.remove(w.segment)
+		} else {
+			w.rotated[w.segment] = segmentInfo{size: w.size, modified: w.now()}
+			w.rotatedSize += w.size
+		}
+	}
+	file, err := os.OpenFile(w.path(w.segment+1), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	w.segment++
+	w.file, w.size = file, 0
+	if w.w == nil {
+		w.w = bufio.NewWriter(file)
+	} else {
+		w.w.Reset(file)
+	}
+	return nil
+}
+
+// remove deletes a segment every event of which was acked, replayed or
+// evicted. w.mu must be held.
+func (w *WAL) remove(segment uint64) {
+	delete(w.outstanding, segment)
+	if info, ok := w.rotated[segment]; ok {
+		delete(w.rotated, segment)
+		w.rotatedSize -= info.size
+	}
+	if err := os.Remove(w.path(segment)); err != nil {
+		logger.WithError(err).WithField("segment", segment).Error("Failed to remove write-ahead log segment")
+	}
+}
+
+// evict deletes a segment before it's acked or replayed, counting it under
+// reason. w.mu must be held.
+func (w *WAL) evict(segment uint64, reason string) {
+	size := w.rotated[segment].size
+	w.remove(segment)
+	_ = w.stats.Inc(w.prefix+"evicted."+reason, 1, 1)
+	_ = w.stats.Inc(w.prefix+"evicted_bytes", size, 1)
+	logger.WithField("segment", segment).WithField("reason", reason).Warn("Evicted write-ahead log segment")
+}
+
+// makeRoom evicts the oldest segments until n more bytes fit in MaxBytes, or
+// returns ErrFull if the Retention policy is reject. w.mu must be held.
+func (w *WAL) makeRoom(n int64) error {
+	if w.config.MaxBytes == 0 {
+		return nil
+	}
+	for w.rotatedSize+w.size+n > w.config.MaxBytes && len(w.rotated) > 0 {
+		if w.config.Retention == RetentionReject {
+			_ = w.stats.Inc(w.prefix+"rejected", 1, 0.1)
+			return ErrFull
+		}
+		oldest := w.segment
+		for segment := range w.rotated {
+			if segment < oldest {
+				oldest = segment
+			}
+		}
+		w.evict(oldest, "full")
+	}
+	return nil
+}
+
+// expire evicts the segments rotated out longer than MaxAge ago. w.mu must
+// be held.
+func (w *WAL) expire() {
+	if w.maxAge == 0 {
+		return
+	}
+	cutoff := w.now().Add(-w.maxAge)
+	for segment, info := range w.rotated {
+		if info.modified.Before(cutoff) {
+			w.evict(segment, "expired")
+		}
+	}
+}
+
+// Append appends e to the log, returning the Ticket to Ack it with once the
+// sinks have it. With the commit Sync policy, it returns once e is synced.
+func (w *WAL) Append(e *spade.Event) (Ticket, error) {
+	b, err := spade.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+	length := uint32(len(b))
+	if w.aead != nil {
+		nonce := make([]byte, w.aead.NonceSize(), w.aead.NonceSize()+len(b)+w.aead.Overhead())
+		if _, err = rand.Read(nonce); err != nil {
+			return 0, err
+		}
+		b = w.aead.Seal(nonce, nonce, b, nil)
+		length = uint32(len(b)) | encryptedFlag
+	}
+	var header [headerSize]byte
+	binary.BigEndian.PutUint32(header[:4], length)
+	binary.BigEndian.PutUint32(header[4:], crc32.ChecksumIEEE(b))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, errClosed
+	}
+	if w.size > 0 && w.size+int64(headerSize+len(b)) > w.config.SegmentSize {
+		if err = w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if err = w.makeRoom(int64(headerSize + len(b))); err != nil {
+		return 0, err
+	}
+	if _, err = w.w.Write(header[:]); err == nil {
+		_, err = w.w.Write(b)
+	}
+	if err != nil {
+		return 0, err
+	}
+	w.size += int64(headerSize + len(b))
+	w.outstanding[w.segment]++
+	w.appended++
+	ticket := Ticket(w.segment)
+
+	if w.config.Sync != SyncCommit {
+		return ticket, nil
+	}
+	for seq := w.appended; w.syncedTo < seq && !w.closed; {
+		w.synced.Wait()
+	}
+	if w.syncErr != nil {
+		return ticket, w.syncErr
+	}
+	if w.syncedTo < w.appended && w.closed {
+		return ticket, errClosed
+	}
+	return ticket, nil
+}
+
+// Ack marks the event t was returned for as written to the sinks.
+func (w *WAL) Ack(t Ticket) {
+	segment := uint64(t)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.outstanding[segment]; !ok {
+		// The segment was evicted.
+		return
+	}
+	w.outstanding[segment]--
+	if w.outstanding[segment] <= 0 && segment != w.segment {
+		w.remove(segment)
+	}
+}
+
+// sync flushes the appends since the last sync, and fsyncs them if fsync is
+// set, waking appenders waiting for them. w.mu must be held.
+func (w *WAL) sync(fsync bool) error {
+	if w.syncedTo == w.appended {
+		return nil
+	}
+	err := w.w.Flush()
+	if err == nil && fsync {
+		err = w.file.Sync()
+	}
+	w.syncErr = err
+	w.syncedTo = w.appended
+	w.synced.Broadcast()
+	return err
+}
+
+func (w *WAL) syncLoop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.syncInterval)
+	defer ticker.Stop()
+	housekeeping := time.NewTicker(housekeepingInterval)
+	defer housekeeping.Stop()
+	for {
+		select {
+		case <-w.quit:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if err := w.sync(w.config.Sync != SyncNever); err != nil {
+				logger.WithError(err).Error("Failed to sync write-ahead log")
+			}
+			w.mu.Unlock()
+		case <-housekeeping.C:
+			w.mu.Lock()
+			w.expire()
+			size := w.rotatedSize + w.size
+			w.mu.Unlock()
+			_ = w.stats.Gauge(w.prefix+"bytes", size, 1)
+		}
+	}
+}
+
+// Replay calls fn with each event left in the log by an earlier run, and
+// deletes each of that run's segments once fn has been called with all of
+// its events without an error. It stops at the first error, leaving the rest
+// to be replayed by the next run. Segments evicted before they're replayed
+// are skipped.
+func (w *WAL) Replay(fn func(*spade.Event) error) error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+	for _, segment := range pending {
+		w.mu.Lock()
+		_, ok := w.rotated[segment]
+		w.mu.Unlock()
+		if !ok {
+			continue
+		}
+		if err := w.replaySegment(segment, fn); err != nil {
+			return err
+		}
+		w.mu.Lock()
+		if _, ok = w.rotated[segment]; ok {
+			w.remove(segment)
+		}
+		w.mu.Unlock()
+	}
+	return nil
+}
+
+// replaySegment calls fn with each event of segment. A record cut short at
+// the end of the segment is a torn write, which was never acked. Records that
+// are corrupt elsewhere are skipped, reading on from the next record that
+// checks out, and counted under <prefix>replay.corrupt.
+func (w *WAL) replaySegment(segment uint64, fn func(*spade.Event) error) error {
+	b, err := ioutil.ReadFile(w.path(segment))
+	if err != nil {
+		return err
+	}
+	for off := 0; off < len(b); {
+		payload, encrypted, n, err := readRecord(b[off:])
+		if err != nil {
+			next := nextRecord(b, off+1)
+			if next == len(b) && err == errTorn {
+				logger.WithField("segment", segment).Warn("Truncated write-ahead log record")
+				return nil
+			}
+			logger.WithError(err).WithField("segment", segment).WithField("offset", off).
+				Warn("Skipping corrupt write-ahead log records")
+			_ = w.stats.Inc(w.prefix+"replay.corrupt", 1, 1)
+			off = next
+			continue
+		}
+		off += n
+		if encrypted {
+			if payload, err = w.decrypt(payload); err != nil {
+				logger.WithError(err).WithField("segment", segment).Warn("Undecryptable write-ahead log record")
+				_ = w.stats.Inc(w.prefix+"replay.corrupt", 1, 1)
+				continue
+			}
+		}
+		var e spade.Event
+		if err = spade.Unmarshal(payload, &e); err != nil {
+			logger.WithError(err).WithField("segment", segment).Warn("Corrupt write-ahead log record")
+			_ = w.stats.Inc(w.prefix+"replay.corrupt", 1, 1)
+			continue
+		}
+		if err = fn(&e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	// errTorn is a record cut short by the end of the segment.
+	errTorn = errors.New("record cut short")
+
+	errCorrupt = errors.New("record fails its checksum")
+)
+
+// readRecord reads the record at the start of b, returning its payload,
+// whether it's encrypted and its size with the header.
+func readRecord(b []byte) (payload []byte, encrypted bool, n int, err error) {
+	if len(b) < headerSize {
+		return nil, false, 0, errTorn
+	}
+	size := binary.BigEndian.Uint32(b[:4])
+	encrypted = size&encryptedFlag != 0
+	size &^= encryptedFlag
+	if size == 0 || size > maxRecordSize {
+		return nil, false, 0, errCorrupt
+	}
+	n = headerSize + int(size)
+	if n > len(b) {
+		return nil, false, 0, errTorn
+	}
+	payload = b[headerSize:n]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(b[4:headerSize]) {
+		return nil, false, 0, errCorrupt
+	}
+	return payload, encrypted, n, nil
+}
+
+// nextRecord returns the offset of the first record of b at or after from
+// that checks out, or len(b) if there's none.
+func nextRecord(b []byte, from int) int {
+	for off := from; off < len(b); off++ {
+		if _, _, _, err := readRecord(b[off:]); err == nil {
+			return off
+		}
+	}
+	return len(b)
+}
+
+// decrypt returns the plaintext of an encrypted record.
+func (w *WAL) decrypt(b []byte) ([]byte, error) {
+	if w.aead == nil {
+		return nil, errors.New("encrypted record without a Key")
+	}
+	if len(b) < w.aead.NonceSize() {
+		return nil, errors.New("encrypted record too short")
+	}
+	nonce := b[:w.aead.NonceSize()]
+	return w.aead.Open(nil, nonce, b[len(nonce):], nil)
+}
+
+// DiskUsage returns the size, in bytes, of the log's segments on disk,
+// including those left to replay. Appends not yet synced aren't counted.
+func (w *WAL) DiskUsage() (int64, error) {
+	infos, err := ioutil.ReadDir(w.config.Dir)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, info := range infos {
+		if strings.HasSuffix(info.Name(), segmentSuffix) {
+			size += info.Size()
+		}
+	}
+	return size, nil
+}
+
+// Close syncs the log and closes it. Segments with events that weren't acked
+// are kept for the next run to replay.
+func (w *WAL) Close() error {
+	close(w.quit)
+	<-w.done
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.sync(w.config.Sync != SyncNever)
+	w.closed = true
+	w.synced.Broadcast()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if w.outstanding[w.segment] == 0 {
+		w.remove(w.segment)
+	}
+	if w.lock != nil {
+		if closeErr := w.lock.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,346 @@
+package wal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/statsd/statsdtest"
+	"github.com/twitchscience/scoop_protocol/spade"
+)
+
+func openTestWAL(t *testing.T, dir string, config Config) *WAL {
+	config.Dir = dir
+	stats, _ := statsd.NewNoop()
+	w, err := Open(config, stats, "wal.")
+	if err != nil {
+		t.Fatalf("Failed to open write-ahead log: %s", err)
+	}
+	return w
+}
+
+func replayed(t *testing.T, w *WAL) []string {
+	var uuids []string
+	if err := w.Replay(func(e *spade.Event) error {
+		uuids = append(uuids, e.Uuid)
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to replay: %s", err)
+	}
+	return uuids
+}
+
+func TestReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// Small segments, so each holds one event.
+	w := openTestWAL(t, dir, Config{SegmentSize: 1})
+	tickets := make(map[string]Ticket)
+	for _, uuid := range []string{"a", "b", "c"} {
+		ticket, appendErr := w.Append(&spade.Event{Uuid: uuid, Data: "eyJldmVudCI6ImhlbGxvIn0="})
+		if appendErr != nil {
+			t.Fatalf("Failed to append: %s", appendErr)
+		}
+		tickets[uuid] = ticket
+	}
+	w.Ack(tickets["a"])
+	w.Ack(tickets["c"])
+	if err = w.Close(); err != nil {
+		t.Fatalf("Failed to close: %s", err)
+	}
+
+	w = openTestWAL(t, dir, Config{})
+	if size, sizeErr := w.DiskUsage(); sizeErr != nil || size == 0 {
+		t.Errorf("Expected the unacked segment to take up disk, got %d, %v", size, sizeErr)
+	}
+	if got := replayed(t, w); len(got) != 1 || got[0] != "b" {
+		t.Errorf("Expected the unacked segment replayed, got %v", got)
+	}
+	if got := replayed(t, w); len(got) != 0 {
+		t.Errorf("Expected replayed segments to be removed, got %v", got)
+	}
+	ticket, err := w.Append(&spade.Event{Uuid: "d"})
+	if err != nil {
+		t.Fatalf("Failed to append: %s", err)
+	}
+	w.Ack(ticket)
+	if err = w.Close(); err != nil {
+		t.Fatalf("Failed to close: %s", err)
+	}
+	if left, _ := segments(dir); len(left) != 0 {
+		t.Errorf("Expected no segments left, got %d", len(left))
+	}
+}
+
+func TestTornWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for _, sync := range []string{SyncInterval, SyncNever} {
+		w := openTestWAL(t, dir, Config{Sync: sync})
+		if _, err = w.Append(&spade.Event{Uuid: sync}); err != nil {
+			t.Fatalf("Failed to append: %s", err)
+		}
+		if err = w.Close(); err != nil {
+			t.Fatalf("Failed to close: %s", err)
+		}
+	}
+	// A crash mid-append leaves part of a record.
+	path := filepath.Join(dir, "0000000000000002.wal")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write([]byte{0, 0, 1})
+	_ = f.Close()
+
+	w := openTestWAL(t, dir, Config{})
+	defer func() { _ = w.Close() }()
+	if got := replayed(t, w); len(got) != 2 || got[0] != SyncInterval || got[1] != SyncNever {
+		t.Errorf("Expected both events before the torn write, got %v", got)
+	}
+
+	// Replay stops at an error, leaving the segment for next time.
+	if _, err = w.Append(&spade.Event{Uuid: "e"}); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	w = openTestWAL(t, dir, Config{})
+	failed := errors.New("sinks down")
+	if err = w.Replay(func(*spade.Event) error { return failed }); err != failed {
+		t.Errorf("Expected the replay error, got %v", err)
+	}
+	_ = w.Close()
+	w = openTestWAL(t, dir, Config{})
+	if got := replayed(t, w); len(got) != 1 || got[0] != "e" {
+		t.Errorf("Expected the event replayed again, got %v", got)
+	}
+}
+
+func TestCorruptRecord(t *testing.T) {
+	for name, corrupt := range map[string]func(record []byte){
+		"payload": func(record []byte) { record[headerSize] ^= 0xff },
+		"length":  func(record []byte) { binary.BigEndian.PutUint32(record, maxRecordSize+1) },
+	} {
+		dir, err := ioutil.TempDir("", "wal")
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := openTestWAL(t, dir, Config{})
+		for _, uuid := range []string{"a", "b", "c"} {
+			if _, err = w.Append(&spade.Event{Uuid: uuid}); err != nil {
+				t.Fatalf("Failed to append: %s", err)
+			}
+		}
+		_ = w.Close()
+		path := filepath.Join(dir, "0000000000000001.wal")
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second := headerSize + int(binary.BigEndian.Uint32(b))
+		corrupt(b[second:])
+		if err = ioutil.WriteFile(path, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		sender := statsdtest.NewRecordingSender()
+		stats, _ := statsd.NewClientWithSender(sender, "")
+		if w, err = Open(Config{Dir: dir}, stats, "wal."); err != nil {
+			t.Fatalf("Failed to reopen write-ahead log: %s", err)
+		}
+		if got := replayed(t, w); len(got) != 2 || got[0] != "a" || got[1] != "c" {
+			t.Errorf("%s: expected the records around the corrupt one replayed, got %v", name, got)
+		}
+		if got := sender.GetSent().CollectNamed("wal.replay.corrupt"); len(got) != 1 {
+			t.Errorf("%s: expected the corrupt record counted, got %v", name, got)
+		}
+		_ = w.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	event := func(uuid string) *spade.Event {
+		return &spade.Event{Uuid: uuid, Data: "eyJldmVudCI6ImhlbGxvIn0="}
+	}
+	b, _ := spade.Marshal(event("a"))
+	// Small segments, so each holds one event, and room for three of them.
+	config := Config{SegmentSize: 1, MaxBytes: 3 * int64(headerSize+len(b))}
+
+	for _, tt := range []struct {
+		retention string
+		appended  []string
+		replayed  []string
+		stat      string
+	}{
+		{RetentionDropOldest, []string{"a", "b", "c", "d", "e"}, []string{"c", "d", "e"}, "wal.evicted.full"},
+		{RetentionReject, []string{"a", "b", "c"}, []string{"a", "b", "c"}, "wal.rejected"},
+	} {
+		dir, err := ioutil.TempDir("", "wal")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+		rs := statsdtest.NewRecordingSender()
+		stats, _ := statsd.NewClientWithSender(rs, "")
+		stats.(statsd.SubStatter).SetSamplerFunc(func(float32) bool { return true })
+		config.Dir, config.Retention = dir, tt.retention
+		w, err := Open(config, stats, "wal.")
+		if err != nil {
+			t.Fatalf("Failed to open write-ahead log: %s", err)
+		}
+		for _, uuid := range []string{"a", "b", "c", "d", "e"} {
+			_, err = w.Append(event(uuid))
+			if err == ErrFull {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("Failed to append: %s", err)
+			}
+		}
+		_ = w.Close()
+		if got := len(rs.GetSent().CollectNamed(tt.stat)); got != 2 {
+			t.Errorf("%s: expected %s counted twice, got %d", tt.retention, tt.stat, got)
+		}
+
+		w = openTestWAL(t, dir, Config{})
+		if got := replayed(t, w); !reflect.DeepEqual(got, tt.replayed) {
+			t.Errorf("%s: expected %v replayed, got %v", tt.retention, tt.replayed, got)
+		}
+		_ = w.Close()
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	w := openTestWAL(t, dir, Config{SegmentSize: 1, MaxAge: "1h"})
+	for _, uuid := range []string{"a", "b"} {
+		if _, err = w.Append(&spade.Event{Uuid: uuid}); err != nil {
+			t.Fatalf("Failed to append: %s", err)
+		}
+	}
+	// The rotated out segment expires, the current one doesn't.
+	w.mu.Lock()
+	w.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	w.expire()
+	w.mu.Unlock()
+	_ = w.Close()
+
+	w = openTestWAL(t, dir, Config{})
+	defer func() { _ = w.Close() }()
+	if got := replayed(t, w); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Expected only the unexpired event replayed, got %v", got)
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, keySize))
+
+	// Records written before encryption was turned on are still replayed.
+	for _, c := range []struct {
+		uuid string
+		key  string
+	}{
+		{"plain-event", ""},
+		{"secret-event", key},
+	} {
+		w := openTestWAL(t, dir, Config{Key: c.key})
+		if _, err = w.Append(&spade.Event{Uuid: c.uuid}); err != nil {
+			t.Fatalf("Failed to append: %s", err)
+		}
+		_ = w.Close()
+	}
+	files, _ := ioutil.ReadDir(dir)
+	for _, f := range files {
+		b, _ := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if bytes.Contains(b, []byte("secret-event")) {
+			t.Errorf("Expected the event encrypted in %s", f.Name())
+		}
+	}
+
+	w := openTestWAL(t, dir, Config{Key: key})
+	defer func() { _ = w.Close() }()
+	if got := replayed(t, w); !reflect.DeepEqual(got, []string{"plain-event", "secret-event"}) {
+		t.Errorf("Expected both events replayed, got %v", got)
+	}
+}
+
+func TestLock(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("The directory is only locked on Linux")
+	}
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	w := openTestWAL(t, dir, Config{})
+	if _, err = w.Append(&spade.Event{Uuid: "a"}); err != nil {
+		t.Fatalf("Failed to append: %s", err)
+	}
+	// Another edge can't open the log while it's open, so it can't replay
+	// segments this one may still ack.
+	stats, _ := statsd.NewNoop()
+	if _, err = Open(Config{Dir: dir}, stats, "wal."); err != ErrLocked {
+		t.Errorf("Expected the log to be locked, got %v", err)
+	}
+	_ = w.Close()
+
+	// Once it's closed, the next edge takes over.
+	w = openTestWAL(t, dir, Config{})
+	defer func() { _ = w.Close() }()
+	if got := replayed(t, w); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Expected the event replayed by the next edge, got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []Config{
+		{},
+		{Dir: "x", Sync: "sometimes"},
+		{Dir: "x", SyncInterval: "soon"},
+		{Dir: "x", SegmentSize: -1},
+		{Dir: "x", SegmentSize: 10, MaxBytes: 15},
+		{Dir: "x", MaxAge: "a while"},
+		{Dir: "x", Retention: "drop-newest"},
+		{Dir: "x", Key: "c2hvcnQ="},
+		{Dir: "x", Key: "c2hvcnQ=", KeyLocation: "ssm:/spade/key"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", c)
+		}
+	}
+	c := Config{Dir: "x"}
+	if err := c.Validate(); err != nil || c.Sync != SyncCommit || c.SyncInterval != "10ms" ||
+		c.Retention != RetentionDropOldest {
+		t.Errorf("Expected defaults, got %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,330 @@
+/*
+Package watchdog captures profiles of the edge while it's slow, so transient
+production slowdowns leave something to investigate once they're over.
+
+A Watchdog times every request it handles, and every CheckInterval compares
+the 99th percentile latency since the last check and the number of
+goroutines against their thresholds. When one is crossed it captures a CPU
+profile over CPUDuration, then a heap and a goroutine profile, to Dir and
+optionally to S3. Captures are at least MinInterval apart, so a long slowdown
+doesn't fill the disk or add profiling overhead to an edge that's already
+struggling.
+*/
+package watchdog
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/twitchscience/aws_utils/logger"
+)
+
+const (
+	defaultCheckInterval = "10s"
+	defaultMinInterval   = "15m"
+	defaultCPUDuration   = "10s"
+
+	// maxSamples bounds the latencies kept between checks; beyond it they're
+	// reservoir sampled.
+	maxSamples = 10000
+
+	// The reasons profiles are captured for.
+	reasonLatency    = "latency"
+	reasonGoroutines = "goroutines"
+)
+
+// Config configures the profiling watchdog.
+type Config struct {
+	// LatencyThreshold is the 99th percentile request latency that triggers
+	// a capture, e.g. "500ms". Empty disables it.
+	LatencyThreshold string
+
+	// GoroutineThreshold is the number of goroutines that triggers a
+	// capture. 0 disables it.
+	GoroutineThreshold int
+
+	// CheckInterval is how often the thresholds are checked, e.g. "10s"
+	CheckInterval string
+
+	// MinInterval is the least time between captures, e.g. "15m"
+	MinInterval string
+
+	// CPUDuration is how long the CPU is profiled for, e.g. "10s"
+	CPUDuration string
+
+	// Dir is where profiles are written. It defaults to a profiles directory
+	// in the LoggingDir.
+	Dir string
+
+	// Bucket, if set, is the S3 bucket profiles are uploaded to, under
+	// Prefix. Uploaded profiles are removed from Dir.
+	Bucket string
+	Prefix string
+}
+
+// Validate verifies that a Config is valid and fills in defaults
+func (c *Config) Validate() error {
+	if c.LatencyThreshold == "" && c.GoroutineThreshold == 0 {
+		return errors.New("LatencyThreshold or GoroutineThreshold is required")
+	}
+	if c.GoroutineThreshold < 0 {
+		return errors.New("GoroutineThreshold must not be negative")
+	}
+	if c.Dir == "" {
+		return errors.New("Dir is required")
+	}
+	if c.CheckInterval == "" {
+		c.CheckInterval = defaultCheckInterval
+	}
+	if c.MinInterval == "" {
+		c.MinInterval = defaultMinInterval
+	}
+	if c.CPUDuration == "" {
+		c.CPUDuration = defaultCPUDuration
+	}
+	for _, d := range []string{c.LatencyThreshold, c.CheckInterval, c.MinInterval, c.CPUDuration} {
+		if d == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			return fmt.Errorf("error parsing %s as a time.Duration: %v", d, err)
+		}
+		if parsed <= 0 {
+			return fmt.Errorf("%s must be greater than 0", d)
+		}
+	}
+	return nil
+}
+
+// Watchdog times requests and captures profiles when the edge is slow.
+type Watchdog struct {
+	config           Config
+	latencyThreshold time.Duration
+	checkInterval    time.Duration
+	minInterval      time.Duration
+	cpuDuration      time.Duration
+	uploader         s3manageriface.UploaderAPI
+	stats            statsd.StatSender
+
+	now          func() time.Time
+	numGoroutine func() int
+
+	mu      sync.Mutex
+	samples []time.Duration
+	seen    int
+
+	// lastCapture is when profiles were last captured, only read and written
+	// by Check.
+	lastCapture time.Time
+
+	runMu   sync.Mutex
+	running bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns a Watchdog for config. uploader is only used if config has a
+// Bucket.
+func New(config Config, uploader s3manageriface.UploaderAPI, stats statsd.StatSender) (*Watchdog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+	w := &Watchdog{
+		config:       config,
+		uploader:     uploader,
+		stats:        stats,
+		now:          time.Now,
+		numGoroutine: runtime.NumGoroutine,
+		samples:      make([]time.Duration, 0, 1024),
+		quit:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	if config.LatencyThreshold != "" {
+		w.latencyThreshold, _ = time.ParseDuration(config.LatencyThreshold)
+	}
+	w.checkInterval, _ = time.ParseDuration(config.CheckInterval)
+	w.minInterval, _ = time.ParseDuration(config.MinInterval)
+	w.cpuDuration, _ = time.ParseDuration(config.CPUDuration)
+	return w, nil
+}
+
+// Handler returns a handler timing the requests it passes to next.
+func (w *Watchdog) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(rw, r)
+		w.observe(time.Since(start))
+	})
+}
+
+func (w *Watchdog) observe(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.seen++
+	if len(w.samples) < maxSamples {
+		w.samples = append(w.samples, d)
+	} else if i := rand.Intn(w.seen); i < maxSamples {
+		w.samples[i] = d
+	}
+}
+
+// p99 returns the 99th percentile of the latencies since the last call.
+func (w *Watchdog) p99() (time.Duration, bool) {
+	w.mu.Lock()
+	samples := w.samples
+	w.samples = make([]time.Duration, 0, cap(samples))
+	w.seen = 0
+	w.mu.Unlock()
+	if len(samples) == 0 {
+		return 0, false
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[len(samples)*99/100], true
+}
+
+// Check compares the latency since the last check and the number of
+// goroutines against their thresholds, capturing profiles if one is crossed
+// and none were captured in the last MinInterval. It returns why profiles
+// were captured, or "" if they weren't.
+func (w *Watchdog) Check() string {
+	reason := ""
+	if p99, ok := w.p99(); ok {
+		_ = w.stats.TimingDuration("watchdog.latency_p99", p99, 1)
+		if w.latencyThreshold > 0 && p99 >= w.latencyThreshold {
+			reason = reasonLatency
+		}
+	}
+	goroutines := w.numGoroutine()
+	if reason == "" && w.config.GoroutineThreshold > 0 && goroutines >= w.config.GoroutineThreshold {
+		reason = reasonGoroutines
+	}
+	now := w.now()
+	if reason == "" || (!w.lastCapture.IsZero() && now.Sub(w.lastCapture) < w.minInterval) {
+		return ""
+	}
+	w.lastCapture = now
+	logger.WithField("reason", reason).WithField("goroutines", goroutines).Warn("Capturing profiles")
+	if err := w.capture(now, reason); err != nil {
+		_ = w.stats.Inc("watchdog.capture_failed", 1, 1)
+		logger.WithError(err).WithField("reason", reason).Error("Failed to capture profiles")
+	}
+	_ = w.stats.Inc("watchdog.captured."+reason, 1, 1)
+	return reason
+}
+
+// capture writes the CPU, heap and goroutine profiles, uploading them if
+// there's a Bucket. It stops profiling the CPU early if the watchdog is
+// closed.
+func (w *Watchdog) capture(now time.Time, reason string) error {
+	stamp := now.UTC().Format("20060102T150405Z")
+	var errs []string
+	for _, profile := range []string{"cpu", "heap", "goroutine"} {
+		name := fmt.Sprintf("%s.%s.%s.pprof", stamp, reason, profile)
+		if err := w.writeProfile(filepath.Join(w.config.Dir, name), profile); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", profile, err))
+			continue
+		}
+		if w.config.Bucket == "" {
+			continue
+		}
+		if err := w.upload(name); err != nil {
+			errs = append(errs, fmt.Sprintf("uploading %s: %v", profile, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+func (w *Watchdog) writeProfile(filename, profile string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	switch profile {
+	case "cpu":
+		if err = pprof.StartCPUProfile(f); err != nil {
+			break
+		}
+		select {
+		case <-time.After(w.cpuDuration):
+		case <-w.quit:
+		}
+		pprof.StopCPUProfile()
+	default:
+		err = pprof.Lookup(profile).WriteTo(f, 0)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(filename)
+	}
+	return err
+}
+
+func (w *Watchdog) upload(name string) error {
+	filename := filepath.Join(w.config.Dir, name)
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	_, err = w.uploader.Upload(&s3manager.UploadInput{
+		Bucket: &w.config.Bucket,
+		Key:    aws.String(path.Join(w.config.Prefix, name)),
+		Body:   f,
+	})
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Remove(filename)
+}
+
+// Run checks the thresholds every CheckInterval until Close is called.
+func (w *Watchdog) Run() {
+	w.runMu.Lock()
+	w.running = true
+	w.runMu.Unlock()
+	defer close(w.done)
+	ticker := time.NewTicker(w.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.quit:
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Close stops Run, waiting for it to return.
+func (w *Watchdog) Close() {
+	close(w.quit)
+	w.runMu.Lock()
+	running := w.running
+	w.runMu.Unlock()
+	if running {
+		<-w.done
+	}
+}
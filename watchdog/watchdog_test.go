@@ -0,0 +1,109 @@
+package watchdog
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/cactus/go-statsd-client/statsd"
+)
+
+type fakeUploader struct {
+	s3manageriface.UploaderAPI
+	keys []string
+}
+
+func (u *fakeUploader) Upload(input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	u.keys = append(u.keys, *input.Key)
+	return &s3manager.UploadOutput{}, nil
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watchdog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	stats, _ := statsd.NewNoop()
+	w, err := New(Config{
+		LatencyThreshold:   "100ms",
+		GoroutineThreshold: 1000,
+		CPUDuration:        "10ms",
+		Dir:                dir,
+	}, nil, stats)
+	if err != nil {
+		t.Fatalf("Failed to create watchdog: %s", err)
+	}
+	now := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	goroutines := 10
+	w.numGoroutine = func() int { return goroutines }
+
+	handler := w.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	for i := 0; i < 100; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}
+	if reason := w.Check(); reason != "" {
+		t.Errorf("Expected no capture for fast requests, got %q", reason)
+	}
+
+	// One slow request in a hundred is the 99th percentile.
+	for i := 0; i < 99; i++ {
+		w.observe(time.Millisecond)
+	}
+	w.observe(time.Second)
+	if reason := w.Check(); reason != reasonLatency {
+		t.Errorf("Expected a latency capture, got %q", reason)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	sort.Strings(names)
+	if len(names) != 3 || names[0] != "20170601T130000Z.latency.cpu.pprof" {
+		t.Errorf("Expected CPU, heap and goroutine profiles, got %v", names)
+	}
+
+	// Captures are rate limited.
+	goroutines = 1000
+	now = now.Add(time.Minute)
+	if reason := w.Check(); reason != "" {
+		t.Errorf("Expected no capture within MinInterval, got %q", reason)
+	}
+	now = now.Add(15 * time.Minute)
+	if reason := w.Check(); reason != reasonGoroutines {
+		t.Errorf("Expected a goroutine capture, got %q", reason)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watchdog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	stats, _ := statsd.NewNoop()
+	uploader := &fakeUploader{}
+	w, err := New(Config{GoroutineThreshold: 1, CPUDuration: "10ms", Dir: dir, Bucket: "profiles", Prefix: "edge"},
+		uploader, stats)
+	if err != nil {
+		t.Fatalf("Failed to create watchdog: %s", err)
+	}
+	w.now = func() time.Time { return time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC) }
+	w.Check()
+	if len(uploader.keys) != 3 || uploader.keys[0] != "edge/20170601T130000Z.goroutines.cpu.pprof" {
+		t.Errorf("Expected the profiles uploaded, got %v", uploader.keys)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected uploaded profiles removed, got %d files", len(files))
+	}
+}